@@ -49,4 +49,13 @@ const (
 
 	// Deploy is the string value for the deploy action when logging.
 	Deploy = "deploy"
+
+	// Revert is the string value for the revert action when logging.
+	Revert = "revert"
+
+	// Resume is the string value for the resume action when logging.
+	Resume = "resume"
+
+	// Upgrade is the string value for the upgrade action when logging.
+	Upgrade = "upgrade"
 )
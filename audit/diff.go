@@ -0,0 +1,159 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/control-center/serviced/domain/auditlog"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Diff returns the field-level changes between two values of the same type,
+// so that an audit entry can record exactly what an update changed.  Structs
+// are compared field by field, maps key by key and slices of the same length
+// element by element; anything else is recorded as a whole.  Fields of
+// embedded structs are named as if they were fields of the outer struct.
+// Fields whose path is in ignore are not compared.
+func Diff(before, after interface{}, ignore ...string) []auditlog.Change {
+	ignored := make(map[string]bool)
+	for _, path := range ignore {
+		ignored[path] = true
+	}
+	d := &differ{ignored: ignored}
+	d.diff("", reflect.ValueOf(before), reflect.ValueOf(after))
+	return d.changes
+}
+
+type differ struct {
+	ignored map[string]bool
+	changes []auditlog.Change
+}
+
+func (d *differ) diff(path string, before, after reflect.Value) {
+	if d.ignored[path] {
+		return
+	}
+	before, after = indirect(before), indirect(after)
+	if !before.IsValid() && !after.IsValid() {
+		return
+	} else if !before.IsValid() || !after.IsValid() || before.Type() != after.Type() {
+		d.record(path, before, after)
+		return
+	}
+	if reflect.DeepEqual(before.Interface(), after.Interface()) {
+		return
+	}
+
+	switch before.Kind() {
+	case reflect.Struct:
+		if before.Type() == timeType {
+			d.record(path, before, after)
+			return
+		}
+		for i := 0; i < before.NumField(); i++ {
+			field := before.Type().Field(i)
+			if field.PkgPath != "" {
+				// unexported
+				continue
+			}
+			fieldPath := path
+			if !field.Anonymous {
+				fieldPath = joinPath(path, field.Name)
+			}
+			d.diff(fieldPath, before.Field(i), after.Field(i))
+		}
+	case reflect.Map:
+		keys := make(map[string]reflect.Value)
+		for _, key := range append(before.MapKeys(), after.MapKeys()...) {
+			keys[fmt.Sprint(key.Interface())] = key
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			key := keys[name]
+			d.diff(fmt.Sprintf("%s[%s]", path, name), before.MapIndex(key), after.MapIndex(key))
+		}
+	case reflect.Slice, reflect.Array:
+		if before.Len() != after.Len() {
+			d.record(path, before, after)
+			return
+		}
+		for i := 0; i < before.Len(); i++ {
+			d.diff(fmt.Sprintf("%s[%d]", path, i), before.Index(i), after.Index(i))
+		}
+	default:
+		d.record(path, before, after)
+	}
+}
+
+func (d *differ) record(path string, before, after reflect.Value) {
+	d.changes = append(d.changes, auditlog.Change{
+		Field:  path,
+		Before: format(before),
+		After:  format(after),
+	})
+}
+
+// indirect follows pointers and interfaces to the value they refer to.  A nil
+// pointer or interface becomes the invalid value.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// format returns the value as a string, using JSON for anything other than a
+// simple value.  The invalid value, for something that was added or removed,
+// is the empty string.
+func format(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface())
+	}
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339)
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	return string(data)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,111 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package audit_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/control-center/serviced/audit"
+	"github.com/control-center/serviced/domain/auditlog"
+)
+
+type DiffBase struct {
+	Version int
+}
+
+type diffChild struct {
+	Name  string
+	Ports []int
+}
+
+type diffSubject struct {
+	DiffBase
+	Name        string
+	Instances   int
+	Environment []string
+	Children    []diffChild
+	Files       map[string]diffChild
+	Parent      *diffChild
+	UpdatedAt   time.Time
+	hidden      string
+}
+
+func TestDiffIdentical(t *testing.T) {
+	subject := diffSubject{Name: "svc", Environment: []string{"A=1"}, Files: map[string]diffChild{"f": {Name: "f"}}}
+	if changes := audit.Diff(subject, subject); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	before := diffSubject{
+		DiffBase:    DiffBase{Version: 1},
+		Name:        "svc",
+		Instances:   1,
+		Environment: []string{"A=1", "B=2"},
+		Children:    []diffChild{{Name: "a", Ports: []int{80}}},
+		Files: map[string]diffChild{
+			"/etc/a.conf": {Name: "old"},
+			"/etc/b.conf": {Name: "gone"},
+		},
+		UpdatedAt: time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC),
+		hidden:    "before",
+	}
+	after := diffSubject{
+		DiffBase:    DiffBase{Version: 2},
+		Name:        "svc",
+		Instances:   3,
+		Environment: []string{"A=1", "B=3"},
+		Children:    []diffChild{{Name: "a", Ports: []int{80, 443}}},
+		Files: map[string]diffChild{
+			"/etc/a.conf": {Name: "new"},
+			"/etc/c.conf": {Name: "added"},
+		},
+		Parent:    &diffChild{Name: "parent"},
+		UpdatedAt: time.Date(2019, 5, 2, 0, 0, 0, 0, time.UTC),
+		hidden:    "after",
+	}
+
+	changes := audit.Diff(before, &after, "UpdatedAt")
+	expected := []auditlog.Change{
+		{Field: "Version", Before: "1", After: "2"},
+		{Field: "Instances", Before: "1", After: "3"},
+		{Field: "Environment[1]", Before: "B=2", After: "B=3"},
+		{Field: "Children[0].Ports", Before: "[80]", After: "[80,443]"},
+		{Field: "Files[/etc/a.conf].Name", Before: "old", After: "new"},
+		{Field: "Files[/etc/b.conf]", Before: `{"Name":"gone","Ports":null}`, After: ""},
+		{Field: "Files[/etc/c.conf]", Before: "", After: `{"Name":"added","Ports":null}`},
+		{Field: "Parent", Before: "", After: `{"Name":"parent","Ports":null}`},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %+v, got %+v", expected, changes)
+	}
+}
+
+func TestDiffTime(t *testing.T) {
+	before := diffSubject{UpdatedAt: time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)}
+	after := diffSubject{UpdatedAt: time.Date(2019, 5, 2, 0, 0, 0, 0, time.UTC)}
+
+	changes := audit.Diff(before, after)
+	expected := []auditlog.Change{
+		{Field: "UpdatedAt", Before: "2019-05-01T00:00:00Z", After: "2019-05-02T00:00:00Z"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %+v, got %+v", expected, changes)
+	}
+}
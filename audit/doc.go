@@ -38,6 +38,14 @@
 
 		time="2017-05-11T19:41:10Z" level=warning msg="Adding Resource Pool Swimming" action=add success=false user=system type=resourcepool id=Swimming
 
+	Audit Trail
+
+	A logger created with NewStoreLogger also stores each entry as an auditlog.Event in the datastore, using the
+	context passed to "Message".  The "user", "action", "type", "id" and "success" fields are stored as the event's
+	User, Action, Type, EntityID and Success; any other fields are kept in the event's Fields.  Stored events can be
+	searched with "serviced audit list" or the /api/v2/audit endpoint.  An entry that cannot be stored is still
+	written to the log file, and does not fail the action being audited.
+
 	API
 
 	The Logger interface provides a fluent API for creating log entries.  A new Logger can be retrieved by calling the NewLogger method.
@@ -14,11 +14,16 @@
 package audit
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/auditlog"
 	"github.com/control-center/serviced/logging"
+	"github.com/control-center/serviced/utils"
 	"github.com/zenoss/logri"
 )
 
@@ -49,6 +54,9 @@ type Logger interface {
 	// Add additional fields to the entry.
 	WithFields(fields logrus.Fields) Logger
 
+	// Set the changes made to the entity, as returned by Diff.
+	Changes(changes []auditlog.Change) Logger
+
 	// Log that the action succeeded.
 	Succeeded()
 
@@ -70,10 +78,21 @@ func NewLogger() Logger {
 	return &logger{loggeri: l}
 }
 
+// NewStoreLogger returns an audit logger that, in addition to writing to the
+// audit log file, stores each entry in the audit trail so that it can be
+// searched later.
+func NewStoreLogger(store auditlog.Store) Logger {
+	l := logri.GetLogger("audit")
+	return &logger{loggeri: l, store: store}
+}
+
 type logger struct {
 	entry   *logrus.Entry
 	message string
 	loggeri *logri.Logger
+	ctx     datastore.Context
+	store   auditlog.Store
+	changes []auditlog.Change
 }
 
 func (l *logger) Action(action string) Logger {
@@ -83,6 +102,7 @@ func (l *logger) Action(action string) Logger {
 func (l *logger) Message(ctx datastore.Context, message string) Logger {
 	result := l.newLoggerWith("user", ctx.User())
 	result.message = message
+	result.ctx = ctx
 	return result
 }
 
@@ -105,6 +125,16 @@ func (l *logger) WithField(name string, value string) Logger {
 	return l.newLoggerWith(name, value)
 }
 
+func (l *logger) Changes(changes []auditlog.Change) Logger {
+	fields := make([]string, len(changes))
+	for i, change := range changes {
+		fields[i] = change.Field
+	}
+	result := l.newLoggerWith("changed", strings.Join(fields, ","))
+	result.changes = changes
+	return result
+}
+
 func (l *logger) newLoggerWith(name string, value string) *logger {
 	return l.newLoggerWithFields(logrus.Fields{name: value})
 }
@@ -114,6 +144,9 @@ func (l *logger) newLoggerWithFields(fields logrus.Fields) *logger {
 		entry:   l.entry,
 		message: l.message,
 		loggeri: l.loggeri,
+		ctx:     l.ctx,
+		store:   l.store,
+		changes: l.changes,
 	}
 	result.addFields(fields)
 	return result
@@ -166,4 +199,46 @@ func (l *logger) log(success bool) {
 	} else {
 		entry.Warn(l.message)
 	}
+	l.persist(success)
+}
+
+// persist stores the entry in the audit trail.  Failing to store the entry
+// does not fail the action being audited.
+func (l *logger) persist(success bool) {
+	if l.store == nil || l.ctx == nil {
+		return
+	}
+	id, err := utils.NewUUID62()
+	if err != nil {
+		plog.WithError(err).WithFields(l.entry.Data).Warn("Unable to create an id for the audit event")
+		return
+	}
+	event := auditlog.Event{
+		ID:      id,
+		Time:    time.Now().UTC(),
+		Message: l.message,
+		Success: success,
+		Changes: l.changes,
+	}
+	for name, value := range l.entry.Data {
+		switch name {
+		case "user":
+			event.User = fmt.Sprint(value)
+		case "action":
+			event.Action = fmt.Sprint(value)
+		case "type":
+			event.Type = fmt.Sprint(value)
+		case "id":
+			event.EntityID = fmt.Sprint(value)
+		case "success", "changed":
+		default:
+			if event.Fields == nil {
+				event.Fields = make(map[string]string)
+			}
+			event.Fields[name] = fmt.Sprint(value)
+		}
+	}
+	if err := l.store.Put(l.ctx, &event); err != nil {
+		plog.WithError(err).WithFields(l.entry.Data).Warn("Unable to store the audit event")
+	}
 }
@@ -0,0 +1,81 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package audit_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/control-center/serviced/audit"
+	datastoremocks "github.com/control-center/serviced/datastore/mocks"
+	"github.com/control-center/serviced/domain/auditlog"
+	auditlogmocks "github.com/control-center/serviced/domain/auditlog/mocks"
+	"github.com/control-center/serviced/metrics"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestContext() *datastoremocks.Context {
+	ctx := &datastoremocks.Context{}
+	ctx.On("User").Return("oncall")
+	ctx.On("Metrics").Return(metrics.NewMetrics())
+	return ctx
+}
+
+func TestStoreLoggerPersistsEvent(t *testing.T) {
+	ctx := newTestContext()
+	store := &auditlogmocks.Store{}
+	var event *auditlog.Event
+	store.On("Put", ctx, mock.AnythingOfType("*auditlog.Event")).Return(nil).Run(func(args mock.Arguments) {
+		event = args.Get(1).(*auditlog.Event)
+	})
+
+	err := audit.NewStoreLogger(store).Message(ctx, "Stopping Service").Action(audit.Stop).
+		Type("service").ID("svc1").WithField("reason", "maintenance").
+		Changes([]auditlog.Change{{Field: "Instances", Before: "1", After: "2"}}).Error(errors.New("failed"))
+
+	if err == nil || err.Error() != "failed" {
+		t.Fatalf("expected the error to be passed through, got %v", err)
+	}
+	if event == nil {
+		t.Fatal("expected an event to be stored")
+	}
+	if event.ID == "" || event.Time.IsZero() {
+		t.Errorf("expected an id and time, got %+v", event)
+	}
+	if event.User != "oncall" || event.Action != audit.Stop || event.Type != "service" || event.EntityID != "svc1" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if event.Message != "Stopping Service" || event.Success {
+		t.Errorf("unexpected outcome %+v", event)
+	}
+	if len(event.Fields) != 1 || event.Fields["reason"] != "maintenance" {
+		t.Errorf("unexpected fields %v", event.Fields)
+	}
+	if len(event.Changes) != 1 || event.Changes[0].Field != "Instances" {
+		t.Errorf("unexpected changes %v", event.Changes)
+	}
+}
+
+func TestStoreLoggerIgnoresStoreErrors(t *testing.T) {
+	ctx := newTestContext()
+	store := &auditlogmocks.Store{}
+	store.On("Put", ctx, mock.AnythingOfType("*auditlog.Event")).Return(errors.New("unavailable"))
+
+	if err := audit.NewStoreLogger(store).Message(ctx, "Adding Host").Action(audit.Add).Error(nil); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+	store.AssertNumberOfCalls(t, "Put", 1)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import "github.com/control-center/serviced/audit"
 import "github.com/control-center/serviced/datastore"
+import "github.com/control-center/serviced/domain/auditlog"
 import "github.com/Sirupsen/logrus"
 import "github.com/stretchr/testify/mock"
 
@@ -105,6 +106,18 @@ func (_m *Logger) WithFields(fields logrus.Fields) audit.Logger {
 
 	return r0
 }
+func (_m *Logger) Changes(changes []auditlog.Change) audit.Logger {
+	ret := _m.Called(changes)
+
+	var r0 audit.Logger
+	if rf, ok := ret.Get(0).(func() audit.Logger); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(audit.Logger)
+	}
+
+	return r0
+}
 func (_m *Logger) Succeeded() {
 	_m.Called()
 }
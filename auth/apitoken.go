@@ -0,0 +1,155 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// apiTokenAudience distinguishes api tokens from the other bearer tokens
+// accepted by the REST api.
+const apiTokenAudience = "serviced-api"
+
+var (
+	// Verify jwtAPIToken implements the APIToken interface
+	_ APIToken   = &jwtAPIToken{}
+	_ jwt.Claims = &jwtAPIToken{}
+)
+
+// APIToken is a long-lived bearer token that automation uses to call the REST
+// api.  The token is signed by the master; its scopes are the role and tenants
+// it was issued with.
+type APIToken interface {
+	Valid() error
+	Expired() bool
+	ID() string
+	User() string
+	Role() Role
+	Tenants() []string
+	Expiration() int64
+}
+
+type jwtAPIToken struct {
+	TokenID     string   `json:"jti,omitempty"`
+	Subject     string   `json:"sub,omitempty"`
+	Audience    string   `json:"aud,omitempty"`
+	IssuedAt    int64    `json:"iat,omitempty"`
+	ExpiresAt   int64    `json:"exp,omitempty"`
+	ScopeRole   Role     `json:"rol,omitempty"`
+	ScopeTenant []string `json:"ten,omitempty"`
+}
+
+// CreateAPIToken returns a signed api token.  A zero expiration creates a
+// token that does not expire.
+func CreateAPIToken(id, user string, role Role, tenants []string, expiration time.Time) (string, error) {
+	now := jwt.TimeFunc().UTC()
+	claims := &jwtAPIToken{
+		TokenID:     id,
+		Subject:     user,
+		Audience:    apiTokenAudience,
+		IssuedAt:    now.Unix(),
+		ScopeRole:   role,
+		ScopeTenant: tenants,
+	}
+	if !expiration.IsZero() {
+		claims.ExpiresAt = expiration.UTC().Unix()
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodPS256, claims)
+	masterPrivKey, err := getMasterPrivateKey()
+	if err != nil {
+		return "", err
+	}
+	return token.SignedString(masterPrivKey)
+}
+
+// ParseAPIToken parses an api token, verifying that it was signed by the
+// master.  Returns ErrNotAPIToken if the token is some other kind of bearer
+// token.
+func ParseAPIToken(token string) (APIToken, error) {
+	claims := &jwtAPIToken{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		if claims.Audience != apiTokenAudience {
+			return nil, ErrNotAPIToken
+		}
+		// Validate the algorithm matches the key
+		if _, ok := token.Method.(*jwt.SigningMethodRSAPSS); !ok {
+			return nil, ErrInvalidSigningMethod
+		}
+		return GetMasterPublicKey()
+	})
+	if err != nil {
+		if verr, ok := err.(*jwt.ValidationError); ok {
+			if verr.Inner == ErrNotAPIToken || verr.Inner == ErrBadAPIToken {
+				return nil, verr.Inner
+			}
+			if verr.Errors&jwt.ValidationErrorMalformed != 0 {
+				return nil, ErrNotAPIToken
+			}
+			if verr.Inner == ErrAPITokenExpired {
+				return nil, ErrAPITokenExpired
+			}
+			if verr.Errors&(jwt.ValidationErrorSignatureInvalid|jwt.ValidationErrorUnverifiable) != 0 {
+				return nil, ErrAPITokenBadSig
+			}
+			if verr.Inner != nil {
+				return nil, verr.Inner
+			}
+		}
+		return nil, err
+	}
+	if claims, ok := parsed.Claims.(*jwtAPIToken); ok && parsed.Valid {
+		return claims, nil
+	}
+	return nil, ErrBadAPIToken
+}
+
+func (t *jwtAPIToken) Valid() error {
+	if t.TokenID == "" || t.Subject == "" {
+		return ErrBadAPIToken
+	}
+	if t.Expired() {
+		return ErrAPITokenExpired
+	}
+	return nil
+}
+
+func (t *jwtAPIToken) Expired() bool {
+	if t.ExpiresAt == 0 {
+		return false
+	}
+	now := jwt.TimeFunc().UTC().Unix()
+	return now >= t.ExpiresAt
+}
+
+func (t *jwtAPIToken) ID() string {
+	return t.TokenID
+}
+
+func (t *jwtAPIToken) User() string {
+	return t.Subject
+}
+
+func (t *jwtAPIToken) Role() Role {
+	return t.ScopeRole
+}
+
+func (t *jwtAPIToken) Tenants() []string {
+	return t.ScopeTenant
+}
+
+func (t *jwtAPIToken) Expiration() int64 {
+	return t.ExpiresAt
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package auth_test
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/auth"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestAuthSuite) TestCreateAndParseAPIToken(c *C) {
+	expiration := time.Now().Add(time.Hour)
+	token, err := auth.CreateAPIToken("tokenid", "jenkins", auth.RoleOperator, []string{"tenant1"}, expiration)
+	c.Assert(err, IsNil)
+
+	parsed, err := auth.ParseAPIToken(token)
+	c.Assert(err, IsNil)
+	c.Assert(parsed.ID(), Equals, "tokenid")
+	c.Assert(parsed.User(), Equals, "jenkins")
+	c.Assert(parsed.Role(), Equals, auth.RoleOperator)
+	c.Assert(parsed.Tenants(), DeepEquals, []string{"tenant1"})
+	c.Assert(parsed.Expiration(), Equals, expiration.Unix())
+	c.Assert(parsed.Expired(), Equals, false)
+}
+
+func (s *TestAuthSuite) TestAPITokenNeverExpires(c *C) {
+	token, err := auth.CreateAPIToken("tokenid", "jenkins", auth.RoleViewer, nil, time.Time{})
+	c.Assert(err, IsNil)
+
+	parsed, err := auth.ParseAPIToken(token)
+	c.Assert(err, IsNil)
+	c.Assert(parsed.Expiration(), Equals, int64(0))
+	c.Assert(parsed.Expired(), Equals, false)
+}
+
+func (s *TestAuthSuite) TestExpiredAPIToken(c *C) {
+	token, err := auth.CreateAPIToken("tokenid", "jenkins", auth.RoleViewer, nil, time.Now().Add(-time.Hour))
+	c.Assert(err, IsNil)
+
+	_, err = auth.ParseAPIToken(token)
+	c.Assert(err, Equals, auth.ErrAPITokenExpired)
+}
+
+func (s *TestAuthSuite) TestParseAPITokenRejectsOtherTokens(c *C) {
+	identity, _, err := auth.CreateJWTIdentity(s.hostId, s.poolId, s.admin, s.dfs, s.delegatePubPEM, time.Hour)
+	c.Assert(err, IsNil)
+
+	_, err = auth.ParseAPIToken(identity)
+	c.Assert(err, Equals, auth.ErrNotAPIToken)
+
+	_, err = auth.ParseAPIToken("garbage")
+	c.Assert(err, Equals, auth.ErrNotAPIToken)
+}
@@ -59,6 +59,14 @@ var (
 	ErrBadRestToken = errors.New("Invalid rest token")
 	// ErrRestTokenBadSig is thrown when a rest token has a bad signature
 	ErrRestTokenBadSig = errors.New("Rest token signature cannot be verified")
+	// ErrAPITokenExpired is thrown when an api token is expired
+	ErrAPITokenExpired = errors.New("API token expired")
+	// ErrBadAPIToken is thrown when an api token cant be parsed or is missing required claims
+	ErrBadAPIToken = errors.New("Invalid API token")
+	// ErrAPITokenBadSig is thrown when an api token has a bad signature
+	ErrAPITokenBadSig = errors.New("API token signature cannot be verified")
+	// ErrNotAPIToken is thrown when a bearer token is not an api token
+	ErrNotAPIToken = errors.New("Not an API token")
 	// ErrSSHFailed is thrown when we can't ssh to a remote host to register keys
 	ErrSSHFailed = errors.New("Unable to make an ssh connection to host")
 	// ErrAuth0TokenExpired is thrown when an auth0 token is expired
@@ -90,5 +98,6 @@ type Identity interface {
 	PoolID() string
 	HasAdminAccess() bool
 	HasDFSAccess() bool
+	Role() Role
 	Verifier() (Verifier, error)
 }
@@ -65,6 +65,7 @@ func (t *jwtAuth0Claims) Valid() error {
 
 type Auth0Token interface {
 	HasAdminAccess() bool
+	Role() Role
 	User() string
 	Expiration() int64
 }
@@ -110,6 +111,15 @@ func (t *jwtAuth0Claims) HasAdminAccess() bool {
 	return found
 }
 
+// Role returns the role granted by the token's groups.  Members of the
+// configured Auth0 groups are cluster administrators.
+func (t *jwtAuth0Claims) Role() Role {
+	if t.HasAdminAccess() {
+		return RoleClusterAdmin
+	}
+	return RoleNone
+}
+
 type JSONWebkeys struct {
 	Kty string   `json:"kty"`
 	Kid string   `json:"kid"`
@@ -50,6 +50,7 @@ type jwtIdentity struct {
 	AdminAccess bool   `json:"adm,omitempty"`
 	DFSAccess   bool   `json:"dfs,omitempty"`
 	PubKey      string `json:"key,omitempty"`
+	UserRole    Role   `json:"rol,omitempty"`
 }
 
 // ParseJWTIdentity parses a JSON Web Token string, verifying that it was signed by the master.
@@ -85,15 +86,26 @@ func ParseJWTIdentity(token string) (Identity, error) {
 
 // CreateJWTIdentity returns a signed string
 func CreateJWTIdentity(hostID, poolID string, admin, dfs bool, pubKeyPEM []byte, expiration time.Duration) (string, int64, error) {
+	role := RoleNone
+	if admin {
+		role = RoleClusterAdmin
+	}
+	return CreateJWTIdentityWithRole(hostID, poolID, role, dfs, pubKeyPEM, expiration)
+}
+
+// CreateJWTIdentityWithRole returns a signed string for an identity with the
+// given role.  Only cluster admins have admin access.
+func CreateJWTIdentityWithRole(hostID, poolID string, role Role, dfs bool, pubKeyPEM []byte, expiration time.Duration) (string, int64, error) {
 	now := jwt.TimeFunc().UTC()
 	claims := &jwtIdentity{
 		Host:        hostID,
 		Pool:        poolID,
 		ExpiresAt:   now.Add(expiration).Unix(),
 		IssuedAt:    now.Unix(),
-		AdminAccess: admin,
+		AdminAccess: role == RoleClusterAdmin,
 		DFSAccess:   dfs,
 		PubKey:      string(pubKeyPEM),
+		UserRole:    role,
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodPS256, claims)
 	masterPrivKey, err := getMasterPrivateKey()
@@ -142,6 +154,18 @@ func (id *jwtIdentity) HasDFSAccess() bool {
 	return id.DFSAccess
 }
 
+// Role returns the role claim of the token.  Tokens issued without one have
+// the role implied by their admin access.
+func (id *jwtIdentity) Role() Role {
+	if id.UserRole != RoleNone {
+		return id.UserRole
+	}
+	if id.AdminAccess {
+		return RoleClusterAdmin
+	}
+	return RoleNone
+}
+
 func (id *jwtIdentity) Verifier() (Verifier, error) {
 	return RSAVerifierFromPEM([]byte(id.PubKey))
 }
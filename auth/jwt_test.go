@@ -47,6 +47,27 @@ func (s *TestAuthSuite) TestIdentityHappyPath(c *C) {
 	c.Assert(err, IsNil)
 }
 
+func (s *TestAuthSuite) TestIdentityRole(c *C) {
+	token, _, err := auth.CreateJWTIdentityWithRole("host", "pool", auth.RoleOperator, false, s.delegatePubPEM, time.Minute)
+	c.Assert(err, IsNil)
+	identity, err := auth.ParseJWTIdentity(token)
+	c.Assert(err, IsNil)
+	c.Assert(identity.Role(), Equals, auth.RoleOperator)
+	c.Assert(identity.HasAdminAccess(), Equals, false)
+
+	token, _, err = auth.CreateJWTIdentity("host", "pool", true, false, s.delegatePubPEM, time.Minute)
+	c.Assert(err, IsNil)
+	identity, err = auth.ParseJWTIdentity(token)
+	c.Assert(err, IsNil)
+	c.Assert(identity.Role(), Equals, auth.RoleClusterAdmin)
+
+	token, _, err = auth.CreateJWTIdentity("host", "pool", false, false, s.delegatePubPEM, time.Minute)
+	c.Assert(err, IsNil)
+	identity, err = auth.ParseJWTIdentity(token)
+	c.Assert(err, IsNil)
+	c.Assert(identity.Role(), Equals, auth.RoleNone)
+}
+
 func (s *TestAuthSuite) TestExpiredToken(c *C) {
 	token, _, _ := auth.CreateJWTIdentity("host", "pool", true, false, s.delegatePubPEM, time.Minute)
 
@@ -40,6 +40,7 @@ type RestToken interface {
 	RestToken() string
 	ValidateRequestHash(r *http.Request) bool
 	HasAdminAccess() bool
+	Role() Role
 }
 
 type jwtRestClaims struct {
@@ -80,6 +81,10 @@ func (t *jwtRestToken) HasAdminAccess() bool {
 	return t.authIdentity.HasAdminAccess()
 }
 
+func (t *jwtRestToken) Role() Role {
+	return t.authIdentity.Role()
+}
+
 func (t *jwtRestToken) RestToken() string {
 	return t.restToken
 }
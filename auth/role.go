@@ -0,0 +1,92 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Role is the level of access granted to a user or token.  Roles are ordered,
+// and each role is allowed to do everything the roles below it can do.
+type Role string
+
+const (
+	// RoleNone is the absence of a role; it satisfies no requirement
+	RoleNone Role = ""
+	// RoleViewer may read the state of the cluster
+	RoleViewer Role = "viewer"
+	// RoleOperator may also start, stop and restart services
+	RoleOperator Role = "operator"
+	// RoleTenantAdmin may also add, edit and remove services and templates
+	RoleTenantAdmin Role = "tenant-admin"
+	// RoleClusterAdmin may do everything, including managing hosts, pools,
+	// users and backups
+	RoleClusterAdmin Role = "cluster-admin"
+)
+
+var (
+	// ErrInvalidRole is returned when a role name is not recognized
+	ErrInvalidRole = errors.New("invalid role")
+
+	roles = []Role{RoleViewer, RoleOperator, RoleTenantAdmin, RoleClusterAdmin}
+)
+
+// Roles returns all of the valid roles, from the least to the most privileged
+func Roles() []Role {
+	result := make([]Role, len(roles))
+	copy(result, roles)
+	return result
+}
+
+// ParseRole returns the role with the given name
+func ParseRole(name string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(name)))
+	if !role.Valid() {
+		return RoleNone, fmt.Errorf("%s: %q (expected one of %s)", ErrInvalidRole, name, roleNames())
+	}
+	return role, nil
+}
+
+// Valid returns true if the role is one of the known roles
+func (r Role) Valid() bool {
+	return r.rank() > 0
+}
+
+// Satisfies returns true if the role is allowed to perform an action that
+// requires the given role.
+func (r Role) Satisfies(required Role) bool {
+	if required == RoleNone {
+		return true
+	}
+	return r.Valid() && r.rank() >= required.rank()
+}
+
+func (r Role) rank() int {
+	for i, role := range roles {
+		if r == role {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func roleNames() string {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,41 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package auth_test
+
+import (
+	"github.com/control-center/serviced/auth"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestAuthSuite) TestParseRole(c *C) {
+	role, err := auth.ParseRole(" Tenant-Admin ")
+	c.Assert(err, IsNil)
+	c.Assert(role, Equals, auth.RoleTenantAdmin)
+
+	role, err = auth.ParseRole("superuser")
+	c.Assert(err, NotNil)
+	c.Assert(role, Equals, auth.RoleNone)
+}
+
+func (s *TestAuthSuite) TestRoleSatisfies(c *C) {
+	c.Assert(auth.RoleClusterAdmin.Satisfies(auth.RoleViewer), Equals, true)
+	c.Assert(auth.RoleOperator.Satisfies(auth.RoleOperator), Equals, true)
+	c.Assert(auth.RoleOperator.Satisfies(auth.RoleTenantAdmin), Equals, false)
+	c.Assert(auth.RoleViewer.Satisfies(auth.RoleNone), Equals, true)
+	c.Assert(auth.RoleNone.Satisfies(auth.RoleViewer), Equals, false)
+	c.Assert(auth.Role("superuser").Satisfies(auth.RoleViewer), Equals, false)
+}
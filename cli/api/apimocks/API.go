@@ -1,8 +1,12 @@
 package mocks
 
 import api "github.com/control-center/serviced/cli/api"
+import auth "github.com/control-center/serviced/auth"
+import apitoken "github.com/control-center/serviced/domain/apitoken"
+import auditlog "github.com/control-center/serviced/domain/auditlog"
 import applicationendpoint "github.com/control-center/serviced/domain/applicationendpoint"
 import dao "github.com/control-center/serviced/dao"
+import health "github.com/control-center/serviced/health"
 import host "github.com/control-center/serviced/domain/host"
 import io "io"
 import isvcs "github.com/control-center/serviced/isvcs"
@@ -13,7 +17,10 @@ import script "github.com/control-center/serviced/script"
 import "github.com/control-center/serviced/utils"
 import service "github.com/control-center/serviced/domain/service"
 import servicedefinition "github.com/control-center/serviced/domain/servicedefinition"
+import servicerevision "github.com/control-center/serviced/domain/servicerevision"
 import servicetemplate "github.com/control-center/serviced/domain/servicetemplate"
+import snapshotschedule "github.com/control-center/serviced/domain/snapshotschedule"
+import user "github.com/control-center/serviced/domain/user"
 import volume "github.com/control-center/serviced/volume"
 
 // API is an autogenerated mock type for the API type
@@ -21,6 +28,50 @@ type API struct {
 	mock.Mock
 }
 
+// AbortImageUpgrade provides a mock function with given fields: serviceID
+func (_m *API) AbortImageUpgrade(serviceID string) error {
+	ret := _m.Called(serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddAPIToken provides a mock function with given fields: _a0
+func (_m *API) AddAPIToken(_a0 api.APITokenConfig) (*apitoken.APIToken, string, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *apitoken.APIToken
+	if rf, ok := ret.Get(0).(func(api.APITokenConfig) *apitoken.APIToken); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apitoken.APIToken)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(api.APITokenConfig) string); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(api.APITokenConfig) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // AddHost provides a mock function with given fields: _a0
 func (_m *API) AddHost(_a0 api.HostConfig) (*host.Host, []byte, error) {
 	ret := _m.Called(_a0)
@@ -249,6 +300,393 @@ func (_m *API) AssignIP(_a0 api.IPConfig) error {
 	return r0
 }
 
+// CancelRollingRestart provides a mock function with given fields: serviceID
+func (_m *API) CancelRollingRestart(serviceID string) error {
+	ret := _m.Called(serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAPITokens provides a mock function with given fields: 
+func (_m *API) GetAPITokens() ([]apitoken.APIToken, error) {
+	ret := _m.Called()
+
+	var r0 []apitoken.APIToken
+	if rf, ok := ret.Get(0).(func() []apitoken.APIToken); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]apitoken.APIToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuditEvents provides a mock function with given fields: _a0
+func (_m *API) GetAuditEvents(_a0 auditlog.Query) ([]auditlog.Event, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []auditlog.Event
+	if rf, ok := ret.Get(0).(func(auditlog.Query) []auditlog.Event); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auditlog.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(auditlog.Query) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetImageUpgradeStatus provides a mock function with given fields: serviceID
+func (_m *API) GetImageUpgradeStatus(serviceID string) (*service.ImageUpgradeStatus, error) {
+	ret := _m.Called(serviceID)
+
+	var r0 *service.ImageUpgradeStatus
+	if rf, ok := ret.Get(0).(func(string) *service.ImageUpgradeStatus); ok {
+		r0 = rf(serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ImageUpgradeStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPlacementDecisions provides a mock function with given fields: serviceID
+func (_m *API) GetPlacementDecisions(serviceID string) ([]service.PlacementDecision, error) {
+	ret := _m.Called(serviceID)
+
+	var r0 []service.PlacementDecision
+	if rf, ok := ret.Get(0).(func(string) []service.PlacementDecision); ok {
+		r0 = rf(serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.PlacementDecision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPoolUsage provides a mock function with given fields: _a0
+func (_m *API) GetPoolUsage(_a0 string) (*pool.PoolUsage, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *pool.PoolUsage
+	if rf, ok := ret.Get(0).(func(string) *pool.PoolUsage); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.PoolUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRollingRestartStatus provides a mock function with given fields: serviceID
+func (_m *API) GetRollingRestartStatus(serviceID string) (*service.RollingRestartStatus, error) {
+	ret := _m.Called(serviceID)
+
+	var r0 *service.RollingRestartStatus
+	if rf, ok := ret.Get(0).(func(string) *service.RollingRestartStatus); ok {
+		r0 = rf(serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.RollingRestartStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceHealthHistory provides a mock function with given fields: serviceID
+func (_m *API) GetServiceHealthHistory(serviceID string) (map[int]map[string]health.HealthCheckHistory, error) {
+	ret := _m.Called(serviceID)
+
+	var r0 map[int]map[string]health.HealthCheckHistory
+	if rf, ok := ret.Get(0).(func(string) map[int]map[string]health.HealthCheckHistory); ok {
+		r0 = rf(serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]map[string]health.HealthCheckHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceRevision provides a mock function with given fields: serviceID, revision
+func (_m *API) GetServiceRevision(serviceID string, revision int) (*servicerevision.Revision, error) {
+	ret := _m.Called(serviceID, revision)
+
+	var r0 *servicerevision.Revision
+	if rf, ok := ret.Get(0).(func(string, int) *servicerevision.Revision); ok {
+		r0 = rf(serviceID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*servicerevision.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(serviceID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceRevisions provides a mock function with given fields: serviceID
+func (_m *API) GetServiceRevisions(serviceID string) ([]servicerevision.Revision, error) {
+	ret := _m.Called(serviceID)
+
+	var r0 []servicerevision.Revision
+	if rf, ok := ret.Get(0).(func(string) []servicerevision.Revision); ok {
+		r0 = rf(serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]servicerevision.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSnapshotSchedule provides a mock function with given fields: _a0
+func (_m *API) GetSnapshotSchedule(_a0 string) (*snapshotschedule.Schedule, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *snapshotschedule.Schedule
+	if rf, ok := ret.Get(0).(func(string) *snapshotschedule.Schedule); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*snapshotschedule.Schedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSnapshotSchedules provides a mock function with given fields: 
+func (_m *API) GetSnapshotSchedules() ([]snapshotschedule.Schedule, error) {
+	ret := _m.Called()
+
+	var r0 []snapshotschedule.Schedule
+	if rf, ok := ret.Get(0).(func() []snapshotschedule.Schedule); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]snapshotschedule.Schedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsers provides a mock function with given fields: 
+func (_m *API) GetUsers() ([]user.User, error) {
+	ret := _m.Called()
+
+	var r0 []user.User
+	if rf, ok := ret.Get(0).(func() []user.User); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanServiceTemplateDeploy provides a mock function with given fields: _a0
+func (_m *API) PlanServiceTemplateDeploy(_a0 api.DeployTemplateConfig) (*service.ChangePlan, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *service.ChangePlan
+	if rf, ok := ret.Get(0).(func(api.DeployTemplateConfig) *service.ChangePlan); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ChangePlan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(api.DeployTemplateConfig) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanServiceUpdate provides a mock function with given fields: _a0
+func (_m *API) PlanServiceUpdate(_a0 io.Reader) (*service.ChangePlan, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *service.ChangePlan
+	if rf, ok := ret.Get(0).(func(io.Reader) *service.ChangePlan); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ChangePlan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(io.Reader) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RebalancePool provides a mock function with given fields: _a0, _a1, _a2
+func (_m *API) RebalancePool(_a0 string, _a1 int, _a2 bool) ([]service.Migration, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []service.Migration
+	if rf, ok := ret.Get(0).(func(string, int, bool) []service.Migration); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.Migration)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveAPIToken provides a mock function with given fields: _a0
+func (_m *API) RemoveAPIToken(_a0 string) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveHostLabels provides a mock function with given fields: _a0, _a1
+func (_m *API) RemoveHostLabels(_a0 string, _a1 []string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveIP provides a mock function with given fields: args
 func (_m *API) RemoveIP(args []string) error {
 	ret := _m.Called(args)
@@ -263,6 +701,122 @@ func (_m *API) RemoveIP(args []string) error {
 	return r0
 }
 
+// RemoveSnapshotSchedule provides a mock function with given fields: _a0
+func (_m *API) RemoveSnapshotSchedule(_a0 string) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResumeImageUpgrade provides a mock function with given fields: serviceID
+func (_m *API) ResumeImageUpgrade(serviceID string) error {
+	ret := _m.Called(serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResumeRollingRestart provides a mock function with given fields: serviceID
+func (_m *API) ResumeRollingRestart(serviceID string) error {
+	ret := _m.Called(serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevertService provides a mock function with given fields: serviceID, revision
+func (_m *API) RevertService(serviceID string, revision int) error {
+	ret := _m.Called(serviceID, revision)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(serviceID, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RollingRestartService provides a mock function with given fields: _a0
+func (_m *API) RollingRestartService(_a0 service.RollingRestartRequest) (*service.RollingRestartStatus, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *service.RollingRestartStatus
+	if rf, ok := ret.Get(0).(func(service.RollingRestartRequest) *service.RollingRestartStatus); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.RollingRestartStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(service.RollingRestartRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServicesHealthCheck provides a mock function with given fields:
+func (_m *API) ServicesHealthCheck() (map[string]map[int]map[string]health.HealthStatus, error) {
+	ret := _m.Called()
+
+	var r0 map[string]map[int]map[string]health.HealthStatus
+	if rf, ok := ret.Get(0).(func() map[string]map[int]map[string]health.HealthStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]map[int]map[string]health.HealthStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetHostLabels provides a mock function with given fields: _a0, _a1
+func (_m *API) SetHostLabels(_a0 string, _a1 map[string]string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, map[string]string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetIP provides a mock function with given fields: _a0
 func (_m *API) SetIP(_a0 api.IPConfig) error {
 	ret := _m.Called(_a0)
@@ -319,20 +873,20 @@ func (_m *API) AuthenticateHost(_a0 string) (string, int64, error) {
 	return r0, r1, r2
 }
 
-// Backup provides a mock function with given fields: _a0, _a1
-func (_m *API) Backup(_a0 string, _a1 []string, _a2 bool) (string, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// Backup provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *API) Backup(_a0 string, _a1 []string, _a2 bool, _a3 bool) (string, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, []string, bool) string); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(string, []string, bool, bool) string); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, []string, bool) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(string, []string, bool, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1474,6 +2028,48 @@ func (_m *API) SetHostMemory(_a0 api.HostUpdateConfig) error {
 	return r0
 }
 
+// SetSnapshotSchedule provides a mock function with given fields: _a0
+func (_m *API) SetSnapshotSchedule(_a0 snapshotschedule.Schedule) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(snapshotschedule.Schedule) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserRole provides a mock function with given fields: userName, role
+func (_m *API) SetUserRole(userName string, role auth.Role) error {
+	ret := _m.Called(userName, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, auth.Role) error); ok {
+		r0 = rf(userName, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserTenants provides a mock function with given fields: userName, tenantIDs
+func (_m *API) SetUserTenants(userName string, tenantIDs []string) error {
+	ret := _m.Called(userName, tenantIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(userName, tenantIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StartServer provides a mock function with given fields:
 func (_m *API) StartServer() error {
 	ret := _m.Called()
@@ -1544,6 +2140,29 @@ func (_m *API) StopService(_a0 api.SchedulerConfig) (int, error) {
 	return r0, r1
 }
 
+// UpgradeServiceImage provides a mock function with given fields: _a0
+func (_m *API) UpgradeServiceImage(_a0 service.ImageUpgradeRequest) (*service.ImageUpgradeStatus, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *service.ImageUpgradeStatus
+	if rf, ok := ret.Get(0).(func(service.ImageUpgradeRequest) *service.ImageUpgradeStatus); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ImageUpgradeStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(service.ImageUpgradeRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // pauseService provides a mock function with given fields: _a0
 func (_m *API) PauseService(_a0 api.SchedulerConfig) (int, error) {
 	ret := _m.Called(_a0)
@@ -0,0 +1,64 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/domain/apitoken"
+)
+
+// APITokenConfig is the deserialized data from the command-line
+type APITokenConfig struct {
+	UserName   string
+	Name       string
+	Role       auth.Role
+	TenantIDs  []string
+	Expiration time.Duration
+}
+
+// Returns a list of all api tokens
+func (a *api) GetAPITokens() ([]apitoken.APIToken, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetAPITokens()
+}
+
+// Creates a new api token, and returns it with its bearer token
+func (a *api) AddAPIToken(config APITokenConfig) (*apitoken.APIToken, string, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, bearer, err := client.AddAPIToken(config.UserName, config.Name, config.Role, config.TenantIDs, config.Expiration)
+	if err != nil {
+		return nil, "", err
+	}
+	return &token, bearer, nil
+}
+
+// Revokes an api token
+func (a *api) RemoveAPIToken(id string) error {
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+
+	return client.RemoveAPIToken(id)
+}
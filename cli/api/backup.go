@@ -24,8 +24,9 @@ import (
 
 // Dump all templates and services to a tgz file.
 // This includes a snapshot of all shared file systems
-// and exports all docker images the services depend on.
-func (a *api) Backup(dirpath string, excludes []string, force bool) (string, error) {
+// and exports all docker images the services depend on.  An incremental
+// backup only exports what changed since the latest incremental backup.
+func (a *api) Backup(dirpath string, excludes []string, force, incremental bool) (string, error) {
 	client, err := a.connectDAO()
 	if err != nil {
 		return "", err
@@ -36,6 +37,7 @@ func (a *api) Backup(dirpath string, excludes []string, force bool) (string, err
 		SnapshotSpacePercent: config.GetOptions().SnapshotSpacePercent,
 		Excludes:             excludes,
 		Force:                force,
+		Incremental:          incremental,
 	}
 
 	est := dao.BackupEstimate{}
@@ -33,12 +33,16 @@ import (
 	"github.com/control-center/serviced/dfs/nfs"
 	"github.com/control-center/serviced/dfs/registry"
 	"github.com/control-center/serviced/domain/addressassignment"
+	"github.com/control-center/serviced/domain/apitoken"
+	"github.com/control-center/serviced/domain/auditlog"
 	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/pool"
 	"github.com/control-center/serviced/domain/properties"
 	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/serviceconfigfile"
+	"github.com/control-center/serviced/domain/servicerevision"
 	"github.com/control-center/serviced/domain/servicetemplate"
+	"github.com/control-center/serviced/domain/snapshotschedule"
 	"github.com/control-center/serviced/domain/user"
 	"github.com/control-center/serviced/facade"
 	"github.com/control-center/serviced/health"
@@ -46,11 +50,13 @@ import (
 	"github.com/control-center/serviced/logging"
 	"github.com/control-center/serviced/metrics"
 	"github.com/control-center/serviced/node"
+	"github.com/control-center/serviced/notify"
 	"github.com/control-center/serviced/proxy"
 	"github.com/control-center/serviced/rpc/agent"
 	"github.com/control-center/serviced/rpc/master"
 	"github.com/control-center/serviced/rpc/rpcutils"
 	"github.com/control-center/serviced/scheduler"
+	"github.com/control-center/serviced/scheduler/strategy"
 	"github.com/control-center/serviced/servicedversion"
 	"github.com/control-center/serviced/shell"
 	"github.com/control-center/serviced/stats"
@@ -60,6 +66,7 @@ import (
 	"github.com/control-center/serviced/volume"
 	"github.com/control-center/serviced/volume/devicemapper"
 	"github.com/docker/go-units"
+	"github.com/fsnotify/fsnotify"
 
 	"github.com/control-center/serviced/web"
 	"github.com/control-center/serviced/zzk"
@@ -425,6 +432,10 @@ func (d *daemon) initZK(zks []string) (*coordclient.Client, error) {
 func (d *daemon) startMaster() (err error) {
 	log.Debug("Starting serviced master")
 	options := config.GetOptions()
+
+	// External strategies are registered before any service is validated
+	// against them.
+	d.registerExternalStrategies()
 	agentIP := options.OutboundIP
 	if agentIP == "" {
 		agentIP, err = utils.GetIPAddress()
@@ -556,6 +567,9 @@ func (d *daemon) startMaster() (err error) {
 	d.addTemplates()
 	d.startScheduler()
 	d.startPoolListener()
+	go d.startAutoscaler()
+	go d.startRebalancer()
+	go d.startNotifier()
 
 	log.Info("Started serviced master")
 
@@ -1205,6 +1219,10 @@ func (d *daemon) initDriver() datastore.Driver {
 	eDriver.AddMapping(addressassignment.MAPPING)
 	eDriver.AddMapping(serviceconfigfile.MAPPING)
 	eDriver.AddMapping(user.MAPPING)
+	eDriver.AddMapping(apitoken.MAPPING)
+	eDriver.AddMapping(auditlog.MAPPING)
+	eDriver.AddMapping(servicerevision.MAPPING)
+	eDriver.AddMapping(snapshotschedule.MAPPING)
 	err := eDriver.Initialize(10 * time.Second)
 	if err != nil {
 		log.WithError(err).Fatal("Unable to establish connection to Elastic database")
@@ -1268,6 +1286,127 @@ func (d *daemon) startLogstashPurger(initialStart, cycleTime time.Duration) {
 	}
 }
 
+func (d *daemon) startAutoscaler() {
+	options := config.GetOptions()
+	if options.AutoscaleInterval <= 0 {
+		log.Info("Autoscaling is disabled")
+		return
+	}
+	interval := time.Duration(options.AutoscaleInterval) * time.Second
+	log.WithField("interval", interval).Info("Started service autoscaler")
+	defer log.Info("Stopped service autoscaler")
+	for {
+		select {
+		case <-d.shutdown:
+			return
+		case <-time.After(interval):
+		}
+		if count, err := d.facade.AutoscaleServices(d.dsContext); err != nil {
+			log.WithError(err).Warn("Unable to autoscale services")
+		} else if count > 0 {
+			log.WithField("services", count).Debug("Autoscaled services")
+		}
+	}
+}
+
+func (d *daemon) startNotifier() {
+	options := config.GetOptions()
+	if options.NotificationConfig == "" {
+		log.Info("Alert notifications are disabled")
+		return
+	}
+	logger := log.WithField("config", options.NotificationConfig)
+	notifyConfig, err := notify.LoadConfig(options.NotificationConfig)
+	if err != nil {
+		logger.WithError(err).Error("Unable to load notification config, alert notifications are disabled")
+		return
+	}
+	notifier, err := notify.NewNotifier(*notifyConfig)
+	if err != nil {
+		logger.WithError(err).Error("Unable to set up notification sinks, alert notifications are disabled")
+		return
+	}
+	// reload the sinks, routes and silences when the config changes
+	filechanges, err := auth.NotifyOnChange(options.NotificationConfig, fsnotify.Write|fsnotify.Create, d.shutdown)
+	if err != nil {
+		logger.WithError(err).Warn("Unable to watch notification config for changes")
+	}
+	interval := time.Duration(options.AlertInterval) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	logger.WithField("interval", interval).Info("Started alert notifier")
+	defer log.Info("Stopped alert notifier")
+	for {
+		select {
+		case <-d.shutdown:
+			return
+		case <-filechanges:
+			notifyConfig, err := notify.LoadConfig(options.NotificationConfig)
+			if err != nil {
+				logger.WithError(err).Warn("Unable to reload notification config, keeping the current config")
+			} else if err := notifier.Reload(*notifyConfig); err != nil {
+				logger.WithError(err).Warn("Unable to set up notification sinks, keeping the current config")
+			} else {
+				logger.Info("Reloaded notification config")
+			}
+			continue
+		case <-time.After(interval):
+		}
+		alerts, err := d.facade.EvaluateAlerts(d.dsContext)
+		if err != nil {
+			log.WithError(err).Warn("Unable to evaluate alerts")
+			continue
+		}
+		if count := notifier.Notify(*alerts, time.Now()); count > 0 {
+			log.WithField("events", count).Debug("Sent alert notifications")
+		}
+	}
+}
+
+func (d *daemon) startRebalancer() {
+	options := config.GetOptions()
+	if options.RebalanceInterval <= 0 {
+		log.Info("Pool rebalancing is disabled")
+		return
+	}
+	interval := time.Duration(options.RebalanceInterval) * time.Second
+	log.WithField("interval", interval).Info("Started pool rebalancer")
+	defer log.Info("Stopped pool rebalancer")
+	for {
+		select {
+		case <-d.shutdown:
+			return
+		case <-time.After(interval):
+		}
+		pools, err := d.facade.GetResourcePools(d.dsContext)
+		if err != nil {
+			log.WithError(err).Warn("Unable to look up pools to rebalance")
+			continue
+		}
+		for _, p := range pools {
+			logger := log.WithField("poolid", p.ID)
+			migrations, err := d.facade.RebalancePool(d.dsContext, p.ID, options.RebalanceMaxMigrations, false)
+			if err != nil {
+				logger.WithError(err).Warn("Unable to rebalance pool")
+				continue
+			}
+			migrated := 0
+			for _, m := range migrations {
+				if m.Migrated {
+					migrated++
+				}
+			}
+			if len(migrations) > 0 {
+				logger.WithFields(logrus.Fields{
+					"planned":  len(migrations),
+					"migrated": migrated,
+				}).Info("Rebalanced pool")
+			}
+		}
+	}
+}
+
 func (d *daemon) startStorageMonitor() {
 	options := config.GetOptions()
 	defer log.Info("Stopped monitoring application storage availability")
@@ -1443,6 +1582,22 @@ func (d *daemon) startScheduler() {
 	go d.runScheduler()
 }
 
+func (d *daemon) registerExternalStrategies() {
+	options := config.GetOptions()
+	for _, definition := range options.ExternalStrategies {
+		strat, err := strategy.ParseExternalStrategy(definition)
+		if err != nil {
+			log.WithError(err).Warn("Unable to load external scheduler strategy")
+			continue
+		}
+		if err := strategy.Register(strat); err != nil {
+			log.WithField("strategy", strat.Name()).WithError(err).Warn("Unable to register external scheduler strategy")
+			continue
+		}
+		log.WithField("strategy", strat.Name()).Info("Registered external scheduler strategy")
+	}
+}
+
 func (d *daemon) addTemplates() {
 	root := utils.LocalDir("templates")
 	log := log.WithFields(logrus.Fields{
@@ -14,6 +14,7 @@
 package api
 
 import (
+	"github.com/control-center/serviced/health"
 	"github.com/control-center/serviced/isvcs"
 )
 
@@ -29,3 +30,13 @@ func (a *api) ServicedHealthCheck(IServiceNames []string) ([]isvcs.IServiceHealt
 		return results, nil
 	}
 }
+
+// ServicesHealthCheck returns the latest health check results of the
+// instances of all services
+func (a *api) ServicesHealthCheck() (map[string]map[int]map[string]health.HealthStatus, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+	return client.GetServicesHealth()
+}
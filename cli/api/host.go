@@ -19,6 +19,7 @@ import (
 	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/dao"
 	"github.com/control-center/serviced/domain/host"
+	"github.com/control-center/serviced/domain/servicedefinition"
 	"github.com/control-center/serviced/metrics"
 	"github.com/control-center/serviced/rpc/agent"
 	"github.com/control-center/serviced/rpc/master"
@@ -246,6 +247,46 @@ func (a *api) SetHostMemory(config HostUpdateConfig) error {
 	return client.UpdateHost(*h)
 }
 
+// SetHostLabels adds or replaces labels on an existing host
+func (a *api) SetHostLabels(hostID string, labels map[string]string) error {
+	for key, value := range labels {
+		if err := servicedefinition.ValidLabel(key, value); err != nil {
+			return err
+		}
+	}
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+	h, err := client.GetHost(hostID)
+	if err != nil {
+		return err
+	}
+	if h.Labels == nil {
+		h.Labels = make(map[string]string)
+	}
+	for key, value := range labels {
+		h.Labels[key] = value
+	}
+	return client.UpdateHost(*h)
+}
+
+// RemoveHostLabels removes labels from an existing host
+func (a *api) RemoveHostLabels(hostID string, keys []string) error {
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+	h, err := client.GetHost(hostID)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		delete(h.Labels, key)
+	}
+	return client.UpdateHost(*h)
+}
+
 func (a *api) AuthenticateHost(hostID string) (string, int64, error) {
 	client, err := a.connectMaster()
 	if err != nil {
@@ -16,13 +16,20 @@ package api
 import (
 	"io"
 
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/dao"
+	"github.com/control-center/serviced/domain/apitoken"
 	"github.com/control-center/serviced/domain/applicationendpoint"
+	"github.com/control-center/serviced/domain/auditlog"
 	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/pool"
 	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/domain/servicerevision"
 	template "github.com/control-center/serviced/domain/servicetemplate"
+	"github.com/control-center/serviced/domain/snapshotschedule"
+	"github.com/control-center/serviced/domain/user"
+	"github.com/control-center/serviced/health"
 	"github.com/control-center/serviced/isvcs"
 	"github.com/control-center/serviced/metrics"
 	"github.com/control-center/serviced/script"
@@ -36,6 +43,7 @@ type API interface {
 	// Server
 	StartServer() error
 	ServicedHealthCheck(IServiceNames []string) ([]isvcs.IServiceHealthResult, error)
+	ServicesHealthCheck() (map[string]map[int]map[string]health.HealthStatus, error)
 
 	// Hosts
 	GetHosts() ([]host.Host, error)
@@ -46,6 +54,8 @@ type API interface {
 	RemoveHost(string) error
 	GetHostMemory(string) (*metrics.MemoryUsageStats, error)
 	SetHostMemory(HostUpdateConfig) error
+	SetHostLabels(string, map[string]string) error
+	RemoveHostLabels(string, []string) error
 	GetHostPublicKey(string) ([]byte, error)
 	RegisterHost([]byte) error
 	RegisterRemoteHost(*host.Host, utils.URL, []byte, bool) error
@@ -62,9 +72,24 @@ type API interface {
 	RemoveResourcePool(string) error
 	UpdateResourcePool(pool pool.ResourcePool) error
 	GetPoolIPs(string) (*pool.PoolIPs, error)
+	RebalancePool(string, int, bool) ([]service.Migration, error)
+	GetPoolUsage(string) (*pool.PoolUsage, error)
 	AddVirtualIP(pool.VirtualIP) error
 	RemoveVirtualIP(pool.VirtualIP) error
 
+	// Users
+	GetUsers() ([]user.User, error)
+	SetUserRole(userName string, role auth.Role) error
+	SetUserTenants(userName string, tenantIDs []string) error
+
+	// API Tokens
+	GetAPITokens() ([]apitoken.APIToken, error)
+	AddAPIToken(APITokenConfig) (*apitoken.APIToken, string, error)
+	RemoveAPIToken(string) error
+
+	// Audit Trail
+	GetAuditEvents(auditlog.Query) ([]auditlog.Event, error)
+
 	// Services
 	GetAllServiceDetails() ([]service.ServiceDetails, error)
 	GetServiceDetails(serviceID string) (*service.ServiceDetails, error)
@@ -75,9 +100,20 @@ type API interface {
 	RemoveService(string) error
 	UpdateService(io.Reader) (*service.ServiceDetails, error)
 	UpdateServiceObj(service.Service) (*service.ServiceDetails, error)
+	PlanServiceUpdate(io.Reader) (*service.ChangePlan, error)
 	StartService(SchedulerConfig) (int, error)
 	RestartService(SchedulerConfig) (int, error)
 	RebalanceService(SchedulerConfig) (int, error)
+	RollingRestartService(service.RollingRestartRequest) (*service.RollingRestartStatus, error)
+	GetRollingRestartStatus(serviceID string) (*service.RollingRestartStatus, error)
+	ResumeRollingRestart(serviceID string) error
+	CancelRollingRestart(serviceID string) error
+	UpgradeServiceImage(service.ImageUpgradeRequest) (*service.ImageUpgradeStatus, error)
+	GetImageUpgradeStatus(serviceID string) (*service.ImageUpgradeStatus, error)
+	ResumeImageUpgrade(serviceID string) error
+	AbortImageUpgrade(serviceID string) error
+	GetPlacementDecisions(serviceID string) ([]service.PlacementDecision, error)
+	GetServiceHealthHistory(serviceID string) (map[int]map[string]health.HealthCheckHistory, error)
 	StopService(SchedulerConfig) (int, error)
 	PauseService(SchedulerConfig) (int, error)
 	AssignIP(IPConfig) error
@@ -86,6 +122,9 @@ type API interface {
 	ClearEmergency(serviceID string) (int, error)
 	RemoveIP(args []string) error
 	SetIP(IPConfig) error
+	GetServiceRevisions(serviceID string) ([]servicerevision.Revision, error)
+	GetServiceRevision(serviceID string, revision int) (*servicerevision.Revision, error)
+	RevertService(serviceID string, revision int) error
 
 	// Shell
 	StartShell(ShellConfig) error
@@ -100,6 +139,10 @@ type API interface {
 	Rollback(string, bool) error
 	TagSnapshot(string, string) error
 	RemoveSnapshotTag(string, string) (string, error)
+	GetSnapshotSchedules() ([]snapshotschedule.Schedule, error)
+	GetSnapshotSchedule(string) (*snapshotschedule.Schedule, error)
+	SetSnapshotSchedule(snapshotschedule.Schedule) error
+	RemoveSnapshotSchedule(string) error
 
 	// Templates
 	GetServiceTemplates() ([]template.ServiceTemplate, error)
@@ -108,10 +151,11 @@ type API interface {
 	RemoveServiceTemplate(string) error
 	CompileServiceTemplate(CompileTemplateConfig) (*template.ServiceTemplate, error)
 	DeployServiceTemplate(DeployTemplateConfig) ([]service.ServiceDetails, error)
+	PlanServiceTemplateDeploy(DeployTemplateConfig) (*service.ChangePlan, error)
 
 	// Backup & Restore
 	GetBackupEstimate(string, []string) (*dao.BackupEstimate, error)
-	Backup(string, []string, bool) (string, error)
+	Backup(string, []string, bool, bool) (string, error)
 	Restore(string) error
 
 	// Docker
@@ -85,6 +85,18 @@ func ValidateServerOptions(options *config.Options) error {
 		log.WithFields(logrus.Fields{
 			"poolid": options.MasterPoolID,
 		}).Debug("Using configured default pool ID")
+
+		if options.BackupSchedule != "" {
+			if _, err := utils.ParseCronSchedule(options.BackupSchedule); err != nil {
+				return fmt.Errorf("error validating backup-schedule: %s", err)
+			}
+		}
+		if options.BackupKeepLast < 0 || options.BackupKeepDays < 0 {
+			return fmt.Errorf("backup-keep-last and backup-keep-days cannot be negative")
+		}
+		if options.IncrementalBackupChain < 0 {
+			return fmt.Errorf("incremental-backup-chain cannot be negative")
+		}
 	}
 	return nil
 }
@@ -195,7 +207,18 @@ func GetDefaultOptions(cfg utils.ConfigReader) config.Options {
 		StorageMetricMonitorWindow: cfg.IntVal("STORAGE_METRIC_MONITOR_WINDOW", 300),
 		StorageLookaheadPeriod:     cfg.IntVal("STORAGE_LOOKAHEAD_PERIOD", 360),
 		StorageMinimumFreeSpace:    cfg.StringVal("STORAGE_MIN_FREE", "3G"),
+		AutoscaleInterval:          cfg.IntVal("AUTOSCALE_INTERVAL", 60),
+		ExternalStrategies:         cfg.StringSlice("EXTERNAL_STRATEGIES", []string{}),
+		RebalanceInterval:          cfg.IntVal("REBALANCE_INTERVAL", 0),
+		RebalanceMaxMigrations:     cfg.IntVal("REBALANCE_MAX_MIGRATIONS", 3),
+		NotificationConfig:         cfg.StringVal("NOTIFICATION_CONFIG", ""),
+		AlertInterval:              cfg.IntVal("ALERT_INTERVAL", 60),
 		BackupEstimatedCompression: cfg.Float64Val("BACKUP_ESTIMATED_COMPRESSION", 1.0),
+		BackupSchedule:             cfg.StringVal("BACKUP_SCHEDULE", ""),
+		BackupExcludes:             cfg.StringSlice("BACKUP_EXCLUDES", []string{}),
+		BackupKeepLast:             cfg.IntVal("BACKUP_KEEP_LAST", 0),
+		BackupKeepDays:             cfg.IntVal("BACKUP_KEEP_DAYS", 0),
+		IncrementalBackupChain:     cfg.IntVal("INCREMENTAL_BACKUP_CHAIN", 7),
 		BackupMinOverhead:          cfg.StringVal("BACKUP_MIN_OVERHEAD", "0G"),
 		// Auth0 configuration parameters. Default to empty strings - must edit in serviced.conf to configure for auth0.
 		Auth0Domain:   cfg.StringVal("AUTH0_DOMAIN", ""),
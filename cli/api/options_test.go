@@ -99,6 +99,18 @@ func (s *TestAPISuite) TestValidateServerOptionsFailsIfStorageInvalid(c *C) {
 	s.assertErrorContent(c, err, "Use of devicemapper loop back device is not allowed")
 }
 
+func (s *TestAPISuite) TestValidateServerOptionsFailsIfBackupScheduleInvalid(c *C) {
+	configReader := utils.TestConfigReader(map[string]string{"BACKUP_SCHEDULE": "0 25 * * *"})
+	testOptions := GetDefaultOptions(configReader)
+	testOptions.Master = true
+	testOptions.FSType = volume.DriverTypeBtrFS
+	config.LoadOptions(testOptions)
+
+	err := ValidateServerOptions(&testOptions)
+
+	s.assertErrorContent(c, err, "error validating backup-schedule")
+}
+
 func (s *TestAPISuite) TestValidateServerOptionsFailsIfAgentMissingEndpoint(c *C) {
 	configReader := utils.TestConfigReader(map[string]string{})
 	testOptions := GetDefaultOptions(configReader)
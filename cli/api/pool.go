@@ -15,6 +15,7 @@ package api
 
 import (
 	"github.com/control-center/serviced/domain/pool"
+	"github.com/control-center/serviced/domain/service"
 )
 
 const ()
@@ -121,3 +122,25 @@ func (a *api) RemoveVirtualIP(requestVirtualIP pool.VirtualIP) error {
 
 	return client.RemoveVirtualIP(requestVirtualIP)
 }
+
+// RebalancePool migrates service instances to even out the commitment of the
+// hosts in a pool, or only plans the migrations if dryRun is set
+func (a *api) RebalancePool(id string, limit int, dryRun bool) ([]service.Migration, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.RebalancePool(id, limit, dryRun)
+}
+
+// GetPoolUsage returns the resources committed to the services in a pool
+// against the capacity of its hosts and its limits
+func (a *api) GetPoolUsage(id string) (*pool.PoolUsage, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetPoolUsage(id)
+}
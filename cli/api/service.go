@@ -87,6 +87,15 @@ func (a *api) GetServiceDetails(serviceID string) (*service.ServiceDetails, erro
 	}
 }
 
+// cpuQuotaString formats the cpu quota (in cores) of a service, which is
+// blank if the service is unlimited
+func cpuQuotaString(quota float64) string {
+	if quota <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%g", quota)
+}
+
 func (a *api) GetServiceStatus(serviceID string) (map[string]map[string]interface{}, error) {
 	client, err := a.connectDAO()
 	if err != nil {
@@ -133,6 +142,7 @@ func (a *api) GetServiceStatus(serviceID string) (map[string]map[string]interfac
 				row["ParentID"] = ""
 			}
 			row["RAM"] = bytefmt.ByteSize(svc.RAMCommitment.Value)
+			row["CPU Quota"] = cpuQuotaString(svc.CPUQuota)
 
 			row["Status"] = svc.CurrentState
 			if svc.Instances > 0 {
@@ -163,6 +173,7 @@ func (a *api) GetServiceStatus(serviceID string) (map[string]map[string]interfac
 				}
 
 				row["RAM"] = bytefmt.ByteSize(svc.RAMCommitment.Value)
+				row["CPU Quota"] = cpuQuotaString(svc.CPUQuota)
 				row["Status"] = stat.CurrentState
 				row["Hostname"] = stat.HostName
 				row["DockerID"] = fmt.Sprintf("%.12s", stat.ContainerID)
@@ -339,6 +350,21 @@ func (a *api) UpdateServiceObj(s service.Service) (*service.ServiceDetails, erro
 	return a.GetServiceDetails(s.ID)
 }
 
+// PlanServiceUpdate returns the changes that updating an existing service
+// would make, without making them
+func (a *api) PlanServiceUpdate(reader io.Reader) (*service.ChangePlan, error) {
+	var s service.Service
+	if err := json.NewDecoder(reader).Decode(&s); err != nil {
+		return nil, fmt.Errorf("could not unmarshal json: %s", err)
+	}
+
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+	return client.PlanServiceUpdate(s)
+}
+
 // StartService starts a service
 func (a *api) StartService(config SchedulerConfig) (int, error) {
 	client, err := a.connectDAO()
@@ -375,6 +401,103 @@ func (a *api) RebalanceService(config SchedulerConfig) (int, error) {
 	return affected, err
 }
 
+// RollingRestartService restarts the running instances of a service in
+// health-gated batches
+func (a *api) RollingRestartService(request service.RollingRestartRequest) (*service.RollingRestartStatus, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+	return client.RollingRestartService(request)
+}
+
+// GetRollingRestartStatus returns the progress of the most recent rolling
+// restart of a service
+func (a *api) GetRollingRestartStatus(serviceID string) (*service.RollingRestartStatus, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+	return client.GetRollingRestartStatus(serviceID)
+}
+
+// ResumeRollingRestart continues a paused rolling restart
+func (a *api) ResumeRollingRestart(serviceID string) error {
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+	return client.ResumeRollingRestart(serviceID)
+}
+
+// CancelRollingRestart stops a rolling restart before its next batch
+func (a *api) CancelRollingRestart(serviceID string) error {
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+	return client.CancelRollingRestart(serviceID)
+}
+
+// UpgradeServiceImage changes the image of the services in a service tree,
+// restarting their instances one at a time
+func (a *api) UpgradeServiceImage(request service.ImageUpgradeRequest) (*service.ImageUpgradeStatus, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+	return client.UpgradeServiceImage(request)
+}
+
+// GetImageUpgradeStatus returns the progress of the most recent image upgrade
+// of a service tree
+func (a *api) GetImageUpgradeStatus(serviceID string) (*service.ImageUpgradeStatus, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+	return client.GetImageUpgradeStatus(serviceID)
+}
+
+// ResumeImageUpgrade continues a paused image upgrade
+func (a *api) ResumeImageUpgrade(serviceID string) error {
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+	return client.ResumeImageUpgrade(serviceID)
+}
+
+// AbortImageUpgrade stops an image upgrade and rolls back to the previous
+// images
+func (a *api) AbortImageUpgrade(serviceID string) error {
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+	return client.AbortImageUpgrade(serviceID)
+}
+
+// GetPlacementDecisions returns how the scheduler chose a host for each
+// instance of a service
+func (a *api) GetPlacementDecisions(serviceID string) ([]service.PlacementDecision, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+	return client.GetPlacementDecisions(serviceID)
+}
+
+// GetServiceHealthHistory returns the recent health check results of each
+// instance of a service
+func (a *api) GetServiceHealthHistory(serviceID string) (map[int]map[string]health.HealthCheckHistory, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+	return client.GetServiceHealthHistory(serviceID)
+}
+
 // StopService stops a service
 func (a *api) StopService(config SchedulerConfig) (int, error) {
 	client, err := a.connectDAO()
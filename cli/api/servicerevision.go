@@ -0,0 +1,48 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"github.com/control-center/serviced/domain/servicerevision"
+)
+
+// Returns the stored revisions of a service, most recent first
+func (a *api) GetServiceRevisions(serviceID string) ([]servicerevision.Revision, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetServiceRevisions(serviceID)
+}
+
+// Returns a revision of a service
+func (a *api) GetServiceRevision(serviceID string, revision int) (*servicerevision.Revision, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetServiceRevision(serviceID, revision)
+}
+
+// Updates a service to the definition stored in one of its revisions
+func (a *api) RevertService(serviceID string, revision int) error {
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+
+	return client.RevertService(serviceID, revision)
+}
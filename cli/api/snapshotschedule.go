@@ -0,0 +1,58 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"github.com/control-center/serviced/domain/snapshotschedule"
+)
+
+// Returns the snapshot schedules of all tenants
+func (a *api) GetSnapshotSchedules() ([]snapshotschedule.Schedule, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetSnapshotSchedules()
+}
+
+// Returns the snapshot schedule of a tenant
+func (a *api) GetSnapshotSchedule(tenantID string) (*snapshotschedule.Schedule, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetSnapshotSchedule(tenantID)
+}
+
+// Adds or replaces the snapshot schedule of a tenant
+func (a *api) SetSnapshotSchedule(sched snapshotschedule.Schedule) error {
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+
+	return client.SetSnapshotSchedule(sched)
+}
+
+// Removes the snapshot schedule of a tenant
+func (a *api) RemoveSnapshotSchedule(tenantID string) error {
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+
+	return client.RemoveSnapshotSchedule(tenantID)
+}
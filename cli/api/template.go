@@ -168,3 +168,19 @@ func (a *api) DeployServiceTemplate(config DeployTemplateConfig) ([]service.Serv
 
 	return svcs, nil
 }
+
+// PlanServiceTemplateDeploy returns the services that deploying a template
+// would create, without creating them
+func (a *api) PlanServiceTemplateDeploy(config DeployTemplateConfig) (*service.ChangePlan, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+
+	req := template.ServiceTemplateDeploymentRequest{
+		PoolID:       config.PoolID,
+		TemplateID:   config.ID,
+		DeploymentID: config.DeploymentID,
+	}
+	return client.PlanTemplateDeploy(req)
+}
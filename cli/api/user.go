@@ -0,0 +1,49 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/domain/user"
+)
+
+// Returns a list of all stored users
+func (a *api) GetUsers() ([]user.User, error) {
+	client, err := a.connectMaster()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetUsers()
+}
+
+// Grants a role to a user
+func (a *api) SetUserRole(userName string, role auth.Role) error {
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+
+	return client.SetUserRole(userName, role)
+}
+
+// Restricts a user to the given tenants
+func (a *api) SetUserTenants(userName string, tenantIDs []string) error {
+	client, err := a.connectMaster()
+	if err != nil {
+		return err
+	}
+
+	return client.SetUserTenants(userName, tenantIDs)
+}
@@ -0,0 +1,175 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/control-center/serviced/domain/auditlog"
+	"github.com/control-center/serviced/domain/service"
+)
+
+// Initializer for serviced audit subcommands
+func (c *ServicedCli) initAudit() {
+	c.app.Commands = append(c.app.Commands, cli.Command{
+		Name:        "audit",
+		Usage:       "Searches the audit trail",
+		Description: "",
+		Subcommands: []cli.Command{
+			{
+				Name:         "list",
+				Usage:        "Lists audit events, most recent first",
+				Description:  "serviced audit list",
+				BashComplete: nil,
+				Action:       c.cmdAuditList,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "since",
+						Value: "",
+						Usage: "Only show events after a time (e.g. 24h, 2019-05-01 or 2019-05-01T12:00:00Z)",
+					},
+					cli.StringFlag{
+						Name:  "until",
+						Value: "",
+						Usage: "Only show events before a time (e.g. 1h, 2019-05-02 or 2019-05-02T12:00:00Z)",
+					},
+					cli.StringFlag{
+						Name:  "user",
+						Value: "",
+						Usage: "Only show events performed by a user",
+					},
+					cli.StringFlag{
+						Name:  "service",
+						Value: "",
+						Usage: "Only show events on a service",
+					},
+					cli.StringFlag{
+						Name:  "type",
+						Value: "",
+						Usage: "Only show events on a type of entity (e.g. service, host, resourcepool)",
+					},
+					cli.StringFlag{
+						Name:  "action",
+						Value: "",
+						Usage: "Only show events with an action (e.g. add, update, stop)",
+					},
+					cli.IntFlag{
+						Name:  "limit",
+						Value: auditlog.DefaultLimit,
+						Usage: "The maximum number of events to show",
+					},
+					cli.IntFlag{
+						Name:  "offset",
+						Value: 0,
+						Usage: "The number of matching events to skip",
+					},
+					cli.BoolFlag{
+						Name:  "verbose, v",
+						Usage: "Show JSON format",
+					},
+					cli.StringFlag{
+						Name:  "show-fields",
+						Value: "Time,User,Action,Type,EntityID,Success,Message",
+						Usage: "Comma-delimited list describing which fields to display",
+					},
+				},
+			},
+		},
+	})
+}
+
+// serviced audit list
+func (c *ServicedCli) cmdAuditList(ctx *cli.Context) {
+	query := auditlog.Query{
+		User:   ctx.String("user"),
+		Type:   ctx.String("type"),
+		Action: ctx.String("action"),
+		Limit:  ctx.Int("limit"),
+		Offset: ctx.Int("offset"),
+	}
+	if serviceID := ctx.String("service"); serviceID != "" {
+		if query.Type != "" && query.Type != service.GetType() {
+			fmt.Fprintln(os.Stderr, "--service may not be used with --type", query.Type)
+			return
+		}
+		query.Type = service.GetType()
+		query.EntityID = serviceID
+	}
+
+	var err error
+	now := time.Now()
+	if query.Since, err = parseAuditTime(ctx.String("since"), now); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if query.Until, err = parseAuditTime(ctx.String("until"), now); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+
+	events, err := c.driver.GetAuditEvents(query)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	} else if len(events) == 0 {
+		fmt.Fprintln(os.Stderr, "no audit events found")
+		return
+	}
+
+	if ctx.Bool("verbose") {
+		if jsonEvents, err := json.MarshalIndent(events, " ", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal audit event list: %s", err)
+		} else {
+			fmt.Println(string(jsonEvents))
+		}
+	} else {
+		t := NewTable(ctx.String("show-fields"))
+		for _, event := range events {
+			t.AddRow(map[string]interface{}{
+				"ID":       event.ID,
+				"Time":     event.Time.Local().Format(time.RFC3339),
+				"User":     event.User,
+				"Action":   event.Action,
+				"Type":     event.Type,
+				"EntityID": event.EntityID,
+				"Success":  event.Success,
+				"Message":  event.Message,
+			})
+		}
+		t.Padding = 6
+		t.Print()
+	}
+}
+
+// parseAuditTime returns the time described by a duration before now, a date
+// or an RFC3339 timestamp.  An empty value is the zero time.
+func parseAuditTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %s", value)
+}
@@ -0,0 +1,130 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/control-center/serviced/cli/api"
+	"github.com/control-center/serviced/domain/auditlog"
+)
+
+var DefaultTestAuditEvents = []auditlog.Event{
+	{
+		ID:       "event2",
+		Time:     time.Date(2019, 5, 2, 0, 0, 0, 0, time.UTC),
+		User:     "oncall",
+		Action:   "stop",
+		Type:     "service",
+		EntityID: "svc1",
+		Message:  "Stopping Service",
+		Success:  true,
+	}, {
+		ID:       "event1",
+		Time:     time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC),
+		User:     "admin",
+		Action:   "add",
+		Type:     "resourcepool",
+		EntityID: "default",
+		Message:  "Adding Resource Pool",
+		Success:  false,
+	},
+}
+
+var ErrInvalidAuditQuery = errors.New("invalid audit query")
+
+type AuditAPITest struct {
+	api.API
+	fail bool
+}
+
+func (t AuditAPITest) GetAuditEvents(query auditlog.Query) ([]auditlog.Event, error) {
+	if t.fail {
+		return nil, ErrInvalidAuditQuery
+	}
+	var events []auditlog.Event
+	for _, e := range DefaultTestAuditEvents {
+		if (query.User == "" || e.User == query.User) &&
+			(query.Type == "" || e.Type == query.Type) &&
+			(query.EntityID == "" || e.EntityID == query.EntityID) &&
+			(query.Action == "" || e.Action == query.Action) &&
+			(query.Since.IsZero() || !e.Time.Before(query.Since)) &&
+			(query.Until.IsZero() || e.Time.Before(query.Until)) {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func ExampleServicedCLI_CmdAuditList() {
+	RunCmd(AuditAPITest{}, "serviced", "audit", "list", "--show-fields", "User,Action,Type,EntityID,Success")
+
+	// Output:
+	// User        Action      Type              EntityID      Success
+	// oncall      stop        service           svc1          true
+	// admin       add         resourcepool      default       false
+}
+
+func ExampleServicedCLI_CmdAuditList_service() {
+	RunCmd(AuditAPITest{}, "serviced", "audit", "list", "--service", "svc1", "--show-fields", "User,Action,EntityID")
+
+	// Output:
+	// User        Action      EntityID
+	// oncall      stop        svc1
+}
+
+func ExampleServicedCLI_CmdAuditList_since() {
+	RunCmd(AuditAPITest{}, "serviced", "audit", "list", "--since", "2019-05-01T12:00:00Z", "--show-fields", "User,Action")
+
+	// Output:
+	// User        Action
+	// oncall      stop
+}
+
+func ExampleServicedCLI_CmdAuditList_invalidSince() {
+	pipeStderr(func() { RunCmd(AuditAPITest{}, "serviced", "audit", "list", "--since", "last tuesday") })
+
+	// Output:
+	// invalid time: last tuesday
+}
+
+func ExampleServicedCLI_CmdAuditList_fail() {
+	pipeStderr(func() { RunCmd(AuditAPITest{fail: true}, "serviced", "audit", "list") })
+
+	// Output:
+	// invalid audit query
+}
+
+func TestParseAuditTime(t *testing.T) {
+	now := time.Date(2019, 5, 2, 12, 0, 0, 0, time.UTC)
+	if got, err := parseAuditTime("", now); err != nil || !got.IsZero() {
+		t.Errorf("expected zero time, got %v (%v)", got, err)
+	}
+	if got, err := parseAuditTime("2h", now); err != nil || !got.Equal(now.Add(-2*time.Hour)) {
+		t.Errorf("expected two hours ago, got %v (%v)", got, err)
+	}
+	if got, err := parseAuditTime("2019-05-01T00:00:00Z", now); err != nil || !got.Equal(time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected timestamp, got %v (%v)", got, err)
+	}
+	if got, err := parseAuditTime("2019-05-01", now); err != nil || !got.Equal(time.Date(2019, 5, 1, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("expected date, got %v (%v)", got, err)
+	}
+	if _, err := parseAuditTime("yesterday", now); err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -43,6 +43,10 @@ func (c *ServicedCli) initBackup() {
 					Name: "force",
 					Usage: "attempt backup even if space check fails",
 				},
+				cli.BoolFlag{
+					Name:  "incremental",
+					Usage: "only back up the changes since the latest incremental backup",
+				},
 			},
 		},
 		cli.Command{
@@ -81,7 +85,7 @@ func (c *ServicedCli) cmdBackup(ctx *cli.Context)  {
 		return
 	}
 	// do backup
-	if path, err := c.driver.Backup(args[0], ctx.StringSlice("exclude"), ctx.Bool("force")); err != nil {
+	if path, err := c.driver.Backup(args[0], ctx.StringSlice("exclude"), ctx.Bool("force"), ctx.Bool("incremental")); err != nil {
 		fmt.Fprintln(os.Stdout, err)
 		c.exit(1)
 		return
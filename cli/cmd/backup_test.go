@@ -54,7 +54,7 @@ func InitBackupAPITestNoExit(args ...string) {
 	c.Run(args)
 }
 
-func (t BackupAPITest) Backup(dirpath string, excludes []string, force bool) (string, error) {
+func (t BackupAPITest) Backup(dirpath string, excludes []string, force, incremental bool) (string, error) {
 	switch dirpath {
 	case PathNotFound:
 		return "", ErrBackupFailed
@@ -67,6 +67,9 @@ func (t BackupAPITest) Backup(dirpath string, excludes []string, force bool) (st
 			return "", ErrBackupPathTooSmall
 		}
 	default:
+		if incremental {
+			return fmt.Sprintf("%s-incremental.tgz", path.Base(dirpath)), nil
+		}
 		return fmt.Sprintf("%s.tgz", path.Base(dirpath)), nil
 	}
 }
@@ -148,6 +151,7 @@ func ExampleServicedCLI_CmdBackup_usage() {
 	//    --exclude '--exclude option --exclude option'	Subdirectory of the tenant volume to exclude from backup
 	//    --check						check space, but do not do backup
 	//    --force						attempt backup even if space check fails
+	//    --incremental					only back up the changes since the latest incremental backup
 }
 
 func ExampleServicedCLI_CmdBackup_noforce() {
@@ -167,6 +171,14 @@ func ExampleServicedCLI_CmdBackup_force() {
 	// TooSmallPath.tgz
 }
 
+func ExampleServicedCLI_CmdBackup_incremental() {
+	// Backup called with incremental flag
+	InitBackupAPITest("serviced", "backup", "path/to/dir", "--incremental")
+
+	// Output:
+	// dir-incremental.tgz
+}
+
 func ExampleServicedCLI_CmdBackup_check() {
 	// Backup called with check-only flag
 	InitBackupAPITestNoExit("serviced", "backup", "path/to/dir", "--check")
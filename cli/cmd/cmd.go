@@ -147,6 +147,12 @@ func New(driver api.API, config utils.ConfigReader, logControl logging.LogContro
 		cli.IntFlag{"storage-metric-monitor-window", defaultOps.StorageMetricMonitorWindow, "the amount of time in seconds for which serviced will consider storage availability metrics in order to predict future availability"},
 		cli.IntFlag{"storage-lookahead-period", defaultOps.StorageLookaheadPeriod, "the amount of time in the future in seconds serviced should predict storage availability for the purposes of emergency shutdown"},
 		cli.StringFlag{"storage-min-free", string(defaultOps.StorageMinimumFreeSpace), "the amount of space the emergency shutdown algorithm should reserve when deciding to shut down"},
+		cli.IntFlag{"autoscale-interval", defaultOps.AutoscaleInterval, "the time in seconds between evaluations of service scaling policies, 0 to disable autoscaling"},
+		cli.IntFlag{"rebalance-interval", defaultOps.RebalanceInterval, "the time in seconds between rebalances of the instances in each pool, 0 to disable rebalancing"},
+		cli.IntFlag{"rebalance-max-migrations", defaultOps.RebalanceMaxMigrations, "the maximum number of instances to migrate in each pool per rebalance"},
+		cli.StringFlag{"notification-config", defaultOps.NotificationConfig, "path to the json file that configures where alert notifications are delivered, empty to disable notifications"},
+		cli.IntFlag{"alert-interval", defaultOps.AlertInterval, "the time in seconds between evaluations of thresholds and health checks for alert notifications"},
+		cli.StringSliceFlag{"external-strategy", convertToStringSlice(defaultOps.ExternalStrategies), "scheduler strategy implemented outside of serviced, as NAME=ADDRESS where ADDRESS is an http(s):// or unix:// url; services select it with the host policy external:NAME"},
 
 		cli.IntFlag{"logstash-cycle-time", defaultOps.LogstashCycleTime, "logstash purging cycle time in hours"},
 		cli.IntFlag{"v", defaultOps.Verbosity, "log level for V logs"},
@@ -157,6 +163,11 @@ func New(driver api.API, config utils.ConfigReader, logControl logging.LogContro
 		cli.StringFlag{"allow-loop-back", defaultOps.AllowLoopBack, "allow loop-back device with devicemapper"},
 		cli.StringFlag{"backup-min-overhead", defaultOps.BackupMinOverhead, "Minimum free space to allow when calculating backup estimates"},
 		cli.Float64Flag{"backup-estimated-compression", defaultOps.BackupEstimatedCompression, "Estimate of compression rate to use when calculating backup estimates"},
+		cli.StringFlag{"backup-schedule", defaultOps.BackupSchedule, "cron expression in the master's local time zone for when it takes backups (e.g. '0 1 * * *' or @daily), empty to disable scheduled backups"},
+		cli.StringSliceFlag{"backup-exclude", convertToStringSlice(defaultOps.BackupExcludes), "volume path to exclude from scheduled backups"},
+		cli.IntFlag{"backup-keep-last", defaultOps.BackupKeepLast, "number of most recent scheduled backups to keep"},
+		cli.IntFlag{"backup-keep-days", defaultOps.BackupKeepDays, "number of days to keep scheduled backups; scheduled backups are kept forever if this and backup-keep-last are 0"},
+		cli.IntFlag{"incremental-backup-chain", defaultOps.IncrementalBackupChain, "number of incremental backups in a chain before a new chain is started with a full backup, 0 for no limit"},
 		cli.StringFlag{"auth0-domain", defaultOps.Auth0Domain, "Domain configured for tenant in Auth0. Ref: https://auth0.com/docs/getting-started/the-basics#domain"},
 		cli.StringFlag{"auth0-audience", defaultOps.Auth0Audience, "Audience configured for application (?) in Auth0."},
 		cli.StringSliceFlag{"auth0-group", convertToStringSlice(defaultOps.Auth0Group), "Group(s) configured for application in Auth0. A comma-separated list."},
@@ -183,6 +194,9 @@ func New(driver api.API, config utils.ConfigReader, logControl logging.LogContro
 	c.initServer()
 	c.initVolume()
 	c.initKey()
+	c.initUser()
+	c.initToken()
+	c.initAudit()
 	c.initDebug()
 
 	return c
@@ -313,8 +327,19 @@ func getRuntimeOptions(cfg utils.ConfigReader, ctx *cli.Context) config.Options
 		StorageMetricMonitorWindow: ctx.GlobalInt("storage-metric-monitor-window"),
 		StorageLookaheadPeriod:     ctx.GlobalInt("storage-lookahead-period"),
 		StorageMinimumFreeSpace:    ctx.GlobalString("storage-min-free"),
+		AutoscaleInterval:          ctx.GlobalInt("autoscale-interval"),
+		ExternalStrategies:         ctx.GlobalStringSlice("external-strategy"),
+		RebalanceInterval:          ctx.GlobalInt("rebalance-interval"),
+		RebalanceMaxMigrations:     ctx.GlobalInt("rebalance-max-migrations"),
+		NotificationConfig:         ctx.GlobalString("notification-config"),
+		AlertInterval:              ctx.GlobalInt("alert-interval"),
 		BackupEstimatedCompression: ctx.Float64("backup-estimated-compression"),
 		BackupMinOverhead:          ctx.String("backup-min-overhead"),
+		BackupSchedule:             ctx.GlobalString("backup-schedule"),
+		BackupExcludes:             ctx.GlobalStringSlice("backup-exclude"),
+		BackupKeepLast:             ctx.GlobalInt("backup-keep-last"),
+		BackupKeepDays:             ctx.GlobalInt("backup-keep-days"),
+		IncrementalBackupChain:     ctx.GlobalInt("incremental-backup-chain"),
 		Auth0Domain:                ctx.String("auth0-domain"),
 		Auth0Audience:              ctx.String("auth0-audience"),
 		Auth0Group:                 ctx.GlobalStringSlice("auth0-group"),
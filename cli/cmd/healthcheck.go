@@ -16,8 +16,10 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/codegangsta/cli"
+	"github.com/control-center/serviced/health"
 )
 
 // Initializer for serviced healthcheck subcommands
@@ -27,6 +29,12 @@ func (c *ServicedCli) initHealthCheck() {
 		Usage:       "Reports on health of serviced",
 		Description: "serviced healthcheck [ISERVICENAME-1 [ISERVICENAME-2 ... [ISERVICENAME-N]]]",
 		Before:      c.cmdHealthCheck,
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "services",
+				Usage: "Report on the health checks of service instances, filtered by SERVICEID, instead of the internal services",
+			},
+		},
 	})
 }
 
@@ -36,6 +44,10 @@ func (c *ServicedCli) cmdHealthCheck(ctx *cli.Context) error {
 		return nil
 	}
 
+	if ctx.Bool("services") {
+		return c.cmdServicesHealthCheck(ctx)
+	}
+
 	if results, err := c.driver.ServicedHealthCheck(ctx.Args()); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return c.exit(2)
@@ -67,6 +79,68 @@ func (c *ServicedCli) cmdHealthCheck(ctx *cli.Context) error {
 	}
 }
 
+// serviced healthcheck --services [SERVICEID]
+func (c *ServicedCli) cmdServicesHealthCheck(ctx *cli.Context) error {
+	results, err := c.driver.ServicesHealthCheck()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return c.exit(2)
+	}
+
+	serviceIDs := []string(ctx.Args())
+	if len(serviceIDs) == 0 {
+		for serviceID := range results {
+			serviceIDs = append(serviceIDs, serviceID)
+		}
+	}
+	sort.Strings(serviceIDs)
+
+	exitStatus := 0
+	t := NewTable("Service ID,Instance,Health Check,Status")
+	t.Padding = 2
+	for _, serviceID := range serviceIDs {
+		instances, ok := results[serviceID]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "could not find service %q\n", serviceID)
+			return c.exit(2)
+		}
+
+		instanceIDs := make([]int, 0, len(instances))
+		for instanceID := range instances {
+			instanceIDs = append(instanceIDs, instanceID)
+		}
+		sort.Ints(instanceIDs)
+
+		for _, instanceID := range instanceIDs {
+			names := make([]string, 0, len(instances[instanceID]))
+			for name := range instances[instanceID] {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				status := instances[instanceID][name]
+				failure := ""
+				if status.Status != health.OK {
+					exitStatus = 1
+					failure = lastOutputLine(status.Output)
+					if status.ExitCode != 0 {
+						failure = fmt.Sprintf("exit code %d: %s", status.ExitCode, failure)
+					}
+				}
+				t.AddRow(map[string]interface{}{
+					"Service ID":   serviceID,
+					"Instance":     instanceID,
+					"Health Check": name,
+					"Status":       getCombinedStatus(healthStatusString(status.Status), failure),
+				})
+			}
+		}
+	}
+	t.Print()
+	return c.exit(exitStatus)
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
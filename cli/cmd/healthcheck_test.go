@@ -20,6 +20,7 @@ import (
 
 	"github.com/control-center/serviced/cli/api"
 	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/health"
 	"github.com/control-center/serviced/isvcs"
 	"github.com/control-center/serviced/utils"
 )
@@ -125,6 +126,47 @@ func (t HealthCheckAPITest) ServicedHealthCheck(IServiceNames []string) ([]isvcs
 	return mockResults, nil
 }
 
+func (t HealthCheckAPITest) ServicesHealthCheck() (map[string]map[int]map[string]health.HealthStatus, error) {
+	return map[string]map[int]map[string]health.HealthStatus{
+		"test-service-1": {
+			0: {
+				"answering": {Status: health.OK, Output: "ok\n"},
+				"running":   {Status: health.OK},
+			},
+			1: {
+				"answering": {Status: health.Failed, ExitCode: 7, Output: "connecting\ncurl: (7) connection refused\n"},
+				"running":   {Status: health.OK},
+			},
+		},
+		"test-service-2": {
+			0: {
+				"running": {Status: health.OK},
+			},
+		},
+	}, nil
+}
+
+func ExampleServicedCLI_CmdHealthCheck_services() {
+	pipeStderr(func() { InitHealthCheckAPITest("serviced", "healthcheck", "--services") })
+
+	// Output:
+	// Service ID      Instance  Health Check  Status
+	// test-service-1  0         answering     passed
+	// test-service-1  0         running       passed
+	// test-service-1  1         answering     failed - exit code 7: curl: (7) connection refused
+	// test-service-1  1         running       passed
+	// test-service-2  0         running       passed
+	// exit code 1
+}
+
+func ExampleServicedCLI_CmdHealthCheck_oneServiceInstances() {
+	InitHealthCheckAPITest("serviced", "healthcheck", "--services", "test-service-2")
+
+	// Output:
+	// Service ID      Instance  Health Check  Status
+	// test-service-2  0         running       passed
+}
+
 func ExampleServicedCLI_CmdHealthCheck_oneService() {
 	InitHealthCheckAPITest("serviced", "healthcheck", "test-iservice-1")
 
@@ -176,3 +218,11 @@ func ExampleServicedCLI_CmdHealthCheck_unknownStatus() {
 	// test-iservice-unknown  container-unknown  id-unknown    running       unknown
 	// exit code 1
 }
+
+func ExampleServicedCLI_CmdHealthCheck_undefinedServiceInstances() {
+	pipeStderr(func() { InitHealthCheckAPITest("serviced", "healthcheck", "--services", "undefined-service") })
+
+	// Output:
+	// could not find service "undefined-service"
+	// exit code 2
+}
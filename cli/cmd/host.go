@@ -19,6 +19,7 @@ import (
 	"io/ioutil"
 	"net"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/codegangsta/cli"
@@ -115,6 +116,24 @@ func (c *ServicedCli) initHost() {
 				Description:  "serviced host set-memory HOSTID ALLOCATION",
 				BashComplete: c.printHostsAll,
 				Action:       c.cmdHostSetMemory,
+			}, {
+				Name:         "labels",
+				Usage:        "Lists the labels assigned to a specific host",
+				Description:  "serviced host labels HOSTID",
+				BashComplete: c.printHostsFirst,
+				Action:       c.cmdHostLabels,
+			}, {
+				Name:         "set-label",
+				Usage:        "Adds or replaces labels on a specific host",
+				Description:  "serviced host set-label HOSTID KEY=VALUE ...",
+				BashComplete: c.printHostsFirst,
+				Action:       c.cmdHostSetLabel,
+			}, {
+				Name:         "remove-label",
+				Usage:        "Removes labels from a specific host",
+				Description:  "serviced host remove-label HOSTID KEY ...",
+				BashComplete: c.printHostsFirst,
+				Action:       c.cmdHostRemoveLabel,
 			},
 		},
 	})
@@ -419,6 +438,72 @@ func (c *ServicedCli) cmdHostSetMemory(ctx *cli.Context) {
 	}
 }
 
+// serviced host labels HOSTID
+func (c *ServicedCli) cmdHostLabels(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) != 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "labels")
+		return
+	}
+
+	h, err := c.driver.GetHost(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	} else if h == nil {
+		fmt.Fprintln(os.Stderr, "host not found")
+		return
+	}
+
+	keys := make([]string, 0, len(h.Labels))
+	for key := range h.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("%s=%s\n", key, h.Labels[key])
+	}
+}
+
+// serviced host set-label HOSTID KEY=VALUE ...
+func (c *ServicedCli) cmdHostSetLabel(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 2 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "set-label")
+		return
+	}
+
+	labels := make(map[string]string)
+	for _, arg := range args[1:] {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			fmt.Fprintf(os.Stderr, "invalid label %s: expected KEY=VALUE\n", arg)
+			return
+		}
+		labels[parts[0]] = parts[1]
+	}
+
+	if err := c.driver.SetHostLabels(args[0], labels); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+// serviced host remove-label HOSTID KEY ...
+func (c *ServicedCli) cmdHostRemoveLabel(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 2 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "remove-label")
+		return
+	}
+
+	if err := c.driver.RemoveHostLabels(args[0], args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
 // serviced host register (KEYSFILE | -)
 func (c *ServicedCli) cmdHostRegister(ctx *cli.Context) {
 	args := ctx.Args()
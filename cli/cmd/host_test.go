@@ -41,6 +41,7 @@ var DefaultTestHosts = []host.Host{
 		Cores:          4,
 		Memory:         4 * 1024 * 1024 * 1024,
 		PrivateNetwork: "172.16.42.0/24",
+		Labels:         map[string]string{"rack": "a", "disk": "ssd"},
 	}, {
 		ID:             "test-host-id-2",
 		PoolID:         "default",
@@ -129,6 +130,24 @@ func (t HostAPITest) RemoveHost(id string) error {
 	return nil
 }
 
+func (t HostAPITest) SetHostLabels(id string, labels map[string]string) error {
+	if h, err := t.GetHost(id); err != nil {
+		return err
+	} else if h == nil {
+		return ErrNoHostFound
+	}
+	return nil
+}
+
+func (t HostAPITest) RemoveHostLabels(id string, keys []string) error {
+	if h, err := t.GetHost(id); err != nil {
+		return err
+	} else if h == nil {
+		return ErrNoHostFound
+	}
+	return nil
+}
+
 func (t HostAPITest) RegisterRemoteHost(h *host.Host, nat utils.URL, data []byte, prompt bool) error {
 	if t.registerFail {
 		return errors.New("Forcing RemoteRegisterHost to fail for testing")
@@ -418,6 +437,56 @@ func ExampleServicedCLI_CmdHostRemove_complete() {
 	// test-host-id-3
 }
 
+func ExampleServicedCLI_CmdHostLabels() {
+	InitHostAPITest("serviced", "host", "labels", "test-host-id-1")
+	InitHostAPITest("serviced", "host", "labels", "test-host-id-2")
+
+	// Output:
+	// disk=ssd
+	// rack=a
+}
+
+func ExampleServicedCLI_CmdHostLabels_err() {
+	pipeStderr(func() { InitHostAPITest("serviced", "host", "labels", "test-host-id-0") })
+
+	// Output:
+	// host not found
+}
+
+func ExampleServicedCLI_CmdHostSetLabel_err() {
+	pipeStderr(func() { InitHostAPITest("serviced", "host", "set-label", "test-host-id-1", "disk") })
+	pipeStderr(func() { InitHostAPITest("serviced", "host", "set-label", "test-host-id-0", "disk=ssd") })
+
+	// Output:
+	// invalid label disk: expected KEY=VALUE
+	// no host found
+}
+
+func ExampleServicedCLI_CmdHostRemoveLabel_usage() {
+	InitHostAPITest("serviced", "host", "remove-label", "test-host-id-1")
+
+	// Output:
+	// Incorrect Usage.
+	//
+	// NAME:
+	//    remove-label - Removes labels from a specific host
+	//
+	// USAGE:
+	//    command remove-label [command options] [arguments...]
+	//
+	// DESCRIPTION:
+	//    serviced host remove-label HOSTID KEY ...
+	//
+	// OPTIONS:
+}
+
+func ExampleServicedCLI_CmdHostRemoveLabel_err() {
+	pipeStderr(func() { InitHostAPITest("serviced", "host", "remove-label", "test-host-id-0", "disk") })
+
+	// Output:
+	// no host found
+}
+
 func ExampleServicedCLI_CmdHostRegister_usage() {
 	InitHostAPITest("serviced", "host", "register")
 
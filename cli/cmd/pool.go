@@ -21,8 +21,10 @@ import (
 	"time"
 
 	"github.com/codegangsta/cli"
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/cli/api"
 	"github.com/control-center/serviced/domain/pool"
+	"github.com/control-center/serviced/utils"
 )
 
 // Initializer for serviced pool subcommands
@@ -65,6 +67,14 @@ func (c *ServicedCli) initPool() {
 						Name:  "admin",
 						Usage: "Allow pool to use administrative functions",
 					},
+					cli.IntFlag{
+						Name:  "core-limit",
+						Usage: "Maximum number of cores committed to services in the pool, 0 = unlimited",
+					},
+					cli.StringFlag{
+						Name:  "memory-limit",
+						Usage: "Maximum amount of memory committed to services in the pool, e.g. 64G; 0 = unlimited",
+					},
 				},
 			}, {
 				Name:         "remove",
@@ -123,6 +133,59 @@ func (c *ServicedCli) initPool() {
 						Name:  "admin",
 						Usage: "Control permission to use administrative functions",
 					},
+					cli.StringFlag{
+						Name:  "role",
+						Usage: "Role of the pool's hosts when they do not have admin access (viewer, operator, tenant-admin or none)",
+					},
+				},
+			}, {
+				Name:         "set-limits",
+				Usage:        "Set the quotas on the resources committed to services in a pool",
+				Description:  "serviced pool set-limits [FLAGS] POOLID",
+				BashComplete: c.printPoolsFirst,
+				Action:       c.cmdSetLimits,
+				Flags: []cli.Flag{
+					cli.IntFlag{
+						Name:  "core-limit",
+						Usage: "Maximum number of cores committed to services in the pool, 0 = unlimited",
+					},
+					cli.StringFlag{
+						Name:  "memory-limit",
+						Usage: "Maximum amount of memory committed to services in the pool, e.g. 64G; 0 = unlimited",
+					},
+				},
+			}, {
+				Name:         "usage",
+				Usage:        "Shows the resources committed to services in a pool against its capacity and limits",
+				Description:  "serviced pool usage POOLID",
+				BashComplete: c.printPoolsFirst,
+				Action:       c.cmdPoolUsage,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "verbose, v",
+						Usage: "Show JSON format",
+					},
+				},
+			}, {
+				Name:         "rebalance",
+				Usage:        "Migrates service instances to even out the commitment of the hosts in a pool",
+				Description:  "serviced pool rebalance POOLID",
+				BashComplete: c.printPoolsFirst,
+				Action:       c.cmdPoolRebalance,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show the migrations without doing them",
+					},
+					cli.IntFlag{
+						Name:  "max-migrations",
+						Value: 3,
+						Usage: "Migrates at most this many instances, one at a time",
+					},
+					cli.BoolFlag{
+						Name:  "verbose, v",
+						Usage: "Show JSON format",
+					},
 				},
 			},
 		},
@@ -233,19 +296,19 @@ func (c *ServicedCli) cmdPoolAdd(ctx *cli.Context) {
 	cfg := api.PoolConfig{}
 	cfg.PoolID = args[0]
 
-	/* Disabled until enforced. See ZEN-11450
-	cfg.CoreLimit, err = strconv.Atoi(args[1])
-	if err != nil {
-		fmt.Println("CORE_LIMIT must be a number")
+	cfg.CoreLimit = ctx.Int("core-limit")
+	if cfg.CoreLimit < 0 {
+		fmt.Fprintln(os.Stderr, "core limit cannot be negative")
 		return
 	}
-
-	cfg.MemoryLimit, err = strconv.ParseUint(args[2], 10, 64)
-	if err != nil {
-		fmt.Println("MEMORY_LIMIT must be a number")
-		return
+	if limit := ctx.String("memory-limit"); limit != "" {
+		memoryLimit, err := utils.ParseEngineeringNotation(limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not parse memory limit: %s\n", err)
+			return
+		}
+		cfg.MemoryLimit = memoryLimit
 	}
-	*/
 
 	/* TODO: 1.1
 	if len(args) > 2 {
@@ -438,9 +501,156 @@ func (c *ServicedCli) cmdSetPermission(ctx *cli.Context) {
 	p.Permissions &^= perm_mask
 	p.Permissions |= perm_val
 
+	if ctx.IsSet("role") {
+		if name := ctx.String("role"); name == "none" {
+			p.DelegateRole = auth.RoleNone
+		} else if role, err := auth.ParseRole(name); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		} else {
+			p.DelegateRole = role
+		}
+	}
+
 	// Update the pool
 	if err := c.driver.UpdateResourcePool(*p); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return
 	}
 }
+
+// serviced pool set-limits [--core-limit=N] [--memory-limit=N] POOLID
+func (c *ServicedCli) cmdSetLimits(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) != 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "set-limits")
+		return
+	}
+
+	p, err := c.driver.GetResourcePool(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	} else if p == nil {
+		fmt.Fprintln(os.Stderr, "pool not found")
+		return
+	}
+
+	if ctx.IsSet("core-limit") {
+		p.CoreLimit = ctx.Int("core-limit")
+		if p.CoreLimit < 0 {
+			fmt.Fprintln(os.Stderr, "core limit cannot be negative")
+			return
+		}
+	}
+	if ctx.IsSet("memory-limit") {
+		memoryLimit, err := utils.ParseEngineeringNotation(ctx.String("memory-limit"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not parse memory limit: %s\n", err)
+			return
+		}
+		p.MemoryLimit = memoryLimit
+	}
+
+	if err := c.driver.UpdateResourcePool(*p); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+}
+
+// serviced pool usage POOLID
+func (c *ServicedCli) cmdPoolUsage(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) != 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "usage")
+		return
+	}
+
+	usage, err := c.driver.GetPoolUsage(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+
+	if ctx.Bool("verbose") {
+		if jsonUsage, err := json.MarshalIndent(usage, " ", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal pool usage: %s\n", err)
+		} else {
+			fmt.Println(string(jsonUsage))
+		}
+		return
+	}
+
+	limit := func(value uint64) interface{} {
+		if value == 0 {
+			return "unlimited"
+		}
+		return value
+	}
+	t := NewTable("Resource,Committed,Capacity,Limit")
+	t.AddRow(map[string]interface{}{
+		"Resource":  "Cores",
+		"Committed": usage.CoreCommitment,
+		"Capacity":  usage.CoreCapacity,
+		"Limit":     limit(uint64(usage.CoreLimit)),
+	})
+	t.AddRow(map[string]interface{}{
+		"Resource":  "Memory",
+		"Committed": usage.MemoryCommitment,
+		"Capacity":  usage.MemoryCapacity,
+		"Limit":     limit(usage.MemoryLimit),
+	})
+	t.Padding = 6
+	t.Print()
+}
+
+// serviced pool rebalance POOLID
+func (c *ServicedCli) cmdPoolRebalance(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) != 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "rebalance")
+		return
+	}
+
+	migrations, err := c.driver.RebalancePool(args[0], ctx.Int("max-migrations"), ctx.Bool("dry-run"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	} else if len(migrations) == 0 {
+		fmt.Println("no instances need to be migrated")
+		return
+	}
+
+	if ctx.Bool("verbose") {
+		if jsonMigrations, err := json.MarshalIndent(migrations, " ", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal migrations: %s\n", err)
+		} else {
+			fmt.Println(string(jsonMigrations))
+		}
+		return
+	}
+
+	t := NewTable("Service,Instance,From,From Score,To,To Score,Status")
+	for _, m := range migrations {
+		status := "planned"
+		if m.Migrated {
+			status = "migrated"
+		} else if m.Message != "" {
+			status = m.Message
+		}
+		t.AddRow(map[string]interface{}{
+			"Service":    m.ServiceName,
+			"Instance":   m.InstanceID,
+			"From":       m.FromHostID,
+			"From Score": m.FromScore,
+			"To":         m.ToHostID,
+			"To Score":   m.ToScore,
+			"Status":     status,
+		})
+	}
+	t.Padding = 6
+	t.Print()
+}
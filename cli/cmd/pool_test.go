@@ -22,9 +22,11 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/cli/api"
 	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/pool"
+	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/utils"
 )
 
@@ -171,6 +173,61 @@ func (t PoolAPITest) UpdateResourcePool(pool pool.ResourcePool) error {
 	return ErrInvalidPool
 }
 
+func (t PoolAPITest) GetPoolUsage(id string) (*pool.PoolUsage, error) {
+	p, err := t.GetResourcePool(id)
+	if err != nil {
+		return nil, err
+	} else if p == nil {
+		return nil, ErrNoPoolFound
+	}
+
+	return &pool.PoolUsage{
+		PoolID:           p.ID,
+		CoreCommitment:   3,
+		CoreCapacity:     16,
+		CoreLimit:        p.CoreLimit,
+		MemoryCommitment: 1024 * 1024 * 1024,
+		MemoryCapacity:   32 * 1024 * 1024 * 1024,
+		MemoryLimit:      p.MemoryLimit,
+	}, nil
+}
+
+func (t PoolAPITest) RebalancePool(id string, limit int, dryRun bool) ([]service.Migration, error) {
+	if p, err := t.GetResourcePool(id); err != nil {
+		return nil, err
+	} else if p == nil {
+		return nil, ErrNoPoolFound
+	} else if id == "test-pool-id-2" {
+		return []service.Migration{}, nil
+	}
+
+	migrations := []service.Migration{
+		{
+			ServiceName: "zencommand",
+			InstanceID:  1,
+			FromHostID:  "test-host-id-1",
+			ToHostID:    "test-host-id-3",
+			FromScore:   150,
+			ToScore:     60,
+			Migrated:    !dryRun,
+		}, {
+			ServiceName: "redis",
+			InstanceID:  0,
+			FromHostID:  "test-host-id-2",
+			ToHostID:    "test-host-id-3",
+			FromScore:   120,
+			ToScore:     90,
+		},
+	}
+	if limit < len(migrations) {
+		migrations = migrations[:limit]
+	}
+	if !dryRun && len(migrations) > 1 {
+		migrations[1].Message = "an earlier migration failed"
+	}
+	return migrations, nil
+}
+
 func TestServicedCLI_CmdPoolList_one(t *testing.T) {
 	poolID := "test-pool-id-1"
 
@@ -447,3 +504,100 @@ func TestServicedCLI_CmdPoolSetPermission(t *testing.T) {
 	RunCmd(test, "serviced", "pool", "set-permission", "--admin", "--dfs=false", poolID)
 	assertPerm(poolID, pool.AdminAccess)
 }
+
+func TestServicedCLI_CmdPoolSetPermission_role(t *testing.T) {
+	test := EmptyPoolAPI()
+	assertRole := func(poolID string, expected auth.Role) {
+		if p, err := test.GetResourcePool(poolID); err != nil {
+			t.Fatalf("GetResourcePool(\"%s\"): %s", poolID, err.Error())
+		} else if p.DelegateRole != expected {
+			t.Fatalf("Unexpected delegate role for %s: %q != %q", poolID, p.DelegateRole, expected)
+		}
+	}
+
+	poolID := "poolID"
+	RunCmd(test, "serviced", "pool", "add", poolID)
+	assertRole(poolID, auth.RoleNone)
+	RunCmd(test, "serviced", "pool", "set-permission", "--role", "operator", poolID)
+	assertRole(poolID, auth.RoleOperator)
+	pipeStderr(func() { RunCmd(test, "serviced", "pool", "set-permission", "--role", "oncall", poolID) })
+	assertRole(poolID, auth.RoleOperator)
+	RunCmd(test, "serviced", "pool", "set-permission", "--role", "none", poolID)
+	assertRole(poolID, auth.RoleNone)
+}
+
+func TestServicedCLI_CmdPoolAdd_limits(t *testing.T) {
+	test := EmptyPoolAPI()
+	assertLimits := func(poolID string, coreLimit int, memoryLimit uint64) {
+		if p, err := test.GetResourcePool(poolID); err != nil {
+			t.Fatalf("GetResourcePool(\"%s\"): %s", poolID, err.Error())
+		} else if p.CoreLimit != coreLimit || p.MemoryLimit != memoryLimit {
+			t.Fatalf("Unexpected limits for %s: %d, %d != %d, %d", poolID, p.CoreLimit, p.MemoryLimit, coreLimit, memoryLimit)
+		}
+	}
+
+	poolID := "poolID"
+	RunCmd(test, "serviced", "pool", "add", "--core-limit", "4", "--memory-limit", "2G", poolID)
+	assertLimits(poolID, 4, 2*1024*1024*1024)
+	RunCmd(test, "serviced", "pool", "set-limits", "--core-limit", "8", poolID)
+	assertLimits(poolID, 8, 2*1024*1024*1024)
+	RunCmd(test, "serviced", "pool", "set-limits", "--memory-limit", "0", poolID)
+	assertLimits(poolID, 8, 0)
+}
+
+func ExampleServicedCLI_CmdSetLimits_err() {
+	test := DefaultPoolAPI()
+	pipeStderr(func() { RunCmd(test, "serviced", "pool", "set-limits", "--core-limit", "2", "test-pool-id-0") })
+	pipeStderr(func() { RunCmd(test, "serviced", "pool", "set-limits", "--core-limit", "-1", "test-pool-id-1") })
+
+	// Output:
+	// pool not found
+	// core limit cannot be negative
+}
+
+func ExampleServicedCLI_CmdPoolUsage() {
+	RunCmd(DefaultPoolAPI(), "serviced", "pool", "usage", "test-pool-id-1")
+
+	// Output:
+	// Resource      Committed       Capacity         Limit
+	// Cores         3               16               8
+	// Memory        1073741824      34359738368      unlimited
+}
+
+func ExampleServicedCLI_CmdPoolUsage_fail() {
+	pipeStderr(func() { RunCmd(DefaultPoolAPI(), "serviced", "pool", "usage", "test-pool-id-0") })
+
+	// Output:
+	// no pool found
+}
+
+func ExampleServicedCLI_CmdPoolRebalance_dryRun() {
+	RunCmd(DefaultPoolAPI(), "serviced", "pool", "rebalance", "--dry-run", "test-pool-id-1")
+
+	// Output:
+	// Service         Instance      From                From Score      To                  To Score      Status
+	// zencommand      1             test-host-id-1      150             test-host-id-3      60            planned
+	// redis           0             test-host-id-2      120             test-host-id-3      90            planned
+}
+
+func ExampleServicedCLI_CmdPoolRebalance() {
+	RunCmd(DefaultPoolAPI(), "serviced", "pool", "rebalance", "--max-migrations", "1", "test-pool-id-1")
+
+	// Output:
+	// Service         Instance      From                From Score      To                  To Score      Status
+	// zencommand      1             test-host-id-1      150             test-host-id-3      60            migrated
+}
+
+func ExampleServicedCLI_CmdPoolRebalance_balanced() {
+	RunCmd(DefaultPoolAPI(), "serviced", "pool", "rebalance", "test-pool-id-2")
+
+	// Output:
+	// no instances need to be migrated
+}
+
+func ExampleServicedCLI_CmdPoolRebalance_fail() {
+	pipeStderr(func() { RunCmd(DefaultPoolAPI(), "serviced", "pool", "rebalance", "test-pool-id-0") })
+
+	// Output:
+	// no pool found
+}
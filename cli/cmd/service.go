@@ -34,6 +34,7 @@ import (
 	"github.com/control-center/serviced/cli/api"
 	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/health"
 	"github.com/control-center/serviced/utils"
 )
 
@@ -89,7 +90,7 @@ func (c *ServicedCli) initService() {
 					},
 					cli.StringFlag{
 						Name:  "show-fields",
-						Value: "Name,ServiceID,Status,HC Fail,Healthcheck,Healthcheck Status,Uptime,RAM,Cur/Max/Avg,Hostname,InSync,DockerID",
+						Value: "Name,ServiceID,Status,HC Fail,Healthcheck,Healthcheck Status,Uptime,RAM,CPU Quota,Cur/Max/Avg,Hostname,InSync,DockerID",
 						Usage: "Comma-delimited list describing which fields to display",
 					},
 					cli.BoolFlag{
@@ -164,6 +165,43 @@ func (c *ServicedCli) initService() {
 						Name:  "no-prefix-match, np",
 						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
 					},
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show the changes that would be made without making them",
+					},
+				},
+			}, {
+				Name:         "history",
+				Usage:        "Lists the revisions of a service definition",
+				Description:  "serviced service history SERVICEID [REVISION]",
+				BashComplete: c.printServicesFirst,
+				Action:       c.cmdServiceHistory,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "verbose, v",
+						Usage: "Show JSON format",
+					},
+					cli.StringFlag{
+						Name:  "show-fields",
+						Value: "Revision,Time,User,Changed",
+						Usage: "Comma-delimited list describing which fields to display",
+					},
+					cli.BoolFlag{
+						Name:  "no-prefix-match, np",
+						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
+					},
+				},
+			}, {
+				Name:         "revert",
+				Usage:        "Reverts a service definition to one of its revisions",
+				Description:  "serviced service revert SERVICEID REVISION",
+				BashComplete: c.printServicesFirst,
+				Action:       c.cmdServiceRevert,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "no-prefix-match, np",
+						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
+					},
 				},
 			}, {
 				Name:         "assign-ip",
@@ -216,6 +254,147 @@ func (c *ServicedCli) initService() {
 						Name:  "rebalance",
 						Usage: "Stops all instances before restarting them, instead of performing a rolling restart",
 					},
+					cli.IntFlag{
+						Name:  "batch-size",
+						Usage: "Restarts this many instances at a time, waiting for each batch to pass its health checks",
+					},
+					cli.IntFlag{
+						Name:  "batch-percent",
+						Usage: "Restarts this percentage of instances at a time, waiting for each batch to pass its health checks",
+					},
+					cli.IntFlag{
+						Name:  "batch-timeout",
+						Usage: "Seconds to wait for a batch to pass its health checks before pausing (default: the run level timeout)",
+					},
+					cli.BoolFlag{
+						Name:  "no-prefix-match, np",
+						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
+					},
+				},
+			}, {
+				Name:         "restart-status",
+				Usage:        "Shows the progress of the latest batched restart of a service",
+				Description:  "serviced service restart-status SERVICEID",
+				BashComplete: c.printServicesFirst,
+				Action:       c.cmdServiceRestartStatus,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "no-prefix-match, np",
+						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
+					},
+				},
+			}, {
+				Name:         "restart-resume",
+				Usage:        "Resumes a batched restart that paused on a failed health check",
+				Description:  "serviced service restart-resume SERVICEID",
+				BashComplete: c.printServicesFirst,
+				Action:       c.cmdServiceRestartResume,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "no-prefix-match, np",
+						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
+					},
+				},
+			}, {
+				Name:         "restart-cancel",
+				Usage:        "Cancels a batched restart before its next batch",
+				Description:  "serviced service restart-cancel SERVICEID",
+				BashComplete: c.printServicesFirst,
+				Action:       c.cmdServiceRestartCancel,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "no-prefix-match, np",
+						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
+					},
+				},
+			}, {
+				Name:         "upgrade",
+				Usage:        "Changes the image of a service and its children one instance at a time",
+				Description:  "serviced service upgrade SERVICEID IMAGEID",
+				BashComplete: c.printServicesFirst,
+				Action:       c.cmdServiceUpgrade,
+				Flags: []cli.Flag{
+					cli.StringSliceFlag{
+						Name:  "replace",
+						Value: &cli.StringSlice{},
+						Usage: "Image to replace with IMAGEID (default: the repo of IMAGEID)",
+					},
+					cli.IntFlag{
+						Name:  "timeout",
+						Usage: "Seconds to wait for an instance to pass its health checks before pausing (default: the run level timeout)",
+					},
+					cli.BoolFlag{
+						Name:  "no-prefix-match, np",
+						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
+					},
+				},
+			}, {
+				Name:         "upgrade-status",
+				Usage:        "Shows the progress of the latest image upgrade of a service",
+				Description:  "serviced service upgrade-status SERVICEID",
+				BashComplete: c.printServicesFirst,
+				Action:       c.cmdServiceUpgradeStatus,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "no-prefix-match, np",
+						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
+					},
+				},
+			}, {
+				Name:         "upgrade-resume",
+				Usage:        "Resumes an image upgrade that paused on a failed health check",
+				Description:  "serviced service upgrade-resume SERVICEID",
+				BashComplete: c.printServicesFirst,
+				Action:       c.cmdServiceUpgradeResume,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "no-prefix-match, np",
+						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
+					},
+				},
+			}, {
+				Name:         "upgrade-abort",
+				Usage:        "Aborts an image upgrade and rolls back to the previous images",
+				Description:  "serviced service upgrade-abort SERVICEID",
+				BashComplete: c.printServicesFirst,
+				Action:       c.cmdServiceUpgradeAbort,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "no-prefix-match, np",
+						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
+					},
+				},
+			}, {
+				Name:         "explain-placement",
+				Usage:        "Explains how the scheduler chose hosts for the instances of a service",
+				Description:  "serviced service explain-placement SERVICEID [INSTANCE]",
+				BashComplete: c.printServicesFirst,
+				Action:       c.cmdServiceExplainPlacement,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "verbose, v",
+						Usage: "Show JSON format",
+					},
+					cli.BoolFlag{
+						Name:  "no-prefix-match, np",
+						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
+					},
+				},
+			}, {
+				Name:         "health",
+				Usage:        "Shows the results of the health checks of a service's instances",
+				Description:  "serviced service health SERVICEID",
+				BashComplete: c.printServicesFirst,
+				Action:       c.cmdServiceHealth,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "history",
+						Usage: "Show the recent results of each health check",
+					},
+					cli.BoolFlag{
+						Name:  "verbose, v",
+						Usage: "Show JSON format",
+					},
 					cli.BoolFlag{
 						Name:  "no-prefix-match, np",
 						Usage: "Make SERVICEID matches on name strict 'ends with' matches",
@@ -1331,6 +1510,19 @@ func (c *ServicedCli) cmdServiceEdit(ctx *cli.Context) {
 		return
 	}
 
+	if ctx.Bool("dry-run") {
+		if plan, err := c.driver.PlanServiceUpdate(reader); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			c.exit(1)
+		} else if plan == nil {
+			fmt.Fprintln(os.Stderr, "received nil plan")
+			c.exit(1)
+		} else {
+			printChangePlan(plan)
+		}
+		return
+	}
+
 	if service, err := c.driver.UpdateService(reader); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		c.exit(1)
@@ -1343,6 +1535,114 @@ func (c *ServicedCli) cmdServiceEdit(ctx *cli.Context) {
 	return
 }
 
+// serviced service history SERVICEID [REVISION]
+func (c *ServicedCli) cmdServiceHistory(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "history")
+		c.exit(1)
+		return
+	}
+
+	svcDetails, _, err := c.searchForService(args[0], ctx.Bool("no-prefix-match"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	if len(args) > 1 {
+		revision, err := strconv.Atoi(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid revision: %s\n", args[1])
+			c.exit(1)
+			return
+		}
+		rev, err := c.driver.GetServiceRevision(svcDetails.ID, revision)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			c.exit(1)
+			return
+		}
+		if jsonService, err := json.MarshalIndent(rev.Service, " ", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal service revision: %s\n", err)
+			c.exit(1)
+		} else {
+			fmt.Println(string(jsonService))
+		}
+		return
+	}
+
+	revisions, err := c.driver.GetServiceRevisions(svcDetails.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	} else if len(revisions) == 0 {
+		fmt.Fprintln(os.Stderr, "no revisions found")
+		return
+	}
+
+	if ctx.Bool("verbose") {
+		if jsonRevisions, err := json.MarshalIndent(revisions, " ", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal service revision list: %s\n", err)
+			c.exit(1)
+		} else {
+			fmt.Println(string(jsonRevisions))
+		}
+		return
+	}
+
+	t := NewTable(ctx.String("show-fields"))
+	for _, rev := range revisions {
+		changed := make([]string, len(rev.Changes))
+		for i, change := range rev.Changes {
+			changed[i] = change.Field
+		}
+		t.AddRow(map[string]interface{}{
+			"Revision": rev.Revision,
+			"Time":     rev.Time.Local().Format(time.RFC3339),
+			"User":     rev.User,
+			"Changed":  strings.Join(changed, ","),
+		})
+	}
+	t.Padding = 6
+	t.Print()
+}
+
+// serviced service revert SERVICEID REVISION
+func (c *ServicedCli) cmdServiceRevert(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 2 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "revert")
+		c.exit(1)
+		return
+	}
+
+	revision, err := strconv.Atoi(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid revision: %s\n", args[1])
+		c.exit(1)
+		return
+	}
+
+	svcDetails, _, err := c.searchForService(args[0], ctx.Bool("no-prefix-match"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	if err := c.driver.RevertService(svcDetails.ID, revision); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+	fmt.Println(svcDetails.ID)
+}
+
 // serviced service config list SERVICEID [CONFIGFILE]
 func (c *ServicedCli) cmdServiceConfigList(ctx *cli.Context) {
 	args := ctx.Args()
@@ -1822,7 +2122,23 @@ func (c *ServicedCli) cmdServiceRestart(ctx *cli.Context) {
 	}
 
 	// Batch start services
-	if len(sIds) > 0 {
+	if len(sIds) > 0 && (ctx.Int("batch-size") > 0 || ctx.Int("batch-percent") > 0) {
+		for _, serviceID := range sIds {
+			request := service.RollingRestartRequest{
+				ServiceID:    serviceID,
+				BatchSize:    ctx.Int("batch-size"),
+				BatchPercent: ctx.Int("batch-percent"),
+				Timeout:      time.Duration(ctx.Int("batch-timeout")) * time.Second,
+				AutoLaunch:   ctx.Bool("auto-launch"),
+			}
+			if status, err := c.driver.RollingRestartService(request); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				c.exit(1)
+			} else {
+				fmt.Printf("Restarting %s in %d batch(es) of %d instance(s)\n", status.ServiceID, status.Batches, status.BatchSize)
+			}
+		}
+	} else if len(sIds) > 0 {
 		if ctx.Bool("rebalance") {
 			if affected, err := c.driver.RebalanceService(api.SchedulerConfig{sIds, ctx.Bool("auto-launch"), ctx.Bool("sync")}); err != nil {
 				fmt.Fprintln(os.Stderr, err)
@@ -1852,6 +2168,413 @@ func (c *ServicedCli) cmdServiceRestart(ctx *cli.Context) {
 	return
 }
 
+// serviced service restart-status SERVICEID
+func (c *ServicedCli) cmdServiceRestartStatus(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "restart-status")
+		c.exit(1)
+		return
+	}
+
+	svc, _, err := c.searchForService(args[0], ctx.Bool("no-prefix-match"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	status, err := c.driver.GetRollingRestartStatus(svc.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	fmt.Printf("%-12s%s (%s)\n", "Service:", status.ServiceName, status.ServiceID)
+	fmt.Printf("%-12s%s\n", "State:", status.State)
+	fmt.Printf("%-12s%d of %d (%d instance(s) per batch)\n", "Batch:", status.Batch, status.Batches, status.BatchSize)
+	fmt.Printf("%-12s%s\n", "Restarted:", joinInstanceIDsOrNone(status.Restarted))
+	fmt.Printf("%-12s%s\n", "Pending:", joinInstanceIDsOrNone(status.Pending))
+	if len(status.Unhealthy) > 0 {
+		fmt.Printf("%-12s%s\n", "Unhealthy:", joinInstanceIDs(status.Unhealthy))
+	}
+	if status.Message != "" {
+		fmt.Printf("%-12s%s\n", "Message:", status.Message)
+	}
+	fmt.Printf("%-12s%s\n", "Started:", status.Started.Format(time.RFC3339))
+	fmt.Printf("%-12s%s\n", "Updated:", status.Updated.Format(time.RFC3339))
+}
+
+// serviced service restart-resume SERVICEID
+func (c *ServicedCli) cmdServiceRestartResume(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "restart-resume")
+		c.exit(1)
+		return
+	}
+
+	svc, _, err := c.searchForService(args[0], ctx.Bool("no-prefix-match"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	if err := c.driver.ResumeRollingRestart(svc.ID); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+	} else {
+		fmt.Println(svc.ID)
+	}
+}
+
+// serviced service restart-cancel SERVICEID
+func (c *ServicedCli) cmdServiceRestartCancel(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "restart-cancel")
+		c.exit(1)
+		return
+	}
+
+	svc, _, err := c.searchForService(args[0], ctx.Bool("no-prefix-match"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	if err := c.driver.CancelRollingRestart(svc.ID); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+	} else {
+		fmt.Println(svc.ID)
+	}
+}
+
+// serviced service upgrade SERVICEID IMAGEID
+func (c *ServicedCli) cmdServiceUpgrade(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 2 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "upgrade")
+		c.exit(1)
+		return
+	}
+
+	svc, _, err := c.searchForService(args[0], ctx.Bool("no-prefix-match"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	request := service.ImageUpgradeRequest{
+		ServiceID: svc.ID,
+		ImageID:   args[1],
+		Replace:   ctx.StringSlice("replace"),
+		Timeout:   time.Duration(ctx.Int("timeout")) * time.Second,
+	}
+	status, err := c.driver.UpgradeServiceImage(request)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+	fmt.Printf("Upgrading %d service(s) to %s\n", len(status.Services), status.ImageID)
+}
+
+// serviced service upgrade-status SERVICEID
+func (c *ServicedCli) cmdServiceUpgradeStatus(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "upgrade-status")
+		c.exit(1)
+		return
+	}
+
+	svc, _, err := c.searchForService(args[0], ctx.Bool("no-prefix-match"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	status, err := c.driver.GetImageUpgradeStatus(svc.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	fmt.Printf("%-12s%s\n", "Image:", status.ImageID)
+	fmt.Printf("%-12s%s\n", "State:", status.State)
+	if status.Message != "" {
+		fmt.Printf("%-12s%s\n", "Message:", status.Message)
+	}
+	fmt.Printf("%-12s%s\n", "Started:", status.Started.Format(time.RFC3339))
+	fmt.Printf("%-12s%s\n", "Updated:", status.Updated.Format(time.RFC3339))
+	for _, sim := range status.Services {
+		state := "pending"
+		if sim.ImageUpdated {
+			state = fmt.Sprintf("upgraded %s, pending %s", joinInstanceIDsOrNone(sim.Upgraded), joinInstanceIDsOrNone(sim.Pending))
+			if len(sim.Unhealthy) > 0 {
+				state += fmt.Sprintf(", unhealthy %s", joinInstanceIDs(sim.Unhealthy))
+			}
+		}
+		fmt.Printf("  %s (%s): %s\n", sim.Name, sim.ServiceID, state)
+	}
+}
+
+// serviced service upgrade-resume SERVICEID
+func (c *ServicedCli) cmdServiceUpgradeResume(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "upgrade-resume")
+		c.exit(1)
+		return
+	}
+
+	svc, _, err := c.searchForService(args[0], ctx.Bool("no-prefix-match"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	if err := c.driver.ResumeImageUpgrade(svc.ID); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+	} else {
+		fmt.Println(svc.ID)
+	}
+}
+
+// serviced service upgrade-abort SERVICEID
+func (c *ServicedCli) cmdServiceUpgradeAbort(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "upgrade-abort")
+		c.exit(1)
+		return
+	}
+
+	svc, _, err := c.searchForService(args[0], ctx.Bool("no-prefix-match"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	if err := c.driver.AbortImageUpgrade(svc.ID); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+	} else {
+		fmt.Println(svc.ID)
+	}
+}
+
+// serviced service explain-placement SERVICEID [INSTANCE]
+func (c *ServicedCli) cmdServiceExplainPlacement(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "explain-placement")
+		c.exit(1)
+		return
+	}
+
+	svc, _, err := c.searchForService(args[0], ctx.Bool("no-prefix-match"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	instanceID := -1
+	if len(args) > 1 {
+		if instanceID, err = strconv.Atoi(args[1]); err != nil || instanceID < 0 {
+			fmt.Fprintf(os.Stderr, "invalid instance: %s\n", args[1])
+			c.exit(1)
+			return
+		}
+	}
+
+	decisions, err := c.driver.GetPlacementDecisions(svc.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+	if instanceID >= 0 {
+		var found []service.PlacementDecision
+		for _, decision := range decisions {
+			if decision.InstanceID == instanceID {
+				found = append(found, decision)
+			}
+		}
+		decisions = found
+	}
+	if len(decisions) == 0 {
+		fmt.Fprintln(os.Stderr, "no placement decisions recorded")
+		c.exit(1)
+		return
+	}
+
+	if ctx.Bool("verbose") {
+		if jsonDecisions, err := json.MarshalIndent(decisions, " ", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal placement decisions: %s\n", err)
+			c.exit(1)
+		} else {
+			fmt.Println(string(jsonDecisions))
+		}
+		return
+	}
+
+	for i, decision := range decisions {
+		if i > 0 {
+			fmt.Println()
+		}
+		hostID := decision.HostID
+		if hostID == "" {
+			hostID = "none"
+		}
+		fmt.Printf("%-12s%d\n", "Instance:", decision.InstanceID)
+		if decision.Strategy != "" {
+			fmt.Printf("%-12s%s\n", "Strategy:", decision.Strategy)
+		}
+		fmt.Printf("%-12s%s\n", "Host:", hostID)
+		if decision.Message != "" {
+			fmt.Printf("%-12s%s\n", "Message:", decision.Message)
+		}
+		fmt.Printf("%-12s%s\n", "Decided:", decision.Timestamp.Format(time.RFC3339))
+		for _, candidate := range decision.Candidates {
+			switch {
+			case candidate.Excluded:
+				fmt.Printf("  %s: excluded, %s\n", candidate.HostID, candidate.Reason)
+			case candidate.Oversubscribed:
+				fmt.Printf("  %s: oversubscribed, cpu %d%%, memory %d%%, %d instance(s)\n", candidate.HostID, candidate.CPUScore, candidate.MemoryScore, candidate.Instances)
+			default:
+				fmt.Printf("  %s: score %d, cpu %d%%, memory %d%%, %d instance(s)\n", candidate.HostID, candidate.Score, candidate.CPUScore, candidate.MemoryScore, candidate.Instances)
+			}
+		}
+	}
+}
+
+// serviced service health SERVICEID [--history]
+func (c *ServicedCli) cmdServiceHealth(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "health")
+		c.exit(1)
+		return
+	}
+
+	svc, _, err := c.searchForService(args[0], ctx.Bool("no-prefix-match"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	history, err := c.driver.GetServiceHealthHistory(svc.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+
+	if ctx.Bool("verbose") {
+		if jsonHistory, err := json.MarshalIndent(history, " ", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal health check history: %s\n", err)
+			c.exit(1)
+		} else {
+			fmt.Println(string(jsonHistory))
+		}
+		return
+	}
+
+	instanceIDs := make([]int, 0, len(history))
+	for instanceID := range history {
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+	sort.Ints(instanceIDs)
+
+	var t *Table
+	if ctx.Bool("history") {
+		t = NewTable("Instance,Health Check,Started,Duration,Status,Output")
+	} else {
+		t = NewTable("Instance,Health Check,Status,Last Run,Transitions,Flapping")
+	}
+	t.Padding = 2
+	for _, instanceID := range instanceIDs {
+		names := make([]string, 0, len(history[instanceID]))
+		for name := range history[instanceID] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			hc := history[instanceID][name]
+			if ctx.Bool("history") {
+				for _, result := range hc.Results {
+					t.AddRow(map[string]interface{}{
+						"Instance":     instanceID,
+						"Health Check": name,
+						"Started":      result.StartedAt.Format(time.RFC3339),
+						"Duration":     result.Duration,
+						"Status":       healthStatusString(result.Status),
+						"Output":       lastOutputLine(result.Output),
+					})
+				}
+				continue
+			}
+
+			row := map[string]interface{}{
+				"Instance":     instanceID,
+				"Health Check": name,
+				"Status":       "unknown",
+				"Last Run":     "",
+				"Transitions":  hc.Transitions,
+				"Flapping":     "",
+			}
+			if count := len(hc.Results); count > 0 {
+				row["Status"] = healthStatusString(hc.Results[count-1].Status)
+				row["Last Run"] = hc.Results[count-1].StartedAt.Format(time.RFC3339)
+			}
+			if hc.Flapping {
+				row["Flapping"] = "X"
+			}
+			t.AddRow(row)
+		}
+	}
+	t.Print()
+}
+
+// healthStatusString returns the name of a health check status
+func healthStatusString(status health.Status) string {
+	name, err := status.MarshalJSON()
+	if err != nil {
+		return "invalid"
+	}
+	return strings.Trim(string(name), `"`)
+}
+
+// lastOutputLine returns the last line of health check output
+func lastOutputLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return lines[len(lines)-1]
+}
+
 // serviced service stop SERVICEID
 func (c *ServicedCli) cmdServiceStop(ctx *cli.Context) {
 	args := ctx.Args()
@@ -23,15 +23,20 @@ import (
 	//	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/control-center/serviced/cli/api"
 	"github.com/control-center/serviced/dao"
+	"github.com/control-center/serviced/datastore"
 	"github.com/control-center/serviced/domain"
 	"github.com/control-center/serviced/domain/applicationendpoint"
+	"github.com/control-center/serviced/domain/auditlog"
 	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/pool"
 	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/domain/servicerevision"
+	"github.com/control-center/serviced/health"
 	"github.com/control-center/serviced/utils"
 )
 
@@ -46,6 +51,7 @@ var DefaultServiceAPITest = ServiceAPITest{
 	hosts:     DefaultTestHosts,
 	snapshots: DefaultTestSnapshots,
 	endpoints: DefaultEndpoints,
+	revisions: DefaultTestRevisions,
 }
 
 var DefaultTestServices = []service.Service{
@@ -164,6 +170,28 @@ var DefaultEndpoints = []applicationendpoint.EndpointReport{
 	},
 }
 
+var DefaultTestRevisions = []servicerevision.Revision{
+	{
+		ID:        servicerevision.RevisionID("test-service-1", 2),
+		ServiceID: "test-service-1",
+		Revision:  2,
+		Time:      time.Date(2019, 3, 2, 12, 0, 0, 0, time.Local),
+		User:      "admin",
+		Changes: []auditlog.Change{
+			{Field: "Instances", Before: "0", After: "1"},
+			{Field: "Environment[0]", Before: "A=1", After: "A=2"},
+		},
+		Service: service.Service{ID: "test-service-1", Name: "Zenoss", Instances: 1},
+	}, {
+		ID:        servicerevision.RevisionID("test-service-1", 1),
+		ServiceID: "test-service-1",
+		Revision:  1,
+		Time:      time.Date(2019, 3, 1, 12, 0, 0, 0, time.Local),
+		User:      "system",
+		Service:   service.Service{ID: "test-service-1", Name: "Zenoss"},
+	},
+}
+
 var (
 	ErrNoServiceFound = errors.New("no service found")
 	ErrInvalidService = errors.New("invalid service")
@@ -179,6 +207,7 @@ type ServiceAPITest struct {
 	hosts     []host.Host
 	snapshots []dao.SnapshotInfo
 	endpoints []applicationendpoint.EndpointReport
+	revisions []servicerevision.Revision
 }
 
 func InitServiceAPITest(args ...string) {
@@ -323,6 +352,21 @@ func (t ServiceAPITest) UpdateServiceObj(svc service.Service) (*service.ServiceD
 	return &details, nil
 }
 
+func (t ServiceAPITest) PlanServiceUpdate(reader io.Reader) (*service.ChangePlan, error) {
+	var svc service.Service
+
+	if err := json.NewDecoder(reader).Decode(&svc); err != nil {
+		return nil, ErrInvalidService
+	}
+
+	if _, err := t.GetService(svc.ID); err != nil {
+		return nil, err
+	}
+
+	change := service.PlannedChange{ServiceID: svc.ID, Name: svc.Name, Action: service.PlanUpdate}
+	return &service.ChangePlan{Services: []service.PlannedChange{change}}, nil
+}
+
 func servicesToServiceDetails(svcs []service.Service) []service.ServiceDetails {
 	detailsList := []service.ServiceDetails{}
 	for _, svc := range svcs {
@@ -374,6 +418,160 @@ func (t ServiceAPITest) RestartService(cfg api.SchedulerConfig) (int, error) {
 	return len(cfg.ServiceIDs), nil
 }
 
+func (t ServiceAPITest) RollingRestartService(request service.RollingRestartRequest) (*service.RollingRestartStatus, error) {
+	if t.errs["RollingRestartService"] != nil {
+		return nil, t.errs["RollingRestartService"]
+	}
+	svc, err := t.GetService(request.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	batchSize := request.GetBatchSize(svc.Instances)
+	return &service.RollingRestartStatus{
+		ServiceID: svc.ID,
+		State:     service.RollingRestartRunning,
+		BatchSize: batchSize,
+		Batches:   (svc.Instances + batchSize - 1) / batchSize,
+	}, nil
+}
+
+func (t ServiceAPITest) GetRollingRestartStatus(serviceID string) (*service.RollingRestartStatus, error) {
+	if t.errs["GetRollingRestartStatus"] != nil {
+		return nil, t.errs["GetRollingRestartStatus"]
+	}
+	svc, err := t.GetService(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	started := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &service.RollingRestartStatus{
+		ServiceID:   svc.ID,
+		ServiceName: svc.Name,
+		State:       service.RollingRestartPaused,
+		BatchSize:   1,
+		Batch:       1,
+		Batches:     svc.Instances,
+		Pending:     []int{0, 1},
+		Unhealthy:   []int{0},
+		Message:     "instances 0 did not pass their health checks within 1m0s",
+		Started:     started,
+		Updated:     started.Add(time.Minute),
+	}, nil
+}
+
+func (t ServiceAPITest) ResumeRollingRestart(serviceID string) error {
+	return t.errs["ResumeRollingRestart"]
+}
+
+func (t ServiceAPITest) CancelRollingRestart(serviceID string) error {
+	return t.errs["CancelRollingRestart"]
+}
+
+func (t ServiceAPITest) UpgradeServiceImage(request service.ImageUpgradeRequest) (*service.ImageUpgradeStatus, error) {
+	if t.errs["UpgradeServiceImage"] != nil {
+		return nil, t.errs["UpgradeServiceImage"]
+	}
+	svc, err := t.GetService(request.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	return &service.ImageUpgradeStatus{
+		ServiceID: svc.ID,
+		ImageID:   request.ImageID,
+		State:     service.ImageUpgradeRunning,
+		Services:  []service.ServiceImageUpgrade{{ServiceID: svc.ID, Name: svc.Name}},
+	}, nil
+}
+
+func (t ServiceAPITest) GetImageUpgradeStatus(serviceID string) (*service.ImageUpgradeStatus, error) {
+	if t.errs["GetImageUpgradeStatus"] != nil {
+		return nil, t.errs["GetImageUpgradeStatus"]
+	}
+	svc, err := t.GetService(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	started := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &service.ImageUpgradeStatus{
+		ServiceID: svc.ID,
+		ImageID:   "localhost:5000/tenant/repo:2.0",
+		State:     service.ImageUpgradePaused,
+		Services: []service.ServiceImageUpgrade{
+			{ServiceID: svc.ID, Name: svc.Name, ImageUpdated: true, Pending: []int{1}, Unhealthy: []int{0}},
+			{ServiceID: "test-service-4", Name: "child"},
+		},
+		Message: "instance 0 of service zencommand did not pass its health checks within 1m0s",
+		Started: started,
+		Updated: started.Add(time.Minute),
+	}, nil
+}
+
+func (t ServiceAPITest) ResumeImageUpgrade(serviceID string) error {
+	return t.errs["ResumeImageUpgrade"]
+}
+
+func (t ServiceAPITest) AbortImageUpgrade(serviceID string) error {
+	return t.errs["AbortImageUpgrade"]
+}
+
+func (t ServiceAPITest) GetServiceHealthHistory(serviceID string) (map[int]map[string]health.HealthCheckHistory, error) {
+	if t.errs["GetServiceHealthHistory"] != nil {
+		return nil, t.errs["GetServiceHealthHistory"]
+	}
+	started := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	results := []health.HealthStatus{}
+	for i := 0; i < 6; i++ {
+		status := health.OK
+		output := "ok\n"
+		if i%2 == 1 {
+			status = health.Status(health.Failed)
+			output = "connecting\nconnection refused\n"
+		}
+		results = append(results, health.HealthStatus{
+			Status:    status,
+			StartedAt: started.Add(time.Duration(i) * 10 * time.Second),
+			Duration:  time.Second,
+			Output:    output,
+		})
+	}
+	return map[int]map[string]health.HealthCheckHistory{
+		0: {
+			"answering": health.NewHealthCheckHistory(results),
+			"running":   health.NewHealthCheckHistory(results[:1]),
+		},
+	}, nil
+}
+
+func (t ServiceAPITest) GetPlacementDecisions(serviceID string) ([]service.PlacementDecision, error) {
+	if t.errs["GetPlacementDecisions"] != nil {
+		return nil, t.errs["GetPlacementDecisions"]
+	}
+	svc, err := t.GetService(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	decided := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	return []service.PlacementDecision{
+		{
+			ServiceID:  svc.ID,
+			InstanceID: 0,
+			Strategy:   "balance",
+			HostID:     "test-host-id-1",
+			Candidates: []service.PlacementCandidate{
+				{HostID: "test-host-id-1", Score: 60, CPUScore: 25, MemoryScore: 35},
+				{HostID: "test-host-id-2", Oversubscribed: true, Score: 150, CPUScore: 50, MemoryScore: 150, Instances: 1},
+				{HostID: "test-host-id-3", Excluded: true, Reason: "host does not have the labels required by the service"},
+			},
+			Timestamp: decided,
+		}, {
+			ServiceID:  svc.ID,
+			InstanceID: 1,
+			Message:    "no authenticated hosts available",
+			Timestamp:  decided.Add(time.Minute),
+		},
+	}, nil
+}
+
 func (t ServiceAPITest) StopServiceInstance(serviceID string, instanceID int) error {
 	if s, err := t.GetService(serviceID); err != nil {
 		return err
@@ -468,6 +666,41 @@ func (t ServiceAPITest) AddSnapshot(config api.SnapshotConfig) (string, error) {
 	return fmt.Sprintf("%s-snapshot description=%q tags=%q", config.ServiceID, config.Message, config.Tag), nil
 }
 
+func (t ServiceAPITest) GetServiceRevisions(serviceID string) ([]servicerevision.Revision, error) {
+	if t.errs["GetServiceRevisions"] != nil {
+		return nil, t.errs["GetServiceRevisions"]
+	}
+	revisions := []servicerevision.Revision{}
+	for _, rev := range t.revisions {
+		if rev.ServiceID == serviceID {
+			revisions = append(revisions, rev)
+		}
+	}
+	return revisions, nil
+}
+
+func (t ServiceAPITest) GetServiceRevision(serviceID string, revision int) (*servicerevision.Revision, error) {
+	if t.errs["GetServiceRevision"] != nil {
+		return nil, t.errs["GetServiceRevision"]
+	}
+	for i, rev := range t.revisions {
+		if rev.ServiceID == serviceID && rev.Revision == revision {
+			return &t.revisions[i], nil
+		}
+	}
+	return nil, datastore.ErrNoSuchEntity{Key: servicerevision.Key(servicerevision.RevisionID(serviceID, revision))}
+}
+
+func (t ServiceAPITest) RevertService(serviceID string, revision int) error {
+	if t.errs["RevertService"] != nil {
+		return t.errs["RevertService"]
+	}
+	if _, err := t.GetServiceRevision(serviceID, revision); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (t ServiceAPITest) ClearEmergency(serviceID string) (int, error) {
 	if t.errs["ClearEmergency"] != nil {
 		return 0, t.errs["ClearEmergency"]
@@ -708,6 +941,7 @@ func ExampleServicedCLI_CmdServiceEdit_usage() {
 	// OPTIONS:
 	//    --editor, -e 		Editor used to update the service definition
 	//    --no-prefix-match, --np	Make SERVICEID matches on name strict 'ends with' matches
+	//    --dry-run			Show the changes that would be made without making them
 }
 
 func ExampleServicedCLI_CmdServiceEdit_fail() {
@@ -1045,6 +1279,9 @@ func ExampleServicedCLI_CmdServiceRestart_usage() {
 	//    --auto-launch		Recursively schedules child services
 	//    --sync, -s			Schedules services synchronously
 	//    --rebalance			Stops all instances before restarting them, instead of performing a rolling restart
+	//    --batch-size '0'		Restarts this many instances at a time, waiting for each batch to pass its health checks
+	//    --batch-percent '0'		Restarts this percentage of instances at a time, waiting for each batch to pass its health checks
+	//    --batch-timeout '0'		Seconds to wait for a batch to pass its health checks before pausing (default: the run level timeout)
 	//    --no-prefix-match, --np	Make SERVICEID matches on name strict 'ends with' matches
 }
 
@@ -1088,6 +1325,174 @@ func ExampleServicedCLI_CmdServiceRestart() {
 
 }
 
+func ExampleServicedCLI_CmdServiceRestart_batch() {
+	InitServiceAPITest("serviced", "service", "restart", "--batch-size", "1", "test-service-3")
+	InitServiceAPITest("serviced", "service", "restart", "--batch-percent", "100", "test-service-3")
+
+	// Output:
+	// Restarting test-service-3 in 2 batch(es) of 1 instance(s)
+	// Restarting test-service-3 in 1 batch(es) of 2 instance(s)
+}
+
+func ExampleServicedCLI_CmdServiceRestart_batchErr() {
+	DefaultServiceAPITest.errs["RollingRestartService"] = ErrInvalidService
+	defer func() { DefaultServiceAPITest.errs["RollingRestartService"] = nil }()
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "restart", "--batch-size", "1", "test-service-3") })
+
+	// Output:
+	// invalid service
+}
+
+func ExampleServicedCLI_CmdServiceRestartStatus() {
+	InitServiceAPITest("serviced", "service", "restart-status", "test-service-3")
+
+	// Output:
+	// Service:    zencommand (test-service-3)
+	// State:      paused
+	// Batch:      1 of 2 (1 instance(s) per batch)
+	// Restarted:  none
+	// Pending:    0,1
+	// Unhealthy:  0
+	// Message:    instances 0 did not pass their health checks within 1m0s
+	// Started:    2019-03-01T12:00:00Z
+	// Updated:    2019-03-01T12:01:00Z
+}
+
+func ExampleServicedCLI_CmdServiceRestartStatus_err() {
+	DefaultServiceAPITest.errs["GetRollingRestartStatus"] = ErrInvalidService
+	defer func() { DefaultServiceAPITest.errs["GetRollingRestartStatus"] = nil }()
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "restart-status", "test-service-3") })
+
+	// Output:
+	// invalid service
+}
+
+func ExampleServicedCLI_CmdServiceRestartResume() {
+	InitServiceAPITest("serviced", "service", "restart-resume", "test-service-3")
+
+	// Output:
+	// test-service-3
+}
+
+func ExampleServicedCLI_CmdServiceRestartCancel() {
+	InitServiceAPITest("serviced", "service", "restart-cancel", "test-service-3")
+
+	// Output:
+	// test-service-3
+}
+
+func ExampleServicedCLI_CmdServiceHealth() {
+	InitServiceAPITest("serviced", "service", "health", "test-service-3")
+
+	// Output:
+	// Instance  Health Check  Status  Last Run              Transitions  Flapping
+	// 0         answering     failed  2019-03-01T12:00:50Z  5            X
+	// 0         running       passed  2019-03-01T12:00:00Z  0
+}
+
+func ExampleServicedCLI_CmdServiceHealth_history() {
+	InitServiceAPITest("serviced", "service", "health", "--history", "test-service-3")
+
+	// Output:
+	// Instance  Health Check  Started               Duration  Status  Output
+	// 0         answering     2019-03-01T12:00:00Z  1s        passed  ok
+	// 0         answering     2019-03-01T12:00:10Z  1s        failed  connection refused
+	// 0         answering     2019-03-01T12:00:20Z  1s        passed  ok
+	// 0         answering     2019-03-01T12:00:30Z  1s        failed  connection refused
+	// 0         answering     2019-03-01T12:00:40Z  1s        passed  ok
+	// 0         answering     2019-03-01T12:00:50Z  1s        failed  connection refused
+	// 0         running       2019-03-01T12:00:00Z  1s        passed  ok
+}
+
+func ExampleServicedCLI_CmdServiceHealth_err() {
+	DefaultServiceAPITest.errs["GetServiceHealthHistory"] = ErrInvalidService
+	defer func() { DefaultServiceAPITest.errs["GetServiceHealthHistory"] = nil }()
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "health", "test-service-3") })
+
+	// Output:
+	// invalid service
+}
+
+func ExampleServicedCLI_CmdServiceUpgrade() {
+	InitServiceAPITest("serviced", "service", "upgrade", "test-service-3", "repo:2.0")
+
+	// Output:
+	// Upgrading 1 service(s) to repo:2.0
+}
+
+func ExampleServicedCLI_CmdServiceUpgrade_err() {
+	DefaultServiceAPITest.errs["UpgradeServiceImage"] = ErrInvalidService
+	defer func() { DefaultServiceAPITest.errs["UpgradeServiceImage"] = nil }()
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "upgrade", "test-service-3", "repo:2.0") })
+
+	// Output:
+	// invalid service
+}
+
+func ExampleServicedCLI_CmdServiceUpgradeStatus() {
+	InitServiceAPITest("serviced", "service", "upgrade-status", "test-service-3")
+
+	// Output:
+	// Image:      localhost:5000/tenant/repo:2.0
+	// State:      paused
+	// Message:    instance 0 of service zencommand did not pass its health checks within 1m0s
+	// Started:    2019-03-01T12:00:00Z
+	// Updated:    2019-03-01T12:01:00Z
+	//   zencommand (test-service-3): upgraded none, pending 1, unhealthy 0
+	//   child (test-service-4): pending
+}
+
+func ExampleServicedCLI_CmdServiceUpgradeResume() {
+	InitServiceAPITest("serviced", "service", "upgrade-resume", "test-service-3")
+
+	// Output:
+	// test-service-3
+}
+
+func ExampleServicedCLI_CmdServiceUpgradeAbort() {
+	InitServiceAPITest("serviced", "service", "upgrade-abort", "test-service-3")
+
+	// Output:
+	// test-service-3
+}
+
+func ExampleServicedCLI_CmdServiceExplainPlacement() {
+	InitServiceAPITest("serviced", "service", "explain-placement", "test-service-3")
+
+	// Output:
+	// Instance:   0
+	// Strategy:   balance
+	// Host:       test-host-id-1
+	// Decided:    2019-03-01T12:00:00Z
+	//   test-host-id-1: score 60, cpu 25%, memory 35%, 0 instance(s)
+	//   test-host-id-2: oversubscribed, cpu 50%, memory 150%, 1 instance(s)
+	//   test-host-id-3: excluded, host does not have the labels required by the service
+	//
+	// Instance:   1
+	// Host:       none
+	// Message:    no authenticated hosts available
+	// Decided:    2019-03-01T12:01:00Z
+}
+
+func ExampleServicedCLI_CmdServiceExplainPlacement_instance() {
+	InitServiceAPITest("serviced", "service", "explain-placement", "test-service-3", "1")
+
+	// Output:
+	// Instance:   1
+	// Host:       none
+	// Message:    no authenticated hosts available
+	// Decided:    2019-03-01T12:01:00Z
+}
+
+func ExampleServicedCLI_CmdServiceExplainPlacement_err() {
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "explain-placement", "test-service-3", "2") })
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "explain-placement", "test-service-3", "one") })
+
+	// Output:
+	// no placement decisions recorded
+	// invalid instance: one
+}
+
 func ExampleServicedCLI_CmdServiceStop_usage() {
 	InitServiceAPITest("serviced", "service", "stop")
 
@@ -1761,3 +2166,85 @@ func ExampleServiceCLI_CmdServiceVariableUnset() {
 	// Output:
 	// test-service-2
 }
+
+func ExampleServicedCLI_CmdServiceHistory() {
+	InitServiceAPITest("serviced", "service", "history", "test-service-1")
+
+	// Output:
+	// Revision      Time                      User        Changed
+	// 2             2019-03-02T12:00:00Z      admin       Instances,Environment[0]
+	// 1             2019-03-01T12:00:00Z      system
+}
+
+func ExampleServicedCLI_CmdServiceHistory_revision() {
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "history", "test-service-1", "3") })
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "history", "test-service-1", "latest") })
+
+	// Output:
+	// No such entity {kind:servicerevision, id:test-service-1-3}
+	// invalid revision: latest
+}
+
+func ExampleServicedCLI_CmdServiceHistory_none() {
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "history", "test-service-2") })
+
+	// Output:
+	// no revisions found
+}
+
+func ExampleServicedCLI_CmdServiceHistory_err() {
+	DefaultServiceAPITest.errs["GetServiceRevisions"] = ErrStub
+	defer func() { DefaultServiceAPITest.errs["GetServiceRevisions"] = nil }()
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "history", "test-service-1") })
+
+	// Output:
+	// stub for facade failed
+}
+
+func TestServicedCLI_CmdServiceHistory_revision(t *testing.T) {
+	var actual service.Service
+	output := captureStdout(func() { InitServiceAPITest("serviced", "service", "history", "test-service-1", "2") })
+	if err := json.Unmarshal(output, &actual); err != nil {
+		t.Fatalf("error unmarshaling service revision: %s", err)
+	}
+	if actual.ID != "test-service-1" || actual.Instances != 1 {
+		t.Errorf("expected revision 2 of test-service-1, got %+v", actual)
+	}
+}
+
+func ExampleServicedCLI_CmdServiceRevert() {
+	InitServiceAPITest("serviced", "service", "revert", "test-service-1", "1")
+
+	// Output:
+	// test-service-1
+}
+
+func ExampleServicedCLI_CmdServiceRevert_err() {
+	DefaultServiceAPITest.errs["RevertService"] = ErrStub
+	defer func() { DefaultServiceAPITest.errs["RevertService"] = nil }()
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "revert", "test-service-1", "1") })
+	pipeStderr(func() { InitServiceAPITest("serviced", "service", "revert", "test-service-1", "one") })
+
+	// Output:
+	// stub for facade failed
+	// invalid revision: one
+}
+
+func ExampleServicedCLI_CmdServiceRevert_usage() {
+	InitServiceAPITest("serviced", "service", "revert", "test-service-1")
+
+	// Output:
+	// Incorrect Usage.
+	//
+	// NAME:
+	//    revert - Reverts a service definition to one of its revisions
+	//
+	// USAGE:
+	//    command revert [command options] [arguments...]
+	//
+	// DESCRIPTION:
+	//    serviced service revert SERVICEID REVISION
+	//
+	// OPTIONS:
+	//    --no-prefix-match, --np	Make SERVICEID matches on name strict 'ends with' matches
+}
@@ -102,6 +102,73 @@ func (c *ServicedCli) initSnapshot() {
 				Description:  "serviced snapshot untag SERVICEID TAG-NAME",
 				BashComplete: c.printServicesFirst,
 				Action:       c.cmdSnapshotRemoveTag,
+			}, {
+				Name:        "schedule",
+				Usage:       "Manages scheduled snapshots of tenants",
+				Description: "serviced snapshot schedule",
+				Subcommands: []cli.Command{
+					{
+						Name:        "list",
+						Usage:       "Lists the snapshot schedules of all tenants",
+						Description: "serviced snapshot schedule list",
+						Action:      c.cmdSnapshotScheduleList,
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "verbose, v",
+								Usage: "Show JSON format",
+							},
+							cli.StringFlag{
+								Name:  "show-fields",
+								Value: "TenantID,Cron,Enabled,Retention,LastRun,NextRun,LastError",
+								Usage: "Comma-delimited list describing which fields to display",
+							},
+						},
+					}, {
+						Name:         "set",
+						Usage:        "Adds or replaces the snapshot schedule of a tenant",
+						Description:  "serviced snapshot schedule set TENANTID --cron CRON",
+						BashComplete: c.printServicesFirst,
+						Action:       c.cmdSnapshotScheduleSet,
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "cron",
+								Value: "",
+								Usage: "When to take snapshots, as a cron expression in the master's local time zone (e.g. '0 2 * * *' or @daily)",
+							},
+							cli.IntFlag{
+								Name:  "keep-last",
+								Value: 0,
+								Usage: "Number of most recent scheduled snapshots to keep",
+							},
+							cli.IntFlag{
+								Name:  "keep-hourly",
+								Value: 0,
+								Usage: "Number of hours for which to keep the latest scheduled snapshot",
+							},
+							cli.IntFlag{
+								Name:  "keep-daily",
+								Value: 0,
+								Usage: "Number of days for which to keep the latest scheduled snapshot",
+							},
+							cli.IntFlag{
+								Name:  "keep-weekly",
+								Value: 0,
+								Usage: "Number of weeks for which to keep the latest scheduled snapshot",
+							},
+							cli.BoolFlag{
+								Name:  "disable",
+								Usage: "Stops taking and removing scheduled snapshots without removing the schedule",
+							},
+						},
+					}, {
+						Name:         "remove",
+						ShortName:    "rm",
+						Usage:        "Removes the snapshot schedule of a tenant, keeping its snapshots",
+						Description:  "serviced snapshot schedule remove TENANTID",
+						BashComplete: c.printServicesFirst,
+						Action:       c.cmdSnapshotScheduleRemove,
+					},
+				},
 			},
 		},
 	})
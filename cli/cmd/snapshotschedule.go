@@ -0,0 +1,116 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/codegangsta/cli"
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/snapshotschedule"
+)
+
+// serviced snapshot schedule list
+func (c *ServicedCli) cmdSnapshotScheduleList(ctx *cli.Context) {
+	schedules, err := c.driver.GetSnapshotSchedules()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	} else if len(schedules) == 0 {
+		fmt.Fprintln(os.Stderr, "no snapshot schedules found")
+		return
+	}
+
+	if ctx.Bool("verbose") {
+		if jsonSchedules, err := json.MarshalIndent(schedules, " ", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal snapshot schedule list: %s", err)
+		} else {
+			fmt.Println(string(jsonSchedules))
+		}
+	} else {
+		t := NewTable(ctx.String("show-fields"))
+		for _, sched := range schedules {
+			t.AddRow(map[string]interface{}{
+				"TenantID":  sched.TenantID,
+				"Cron":      sched.Cron,
+				"Enabled":   !sched.Disabled,
+				"Retention": sched.Retention,
+				"LastRun":   tokenTime(sched.LastRun, "never"),
+				"NextRun":   snapshotScheduleNextRun(sched),
+				"LastError": sched.LastError,
+			})
+		}
+		t.Padding = 6
+		t.Print()
+	}
+}
+
+// serviced snapshot schedule set TENANTID --cron CRON
+func (c *ServicedCli) cmdSnapshotScheduleSet(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 || ctx.String("cron") == "" {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "set")
+		return
+	}
+
+	sched := snapshotschedule.Schedule{
+		TenantID: args[0],
+		Cron:     ctx.String("cron"),
+		Disabled: ctx.Bool("disable"),
+		Retention: domain.RetentionPolicy{
+			KeepLast:   ctx.Int("keep-last"),
+			KeepHourly: ctx.Int("keep-hourly"),
+			KeepDaily:  ctx.Int("keep-daily"),
+			KeepWeekly: ctx.Int("keep-weekly"),
+		},
+	}
+	if err := c.driver.SetSnapshotSchedule(sched); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+	fmt.Println(sched.TenantID)
+}
+
+// serviced snapshot schedule remove TENANTID
+func (c *ServicedCli) cmdSnapshotScheduleRemove(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "remove")
+		return
+	}
+
+	if err := c.driver.RemoveSnapshotSchedule(args[0]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		c.exit(1)
+		return
+	}
+	fmt.Println(args[0])
+}
+
+// snapshotScheduleNextRun describes when a schedule will next take a snapshot
+func snapshotScheduleNextRun(sched snapshotschedule.Schedule) string {
+	if sched.Disabled {
+		return "disabled"
+	}
+	next, err := sched.Next()
+	if err != nil {
+		return "invalid"
+	}
+	return tokenTime(next, "never")
+}
@@ -0,0 +1,168 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/control-center/serviced/cli/api"
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/snapshotschedule"
+	"github.com/control-center/serviced/utils"
+)
+
+var DefaultTestSnapshotSchedules = []snapshotschedule.Schedule{
+	{
+		TenantID:  "test-service-1",
+		Cron:      "0 2 * * *",
+		Retention: domain.RetentionPolicy{KeepDaily: 7, KeepWeekly: 4},
+	}, {
+		TenantID:  "test-service-2",
+		Cron:      "@hourly",
+		Disabled:  true,
+		LastError: "not enough space",
+	},
+}
+
+var ErrNoSnapshotSchedule = errors.New("no snapshot schedule found")
+
+type SnapshotScheduleAPITest struct {
+	api.API
+	fail      bool
+	schedules []snapshotschedule.Schedule
+	set       *snapshotschedule.Schedule
+}
+
+func InitSnapshotScheduleAPITest(t SnapshotScheduleAPITest, args ...string) {
+	c := New(t, utils.TestConfigReader(make(map[string]string)), MockLogControl{})
+	c.exitDisabled = true
+	c.Run(args)
+}
+
+func (t SnapshotScheduleAPITest) GetSnapshotSchedules() ([]snapshotschedule.Schedule, error) {
+	if t.fail {
+		return nil, ErrInvalidSnapshot
+	}
+	return t.schedules, nil
+}
+
+func (t SnapshotScheduleAPITest) SetSnapshotSchedule(sched snapshotschedule.Schedule) error {
+	if t.fail {
+		return ErrInvalidSnapshot
+	}
+	*t.set = sched
+	return nil
+}
+
+func (t SnapshotScheduleAPITest) RemoveSnapshotSchedule(tenantID string) error {
+	for _, sched := range t.schedules {
+		if sched.TenantID == tenantID {
+			return nil
+		}
+	}
+	return ErrNoSnapshotSchedule
+}
+
+func TestServicedCLI_CmdSnapshotScheduleList(t *testing.T) {
+	test := SnapshotScheduleAPITest{schedules: DefaultTestSnapshotSchedules}
+	output := captureStdout(func() {
+		InitSnapshotScheduleAPITest(test, "serviced", "snapshot", "schedule", "list",
+			"--show-fields", "TenantID,Cron,Enabled,Retention,LastRun,LastError")
+	})
+	expected :=
+		"TenantID            Cron           Enabled      Retention             LastRun      LastError" +
+			"\ntest-service-1      0 2 * * *      true         daily=7,weekly=4      never" +
+			"\ntest-service-2      @hourly        false        all                   never        not enough space"
+
+	outStr := TrimLines(fmt.Sprintf("%s", output))
+	expected = TrimLines(expected)
+
+	if expected != outStr {
+		t.Fatalf("\ngot:\n%s\nwant:\n%s", outStr, expected)
+	}
+}
+
+func ExampleServicedCLI_CmdSnapshotScheduleList_fail() {
+	test := SnapshotScheduleAPITest{fail: true}
+	pipeStderr(func() { InitSnapshotScheduleAPITest(test, "serviced", "snapshot", "schedule", "list") })
+
+	// Output:
+	// invalid snapshot
+}
+
+func TestServicedCLI_CmdSnapshotScheduleSet(t *testing.T) {
+	test := SnapshotScheduleAPITest{set: &snapshotschedule.Schedule{}}
+	output := captureStdout(func() {
+		InitSnapshotScheduleAPITest(test, "serviced", "snapshot", "schedule", "set", "test-service-1",
+			"--cron", "@daily", "--keep-last", "2", "--keep-weekly", "4", "--disable")
+	})
+	if TrimLines(string(output)) != "test-service-1" {
+		t.Errorf("Unexpected output: %s", output)
+	}
+	expected := snapshotschedule.Schedule{
+		TenantID:  "test-service-1",
+		Cron:      "@daily",
+		Disabled:  true,
+		Retention: domain.RetentionPolicy{KeepLast: 2, KeepWeekly: 4},
+	}
+	if *test.set != expected {
+		t.Errorf("Expected %+v; got %+v", expected, *test.set)
+	}
+}
+
+func ExampleServicedCLI_CmdSnapshotScheduleSet_usage() {
+	InitSnapshotScheduleAPITest(SnapshotScheduleAPITest{}, "serviced", "snapshot", "schedule", "set", "test-service-1")
+
+	// Output:
+	// Incorrect Usage.
+	//
+	// NAME:
+	//    set - Adds or replaces the snapshot schedule of a tenant
+	//
+	// USAGE:
+	//    command set [command options] [arguments...]
+	//
+	// DESCRIPTION:
+	//    serviced snapshot schedule set TENANTID --cron CRON
+	//
+	// OPTIONS:
+	//    --cron 		When to take snapshots, as a cron expression in the master's local time zone (e.g. '0 2 * * *' or @daily)
+	//    --keep-last '0'	Number of most recent scheduled snapshots to keep
+	//    --keep-hourly '0'	Number of hours for which to keep the latest scheduled snapshot
+	//    --keep-daily '0'	Number of days for which to keep the latest scheduled snapshot
+	//    --keep-weekly '0'	Number of weeks for which to keep the latest scheduled snapshot
+	//    --disable		Stops taking and removing scheduled snapshots without removing the schedule
+}
+
+func ExampleServicedCLI_CmdSnapshotScheduleRemove() {
+	test := SnapshotScheduleAPITest{schedules: DefaultTestSnapshotSchedules}
+	InitSnapshotScheduleAPITest(test, "serviced", "snapshot", "schedule", "remove", "test-service-2")
+
+	// Output:
+	// test-service-2
+}
+
+func ExampleServicedCLI_CmdSnapshotScheduleRemove_err() {
+	test := SnapshotScheduleAPITest{schedules: DefaultTestSnapshotSchedules}
+	pipeStderr(func() {
+		InitSnapshotScheduleAPITest(test, "serviced", "snapshot", "schedule", "remove", "test-service-3")
+	})
+
+	// Output:
+	// no snapshot schedule found
+}
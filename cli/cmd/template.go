@@ -73,6 +73,10 @@ func (c *ServicedCli) initTemplate() {
 						Name:  "manual-assign-ips",
 						Usage: "Manually assign IP addresses",
 					},
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show the services that would be deployed without deploying them",
+					},
 				},
 			}, {
 				Name:        "compile",
@@ -253,6 +257,17 @@ func (c *ServicedCli) cmdTemplateDeploy(ctx *cli.Context) {
 		ManualAssignIPs: ctx.Bool("manual-assign-ips"),
 	}
 
+	if ctx.Bool("dry-run") {
+		if plan, err := c.driver.PlanServiceTemplateDeploy(cfg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		} else if plan == nil {
+			fmt.Fprintln(os.Stderr, "received nil plan")
+		} else {
+			printChangePlan(plan)
+		}
+		return
+	}
+
 	fmt.Fprintln(os.Stderr, "Deploying template - please wait...")
 	if svcs, err := c.driver.DeployServiceTemplate(cfg); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -132,6 +132,28 @@ func (t TemplateAPITest) DeployServiceTemplate(cfg api.DeployTemplateConfig) ([]
 	return []service.ServiceDetails{s}, nil
 }
 
+func (t TemplateAPITest) PlanServiceTemplateDeploy(cfg api.DeployTemplateConfig) (*service.ChangePlan, error) {
+	tpl, err := t.GetServiceTemplate(cfg.ID)
+	if err != nil {
+		return nil, err
+	} else if tpl == nil {
+		return nil, nil
+	}
+	change := service.PlannedChange{
+		ServiceID: fmt.Sprintf("%s-service", cfg.ID),
+		Name:      tpl.Name,
+		Action:    service.PlanAdd,
+		AddressAssignments: []service.EndpointChange{
+			{Endpoint: "db", Type: "address", Name: "tcp:3306", Action: service.PlanAdd},
+		},
+		PublicEndpoints: []service.EndpointChange{
+			{Endpoint: "www", Type: "vhost", Name: "app", Action: service.PlanAdd},
+		},
+		Warnings: []string{"port :443 of endpoint www is already in use and will be disabled"},
+	}
+	return &service.ChangePlan{Services: []service.PlannedChange{change}}, nil
+}
+
 func TestServicedCLI_CmdTemplateList_one(t *testing.T) {
 	templateID := "test-template-1"
 
@@ -258,6 +280,25 @@ func ExampleServicedCLI_CmdTemplateDeploy() {
 	// test-template-1-service
 }
 
+func ExampleServicedCLI_CmdTemplateDeploy_dryRun() {
+	InitTemplateAPITest("serviced", "template", "deploy", "--dry-run", "test-template-1", "test-pool", "deployment-id")
+
+	// Output:
+	// add service Alpha (test-template-1-service)
+	//   add address assignment tcp:3306 for endpoint db
+	//   add vhost app for endpoint www
+	//   warning: port :443 of endpoint www is already in use and will be disabled
+}
+
+func ExampleServicedCLI_CmdTemplateDeploy_dryRunErr() {
+	pipeStderr(func() {
+		InitTemplateAPITest("serviced", "template", "deploy", "--dry-run", NilTemplate, "test-pool", "deployment-id")
+	})
+
+	// Output:
+	// received nil plan
+}
+
 func ExampleServicedCLI_CmdTemplateDeploy_usage() {
 	InitTemplateAPITest("serviced", "template", "deploy")
 
@@ -275,6 +316,7 @@ func ExampleServicedCLI_CmdTemplateDeploy_usage() {
 	//
 	// OPTIONS:
 	//    --manual-assign-ips	Manually assign IP addresses
+	//    --dry-run		Show the services that would be deployed without deploying them
 }
 
 func ExampleServicedCLI_CmdTemplateDeploy_fail() {
@@ -0,0 +1,189 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/cli/api"
+)
+
+// Initializer for serviced token subcommands
+func (c *ServicedCli) initToken() {
+	c.app.Commands = append(c.app.Commands, cli.Command{
+		Name:        "token",
+		Usage:       "Administers API tokens for automation",
+		Description: "",
+		Subcommands: []cli.Command{
+			{
+				Name:         "list",
+				Usage:        "Lists all API tokens",
+				Description:  "serviced token list",
+				BashComplete: nil,
+				Action:       c.cmdTokenList,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "verbose, v",
+						Usage: "Show JSON format",
+					},
+					cli.StringFlag{
+						Name:  "show-fields",
+						Value: "ID,Name,User,Role,Tenants,Expires,LastUsed",
+						Usage: "Comma-delimited list describing which fields to display",
+					},
+				},
+			}, {
+				Name:         "add",
+				Usage:        "Creates an API token and prints its ID and bearer token",
+				Description:  "serviced token add NAME",
+				BashComplete: nil,
+				Action:       c.cmdTokenAdd,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "user",
+						Value: "",
+						Usage: "The user the token acts on behalf of",
+					},
+					cli.StringFlag{
+						Name:  "role",
+						Value: string(auth.RoleViewer),
+						Usage: "The highest role the token may use (viewer, operator, tenant-admin or cluster-admin)",
+					},
+					cli.StringSliceFlag{
+						Name:  "tenant",
+						Value: &cli.StringSlice{},
+						Usage: "A tenant the token is restricted to; may be repeated",
+					},
+					cli.StringFlag{
+						Name:  "expires",
+						Value: "",
+						Usage: "How long the token is valid (e.g. 720h); never expires if unset",
+					},
+				},
+			}, {
+				Name:         "remove",
+				ShortName:    "rm",
+				Usage:        "Revokes one or more API tokens",
+				Description:  "serviced token remove TOKENID ...",
+				BashComplete: nil,
+				Action:       c.cmdTokenRemove,
+			},
+		},
+	})
+}
+
+// serviced token list
+func (c *ServicedCli) cmdTokenList(ctx *cli.Context) {
+	tokens, err := c.driver.GetAPITokens()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	} else if len(tokens) == 0 {
+		fmt.Fprintln(os.Stderr, "no API tokens found")
+		return
+	}
+
+	if ctx.Bool("verbose") {
+		if jsonTokens, err := json.MarshalIndent(tokens, " ", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal API token list: %s", err)
+		} else {
+			fmt.Println(string(jsonTokens))
+		}
+	} else {
+		t := NewTable(ctx.String("show-fields"))
+		for _, token := range tokens {
+			t.AddRow(map[string]interface{}{
+				"ID":       token.ID,
+				"Name":     token.Name,
+				"User":     token.User,
+				"Role":     token.Role,
+				"Tenants":  userTenants(token.Tenants),
+				"Expires":  tokenTime(token.ExpiresAt, "never"),
+				"LastUsed": tokenTime(token.LastUsed, "never"),
+			})
+		}
+		t.Padding = 6
+		t.Print()
+	}
+}
+
+// serviced token add NAME
+func (c *ServicedCli) cmdTokenAdd(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "add")
+		return
+	}
+
+	cfg := api.APITokenConfig{
+		Name:      args[0],
+		UserName:  ctx.String("user"),
+		TenantIDs: ctx.StringSlice("tenant"),
+	}
+	if cfg.UserName == "" {
+		fmt.Fprintln(os.Stderr, "--user is required")
+		return
+	}
+
+	var err error
+	if cfg.Role, err = auth.ParseRole(ctx.String("role")); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if expires := ctx.String("expires"); expires != "" {
+		if cfg.Expiration, err = time.ParseDuration(expires); err != nil || cfg.Expiration <= 0 {
+			fmt.Fprintf(os.Stderr, "invalid expiration: %s\n", expires)
+			return
+		}
+	}
+
+	token, bearer, err := c.driver.AddAPIToken(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(token.ID)
+	fmt.Println(bearer)
+}
+
+// serviced token remove TOKENID ...
+func (c *ServicedCli) cmdTokenRemove(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "remove")
+		return
+	}
+
+	for _, id := range args {
+		if err := c.driver.RemoveAPIToken(id); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", id, err)
+		} else {
+			fmt.Println(id)
+		}
+	}
+}
+
+func tokenTime(t time.Time, zero string) string {
+	if t.IsZero() {
+		return zero
+	}
+	return t.Local().Format(time.RFC3339)
+}
@@ -0,0 +1,141 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/cli/api"
+	"github.com/control-center/serviced/domain/apitoken"
+)
+
+var DefaultTestAPITokens = []apitoken.APIToken{
+	{
+		ID:      "token1",
+		Name:    "ci",
+		User:    "jenkins",
+		Role:    auth.RoleOperator,
+		Tenants: []string{"tenant1"},
+	},
+}
+
+var ErrInvalidAPIToken = errors.New("invalid api token")
+
+type TokenAPITest struct {
+	api.API
+	fail   bool
+	tokens *[]apitoken.APIToken
+}
+
+func DefaultTokenAPI() TokenAPITest {
+	test := TokenAPITest{tokens: &[]apitoken.APIToken{}}
+	*test.tokens = append(*test.tokens, DefaultTestAPITokens...)
+	return test
+}
+
+func (t TokenAPITest) GetAPITokens() ([]apitoken.APIToken, error) {
+	if t.fail {
+		return nil, ErrInvalidAPIToken
+	}
+	return *t.tokens, nil
+}
+
+func (t TokenAPITest) AddAPIToken(cfg api.APITokenConfig) (*apitoken.APIToken, string, error) {
+	if t.fail {
+		return nil, "", ErrInvalidAPIToken
+	}
+	token := apitoken.APIToken{
+		ID:      fmt.Sprintf("token%d", len(*t.tokens)+1),
+		Name:    cfg.Name,
+		User:    cfg.UserName,
+		Role:    cfg.Role,
+		Tenants: cfg.TenantIDs,
+	}
+	if cfg.Expiration > 0 {
+		token.ExpiresAt = time.Now().Add(cfg.Expiration)
+	}
+	*t.tokens = append(*t.tokens, token)
+	return &token, "bearer-" + token.ID, nil
+}
+
+func (t TokenAPITest) RemoveAPIToken(id string) error {
+	for i, token := range *t.tokens {
+		if token.ID == id {
+			*t.tokens = append((*t.tokens)[:i], (*t.tokens)[i+1:]...)
+			return nil
+		}
+	}
+	return ErrInvalidAPIToken
+}
+
+func ExampleServicedCLI_CmdTokenList() {
+	RunCmd(DefaultTokenAPI(), "serviced", "token", "list")
+
+	// Output:
+	// ID          Name      User         Role          Tenants      Expires      LastUsed
+	// token1      ci        jenkins      operator      tenant1      never        never
+}
+
+func ExampleServicedCLI_CmdTokenList_fail() {
+	test := DefaultTokenAPI()
+	test.fail = true
+	pipeStderr(func() { RunCmd(test, "serviced", "token", "list") })
+
+	// Output:
+	// invalid api token
+}
+
+func ExampleServicedCLI_CmdTokenAdd() {
+	test := DefaultTokenAPI()
+	RunCmd(test, "serviced", "token", "add", "--user", "auditor", "--tenant", "tenant1", "--tenant", "tenant2", "reports")
+	RunCmd(test, "serviced", "token", "list", "--show-fields", "ID,Name,User,Role,Tenants")
+
+	// Output:
+	// token2
+	// bearer-token2
+	// ID          Name         User         Role          Tenants
+	// token1      ci           jenkins      operator      tenant1
+	// token2      reports      auditor      viewer        tenant1,tenant2
+}
+
+func ExampleServicedCLI_CmdTokenAdd_nouser() {
+	pipeStderr(func() { RunCmd(DefaultTokenAPI(), "serviced", "token", "add", "reports") })
+
+	// Output:
+	// --user is required
+}
+
+func ExampleServicedCLI_CmdTokenAdd_badexpires() {
+	pipeStderr(func() {
+		RunCmd(DefaultTokenAPI(), "serviced", "token", "add", "--user", "auditor", "--expires", "soon", "reports")
+	})
+
+	// Output:
+	// invalid expiration: soon
+}
+
+func ExampleServicedCLI_CmdTokenRemove() {
+	test := DefaultTokenAPI()
+	RunCmd(test, "serviced", "token", "remove", "token1")
+	pipeStderr(func() { RunCmd(test, "serviced", "token", "remove", "token1") })
+
+	// Output:
+	// token1
+	// token1: invalid api token
+}
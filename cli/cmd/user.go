@@ -0,0 +1,162 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/control-center/serviced/auth"
+)
+
+// Initializer for serviced user subcommands
+func (c *ServicedCli) initUser() {
+	c.app.Commands = append(c.app.Commands, cli.Command{
+		Name:        "user",
+		Usage:       "Administers user roles",
+		Description: "",
+		Subcommands: []cli.Command{
+			{
+				Name:         "list",
+				Usage:        "Lists all users with a stored role or password",
+				Description:  "serviced user list",
+				BashComplete: nil,
+				Action:       c.cmdUserList,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "verbose, v",
+						Usage: "Show JSON format",
+					},
+					cli.StringFlag{
+						Name:  "show-fields",
+						Value: "Name,Role,Tenants",
+						Usage: "Comma-delimited list describing which fields to display",
+					},
+				},
+			}, {
+				Name:         "set-role",
+				Usage:        "Grants a role to a user (viewer, operator, tenant-admin or cluster-admin)",
+				Description:  "serviced user set-role USERNAME ROLE",
+				BashComplete: nil,
+				Action:       c.cmdUserSetRole,
+			}, {
+				Name:         "unset-role",
+				Usage:        "Removes the role granted to a user",
+				Description:  "serviced user unset-role USERNAME",
+				BashComplete: nil,
+				Action:       c.cmdUserUnsetRole,
+			}, {
+				Name:         "set-tenants",
+				Usage:        "Restricts a user to the given tenants; no tenants grants access to all",
+				Description:  "serviced user set-tenants USERNAME [TENANTID ...]",
+				BashComplete: nil,
+				Action:       c.cmdUserSetTenants,
+			},
+		},
+	})
+}
+
+// serviced user list
+func (c *ServicedCli) cmdUserList(ctx *cli.Context) {
+	users, err := c.driver.GetUsers()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	} else if len(users) == 0 {
+		fmt.Fprintln(os.Stderr, "no users found")
+		return
+	}
+
+	if ctx.Bool("verbose") {
+		if jsonUsers, err := json.MarshalIndent(users, " ", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal user list: %s", err)
+		} else {
+			fmt.Println(string(jsonUsers))
+		}
+	} else {
+		t := NewTable(ctx.String("show-fields"))
+		for _, u := range users {
+			t.AddRow(map[string]interface{}{
+				"Name":    u.Name,
+				"Role":    u.EffectiveRole(),
+				"Tenants": userTenants(u.Tenants),
+			})
+		}
+		t.Padding = 6
+		t.Print()
+	}
+}
+
+// serviced user set-role USERNAME ROLE
+func (c *ServicedCli) cmdUserSetRole(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 2 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "set-role")
+		return
+	}
+
+	role, err := auth.ParseRole(args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if err := c.driver.SetUserRole(args[0], role); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(args[0])
+}
+
+// serviced user unset-role USERNAME
+func (c *ServicedCli) cmdUserUnsetRole(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "unset-role")
+		return
+	}
+
+	if err := c.driver.SetUserRole(args[0], auth.RoleNone); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(args[0])
+}
+
+// serviced user set-tenants USERNAME [TENANTID ...]
+func (c *ServicedCli) cmdUserSetTenants(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		fmt.Printf("Incorrect Usage.\n\n")
+		cli.ShowCommandHelp(ctx, "set-tenants")
+		return
+	}
+
+	if err := c.driver.SetUserTenants(args[0], args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(args[0])
+}
+
+func userTenants(tenantIDs []string) string {
+	if len(tenantIDs) == 0 {
+		return "all"
+	}
+	return strings.Join(tenantIDs, ",")
+}
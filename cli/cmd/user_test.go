@@ -0,0 +1,145 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/cli/api"
+	"github.com/control-center/serviced/domain/user"
+)
+
+var DefaultTestUsers = []user.User{
+	{
+		Name: "oncall",
+		Role: auth.RoleOperator,
+	}, {
+		Name:     "system_user",
+		Password: "",
+	},
+}
+
+var ErrInvalidUser = errors.New("invalid user")
+
+type UserAPITest struct {
+	api.API
+	fail  bool
+	users *[]user.User
+}
+
+func DefaultUserAPI() UserAPITest {
+	test := UserAPITest{users: &[]user.User{}}
+	*test.users = append(*test.users, DefaultTestUsers...)
+	return test
+}
+
+func (t UserAPITest) GetUsers() ([]user.User, error) {
+	if t.fail {
+		return nil, ErrInvalidUser
+	}
+	return *t.users, nil
+}
+
+func (t UserAPITest) SetUserRole(userName string, role auth.Role) error {
+	if t.fail {
+		return ErrInvalidUser
+	}
+	for i, u := range *t.users {
+		if u.Name == userName {
+			(*t.users)[i].Role = role
+			return nil
+		}
+	}
+	*t.users = append(*t.users, user.User{Name: userName, Role: role})
+	return nil
+}
+
+func (t UserAPITest) SetUserTenants(userName string, tenantIDs []string) error {
+	if t.fail {
+		return ErrInvalidUser
+	}
+	for i, u := range *t.users {
+		if u.Name == userName {
+			(*t.users)[i].Tenants = tenantIDs
+			return nil
+		}
+	}
+	*t.users = append(*t.users, user.User{Name: userName, Tenants: tenantIDs})
+	return nil
+}
+
+func ExampleServicedCLI_CmdUserList() {
+	RunCmd(DefaultUserAPI(), "serviced", "user", "list")
+
+	// Output:
+	// Name             Role               Tenants
+	// oncall           operator           all
+	// system_user      cluster-admin      all
+}
+
+func ExampleServicedCLI_CmdUserList_fail() {
+	test := DefaultUserAPI()
+	test.fail = true
+	pipeStderr(func() { RunCmd(test, "serviced", "user", "list") })
+
+	// Output:
+	// invalid user
+}
+
+func ExampleServicedCLI_CmdUserSetRole() {
+	test := DefaultUserAPI()
+	RunCmd(test, "serviced", "user", "set-role", "auditor", "viewer")
+	RunCmd(test, "serviced", "user", "list", "--show-fields", "Name,Role")
+
+	// Output:
+	// auditor
+	// Name             Role
+	// oncall           operator
+	// system_user      cluster-admin
+	// auditor          viewer
+}
+
+func ExampleServicedCLI_CmdUserSetRole_invalid() {
+	pipeStderr(func() { RunCmd(DefaultUserAPI(), "serviced", "user", "set-role", "auditor", "superuser") })
+
+	// Output:
+	// invalid role: "superuser" (expected one of viewer, operator, tenant-admin, cluster-admin)
+}
+
+func ExampleServicedCLI_CmdUserUnsetRole() {
+	test := DefaultUserAPI()
+	RunCmd(test, "serviced", "user", "unset-role", "oncall")
+	RunCmd(test, "serviced", "user", "list", "--show-fields", "Name,Role")
+
+	// Output:
+	// oncall
+	// Name             Role
+	// oncall           cluster-admin
+	// system_user      cluster-admin
+}
+
+func ExampleServicedCLI_CmdUserSetTenants() {
+	test := DefaultUserAPI()
+	RunCmd(test, "serviced", "user", "set-tenants", "oncall", "tenant1", "tenant2")
+	RunCmd(test, "serviced", "user", "list", "--show-fields", "Name,Tenants")
+
+	// Output:
+	// oncall
+	// Name             Tenants
+	// oncall           tenant1,tenant2
+	// system_user      all
+}
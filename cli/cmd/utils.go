@@ -21,9 +21,11 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 	"syscall"
 
+	"github.com/control-center/serviced/domain/service"
 	"golang.org/x/crypto/ssh/terminal"
 )
 
@@ -104,3 +106,55 @@ func isInstanceID(serviceID string) bool {
 	}
 	return false
 }
+
+// printChangePlan prints the changes that a dry run would make
+func printChangePlan(plan *service.ChangePlan) {
+	if len(plan.Services) == 0 {
+		fmt.Println("no changes")
+		return
+	}
+	for _, svc := range plan.Services {
+		fmt.Printf("%s service %s (%s)\n", svc.Action, svc.Name, svc.ServiceID)
+		for _, change := range svc.Changes {
+			fmt.Printf("  ~ %s: %s -> %s\n", change.Field, change.Before, change.After)
+		}
+		for _, ep := range svc.AddressAssignments {
+			fmt.Printf("  %s address assignment %s for endpoint %s\n", ep.Action, ep.Name, ep.Endpoint)
+		}
+		for _, ep := range svc.PublicEndpoints {
+			fmt.Printf("  %s %s %s for endpoint %s\n", ep.Action, ep.Type, ep.Name, ep.Endpoint)
+		}
+		if svc.StartInstances > 0 {
+			fmt.Printf("  start %d instance(s)\n", svc.StartInstances)
+		}
+		if len(svc.StopInstances) > 0 {
+			fmt.Printf("  stop instance(s) %s\n", joinInstanceIDs(svc.StopInstances))
+		}
+		if len(svc.RestartInstances) > 0 {
+			fmt.Printf("  restart instance(s) %s\n", joinInstanceIDs(svc.RestartInstances))
+		}
+		if svc.RestartRequired {
+			fmt.Println("  running instances must be restarted for all changes to take effect")
+		}
+		for _, warning := range svc.Warnings {
+			fmt.Printf("  warning: %s\n", warning)
+		}
+	}
+}
+
+func joinInstanceIDs(ids []int) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	return strings.Join(s, ",")
+}
+
+// joinInstanceIDsOrNone is joinInstanceIDs, but returns "none" if there are no
+// instances.
+func joinInstanceIDsOrNone(ids []int) string {
+	if len(ids) == 0 {
+		return "none"
+	}
+	return joinInstanceIDs(ids)
+}
@@ -127,8 +127,19 @@ type Options struct {
 	StorageMetricMonitorWindow int               // The amount of time in seconds for which serviced will consider storage availability metrics in order to predict future availability
 	StorageLookaheadPeriod     int               // The amount of time in the future in seconds serviced should predict storage availability for the purposes of emergency shutdown
 	StorageMinimumFreeSpace    string            // The amount of space the emergency shutdown algorithm should reserve when deciding to shut down
+	AutoscaleInterval          int               // The time in seconds between evaluations of service scaling policies, 0 to disable autoscaling
+	ExternalStrategies         []string          // Scheduler strategies implemented outside of serviced, as NAME=ADDRESS
+	RebalanceInterval          int               // The time in seconds between rebalances of the instances in each pool, 0 to disable rebalancing
+	RebalanceMaxMigrations     int               // The maximum number of instances to migrate in each pool per rebalance
+	NotificationConfig         string            // The path to the json file that configures where alert notifications are delivered, empty to disable notifications
+	AlertInterval              int               // The time in seconds between evaluations of thresholds and health checks for alert notifications
 	BackupEstimatedCompression float64           // Best guess for tgz compression ratio (uncompressed size / compressed size) used to determine whether sufficient disk space is available for taking a backup
 	BackupMinOverhead          string            // Warn user if estimated backup size would leave less than this amount of space free
+	BackupSchedule             string            // Cron expression for when the master takes backups, empty to disable scheduled backups
+	BackupExcludes             []string          // Volume paths to exclude from scheduled backups
+	BackupKeepLast             int               // Number of most recent scheduled backups to keep
+	BackupKeepDays             int               // Number of days to keep scheduled backups; scheduled backups are never removed if this and BackupKeepLast are 0
+	IncrementalBackupChain     int               // Number of backups in a chain of incremental backups before a new chain is started with a full backup, 0 for no limit
 	StartZK                    bool              // Should ZooKeeper ISVC be started
 	StartAPIKeyProxy           bool              // Should API Key Proxy ISVC be started
 	BigTableMetrics            bool              // Should serviced metrics be stored in gcp bigtable
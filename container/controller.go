@@ -419,6 +419,16 @@ func NewController(options ControllerOptions) (*Controller, error) {
 
 	zzk.InitializeLocalClient(zClient)
 
+	// readiness checks are not run in service shells
+	var readinessChecks []string
+	if os.Getenv("SERVICED_IS_SERVICE_SHELL") != "true" {
+		for name, hc := range c.healthChecks {
+			if hc.IsReadiness() {
+				readinessChecks = append(readinessChecks, name)
+			}
+		}
+	}
+
 	// get endpoints
 	opts := ContainerEndpointsOptions{
 		HostID:               c.hostID,
@@ -428,6 +438,7 @@ func NewController(options ControllerOptions) (*Controller, error) {
 		TCPMuxPort:           uint16(options.Mux.Port),
 		UseTLS:               !options.Mux.DisableTLS,
 		VirtualAddressSubnet: options.VirtualAddressSubnet,
+		ReadinessChecks:      readinessChecks,
 	}
 	c.endpoints, err = NewContainerEndpoints(service, opts)
 	if err != nil {
@@ -814,7 +825,11 @@ func (c *Controller) kickOffHealthChecks(healthExit chan struct{}) {
 	}
 	for name, hc := range c.healthChecks {
 		glog.Infof("Kicking off health check %s.", name)
-		glog.Infof("Setting up health check: %s", hc.Script)
+		if hc.Type() == health.ScriptCheckType {
+			glog.Infof("Setting up health check: %s", hc.Script)
+		} else {
+			glog.Infof("Setting up %s health check", hc.Type())
+		}
 		key := health.HealthStatusKey{
 			ServiceID:       c.options.Service.ID,
 			InstanceID:      instanceID,
@@ -831,7 +846,11 @@ func (c *Controller) doHealthCheck(cancel <-chan struct{}, key health.HealthStat
 		"instance":    key.InstanceID,
 		"healthcheck": key.HealthCheckName,
 	})
+	ready := &readinessTolerance{tolerance: hc.GetTolerance()}
 	hc.Ping(cancel, key, func(stat health.HealthStatus) {
+		if hc.IsReadiness() {
+			c.endpoints.SetReady(key.HealthCheckName, ready.Update(stat.Status == health.OK))
+		}
 		req := master.HealthStatusRequest{
 			Key:     key,
 			Value:   stat,
@@ -38,6 +38,7 @@ type ContainerEndpointsOptions struct {
 	TCPMuxPort           uint16
 	UseTLS               bool
 	VirtualAddressSubnet string
+	ReadinessChecks      []string
 }
 
 // ContainerEndpoints manages import and export bindings for the instance.
@@ -47,6 +48,7 @@ type ContainerEndpoints struct {
 	cache *proxyCache
 	ports map[uint16]struct{}
 	vifs  *VIFRegistry
+	ready *readinessGate
 }
 
 // NewContainerEndpoints loads the service state and manages port bindings
@@ -57,6 +59,7 @@ func NewContainerEndpoints(svc *service.Service, opts ContainerEndpointsOptions)
 		opts:  opts,
 		ports: make(map[uint16]struct{}),
 		vifs:  NewVIFRegistry(),
+		ready: newReadinessGate(opts.ReadinessChecks...),
 	}
 
 	// load the state object
@@ -197,7 +200,14 @@ func (ce *ContainerEndpoints) Run(cancel <-chan struct{}) {
 	go ce.RunImportListener(cancel, ce.opts.TenantID, ce.state.Imports...)
 }
 
+// SetReady updates the result of a readiness health check.  Exports are only
+// registered while all of the readiness checks are passing.
+func (ce *ContainerEndpoints) SetReady(name string, passing bool) {
+	ce.ready.Set(name, passing)
+}
+
 // AddExport ensures that an export is registered for other services to bind
+// while the instance is ready
 func (ce *ContainerEndpoints) AddExport(cancel <-chan struct{}, bind zkservice.ExportBinding) {
 	logger := plog.WithFields(log.Fields{
 		"application": bind.Application,
@@ -213,6 +223,23 @@ func (ce *ContainerEndpoints) AddExport(cancel <-chan struct{}, bind zkservice.E
 		InstanceID:    ce.state.InstanceID,
 	}
 
+	for {
+		stop := ce.ready.WhileReady(cancel)
+		if stop == nil {
+			return
+		}
+		ce.registerExport(stop, exp, logger)
+		select {
+		case <-cancel:
+			return
+		default:
+			logger.Info("Readiness checks are failing; withdrew export")
+		}
+	}
+}
+
+// registerExport keeps the export registered until stop is closed
+func (ce *ContainerEndpoints) registerExport(stop <-chan struct{}, exp registry.ExportDetails, logger *log.Entry) {
 	logger.Debug("Registering export")
 	defer logger.Debug("Unregistered export")
 
@@ -222,14 +249,14 @@ func (ce *ContainerEndpoints) AddExport(cancel <-chan struct{}, bind zkservice.E
 			if conn != nil {
 
 				logger.Debug("Received coordinator connection")
-				registry.RegisterExport(cancel, conn, ce.opts.TenantID, exp)
+				registry.RegisterExport(stop, conn, ce.opts.TenantID, exp)
 				select {
-				case <-cancel:
+				case <-stop:
 					return
 				default:
 				}
 			}
-		case <-cancel:
+		case <-stop:
 			return
 		}
 	}
@@ -0,0 +1,116 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "sync"
+
+// readinessGate tracks the readiness health checks of a service instance.
+// The instance is ready when every readiness check is passing.
+type readinessGate struct {
+	mu      sync.Mutex
+	failing map[string]struct{}
+	changed chan struct{}
+}
+
+// newReadinessGate returns a gate for the named readiness checks, which are
+// considered failing until they report otherwise.
+func newReadinessGate(checks ...string) *readinessGate {
+	failing := make(map[string]struct{})
+	for _, name := range checks {
+		failing[name] = struct{}{}
+	}
+	return &readinessGate{
+		failing: failing,
+		changed: make(chan struct{}),
+	}
+}
+
+// Set updates the result of a readiness check.
+func (g *readinessGate) Set(name string, passing bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	_, wasFailing := g.failing[name]
+	if passing == !wasFailing {
+		return
+	}
+	if passing {
+		delete(g.failing, name)
+	} else {
+		g.failing[name] = struct{}{}
+	}
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
+
+// state returns whether the instance is ready and a channel that closes on
+// the next change.
+func (g *readinessGate) state() (bool, <-chan struct{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.failing) == 0, g.changed
+}
+
+// WhileReady blocks until the instance is ready and returns a channel that
+// closes once the instance is no longer ready or cancel is closed.  Returns
+// nil if cancel closes while waiting.
+func (g *readinessGate) WhileReady(cancel <-chan struct{}) <-chan struct{} {
+	ready, changed := g.state()
+	for !ready {
+		select {
+		case <-changed:
+			ready, changed = g.state()
+		case <-cancel:
+			return nil
+		}
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		defer close(stop)
+		for {
+			select {
+			case <-changed:
+				if ready, changed = g.state(); !ready {
+					return
+				}
+			case <-cancel:
+				return
+			}
+		}
+	}()
+	return stop
+}
+
+// readinessTolerance filters the results of a readiness check, so that the
+// instance only changes readiness after tolerance consecutive results agree.
+// The check starts out failing.
+type readinessTolerance struct {
+	tolerance int
+	passing   bool
+	count     int
+}
+
+// Update records a result of the check and returns whether the check is
+// passing.
+func (t *readinessTolerance) Update(passing bool) bool {
+	if passing == t.passing {
+		t.count = 0
+		return t.passing
+	}
+	if t.count++; t.count >= t.tolerance {
+		t.passing = passing
+		t.count = 0
+	}
+	return t.passing
+}
@@ -0,0 +1,117 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package container
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReadinessNoChecks(t *testing.T) {
+	gate := newReadinessGate()
+	cancel := make(chan struct{})
+	stop := gate.WhileReady(cancel)
+	if stop == nil {
+		t.Fatalf("expected the instance to be ready")
+	}
+
+	select {
+	case <-stop:
+		t.Fatalf("stopped while ready")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(cancel)
+	select {
+	case <-stop:
+	case <-time.After(time.Second):
+		t.Fatalf("did not stop on cancel")
+	}
+}
+
+func TestReadinessWaitsForChecks(t *testing.T) {
+	gate := newReadinessGate("warm", "db")
+	cancel := make(chan struct{})
+	defer close(cancel)
+
+	stopc := make(chan (<-chan struct{}))
+	go func() { stopc <- gate.WhileReady(cancel) }()
+
+	gate.Set("warm", true)
+	select {
+	case <-stopc:
+		t.Fatalf("ready with a failing check")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	gate.Set("db", true)
+	var stop <-chan struct{}
+	select {
+	case stop = <-stopc:
+		if stop == nil {
+			t.Fatalf("expected the instance to be ready")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("not ready with passing checks")
+	}
+
+	// reporting the same result again does not withdraw the instance
+	gate.Set("db", true)
+	select {
+	case <-stop:
+		t.Fatalf("stopped while ready")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	gate.Set("warm", false)
+	select {
+	case <-stop:
+	case <-time.After(time.Second):
+		t.Fatalf("did not stop on a failing check")
+	}
+}
+
+func TestReadinessCancel(t *testing.T) {
+	gate := newReadinessGate("warm")
+	cancel := make(chan struct{})
+	close(cancel)
+	if gate.WhileReady(cancel) != nil {
+		t.Fatalf("expected nil on cancel")
+	}
+}
+
+func TestReadinessTolerance(t *testing.T) {
+	ready := &readinessTolerance{tolerance: 2}
+	for i, tc := range []struct {
+		passing  bool
+		expected bool
+	}{
+		{true, false},
+		{true, true},
+		{false, true}, // a single failure is tolerated
+		{true, true},
+		{false, true},
+		{false, false},
+		{true, false},
+		{false, false},
+		{true, false},
+		{true, true},
+	} {
+		if actual := ready.Update(tc.passing); actual != tc.expected {
+			t.Fatalf("result %d: expected %v, got %v", i, tc.expected, actual)
+		}
+	}
+}
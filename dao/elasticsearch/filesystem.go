@@ -15,6 +15,7 @@ package elasticsearch
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"path/filepath"
@@ -26,6 +27,7 @@ import (
 	model "github.com/control-center/serviced/dao"
 	"github.com/control-center/serviced/datastore"
 	"github.com/control-center/serviced/dfs"
+	"github.com/control-center/serviced/facade"
 	"github.com/control-center/serviced/logging"
 	"github.com/control-center/serviced/volume"
 	gzip "github.com/klauspost/pgzip"
@@ -104,6 +106,25 @@ func (dao *ControlPlaneDao) Backup(backupRequest model.BackupRequest, filename *
 	if backupRequest.Dirpath == "" {
 		backupRequest.Dirpath = dao.backupsPath
 	}
+
+	// set the progress of the backup file
+	*filename = time.Now().UTC().Format("backup-2006-01-02-150405.tgz")
+	op := "backup"
+	if backupRequest.Scheduled {
+		*filename = model.ScheduledBackupPrefix + *filename
+		op = "scheduled backup"
+	}
+	backupfilename := filepath.Join(backupRequest.Dirpath, *filename)
+
+	inprogress.SetProgress(backupfilename, op)
+	defer func() {
+		if err != nil {
+			log.WithError(err).Error("Backup failed with error")
+			os.Remove(backupfilename)
+		}
+		inprogress.SetError(err)
+	}()
+
 	// CC-2421: Check for space before doing backup
 	est := model.BackupEstimate{}
 	err = dao.facade.EstimateBackup(ctx, backupRequest, &est)
@@ -126,18 +147,6 @@ func (dao *ControlPlaneDao) Backup(backupRequest model.BackupRequest, filename *
 		}
 	}
 
-	// set the progress of the backup file
-	*filename = time.Now().UTC().Format("backup-2006-01-02-150405.tgz")
-	backupfilename := filepath.Join(backupRequest.Dirpath, *filename)
-
-	inprogress.SetProgress(backupfilename, "backup")
-	defer func() {
-		if err != nil {
-			log.WithError(err).Error("Backup failed with error")
-			os.Remove(backupfilename)
-		}
-		inprogress.SetError(err)
-	}()
 	// create the file and write
 	fh, err := os.Create(backupfilename)
 	if err != nil {
@@ -152,10 +161,51 @@ func (dao *ControlPlaneDao) Backup(backupRequest model.BackupRequest, filename *
 	// Smaller blocks will allow other goroutines to get time more frequently.
 	w.SetConcurrency(100000, 2)
 	defer w.Close()
-	err = dao.facade.Backup(ctx, w, backupRequest.Excludes, backupRequest.SnapshotSpacePercent, backupfilename)
+	err = dao.facade.Backup(ctx, w, facade.BackupOptions{
+		Filename:             backupfilename,
+		Excludes:             backupRequest.Excludes,
+		SnapshotSpacePercent: backupRequest.SnapshotSpacePercent,
+		Incremental:          backupRequest.Incremental,
+	})
 	return
 }
 
+// getParentBackups returns the parents of an incremental backup, oldest
+// first.  Parents are looked up in the directory of the backup.
+func getParentBackups(info *dfs.BackupInfo) ([]*dfs.BackupInfo, error) {
+	var parents []*dfs.BackupInfo
+	seen := map[string]bool{info.Filename: true}
+	for info.Parent != "" {
+		filename := filepath.Join(filepath.Dir(info.Filename), info.Parent)
+		if seen[filename] {
+			return nil, fmt.Errorf("backup %s is its own parent", filename)
+		}
+		seen[filename] = true
+		parent, err := dfs.ExtractBackupInfo(filename)
+		if err != nil {
+			return nil, fmt.Errorf("could not read parent backup %s: %s", filename, err)
+		}
+		parents = append([]*dfs.BackupInfo{parent}, parents...)
+		info = parent
+	}
+	return parents, nil
+}
+
+// readBackupFile passes the uncompressed contents of a backup file to a reader
+func readBackupFile(filename string, read func(io.Reader) error) error {
+	fh, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	gz, err := gzip.NewReader(fh)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	return read(gz)
+}
+
 func (dao *ControlPlaneDao) GetBackupEstimate(backupRequest model.BackupRequest, backupEstimate *model.BackupEstimate) (err error) {
 	ctx := datastore.Get()
 	start := time.Now()
@@ -209,17 +259,23 @@ func (dao *ControlPlaneDao) Restore(restoreRequest model.RestoreRequest, _ *int)
 	if err != nil {
 		return err
 	}
-	fh, err := os.Open(restoreRequest.Filename)
+
+	// an incremental backup is restored on top of its parents
+	parents, err := getParentBackups(info)
 	if err != nil {
 		return err
 	}
-	defer fh.Close()
-	gz, err := gzip.NewReader(fh)
-	if err != nil {
-		return err
+	for _, parent := range parents {
+		err = readBackupFile(parent.Filename, func(r io.Reader) error {
+			return dao.facade.RestoreParent(ctx, r, parent, parent.Filename)
+		})
+		if err != nil {
+			return err
+		}
 	}
-	defer gz.Close()
-	err = dao.facade.Restore(ctx, gz, info, restoreRequest.Filename)
+	err = readBackupFile(restoreRequest.Filename, func(r io.Reader) error {
+		return dao.facade.Restore(ctx, r, info, restoreRequest.Filename, parents)
+	})
 	return err
 }
 
@@ -121,12 +121,18 @@ type ServiceInstanceRequest struct {
 	InstanceID int
 }
 
+// ScheduledBackupPrefix begins the names of the files written by scheduled
+// backups.  Only these files are removed by the backup retention settings.
+const ScheduledBackupPrefix = "scheduled-"
+
 type BackupRequest struct {
 	Dirpath              string
 	SnapshotSpacePercent int
 	Excludes             []string
 	Force                bool
 	Username             string
+	Scheduled            bool // taken by the backup schedule of the master
+	Incremental          bool // only exports the changes since the latest incremental backup
 }
 
 type RestoreRequest struct {
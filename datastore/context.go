@@ -30,6 +30,10 @@ type Context interface {
 	User() string
 }
 
+// SystemUser is the user of contexts that are not acting on behalf of a
+// request, such as the scheduler and the rpc server
+const SystemUser = "system"
+
 var savedDriver Driver
 
 //Register a driver to use for the context
@@ -53,14 +57,14 @@ func GetNew() Context {
 // GetNewInstance returns a new instance of the context object, but with the metrics and connections
 // from the global context object.
 func GetNewInstance() Context {
-	return &context{savedDriver, ctx.Metrics(), "system"}
+	return &context{savedDriver, ctx.Metrics(), SystemUser}
 }
 
 var ctx Context
 
 //new Creates a new context with a Driver to a datastore
 func newCtx(driver Driver) Context {
-	return &context{driver, metrics.NewMetrics(), "system"}
+	return &context{driver, metrics.NewMetrics(), SystemUser}
 }
 
 type context struct {
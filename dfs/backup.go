@@ -47,12 +47,6 @@ func (dfs *DistributedFilesystem) Backup(data BackupInfo, w io.Writer) error {
 
 	tarOut := tar.NewWriter(io.MultiWriter(w, progress))
 
-	// write the backup metadata
-	if err := dfs.writeBackupMetadata(data, tarOut); err != nil {
-		plog.WithError(err).Error("Unable to write metadata for backup")
-		return err
-	}
-
 	var images []string
 
 	baseImageLogger := backupLogger.WithField("total", len(data.BaseImages))
@@ -87,12 +81,15 @@ func (dfs *DistributedFilesystem) Backup(data BackupInfo, w io.Writer) error {
 
 	backupLogger.WithField("total", numberOfSnapshots).Info("Preparing snapshots for backup")
 
-	// export the snapshots
+	// load the images from the snapshots
+	vols := make([]volume.Volume, numberOfSnapshots)
+	infos := make([]*volume.SnapshotInfo, numberOfSnapshots)
 	for i, snapshot := range data.Snapshots {
 		vol, info, err := dfs.getSnapshotVolumeAndInfo(snapshot)
 		if err != nil {
 			return err
 		}
+		vols[i], infos[i] = vol, info
 
 		// load the images from this snapshot
 		tenantLogger := backupLogger.WithField("tenant", info.TenantID)
@@ -132,12 +129,41 @@ func (dfs *DistributedFilesystem) Backup(data BackupInfo, w io.Writer) error {
 		}
 
 		timer.Stop()
+	}
+
+	// an incremental backup only saves the images that its parents did not
+	if data.Incremental {
+		var err error
+		if images, err = dfs.newImages(&data, images); err != nil {
+			return err
+		}
+	}
+
+	// write the backup metadata
+	if err := dfs.writeBackupMetadata(data, tarOut); err != nil {
+		plog.WithError(err).Error("Unable to write metadata for backup")
+		return err
+	}
 
+	// export the snapshots
+	for i, snapshot := range data.Snapshots {
+		vol, info := vols[i], infos[i]
 		snapshotLogger := backupLogger.WithField("snapshot", snapshot)
 
+		// an incremental backup only exports the changes since the parent
+		var parent string
+		if parentID := data.ParentSnapshots[snapshot]; parentID != "" {
+			_, parentInfo, err := dfs.getSnapshotVolumeAndInfo(parentID)
+			if err != nil {
+				snapshotLogger.WithError(err).WithField("parent", parentID).Error("Could not find parent snapshot")
+				return err
+			}
+			parent = parentInfo.Label
+		}
+
 		// dump the snapshot into the backup
 		prefix := path.Join(SnapshotsMetadataDir, info.TenantID, info.Label)
-		snapReader, errchan := dfs.snapshotSavePipe(vol, info.Label, data.SnapshotExcludes[snapshot])
+		snapReader, errchan := dfs.snapshotSavePipe(vol, info.Label, parent, data.SnapshotExcludes[snapshot])
 		if err := rewriteTar(prefix, tarOut, snapReader); err != nil {
 			// be a good citizen and clean up any running threads
 			<-errchan
@@ -154,6 +180,12 @@ func (dfs *DistributedFilesystem) Backup(data BackupInfo, w io.Writer) error {
 		}).Info("Exported snapshot to backup")
 	}
 
+	if data.Incremental && len(images) == 0 {
+		tarOut.Close()
+		backupLogger.Info("No new images to export to backup")
+		return nil
+	}
+
 	// dump the images from all the snapshots into the backup
 	imageReader, errchan := dfs.dockerSavePipe(images...)
 	imageLogger := backupLogger.WithField("images", images)
@@ -174,6 +206,35 @@ func (dfs *DistributedFilesystem) Backup(data BackupInfo, w io.Writer) error {
 	return nil
 }
 
+// IncrementalExport returns true if the storage driver can export only the
+// changes since a parent snapshot.  Only btrfs can; the backups of the other
+// drivers always export their snapshots in full.
+func (dfs *DistributedFilesystem) IncrementalExport() bool {
+	return dfs.disk.DriverType() == volume.DriverTypeBtrFS
+}
+
+// newImages returns the images of an incremental backup that were not saved by
+// its parents, and records their image ids in the backup metadata.
+func (dfs *DistributedFilesystem) newImages(data *BackupInfo, images []string) ([]string, error) {
+	var result []string
+	data.Images = make(map[string]string)
+	for _, image := range images {
+		imageLogger := plog.WithField("image", image)
+		img, err := dfs.docker.FindImage(image)
+		if err != nil {
+			imageLogger.WithError(err).Error("Could not find image for backup")
+			return nil, err
+		}
+		if id, ok := data.ParentImages[image]; ok && id == img.ID {
+			imageLogger.Debug("Image was saved by a parent backup, skipping")
+			continue
+		}
+		data.Images[image] = img.ID
+		result = append(result, image)
+	}
+	return result, nil
+}
+
 // savePipe is a generic io pipe that returns the reader
 func savePipe(do func(w io.Writer) error) (*io.PipeReader, <-chan error) {
 	r, w := io.Pipe()
@@ -194,9 +255,9 @@ func (dfs *DistributedFilesystem) dockerSavePipe(images ...string) (*io.PipeRead
 }
 
 // snapshotSavePipe returns a pipe that exports a given volume to the pipe's stdout
-func (dfs *DistributedFilesystem) snapshotSavePipe(vol volume.Volume, label string, excludes []string) (*io.PipeReader, <-chan error) {
+func (dfs *DistributedFilesystem) snapshotSavePipe(vol volume.Volume, label, parent string, excludes []string) (*io.PipeReader, <-chan error) {
 	return savePipe(func(w io.Writer) error {
-		return vol.Export(label, "", w, excludes)
+		return vol.Export(label, parent, w, excludes)
 	})
 }
 
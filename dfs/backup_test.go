@@ -194,3 +194,54 @@ func (s *DFSTestSuite) TestBackup(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(buf.Len() > 0, Equals, true)
 }
+
+func (s *DFSTestSuite) TestBackup_Incremental(c *C) {
+	buf := bytes.NewBufferString("")
+	backupInfo := BackupInfo{
+		BaseImages:      []string{"library/repo:tag"},
+		Snapshots:       []string{"BASE_LABEL"},
+		Timestamp:       time.Now().UTC(),
+		Incremental:     true,
+		Parent:          "backup-parent.tgz",
+		ParentSnapshots: map[string]string{"BASE_LABEL": "BASE_PARENT"},
+		ParentImages: map[string]string{
+			"library/repo:tag":              "baseimageid",
+			"testserver:5000/BASE/repo:tag": "oldimageid",
+		},
+	}
+	s.docker.On("FindImage", "library/repo:tag").Return(&dockerclient.Image{ID: "baseimageid"}, nil)
+	s.docker.On("FindImage", "testserver:5000/BASE/repo:tag").Return(&dockerclient.Image{ID: "newimageid"}, nil)
+	vol := s.getVolumeFromSnapshot("BASE_LABEL", "BASE")
+	s.disk.On("GetTenant", "BASE_PARENT").Return(vol, nil)
+	vol.On("SnapshotInfo", "BASE_LABEL").Return(&volume.SnapshotInfo{
+		Name:     "BASE_LABEL",
+		TenantID: "BASE",
+		Label:    "LABEL",
+	}, nil)
+	vol.On("SnapshotInfo", "BASE_PARENT").Return(&volume.SnapshotInfo{
+		Name:     "BASE_PARENT",
+		TenantID: "BASE",
+		Label:    "PARENT",
+	}, nil)
+	imagesbuf := bytes.NewBufferString("")
+	err := json.NewEncoder(imagesbuf).Encode([]string{"BASE/repo:tag"})
+	c.Assert(err, IsNil)
+	vol.On("ReadMetadata", "LABEL", ImagesMetadataFile).Return(&NopCloser{imagesbuf}, nil)
+	s.registry.On("PullImage", mock.AnythingOfType("<-chan time.Time"), "BASE/repo:tag").Return(nil)
+	s.registry.On("ImagePath", "BASE/repo:tag").Return("testserver:5000/BASE/repo:tag", nil)
+	vol.On("Export", "LABEL", "PARENT", mock.AnythingOfType("*io.PipeWriter")).Return(nil).Run(func(a mock.Arguments) {
+		tar.NewWriter(a.Get(2).(io.Writer)).Close()
+	})
+	s.docker.On("SaveImages", []string{"testserver:5000/BASE/repo:tag"}, mock.AnythingOfType("*io.PipeWriter")).Return(nil).Run(func(a mock.Arguments) {
+		tar.NewWriter(a.Get(1).(io.Writer)).Close()
+	})
+	err = s.dfs.Backup(backupInfo, buf)
+	c.Assert(err, IsNil)
+	vol.AssertExpectations(c)
+	s.docker.AssertExpectations(c)
+
+	info, err := s.dfs.BackupInfo(buf)
+	c.Assert(err, IsNil)
+	c.Assert(info.Parent, Equals, "backup-parent.tgz")
+	c.Assert(info.Images, DeepEquals, map[string]string{"testserver:5000/BASE/repo:tag": "newimageid"})
+}
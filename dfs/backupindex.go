@@ -0,0 +1,91 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfs
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// BackupIndexFile is the name of the file in a backup directory that records
+// the incremental backups in that directory.
+const BackupIndexFile = ".incremental-backups.json"
+
+// IncrementalBackup describes an incremental backup in a BackupIndex
+type IncrementalBackup struct {
+	Parent string // the file name of the parent backup, empty for a base backup
+	Length int    // the number of backups in the chain, including this one
+}
+
+// BackupIndex records the chains of incremental backups in a directory, so
+// that the parent of the next incremental backup can be found without reading
+// the metadata of every backup in the directory.
+type BackupIndex struct {
+	Latest  string                       // the file name of the latest incremental backup
+	Backups map[string]IncrementalBackup // the incremental backups by file name
+}
+
+// ReadBackupIndex loads the backup index of a directory.  A directory without
+// an index has no incremental backups.
+func ReadBackupIndex(dirpath string) (*BackupIndex, error) {
+	index := &BackupIndex{Backups: make(map[string]IncrementalBackup)}
+	data, err := ioutil.ReadFile(filepath.Join(dirpath, BackupIndexFile))
+	if os.IsNotExist(err) {
+		return index, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, err
+	}
+	if index.Backups == nil {
+		index.Backups = make(map[string]IncrementalBackup)
+	}
+	return index, nil
+}
+
+// Add records an incremental backup as the latest in the index.  The parent is
+// empty if the backup is the base of a new chain.
+func (index *BackupIndex) Add(filename, parent string) {
+	length := 1
+	if parent != "" {
+		length += index.Backups[parent].Length
+	}
+	index.Backups[filename] = IncrementalBackup{Parent: parent, Length: length}
+	index.Latest = filename
+}
+
+// Write saves the backup index to a directory.  The index is replaced
+// atomically, so that a failed write does not lose the existing index.
+func (index *BackupIndex) Write(dirpath string) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	fh, err := ioutil.TempFile(dirpath, BackupIndexFile)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fh.Name())
+	if _, err := fh.Write(data); err != nil {
+		fh.Close()
+		return err
+	}
+	if err := fh.Close(); err != nil {
+		return err
+	}
+	return os.Rename(fh.Name(), filepath.Join(dirpath, BackupIndexFile))
+}
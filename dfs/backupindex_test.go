@@ -0,0 +1,58 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package dfs_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/control-center/serviced/dfs"
+	. "gopkg.in/check.v1"
+)
+
+func (s *DFSTestSuite) TestBackupIndex_Missing(c *C) {
+	index, err := ReadBackupIndex(c.MkDir())
+	c.Assert(err, IsNil)
+	c.Assert(index.Latest, Equals, "")
+	c.Assert(index.Backups, HasLen, 0)
+}
+
+func (s *DFSTestSuite) TestBackupIndex_Chain(c *C) {
+	dir := c.MkDir()
+	index, err := ReadBackupIndex(dir)
+	c.Assert(err, IsNil)
+	index.Add("backup-1.tgz", "")
+	index.Add("backup-2.tgz", "backup-1.tgz")
+	index.Add("backup-3.tgz", "backup-2.tgz")
+	c.Assert(index.Write(dir), IsNil)
+
+	index, err = ReadBackupIndex(dir)
+	c.Assert(err, IsNil)
+	c.Assert(index.Latest, Equals, "backup-3.tgz")
+	c.Assert(index.Backups["backup-3.tgz"], Equals, IncrementalBackup{Parent: "backup-2.tgz", Length: 3})
+
+	// a new base starts a new chain
+	index.Add("backup-4.tgz", "")
+	c.Assert(index.Latest, Equals, "backup-4.tgz")
+	c.Assert(index.Backups["backup-4.tgz"], Equals, IncrementalBackup{Length: 1})
+}
+
+func (s *DFSTestSuite) TestBackupIndex_Invalid(c *C) {
+	dir := c.MkDir()
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, BackupIndexFile), []byte("garbage"), 0644), IsNil)
+	_, err := ReadBackupIndex(dir)
+	c.Assert(err, NotNil)
+}
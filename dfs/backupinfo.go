@@ -56,5 +56,6 @@ func ExtractBackupInfo(filename string) (*BackupInfo, error) {
 	if err := json.Unmarshal(data, &info); err != nil {
 		return nil, ErrRestoreNoInfo
 	}
+	info.Filename = filename
 	return &info, nil
 }
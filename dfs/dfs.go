@@ -57,6 +57,9 @@ type DFS interface {
 	Info(snapshotID string) (*SnapshotInfo, error)
 	// Backup saves and exports the current state of the system
 	Backup(info BackupInfo, w io.Writer) error
+	// IncrementalExport returns true if the storage driver can export only
+	// the changes since a parent snapshot
+	IncrementalExport() bool
 	// Restore restores the system to the state of the backup
 	Restore(r io.Reader, version int) error
 	// BackupInfo provides detailed info for a particular backup
@@ -93,8 +96,33 @@ type BackupInfo struct {
 	SnapshotExcludes map[string][]string
 	Timestamp        time.Time
 	BackupVersion    int
+
+	// Incremental is set for backups that are part of a chain of incremental
+	// backups.  Their snapshots are kept so that the next backup of the
+	// chain only has to export what changed since.
+	Incremental bool
+	// Parent is the file name of the backup that an incremental backup is
+	// based on; it is empty for the first backup of the chain.
+	Parent string
+	// ParentSnapshots maps a snapshot to the snapshot of the same tenant in
+	// the parent backup.  Only the changes since the parent are exported.
+	ParentSnapshots map[string]string
+	// Images maps the docker images saved in an incremental backup to their
+	// image ids.
+	Images map[string]string
+	// ParentImages maps the docker images saved in the parents of the backup
+	// to their image ids.  They are not saved again unless they changed.
+	ParentImages map[string]string
+
+	// Filename is the path of the backup file that the info was extracted
+	// from.  It is not part of the backup metadata.
+	Filename string `json:"-"`
 }
 
+// IncrementalBackupVersion is the version of incremental backups, so that
+// they are not restored by releases that cannot apply their parents first.
+const IncrementalBackupVersion = 2
+
 // SnapshotInfo provides meta info about a snapshot
 type SnapshotInfo struct {
 	*volume.SnapshotInfo
@@ -222,6 +222,20 @@ func (_m *DFS) Backup(info dfs.BackupInfo, w io.Writer) error {
 	return r0
 }
 
+// IncrementalExport provides a mock function with given fields:
+func (_m *DFS) IncrementalExport() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Restore provides a mock function with given fields: r, backupInfo
 func (_m *DFS) Restore(r io.Reader, version int) error {
 	ret := _m.Called(r, version)
@@ -35,7 +35,7 @@ func (dfs *DistributedFilesystem) Restore(r io.Reader, version int) error {
 	switch version {
 	case 0:
 		return dfs.restoreV0(r)
-	case 1:
+	case 1, IncrementalBackupVersion:
 		return dfs.restoreV1(r)
 	default:
 		return ErrInvalidBackupVersion
@@ -0,0 +1,186 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/control-center/serviced/dao"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/utils"
+)
+
+// BackupClient takes and lists the backups of the master
+type BackupClient interface {
+	// Backup captures the state of the application stack
+	Backup(dao.BackupRequest, *string) error
+	// ListBackups returns the list of backups in a directory
+	ListBackups(string, *[]dao.BackupFile) error
+}
+
+// BackupRecorder records the outcome of scheduled backups
+type BackupRecorder interface {
+	// RecordScheduledBackup writes an audit event for a scheduled backup
+	RecordScheduledBackup(ctx datastore.Context, backupFilename string, err error)
+}
+
+// BackupSchedule describes when the master takes backups and which of them
+// to keep.  A scheduled backup is removed once it is neither one of the
+// KeepLast most recent nor younger than KeepDays days.  If both are 0,
+// scheduled backups are never removed.
+type BackupSchedule struct {
+	Cron                 *utils.CronSchedule
+	Excludes             []string
+	SnapshotSpacePercent int
+	KeepLast             int
+	KeepDays             int
+}
+
+// BackupScheduler takes backups as the backup schedule comes due, and removes
+// the scheduled backups that it no longer keeps.  Backups are written to the
+// default backups path of the master.
+type BackupScheduler struct {
+	client   BackupClient
+	recorder BackupRecorder
+	schedule BackupSchedule
+	next     time.Time
+	remove   func(string) error
+}
+
+// NewBackupScheduler returns a BackupScheduler
+func NewBackupScheduler(client BackupClient, recorder BackupRecorder, schedule BackupSchedule) *BackupScheduler {
+	return &BackupScheduler{
+		client:   client,
+		recorder: recorder,
+		schedule: schedule,
+		remove:   os.Remove,
+	}
+}
+
+// RunBackupSchedule checks the backup schedule at each interval until
+// cancelled.
+func RunBackupSchedule(client BackupClient, recorder BackupRecorder, schedule BackupSchedule, cancel <-chan interface{}, interval time.Duration) {
+	s := NewBackupScheduler(client, recorder, schedule)
+	plog.WithField("cron", schedule.Cron).Info("Started backup schedule")
+	for {
+		s.Run(time.Now())
+		select {
+		case <-time.After(interval):
+		case <-cancel:
+			return
+		}
+	}
+}
+
+// Run takes a backup if one is due at the given time and then removes the
+// scheduled backups that are no longer kept.  The first run is due at the
+// first time after the latest scheduled backup that matches the schedule, so
+// a backup missed while no master was leading is taken right away.
+func (s *BackupScheduler) Run(now time.Time) {
+	ctx := datastore.Get()
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("BackupScheduler.Run"))
+
+	if s.next.IsZero() {
+		last := now
+		if backups, err := s.scheduledBackups(); err != nil {
+			plog.WithError(err).Warn("Could not look up scheduled backups")
+		} else if len(backups) > 0 {
+			last = backups[0].ModTime
+		}
+		s.next = s.schedule.Cron.Next(last)
+	}
+	if s.next.IsZero() || s.next.After(now) {
+		return
+	}
+	s.next = s.schedule.Cron.Next(now)
+
+	var filename string
+	req := dao.BackupRequest{
+		SnapshotSpacePercent: s.schedule.SnapshotSpacePercent,
+		Excludes:             s.schedule.Excludes,
+		Scheduled:            true,
+	}
+	err := s.client.Backup(req, &filename)
+	s.recorder.RecordScheduledBackup(ctx, filename, err)
+	if err != nil {
+		plog.WithError(err).WithField("backupfile", filename).Warn("Could not take scheduled backup")
+		return
+	}
+	plog.WithField("backupfile", filename).Info("Took scheduled backup")
+	s.prune(now)
+}
+
+// scheduledBackups returns the completed scheduled backups, newest first
+func (s *BackupScheduler) scheduledBackups() ([]dao.BackupFile, error) {
+	var files []dao.BackupFile
+	if err := s.client.ListBackups("", &files); err != nil {
+		return nil, err
+	}
+	var backups []dao.BackupFile
+	for _, file := range files {
+		if !file.InProgress && strings.HasPrefix(file.Name, dao.ScheduledBackupPrefix) {
+			backups = append(backups, file)
+		}
+	}
+	sort.Sort(newestBackupsFirst(backups))
+	return backups, nil
+}
+
+// newestBackupsFirst orders backup files from the newest to the oldest
+type newestBackupsFirst []dao.BackupFile
+
+func (b newestBackupsFirst) Len() int           { return len(b) }
+func (b newestBackupsFirst) Less(i, j int) bool { return b[i].ModTime.After(b[j].ModTime) }
+func (b newestBackupsFirst) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
+// prune removes the scheduled backups that are not kept by the schedule
+func (s *BackupScheduler) prune(now time.Time) {
+	if s.schedule.KeepLast == 0 && s.schedule.KeepDays == 0 {
+		return
+	}
+	backups, err := s.scheduledBackups()
+	if err != nil {
+		plog.WithError(err).Warn("Could not look up scheduled backups")
+		return
+	}
+
+	times := make([]time.Time, len(backups))
+	for i, backup := range backups {
+		times[i] = backup.ModTime
+	}
+	keep := make([]bool, len(backups))
+	if s.schedule.KeepLast > 0 {
+		keep = domain.RetentionPolicy{KeepLast: s.schedule.KeepLast}.Keep(times)
+	}
+	expire := now.AddDate(0, 0, -s.schedule.KeepDays)
+	for i, backup := range backups {
+		if keep[i] || (s.schedule.KeepDays > 0 && backup.ModTime.After(expire)) {
+			continue
+		}
+		logger := plog.WithFields(log.Fields{
+			"backupfile": backup.FullPath,
+			"modtime":    backup.ModTime,
+		})
+		if err := s.remove(backup.FullPath); err != nil {
+			logger.WithError(err).Warn("Could not remove scheduled backup")
+			continue
+		}
+		logger.Info("Removed scheduled backup")
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/control-center/serviced/dao"
+	"github.com/control-center/serviced/datastore"
+	datastoreMocks "github.com/control-center/serviced/datastore/mocks"
+	"github.com/control-center/serviced/utils"
+	. "gopkg.in/check.v1"
+)
+
+var _ = Suite(&BackupSchedulerTestSuite{})
+
+type BackupSchedulerTestSuite struct {
+	client   *testBackupClient
+	recorder *testBackupRecorder
+	removed  []string
+}
+
+type testBackupClient struct {
+	now       time.Time
+	backupErr error
+	requests  []dao.BackupRequest
+	files     []dao.BackupFile
+}
+
+func (t *testBackupClient) Backup(req dao.BackupRequest, filename *string) error {
+	if t.backupErr != nil {
+		return t.backupErr
+	}
+	t.requests = append(t.requests, req)
+	*filename = fmt.Sprintf("%sbackup-%d.tgz", dao.ScheduledBackupPrefix, len(t.files))
+	t.files = append(t.files, dao.BackupFile{
+		Name:     *filename,
+		FullPath: filepath.Join("/backups", *filename),
+		ModTime:  t.now,
+	})
+	return nil
+}
+
+func (t *testBackupClient) ListBackups(dirpath string, files *[]dao.BackupFile) error {
+	*files = append([]dao.BackupFile{}, t.files...)
+	return nil
+}
+
+type testBackupRecorder struct {
+	filenames []string
+	errs      []error
+}
+
+func (t *testBackupRecorder) RecordScheduledBackup(ctx datastore.Context, filename string, err error) {
+	t.filenames = append(t.filenames, filename)
+	t.errs = append(t.errs, err)
+}
+
+func (s *BackupSchedulerTestSuite) SetUpTest(c *C) {
+	datastore.Register(&datastoreMocks.Driver{})
+	s.client = &testBackupClient{}
+	s.recorder = &testBackupRecorder{}
+	s.removed = nil
+}
+
+func (s *BackupSchedulerTestSuite) scheduler(c *C, cron string, keepLast, keepDays int) *BackupScheduler {
+	sched, err := utils.ParseCronSchedule(cron)
+	c.Assert(err, IsNil)
+	scheduler := NewBackupScheduler(s.client, s.recorder, BackupSchedule{
+		Cron:     sched,
+		Excludes: []string{"/excluded"},
+		KeepLast: keepLast,
+		KeepDays: keepDays,
+	})
+	scheduler.remove = func(path string) error {
+		s.removed = append(s.removed, path)
+		for i, file := range s.client.files {
+			if file.FullPath == path {
+				s.client.files = append(s.client.files[:i], s.client.files[i+1:]...)
+				return nil
+			}
+		}
+		return errors.New("file not found")
+	}
+	return scheduler
+}
+
+func (s *BackupSchedulerTestSuite) TestRun_Due(c *C) {
+	last := time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC)
+	s.client.files = []dao.BackupFile{
+		{Name: "scheduled-old.tgz", FullPath: "/backups/scheduled-old.tgz", ModTime: last},
+		{Name: "manual.tgz", FullPath: "/backups/manual.tgz", ModTime: last.Add(20 * time.Hour)},
+	}
+	scheduler := s.scheduler(c, "@daily", 0, 0)
+
+	// not due until a day after the last scheduled backup
+	scheduler.Run(last.Add(23 * time.Hour))
+	c.Assert(s.client.requests, HasLen, 0)
+
+	now := last.Add(25 * time.Hour)
+	s.client.now = now
+	scheduler.Run(now)
+	c.Assert(s.client.requests, DeepEquals, []dao.BackupRequest{
+		{Excludes: []string{"/excluded"}, Scheduled: true},
+	})
+	c.Assert(s.recorder.filenames, DeepEquals, []string{"scheduled-backup-2.tgz"})
+	c.Assert(s.recorder.errs, DeepEquals, []error{nil})
+
+	// the schedule is not due again until the next day
+	scheduler.Run(now.Add(time.Hour))
+	c.Assert(s.client.requests, HasLen, 1)
+	c.Assert(s.removed, HasLen, 0)
+}
+
+func (s *BackupSchedulerTestSuite) TestRun_BackupError(c *C) {
+	s.client.backupErr = errors.New("not enough space")
+	s.client.files = []dao.BackupFile{
+		{Name: "scheduled-old.tgz", FullPath: "/backups/scheduled-old.tgz"},
+	}
+	now := time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC)
+	s.scheduler(c, "@daily", 1, 0).Run(now)
+	c.Assert(s.recorder.filenames, DeepEquals, []string{""})
+	c.Assert(s.recorder.errs, DeepEquals, []error{s.client.backupErr})
+	c.Assert(s.removed, HasLen, 0)
+}
+
+func (s *BackupSchedulerTestSuite) TestRun_Retention(c *C) {
+	now := time.Date(2019, 3, 10, 0, 0, 0, 0, time.UTC)
+	s.client.now = now
+	for i := 1; i <= 4; i++ {
+		name := fmt.Sprintf("%sday-%d.tgz", dao.ScheduledBackupPrefix, i)
+		s.client.files = append(s.client.files, dao.BackupFile{
+			Name:     name,
+			FullPath: filepath.Join("/backups", name),
+			ModTime:  now.AddDate(0, 0, -i),
+		})
+	}
+	s.client.files = append(s.client.files,
+		dao.BackupFile{Name: "manual.tgz", FullPath: "/backups/manual.tgz", ModTime: now.AddDate(0, 0, -10)},
+		dao.BackupFile{Name: "scheduled-partial.tgz", FullPath: "/backups/scheduled-partial.tgz", ModTime: now.AddDate(0, 0, -10), InProgress: true},
+	)
+
+	// keeps the 2 latest backups and those younger than 3 days
+	s.scheduler(c, "@daily", 2, 3).Run(now)
+	c.Assert(s.removed, DeepEquals, []string{"/backups/scheduled-day-3.tgz", "/backups/scheduled-day-4.tgz"})
+}
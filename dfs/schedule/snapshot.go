@@ -0,0 +1,159 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/control-center/serviced/dao"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/snapshotschedule"
+	"github.com/control-center/serviced/logging"
+)
+
+// instantiate the package logger
+var plog = logging.PackageLogger()
+
+// SnapshotClient takes, lists and deletes the snapshots of tenants
+type SnapshotClient interface {
+	// Snapshot captures the state of a single application
+	Snapshot(dao.SnapshotRequest, *string) error
+	// ListSnapshots returns the list of all snapshots given a service id
+	ListSnapshots(string, *[]dao.SnapshotInfo) error
+	// DeleteSnapshot deletes a snapshot by SnapshotID
+	DeleteSnapshot(string, *int) error
+}
+
+// SnapshotScheduleStore looks up snapshot schedules and records their runs
+type SnapshotScheduleStore interface {
+	// GetSnapshotSchedules returns the snapshot schedules of all tenants
+	GetSnapshotSchedules(ctx datastore.Context) ([]snapshotschedule.Schedule, error)
+	// RecordSnapshotScheduleRun stores the outcome of a run of a schedule
+	RecordSnapshotScheduleRun(ctx datastore.Context, tenantID string, ran time.Time, snapshotID, errMsg string) error
+}
+
+// SnapshotScheduler takes the snapshots of tenants as their schedules come
+// due, and removes the scheduled snapshots that their retention policies no
+// longer keep.
+type SnapshotScheduler struct {
+	client       SnapshotClient
+	store        SnapshotScheduleStore
+	spacePercent int
+}
+
+// NewSnapshotScheduler returns a SnapshotScheduler.  spacePercent is the
+// percent of a tenant's volume that must be free to take a snapshot.
+func NewSnapshotScheduler(client SnapshotClient, store SnapshotScheduleStore, spacePercent int) *SnapshotScheduler {
+	return &SnapshotScheduler{client: client, store: store, spacePercent: spacePercent}
+}
+
+// RunSnapshotSchedules checks the snapshot schedules at each interval until
+// cancelled.
+func RunSnapshotSchedules(client SnapshotClient, store SnapshotScheduleStore, spacePercent int, cancel <-chan interface{}, interval time.Duration) {
+	s := NewSnapshotScheduler(client, store, spacePercent)
+	plog.WithField("interval", interval).Debug("Started snapshot schedules")
+	for {
+		s.Run(time.Now())
+		select {
+		case <-time.After(interval):
+		case <-cancel:
+			return
+		}
+	}
+}
+
+// Run takes a snapshot of each tenant whose schedule is due at the given time
+// and then applies the schedule's retention policy.  Failures are recorded on
+// the schedule so they do not stop the other tenants from being snapshotted.
+func (s *SnapshotScheduler) Run(now time.Time) {
+	ctx := datastore.Get()
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("SnapshotScheduler.Run"))
+
+	schedules, err := s.store.GetSnapshotSchedules(ctx)
+	if err != nil {
+		plog.WithError(err).Warn("Could not look up snapshot schedules")
+		return
+	}
+	for _, sched := range schedules {
+		logger := plog.WithFields(log.Fields{
+			"tenantid": sched.TenantID,
+			"cron":     sched.Cron,
+		})
+		if sched.Disabled {
+			continue
+		}
+		next, err := sched.Next()
+		if err != nil {
+			logger.WithError(err).Warn("Could not parse snapshot schedule")
+			continue
+		} else if next.IsZero() || next.After(now) {
+			continue
+		}
+
+		var snapshotID, errMsg string
+		req := dao.SnapshotRequest{
+			ServiceID:            sched.TenantID,
+			Message:              snapshotschedule.ScheduledMessage,
+			Tag:                  snapshotschedule.ScheduledTag(now),
+			SnapshotSpacePercent: s.spacePercent,
+		}
+		if err := s.client.Snapshot(req, &snapshotID); err != nil {
+			logger.WithError(err).Warn("Could not take scheduled snapshot")
+			errMsg = err.Error()
+		} else {
+			logger.WithField("snapshotid", snapshotID).Info("Took scheduled snapshot")
+		}
+		if err := s.store.RecordSnapshotScheduleRun(ctx, sched.TenantID, now, snapshotID, errMsg); err != nil {
+			logger.WithError(err).Warn("Could not record run of snapshot schedule")
+		}
+		if errMsg == "" {
+			s.prune(sched)
+		}
+	}
+}
+
+// prune deletes the scheduled snapshots of a tenant that have not been tagged
+// since they were taken and are not kept by its retention policy.
+func (s *SnapshotScheduler) prune(sched snapshotschedule.Schedule) {
+	logger := plog.WithField("tenantid", sched.TenantID)
+	if sched.Retention.IsZero() {
+		return
+	}
+
+	var snapshots []dao.SnapshotInfo
+	if err := s.client.ListSnapshots(sched.TenantID, &snapshots); err != nil {
+		logger.WithError(err).Warn("Could not look up snapshots for tenant service")
+		return
+	}
+	var candidates []dao.SnapshotInfo
+	var times []time.Time
+	for _, snapshot := range snapshots {
+		if snapshotschedule.IsScheduled(snapshot.Tags) {
+			candidates = append(candidates, snapshot)
+			times = append(times, snapshot.Created)
+		}
+	}
+	for i, keep := range sched.Retention.Keep(times) {
+		if keep {
+			continue
+		}
+		snapshotLogger := logger.WithField("snapshotid", candidates[i].SnapshotID)
+		if err := s.client.DeleteSnapshot(candidates[i].SnapshotID, nil); err != nil {
+			snapshotLogger.WithError(err).Warn("Could not delete scheduled snapshot")
+			continue
+		}
+		snapshotLogger.Debug("Deleted scheduled snapshot")
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/control-center/serviced/dao"
+	"github.com/control-center/serviced/datastore"
+	datastoreMocks "github.com/control-center/serviced/datastore/mocks"
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/snapshotschedule"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+var _ = Suite(&SnapshotSchedulerTestSuite{})
+
+type SnapshotSchedulerTestSuite struct {
+	client *testSnapshotClient
+	store  *testScheduleStore
+}
+
+type testSnapshotClient struct {
+	snapshotErr error
+	snaps       []dao.SnapshotInfo
+	taken       []string
+}
+
+func (t *testSnapshotClient) Snapshot(req dao.SnapshotRequest, snapshotID *string) error {
+	if t.snapshotErr != nil {
+		return t.snapshotErr
+	}
+	*snapshotID = fmt.Sprintf("%s_%d", req.ServiceID, len(t.snaps))
+	t.taken = append(t.taken, req.ServiceID)
+	t.snaps = append(t.snaps, dao.SnapshotInfo{
+		SnapshotID:  *snapshotID,
+		TenantID:    req.ServiceID,
+		Description: req.Message,
+		Tags:        []string{req.Tag},
+		Created:     time.Now(),
+	})
+	return nil
+}
+
+func (t *testSnapshotClient) ListSnapshots(tenantID string, snaps *[]dao.SnapshotInfo) error {
+	*snaps = []dao.SnapshotInfo{}
+	for _, snap := range t.snaps {
+		if snap.TenantID == tenantID {
+			*snaps = append(*snaps, snap)
+		}
+	}
+	return nil
+}
+
+func (t *testSnapshotClient) DeleteSnapshot(snapshotID string, _ *int) error {
+	for i, snap := range t.snaps {
+		if snap.SnapshotID == snapshotID {
+			t.snaps = append(t.snaps[:i], t.snaps[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("snapshot not found")
+}
+
+type testScheduleStore struct {
+	schedules []snapshotschedule.Schedule
+}
+
+func (t *testScheduleStore) GetSnapshotSchedules(ctx datastore.Context) ([]snapshotschedule.Schedule, error) {
+	return t.schedules, nil
+}
+
+func (t *testScheduleStore) RecordSnapshotScheduleRun(ctx datastore.Context, tenantID string, ran time.Time, snapshotID, errMsg string) error {
+	for i := range t.schedules {
+		if t.schedules[i].TenantID == tenantID {
+			t.schedules[i].LastRun = ran
+			t.schedules[i].LastError = errMsg
+			if errMsg == "" {
+				t.schedules[i].LastSnapshot = snapshotID
+			}
+			return nil
+		}
+	}
+	return errors.New("schedule not found")
+}
+
+func (s *SnapshotSchedulerTestSuite) SetUpTest(c *C) {
+	datastore.Register(&datastoreMocks.Driver{})
+	s.client = &testSnapshotClient{}
+	s.store = &testScheduleStore{}
+}
+
+func (s *SnapshotSchedulerTestSuite) TestRun_Due(c *C) {
+	created := time.Date(2019, 3, 4, 10, 30, 0, 0, time.UTC)
+	s.store.schedules = []snapshotschedule.Schedule{
+		{TenantID: "due", Cron: "0 * * * *", CreatedAt: created},
+		{TenantID: "notdue", Cron: "0 0 * * *", CreatedAt: created},
+		{TenantID: "disabled", Cron: "0 * * * *", CreatedAt: created, Disabled: true},
+	}
+	scheduler := NewSnapshotScheduler(s.client, s.store, 0)
+
+	now := time.Date(2019, 3, 4, 11, 0, 0, 0, time.UTC)
+	scheduler.Run(now)
+	c.Assert(s.client.taken, DeepEquals, []string{"due"})
+	c.Assert(s.store.schedules[0].LastRun, Equals, now)
+	c.Assert(s.store.schedules[0].LastSnapshot, Equals, "due_0")
+	c.Assert(s.store.schedules[1].LastRun.IsZero(), Equals, true)
+
+	// the schedule is not due again until the next hour
+	scheduler.Run(now.Add(30 * time.Minute))
+	c.Assert(s.client.taken, HasLen, 1)
+}
+
+func (s *SnapshotSchedulerTestSuite) TestRun_SnapshotError(c *C) {
+	s.store.schedules = []snapshotschedule.Schedule{
+		{TenantID: "tenant", Cron: "@hourly", LastSnapshot: "tenant_old"},
+	}
+	s.client.snapshotErr = errors.New("not enough space")
+	now := time.Now().Add(time.Hour)
+	NewSnapshotScheduler(s.client, s.store, 0).Run(now)
+	c.Assert(s.store.schedules[0].LastRun, Equals, now)
+	c.Assert(s.store.schedules[0].LastError, Equals, "not enough space")
+	c.Assert(s.store.schedules[0].LastSnapshot, Equals, "tenant_old")
+}
+
+func (s *SnapshotSchedulerTestSuite) TestRun_Retention(c *C) {
+	now := time.Now()
+	scheduled := func(created time.Time) []string {
+		return []string{snapshotschedule.ScheduledTag(created)}
+	}
+	s.client.snaps = []dao.SnapshotInfo{
+		{SnapshotID: "old1", TenantID: "tenant", Tags: scheduled(now.Add(-3 * time.Hour)), Created: now.Add(-3 * time.Hour)},
+		{SnapshotID: "old2", TenantID: "tenant", Tags: scheduled(now.Add(-2 * time.Hour)), Created: now.Add(-2 * time.Hour)},
+		{SnapshotID: "tagged", TenantID: "tenant", Tags: append(scheduled(now.Add(-4*time.Hour)), "keep"), Created: now.Add(-4 * time.Hour)},
+		{SnapshotID: "manual", TenantID: "tenant", Description: snapshotschedule.ScheduledMessage, Created: now.Add(-5 * time.Hour)},
+		{SnapshotID: "other", TenantID: "other", Tags: scheduled(now.Add(-5 * time.Hour)), Created: now.Add(-5 * time.Hour)},
+	}
+	s.store.schedules = []snapshotschedule.Schedule{
+		{TenantID: "tenant", Cron: "@hourly", Retention: domain.RetentionPolicy{KeepLast: 2}},
+	}
+	NewSnapshotScheduler(s.client, s.store, 0).Run(now.Add(time.Hour))
+
+	var ids []string
+	for _, snap := range s.client.snaps {
+		ids = append(ids, snap.SnapshotID)
+	}
+	c.Assert(ids, DeepEquals, []string{"old2", "tagged", "manual", "other", "tenant_5"})
+}
@@ -0,0 +1,81 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitoken
+
+import (
+	"strings"
+	"time"
+
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/datastore"
+)
+
+// principalPrefix marks the datastore context user of requests that were
+// authenticated with an api token.
+const principalPrefix = "apitoken:"
+
+// APIToken is a named, revocable bearer token that automation uses to call
+// the REST api on behalf of a user.
+type APIToken struct {
+	ID        string    // unique identifier, embedded in the signed token
+	Name      string    // name given to the token when it was created
+	User      string    // the user the token acts on behalf of
+	Role      auth.Role // the highest role the token may use
+	Tenants   []string  // the tenants the token is restricted to; empty for all
+	Created   time.Time // when the token was created
+	ExpiresAt time.Time // when the token expires; zero if it never expires
+	LastUsed  time.Time // when the token was last used; zero if never used
+	datastore.VersionedEntity
+}
+
+// Expired returns true if the token has passed its expiration time
+func (t APIToken) Expired() bool {
+	return !t.ExpiresAt.IsZero() && !time.Now().Before(t.ExpiresAt)
+}
+
+// Principal returns the name recorded as the user of requests made with the
+// token.
+func (t APIToken) Principal() string {
+	return Principal(t.ID)
+}
+
+// Principal returns the name recorded as the user of requests made with the
+// token with the given id.
+func Principal(id string) string {
+	return principalPrefix + id
+}
+
+// ParsePrincipal returns the id of the token if the name is an api token
+// principal.
+func ParsePrincipal(name string) (string, bool) {
+	if !strings.HasPrefix(name, principalPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(name, principalPrefix), true
+}
+
+// GetType returns the type of an APIToken
+func GetType() string {
+	return kind
+}
+
+// GetType returns the APIToken instance's type
+func (t *APIToken) GetType() string {
+	return GetType()
+}
+
+// GetID returns the APIToken instance's ID
+func (t *APIToken) GetID() string {
+	return t.ID
+}
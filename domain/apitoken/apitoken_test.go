@@ -0,0 +1,63 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package apitoken
+
+import (
+	"testing"
+	"time"
+
+	"github.com/control-center/serviced/auth"
+	. "gopkg.in/check.v1"
+)
+
+func TestAPIToken(t *testing.T) { TestingT(t) }
+
+type APITokenSuite struct{}
+
+var _ = Suite(&APITokenSuite{})
+
+func (s *APITokenSuite) TestExpired(c *C) {
+	token := APIToken{}
+	c.Assert(token.Expired(), Equals, false)
+
+	token.ExpiresAt = time.Now().Add(time.Hour)
+	c.Assert(token.Expired(), Equals, false)
+
+	token.ExpiresAt = time.Now().Add(-time.Hour)
+	c.Assert(token.Expired(), Equals, true)
+}
+
+func (s *APITokenSuite) TestPrincipal(c *C) {
+	token := APIToken{ID: "abc123"}
+	id, ok := ParsePrincipal(token.Principal())
+	c.Assert(ok, Equals, true)
+	c.Assert(id, Equals, "abc123")
+
+	_, ok = ParsePrincipal("admin")
+	c.Assert(ok, Equals, false)
+}
+
+func (s *APITokenSuite) TestValidEntity(c *C) {
+	token := APIToken{ID: "abc123", Name: "ci", User: "admin", Role: auth.RoleOperator}
+	c.Assert(token.ValidEntity(), IsNil)
+
+	token.Name = " ci"
+	c.Assert(token.ValidEntity(), NotNil)
+
+	token.Name = "ci"
+	token.Role = auth.RoleNone
+	c.Assert(token.ValidEntity(), NotNil)
+}
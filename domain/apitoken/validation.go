@@ -0,0 +1,42 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitoken
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/validation"
+)
+
+// ValidEntity validates APIToken fields
+func (t *APIToken) ValidEntity() error {
+	violations := validation.NewValidationError()
+	violations.Add(validation.NotEmpty("APIToken.ID", t.ID))
+	violations.Add(validation.NotEmpty("APIToken.User", t.User))
+
+	trimmed := strings.TrimSpace(t.Name)
+	violations.Add(validation.NotEmpty("APIToken.Name", t.Name))
+	violations.Add(validation.StringsEqual(t.Name, trimmed, "leading and trailing spaces not allowed for APIToken name"))
+
+	if !t.Role.Valid() {
+		violations.Add(fmt.Errorf("%s: %q", auth.ErrInvalidRole, t.Role))
+	}
+
+	if len(violations.Errors) > 0 {
+		return violations
+	}
+	return nil
+}
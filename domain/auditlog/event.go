@@ -0,0 +1,60 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/datastore"
+)
+
+// Event is a persisted entry of the audit trail, recording who performed an
+// action on which entity and whether it succeeded.
+type Event struct {
+	ID       string            // unique identifier of the event
+	Time     time.Time         // when the action was attempted
+	User     string            // the user or api token that performed the action
+	Action   string            // the action performed (e.g. add, update, stop)
+	Type     string            // the type of entity acted upon (e.g. service, host)
+	EntityID string            // the id of the entity acted upon
+	Message  string            // human readable description of the action
+	Success  bool              // whether the action succeeded
+	Fields   map[string]string // any additional fields logged with the action
+	Changes  []Change          // the fields of the entity that were changed
+	datastore.VersionedEntity
+}
+
+// Change is a before and after value of a single field of an entity.  Field
+// is the path to the value, such as "Environment" or
+// "ConfigFiles[/etc/my.cnf].Content".
+type Change struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// GetType returns the type of an Event
+func GetType() string {
+	return kind
+}
+
+// GetType returns the Event instance's type
+func (e *Event) GetType() string {
+	return GetType()
+}
+
+// GetID returns the Event instance's ID
+func (e *Event) GetID() string {
+	return e.ID
+}
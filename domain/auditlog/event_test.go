@@ -0,0 +1,63 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package auditlog
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func TestAuditLog(t *testing.T) { TestingT(t) }
+
+type AuditLogSuite struct{}
+
+var _ = Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) TestValidEntity(c *C) {
+	event := Event{}
+	c.Assert(event.ValidEntity(), NotNil)
+
+	event = Event{ID: "event1", Action: "add", Time: time.Now()}
+	c.Assert(event.ValidEntity(), IsNil)
+
+	event.Time = time.Time{}
+	c.Assert(event.ValidEntity(), NotNil)
+}
+
+func (s *AuditLogSuite) TestQueryConditions(c *C) {
+	c.Assert(Query{}.conditions(), HasLen, 1)
+
+	since := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	conditions := Query{User: "oncall", Type: "service", Since: since}.conditions()
+	c.Assert(conditions, HasLen, 4)
+	c.Assert(hasCondition(conditions, map[string]interface{}{"term": map[string]string{"User": "oncall"}}), Equals, true)
+	c.Assert(hasCondition(conditions, map[string]interface{}{"term": map[string]string{"Type": "service"}}), Equals, true)
+	c.Assert(hasCondition(conditions, map[string]interface{}{
+		"range": map[string]interface{}{"Time": map[string]string{"gte": "2019-05-01T00:00:00Z"}},
+	}), Equals, true)
+}
+
+func hasCondition(conditions []map[string]interface{}, expected map[string]interface{}) bool {
+	for _, condition := range conditions {
+		if reflect.DeepEqual(condition, expected) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"fmt"
+
+	"github.com/control-center/serviced/datastore/elastic"
+	"github.com/control-center/serviced/logging"
+)
+
+var (
+	kind          = "auditevent"
+	plog          = logging.PackageLogger()
+	mappingString = fmt.Sprintf(`
+{
+     "%s": {
+      "properties":{
+        "ID":             {"type": "string", "index":"not_analyzed"},
+        "Time":           {"type": "date", "format" : "dateOptionalTime"},
+        "User":           {"type": "string", "index":"not_analyzed"},
+        "Action":         {"type": "string", "index":"not_analyzed"},
+        "Type":           {"type": "string", "index":"not_analyzed"},
+        "EntityID":       {"type": "string", "index":"not_analyzed"},
+        "Message":        {"type": "string"},
+        "Success":        {"type": "boolean"},
+        "Fields":         {"type": "object"},
+        "Changes":        {
+          "properties": {
+            "Field":      {"type": "string", "index":"not_analyzed"},
+            "Before":     {"type": "string", "index":"no"},
+            "After":      {"type": "string", "index":"no"}
+          }
+        }
+      }
+    }
+}
+`, kind)
+	// MAPPING is the elastic mapping for an audit event
+	MAPPING, mappingError = elastic.NewMapping(mappingString)
+)
+
+func init() {
+	if mappingError != nil {
+		plog.WithError(mappingError).Fatal("error creating mapping for the auditevent object")
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"strings"
+	"time"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/datastore/elastic"
+)
+
+// DefaultLimit is the number of events returned by a search that does not
+// specify a limit.
+const DefaultLimit = 100
+
+// Query filters and pages a search of the audit trail.  Empty fields do not
+// filter.
+type Query struct {
+	Since    time.Time // only events at or after this time
+	Until    time.Time // only events before this time
+	User     string    // only events performed by this user
+	Action   string    // only events with this action
+	Type     string    // only events on entities of this type
+	EntityID string    // only events on the entity with this id
+	Offset   int       // the number of matching events to skip
+	Limit    int       // the maximum number of events to return
+}
+
+// Store is the database for the audit trail
+type Store interface {
+	// Get an Event by id. Return ErrNoSuchEntity if not found
+	Get(ctx datastore.Context, id string) (*Event, error)
+
+	// Put adds an Event
+	Put(ctx datastore.Context, event *Event) error
+
+	// Search returns the events matching the query, most recent first
+	Search(ctx datastore.Context, query Query) ([]Event, error)
+}
+
+type storeImpl struct {
+	ds datastore.DataStore
+}
+
+// NewStore creates a Store for audit events
+func NewStore() Store {
+	return &storeImpl{}
+}
+
+// Get an Event by id.  Return ErrNoSuchEntity if not found
+func (s *storeImpl) Get(ctx datastore.Context, id string) (*Event, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("AuditStore.Get"))
+	val := &Event{}
+	if err := s.ds.Get(ctx, Key(id), val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// Put adds an Event
+func (s *storeImpl) Put(ctx datastore.Context, event *Event) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("AuditStore.Put"))
+	return s.ds.Put(ctx, Key(event.ID), event)
+}
+
+// Search returns the events matching the query, most recent first
+func (s *storeImpl) Search(ctx datastore.Context, query Query) ([]Event, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("AuditStore.Search"))
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	offset := query.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	searchRequest := elastic.ElasticSearchRequest{
+		Pretty: false,
+		Index:  "controlplane",
+		Type:   kind,
+		Scroll: "",
+		Scan:   0,
+		Query: map[string]interface{}{
+			"query": map[string]interface{}{
+				"bool": map[string]interface{}{
+					"must": query.conditions(),
+				},
+			},
+			"sort": []map[string]interface{}{
+				{"Time": map[string]string{"order": "desc"}},
+			},
+			"from": offset,
+			"size": limit,
+		},
+	}
+
+	results, err := datastore.NewQuery(ctx).Execute(searchRequest)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, results.Len())
+	for idx := range events {
+		if err := results.Get(idx, &events[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// conditions returns the elastic search clauses that events must match
+func (q Query) conditions() []map[string]interface{} {
+	must := []map[string]interface{}{
+		{"query_string": map[string]string{"query": "_exists_:ID"}},
+	}
+	terms := map[string]string{
+		"User":     q.User,
+		"Action":   q.Action,
+		"Type":     q.Type,
+		"EntityID": q.EntityID,
+	}
+	for field, value := range terms {
+		if value != "" {
+			must = append(must, map[string]interface{}{
+				"term": map[string]string{field: value},
+			})
+		}
+	}
+	if !q.Since.IsZero() || !q.Until.IsZero() {
+		timeRange := map[string]string{}
+		if !q.Since.IsZero() {
+			timeRange["gte"] = q.Since.UTC().Format(time.RFC3339)
+		}
+		if !q.Until.IsZero() {
+			timeRange["lt"] = q.Until.UTC().Format(time.RFC3339)
+		}
+		must = append(must, map[string]interface{}{
+			"range": map[string]interface{}{"Time": timeRange},
+		})
+	}
+	return must
+}
+
+//Key creates a Key suitable for getting, putting and deleting Events
+func Key(id string) datastore.Key {
+	id = strings.TrimSpace(id)
+	return datastore.NewKey(kind, id)
+}
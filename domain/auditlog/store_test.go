@@ -0,0 +1,78 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build integration
+
+package auditlog
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/datastore/elastic"
+	. "gopkg.in/check.v1"
+)
+
+var _ = Suite(&S{
+	ElasticTest: elastic.ElasticTest{
+		Index:    "controlplane",
+		Mappings: []elastic.Mapping{MAPPING},
+	}})
+
+type S struct {
+	elastic.ElasticTest
+	ctx   datastore.Context
+	store Store
+}
+
+func (s *S) SetUpTest(c *C) {
+	s.ElasticTest.SetUpTest(c)
+	datastore.Register(s.Driver())
+	s.ctx = datastore.Get()
+	s.store = NewStore()
+}
+
+func (s *S) Test_EventPutGetSearch(c *C) {
+	now := time.Now().UTC().Truncate(time.Second)
+	events := []*Event{
+		{ID: "event1", Time: now.Add(-2 * time.Hour), User: "admin", Action: "add", Type: "service", EntityID: "svc1", Success: true},
+		{ID: "event2", Time: now.Add(-time.Hour), User: "oncall", Action: "stop", Type: "service", EntityID: "svc1", Success: true},
+		{ID: "event3", Time: now, User: "oncall", Action: "add", Type: "host", EntityID: "host1", Success: false},
+	}
+	for _, e := range events {
+		c.Assert(s.store.Put(s.ctx, e), IsNil)
+	}
+
+	actual, err := s.store.Get(s.ctx, "event2")
+	c.Assert(err, IsNil)
+	c.Assert(actual.User, Equals, "oncall")
+
+	result, err := s.store.Search(s.ctx, Query{})
+	c.Assert(err, IsNil)
+	c.Assert(result, HasLen, 3)
+	c.Assert(result[0].ID, Equals, "event3")
+
+	result, err = s.store.Search(s.ctx, Query{User: "oncall", Type: "service"})
+	c.Assert(err, IsNil)
+	c.Assert(result, HasLen, 1)
+	c.Assert(result[0].ID, Equals, "event2")
+
+	result, err = s.store.Search(s.ctx, Query{Since: now.Add(-90 * time.Minute)})
+	c.Assert(err, IsNil)
+	c.Assert(result, HasLen, 2)
+
+	result, err = s.store.Search(s.ctx, Query{Offset: 1, Limit: 1})
+	c.Assert(err, IsNil)
+	c.Assert(result, HasLen, 1)
+	c.Assert(result[0].ID, Equals, "event2")
+}
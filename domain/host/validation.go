@@ -19,6 +19,7 @@ import (
 	"net"
 	"strings"
 
+	"github.com/control-center/serviced/domain/servicedefinition"
 	"github.com/control-center/serviced/validation"
 )
 
@@ -50,6 +51,9 @@ func (h *Host) ValidEntity() error {
 	} else if err != nil {
 		violations.Add(err)
 	}
+	for key, value := range h.Labels {
+		violations.Add(servicedefinition.ValidLabel(key, value))
+	}
 	if len(violations.Errors) > 0 {
 		return violations
 	}
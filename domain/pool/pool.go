@@ -18,6 +18,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/datastore"
 	"github.com/control-center/serviced/domain"
 	"github.com/control-center/serviced/domain/host"
@@ -57,6 +58,7 @@ type ResourcePool struct {
 	UpdatedAt         time.Time
 	MonitoringProfile domain.MonitorProfile
 	Permissions       Permission
+	DelegateRole      auth.Role `json:",omitempty"` // The role of the pool's hosts when they do not have admin access
 	datastore.VersionedEntity
 }
 
@@ -71,6 +73,18 @@ type PoolIPs struct {
 	VirtualIPs []VirtualIP
 }
 
+// PoolUsage reports the resources committed to the services in a pool
+// against the capacity of its hosts and the quotas set on the pool.
+type PoolUsage struct {
+	PoolID           string
+	CoreCommitment   int    // Number of cores committed to service instances
+	CoreCapacity     int    // Number of cores on all hosts in the pool
+	CoreLimit        int    // A quota on the number of cores in the pool, 0 = unlimited
+	MemoryCommitment uint64 // Amount (bytes) of RAM committed to service instances
+	MemoryCapacity   uint64 // Amount (bytes) of RAM on all hosts in the pool
+	MemoryLimit      uint64 // A quota on the amount (bytes) of RAM in the pool, 0 = unlimited
+}
+
 // An association between a host and a pool.
 type PoolHost struct {
 	HostID string
@@ -154,6 +168,14 @@ func (a *ResourcePool) HasAdminAccess() bool {
 	return a.Permissions&AdminAccess != 0
 }
 
+// GetDelegateRole returns the role granted to the hosts of the pool
+func (a *ResourcePool) GetDelegateRole() auth.Role {
+	if a.HasAdminAccess() {
+		return auth.RoleClusterAdmin
+	}
+	return a.DelegateRole
+}
+
 // GetType returns a ResourcePool's type or kind, can be used to get
 // the string value of ResourcePool's type without a ResourcePool instance.
 // It returns the kind as a string.
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/validation"
 )
 
@@ -37,6 +38,10 @@ func (p *ResourcePool) ValidEntity() error {
 		violations.Add(validation.NewViolation(fmt.Sprintf("connection timeout cannot be less than 0")))
 	}
 
+	if p.DelegateRole != auth.RoleNone && !p.DelegateRole.Valid() {
+		violations.Add(validation.NewViolation(fmt.Sprintf("invalid delegate role %q", p.DelegateRole)))
+	}
+
 	if len(violations.Errors) > 0 {
 		return violations
 	}
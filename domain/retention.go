@@ -0,0 +1,121 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RetentionPolicy selects which of a series of periodic copies of data, such
+// as scheduled snapshots, to keep.  A copy is kept if any of the rules keeps
+// it.  A policy with no rules keeps everything.
+type RetentionPolicy struct {
+	KeepLast   int // number of most recent copies to keep
+	KeepHourly int // number of hours for which to keep the most recent copy
+	KeepDaily  int // number of days for which to keep the most recent copy
+	KeepWeekly int // number of weeks for which to keep the most recent copy
+}
+
+// IsZero returns true if the policy has no rules
+func (r RetentionPolicy) IsZero() bool {
+	return r.KeepLast == 0 && r.KeepHourly == 0 && r.KeepDaily == 0 && r.KeepWeekly == 0
+}
+
+// String describes the rules of the policy, such as "daily=7,weekly=4"
+func (r RetentionPolicy) String() string {
+	if r.IsZero() {
+		return "all"
+	}
+	var rules []string
+	for _, rule := range []struct {
+		name  string
+		count int
+	}{
+		{"last", r.KeepLast},
+		{"hourly", r.KeepHourly},
+		{"daily", r.KeepDaily},
+		{"weekly", r.KeepWeekly},
+	} {
+		if rule.count > 0 {
+			rules = append(rules, fmt.Sprintf("%s=%d", rule.name, rule.count))
+		}
+	}
+	return strings.Join(rules, ",")
+}
+
+// Validate returns an error if any of the rules is negative
+func (r *RetentionPolicy) Validate() error {
+	if r.KeepLast < 0 || r.KeepHourly < 0 || r.KeepDaily < 0 || r.KeepWeekly < 0 {
+		return fmt.Errorf("retention counts cannot be negative: KeepLast=%d; KeepHourly=%d; KeepDaily=%d; KeepWeekly=%d", r.KeepLast, r.KeepHourly, r.KeepDaily, r.KeepWeekly)
+	}
+	return nil
+}
+
+// Keep returns, for each of the given creation times, whether the copy
+// created at that time is kept by the policy.  Hours, days and weeks are in
+// the location of each time.
+func (r RetentionPolicy) Keep(times []time.Time) []bool {
+	keep := make([]bool, len(times))
+	if r.IsZero() {
+		for i := range keep {
+			keep[i] = true
+		}
+		return keep
+	}
+
+	// visit the copies from newest to oldest
+	order := make([]int, len(times))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Sort(newestFirst{order, times})
+
+	for n, i := range order {
+		if n < r.KeepLast {
+			keep[i] = true
+		}
+	}
+	keepPeriods := func(count int, period func(time.Time) string) {
+		seen := make(map[string]struct{})
+		for _, i := range order {
+			if len(seen) >= count {
+				return
+			}
+			key := period(times[i])
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keep[i] = true
+			}
+		}
+	}
+	keepPeriods(r.KeepHourly, func(t time.Time) string { return t.Format("2006-01-02 15") })
+	keepPeriods(r.KeepDaily, func(t time.Time) string { return t.Format("2006-01-02") })
+	keepPeriods(r.KeepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-%d", year, week)
+	})
+	return keep
+}
+
+type newestFirst struct {
+	order []int
+	times []time.Time
+}
+
+func (s newestFirst) Len() int           { return len(s.order) }
+func (s newestFirst) Swap(i, j int)      { s.order[i], s.order[j] = s.order[j], s.order[i] }
+func (s newestFirst) Less(i, j int) bool { return s.times[s.order[i]].After(s.times[s.order[j]]) }
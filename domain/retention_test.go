@@ -0,0 +1,81 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetentionPolicyValidate(t *testing.T) {
+	r := RetentionPolicy{KeepLast: 1, KeepDaily: 7}
+	if err := r.Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	r.KeepWeekly = -1
+	if err := r.Validate(); err == nil {
+		t.Errorf("Expected error")
+	}
+}
+
+func TestRetentionPolicyString(t *testing.T) {
+	if s := (RetentionPolicy{}).String(); s != "all" {
+		t.Errorf("Expected all; got %s", s)
+	}
+	if s := (RetentionPolicy{KeepLast: 2, KeepWeekly: 4}).String(); s != "last=2,weekly=4" {
+		t.Errorf("Expected last=2,weekly=4; got %s", s)
+	}
+}
+
+func TestRetentionPolicyKeep(t *testing.T) {
+	base := time.Date(2019, 1, 9, 12, 0, 0, 0, time.UTC) // a wednesday
+	times := []time.Time{
+		base.Add(-30 * time.Minute),     // 0: 11:30 today
+		base,                            // 1: 12:00 today
+		base.Add(-45 * time.Minute),     // 2: 11:15 today
+		base.Add(-2 * time.Hour),        // 3: 10:00 today
+		base.Add(-24 * time.Hour),       // 4: yesterday
+		base.Add(-26 * time.Hour),       // 5: yesterday, earlier
+		base.Add(-3 * 24 * time.Hour),   // 6: sunday, last week
+		base.Add(-4 * 24 * time.Hour),   // 7: saturday, last week
+		base.Add(-10 * 24 * time.Hour),  // 8: two weeks ago
+		base.Add(-100 * 24 * time.Hour), // 9: months ago
+	}
+
+	for _, tc := range []struct {
+		policy RetentionPolicy
+		keep   []int
+	}{
+		{RetentionPolicy{}, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{RetentionPolicy{KeepLast: 2}, []int{0, 1}},
+		{RetentionPolicy{KeepHourly: 3}, []int{0, 1, 3}},
+		{RetentionPolicy{KeepDaily: 3}, []int{1, 4, 6}},
+		{RetentionPolicy{KeepWeekly: 3}, []int{1, 6, 8}},
+		{RetentionPolicy{KeepLast: 1, KeepDaily: 2, KeepWeekly: 4}, []int{1, 4, 6, 8, 9}},
+	} {
+		keep := tc.policy.Keep(times)
+		expected := make([]bool, len(times))
+		for _, i := range tc.keep {
+			expected[i] = true
+		}
+		for i := range times {
+			if keep[i] != expected[i] {
+				t.Errorf("%+v: got %v, expected %v", tc.policy, keep, expected)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"github.com/control-center/serviced/domain/auditlog"
+)
+
+// Actions of a planned change
+const (
+	PlanAdd     = "add"
+	PlanUpdate  = "update"
+	PlanRemove  = "remove"
+	PlanEnable  = "enable"
+	PlanDisable = "disable"
+)
+
+// ChangePlan describes the changes that a service update or template
+// deployment would make, as computed by a dry run that does not write to the
+// database or the coordinator.
+type ChangePlan struct {
+	Services []PlannedChange
+}
+
+// PlannedChange describes what would happen to a single service
+type PlannedChange struct {
+	ServiceID          string
+	Name               string
+	Action             string            // add or update
+	Changes            []auditlog.Change // the fields that would change
+	AddressAssignments []EndpointChange  // address assignments that would be added or removed
+	PublicEndpoints    []EndpointChange  // vhosts and ports that would be added, removed, enabled or disabled
+	RestartInstances   []int             // running instances that would be restarted
+	StopInstances      []int             // running instances that would be stopped
+	StartInstances     int               // the number of instances that would be started
+	RestartRequired    bool              // running instances keep the old definition until they restart
+	Warnings           []string
+}
+
+// EndpointChange is a planned change to an address assignment or public
+// endpoint of a service endpoint.
+type EndpointChange struct {
+	Endpoint string // the name of the application endpoint
+	Type     string // address, vhost or port
+	Name     string // the port of the address, the vhost name or the port address
+	Action   string // add, remove, enable or disable
+}
@@ -324,7 +324,8 @@ func (service *Service) EvaluatePrereqsTemplate(gs GetService, fc FindChildServi
 	return
 }
 
-// EvaluateHealthCheckTemplate parses and evals the Script field for each HealthCheck.
+// EvaluateHealthCheckTemplate parses and evals the Script field, or the
+// address of the HTTP, TCP or GRPC check, for each HealthCheck.
 func (service *Service) EvaluateHealthCheckTemplate(gs GetService, fc FindChildService, instanceID int) (err error) {
 	log.WithFields(log.Fields{
 		"servicename": service.Name,
@@ -339,8 +340,31 @@ func (service *Service) EvaluateHealthCheckTemplate(gs GetService, fc FindChildS
 		}
 		if result != "" {
 			healthcheck.Script = result
-			service.HealthChecks[key] = healthcheck
 		}
+
+		// copy the native checks, so that the service definition is not changed
+		if healthcheck.HTTP != nil {
+			check := *healthcheck.HTTP
+			if err, check.URL = service.evaluateTemplate(gs, fc, instanceID, check.URL); err != nil {
+				return err
+			}
+			healthcheck.HTTP = &check
+		}
+		if healthcheck.TCP != nil {
+			check := *healthcheck.TCP
+			if err, check.Address = service.evaluateTemplate(gs, fc, instanceID, check.Address); err != nil {
+				return err
+			}
+			healthcheck.TCP = &check
+		}
+		if healthcheck.GRPC != nil {
+			check := *healthcheck.GRPC
+			if err, check.Address = service.evaluateTemplate(gs, fc, instanceID, check.Address); err != nil {
+				return err
+			}
+			healthcheck.GRPC = &check
+		}
+		service.HealthChecks[key] = healthcheck
 	}
 	return
 }
@@ -0,0 +1,75 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"time"
+)
+
+// States of an image upgrade
+const (
+	ImageUpgradeRunning     = "running"
+	ImageUpgradePaused      = "paused"
+	ImageUpgradeCompleted   = "completed"
+	ImageUpgradeRollingBack = "rollingback"
+	ImageUpgradeRolledBack  = "rolledback"
+)
+
+// ImageUpgradeRequest describes a change of the image of the services in a
+// service tree, where the instances of each service are restarted one at a
+// time and must pass their health checks before the next one is restarted.
+type ImageUpgradeRequest struct {
+	ServiceID string        // the root of the service tree to upgrade
+	ImageID   string        // the image to upgrade to
+	Replace   []string      // the images to replace; defaults to the repo of ImageID
+	Timeout   time.Duration // how long to wait for an instance to become healthy before pausing
+}
+
+// ValidEntity checks that the image upgrade request is valid
+func (r ImageUpgradeRequest) ValidEntity() error {
+	if r.ServiceID == "" {
+		return errors.New("service id is required")
+	} else if r.ImageID == "" {
+		return errors.New("image id is required")
+	} else if r.Timeout < 0 {
+		return errors.New("timeout cannot be negative")
+	}
+	return nil
+}
+
+// ServiceImageUpgrade is the progress of an image upgrade for a single
+// service
+type ServiceImageUpgrade struct {
+	ServiceID       string
+	Name            string
+	StartLevel      uint
+	PreviousImageID string
+	ImageUpdated    bool  // true once the service definition uses the new image
+	Upgraded        []int // instances that restarted and passed their health checks
+	Pending         []int // instances that have not been restarted yet
+	Unhealthy       []int // instances that restarted but did not pass their health checks
+}
+
+// ImageUpgradeStatus is the progress of an image upgrade
+type ImageUpgradeStatus struct {
+	ServiceID string
+	ImageID   string
+	State     string // running, paused, completed, rollingback or rolledback
+	Services  []ServiceImageUpgrade
+	Current   int // the index of the service being upgraded
+	Message   string
+	Started   time.Time
+	Updated   time.Time
+}
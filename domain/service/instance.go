@@ -69,10 +69,15 @@ type Instance struct {
 type StrategyInstance struct {
 	HostID        string
 	ServiceID     string
+	InstanceID    int
+	ServiceName   string
+	DeploymentID  string
 	CPUCommitment int
 	RAMCommitment uint64
 	RAMThreshold  uint
 	HostPolicy    servicedefinition.HostPolicy
+	HostLabels    *servicedefinition.LabelSelector
+	Affinity      *servicedefinition.Affinity
 }
 
 // LocationInstance collection location information about a service instance
@@ -0,0 +1,28 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+// Migration describes the move of an instance of a service from one host of
+// a pool to another, to even out the commitment of the hosts in the pool.
+type Migration struct {
+	ServiceID   string
+	ServiceName string
+	InstanceID  int
+	FromHostID  string
+	ToHostID    string
+	FromScore   int    // score of the source host before the move
+	ToScore     int    // score of the target host after the move
+	Migrated    bool   // the instance is running and healthy on the target host
+	Message     string // why the instance was not migrated
+}
@@ -0,0 +1,42 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import "time"
+
+// PlacementDecision records how the scheduler chose a host for an instance of
+// a service.
+type PlacementDecision struct {
+	ServiceID  string
+	InstanceID int
+	PoolID     string
+	Strategy   string
+	HostID     string // the selected host, or empty if no host was selected
+	Message    string // why no host was selected, or how it was selected without scoring
+	Candidates []PlacementCandidate
+	Timestamp  time.Time
+}
+
+// PlacementCandidate describes how a host was evaluated for an instance of a
+// service.
+type PlacementCandidate struct {
+	HostID         string
+	Score          int
+	CPUScore       int  // percentage of cores committed with the instance deployed
+	MemoryScore    int  // percentage of memory committed with the instance deployed
+	Instances      int  // number of instances of the service already on the host
+	Oversubscribed bool // the host does not have the resources to run the instance
+	Excluded       bool // the host was not scored
+	Reason         string
+}
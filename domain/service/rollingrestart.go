@@ -0,0 +1,86 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"time"
+)
+
+// States of a rolling restart
+const (
+	RollingRestartRunning   = "running"
+	RollingRestartPaused    = "paused"
+	RollingRestartCompleted = "completed"
+	RollingRestartCancelled = "cancelled"
+)
+
+// RollingRestartRequest describes a restart of the running instances of a
+// service in batches, where each batch must pass its health checks before the
+// next batch is restarted.
+type RollingRestartRequest struct {
+	ServiceID    string
+	BatchSize    int           // the number of instances to restart at a time
+	BatchPercent int           // the percentage of instances to restart at a time, if BatchSize is not set
+	Timeout      time.Duration // how long to wait for a batch to become healthy before pausing
+	AutoLaunch   bool          // also restart the running child services, each in its own batches
+}
+
+// ValidEntity checks that the batch size of the request is valid
+func (r RollingRestartRequest) ValidEntity() error {
+	if r.ServiceID == "" {
+		return errors.New("service id is required")
+	} else if r.BatchSize < 0 {
+		return errors.New("batch size cannot be negative")
+	} else if r.BatchPercent < 0 || r.BatchPercent > 100 {
+		return errors.New("batch percent must be between 0 and 100")
+	} else if r.BatchSize > 0 && r.BatchPercent > 0 {
+		return errors.New("batch size and batch percent cannot both be set")
+	} else if r.Timeout < 0 {
+		return errors.New("timeout cannot be negative")
+	}
+	return nil
+}
+
+// GetBatchSize returns the number of instances to restart at a time, given
+// the number of running instances.  It is always at least one.
+func (r RollingRestartRequest) GetBatchSize(instances int) int {
+	size := r.BatchSize
+	if size == 0 && r.BatchPercent > 0 {
+		size = (instances*r.BatchPercent + 99) / 100
+	}
+	if size > instances {
+		size = instances
+	}
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+// RollingRestartStatus is the progress of a rolling restart
+type RollingRestartStatus struct {
+	ServiceID   string
+	ServiceName string
+	State       string // running, paused, completed or cancelled
+	BatchSize   int
+	Batch       int   // the batch being restarted, starting from 1
+	Batches     int   // the total number of batches
+	Restarted   []int // instances that restarted and passed their health checks
+	Pending     []int // instances that have not been restarted yet
+	Unhealthy   []int // instances of the last batch that did not pass their health checks
+	Message     string
+	Started     time.Time
+	Updated     time.Time
+}
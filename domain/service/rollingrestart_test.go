@@ -0,0 +1,44 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package service_test
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/domain/service"
+	. "gopkg.in/check.v1"
+)
+
+func (s *ServiceDomainUnitTestSuite) TestRollingRestartRequestValidEntity(c *C) {
+	c.Assert(service.RollingRestartRequest{ServiceID: "svc"}.ValidEntity(), IsNil)
+	c.Assert(service.RollingRestartRequest{ServiceID: "svc", BatchSize: 2, Timeout: time.Minute}.ValidEntity(), IsNil)
+	c.Assert(service.RollingRestartRequest{ServiceID: "svc", BatchPercent: 100}.ValidEntity(), IsNil)
+
+	c.Assert(service.RollingRestartRequest{}.ValidEntity(), NotNil)
+	c.Assert(service.RollingRestartRequest{ServiceID: "svc", BatchSize: -1}.ValidEntity(), NotNil)
+	c.Assert(service.RollingRestartRequest{ServiceID: "svc", BatchPercent: 101}.ValidEntity(), NotNil)
+	c.Assert(service.RollingRestartRequest{ServiceID: "svc", BatchSize: 1, BatchPercent: 50}.ValidEntity(), NotNil)
+	c.Assert(service.RollingRestartRequest{ServiceID: "svc", Timeout: -time.Second}.ValidEntity(), NotNil)
+}
+
+func (s *ServiceDomainUnitTestSuite) TestRollingRestartRequestGetBatchSize(c *C) {
+	c.Assert(service.RollingRestartRequest{}.GetBatchSize(5), Equals, 1)
+	c.Assert(service.RollingRestartRequest{BatchSize: 2}.GetBatchSize(5), Equals, 2)
+	c.Assert(service.RollingRestartRequest{BatchSize: 8}.GetBatchSize(5), Equals, 5)
+	c.Assert(service.RollingRestartRequest{BatchPercent: 50}.GetBatchSize(5), Equals, 3)
+	c.Assert(service.RollingRestartRequest{BatchPercent: 10}.GetBatchSize(5), Equals, 1)
+	c.Assert(service.RollingRestartRequest{BatchPercent: 100}.GetBatchSize(5), Equals, 5)
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"math"
+
+	"github.com/control-center/serviced/domain"
+)
+
+// ScalingTolerance is how far, as a fraction of the target, the metric of a
+// scaling policy may be from its target before the instances are changed.
+const ScalingTolerance = 0.1
+
+// GetScalingMetric returns the metric of the monitoring profile that the
+// scaling policy of the service refers to.
+func (s *Service) GetScalingMetric() (domain.Metric, bool) {
+	if s.ScalingPolicy == nil {
+		return domain.Metric{}, false
+	}
+	for _, mc := range s.MonitoringProfile.MetricConfigs {
+		for _, metric := range mc.Metrics {
+			if metric.ID == s.ScalingPolicy.Metric {
+				return metric, true
+			}
+		}
+	}
+	return domain.Metric{}, false
+}
+
+// GetScaledInstances returns the number of instances that the scaling policy
+// of the service calls for, given the average value of its metric per
+// instance.  The result is kept within the instance limits of the service,
+// and is never less than one.
+func (s *Service) GetScaledInstances(value float64) int {
+	if s.ScalingPolicy == nil || s.ScalingPolicy.Target <= 0 {
+		return s.Instances
+	}
+	ratio := value / s.ScalingPolicy.Target
+	if math.Abs(ratio-1) <= ScalingTolerance {
+		return s.Instances
+	}
+	instances := int(math.Ceil(float64(s.Instances) * ratio))
+	min := s.InstanceLimits.Min
+	if min < 1 {
+		min = 1
+	}
+	if instances < min {
+		instances = min
+	}
+	if s.InstanceLimits.Max > 0 && instances > s.InstanceLimits.Max {
+		instances = s.InstanceLimits.Max
+	}
+	return instances
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package service_test
+
+import (
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	. "gopkg.in/check.v1"
+)
+
+func scalingService(instances, min, max int) *service.Service {
+	return &service.Service{
+		Instances:      instances,
+		InstanceLimits: domain.MinMax{Min: min, Max: max},
+		ScalingPolicy:  &servicedefinition.ScalingPolicy{Enabled: true, Metric: "requests", Target: 100},
+		MonitoringProfile: domain.MonitorProfile{
+			MetricConfigs: []domain.MetricConfig{
+				{ID: "web", Metrics: []domain.Metric{{ID: "requests"}, {ID: "errors", Counter: true}}},
+			},
+		},
+	}
+}
+
+func (s *ServiceDomainUnitTestSuite) TestGetScalingMetric(c *C) {
+	svc := scalingService(2, 1, 4)
+	metric, ok := svc.GetScalingMetric()
+	c.Assert(ok, Equals, true)
+	c.Assert(metric.ID, Equals, "requests")
+
+	svc.ScalingPolicy.Metric = "missing"
+	_, ok = svc.GetScalingMetric()
+	c.Assert(ok, Equals, false)
+
+	svc.ScalingPolicy = nil
+	_, ok = svc.GetScalingMetric()
+	c.Assert(ok, Equals, false)
+}
+
+func (s *ServiceDomainUnitTestSuite) TestGetScaledInstances(c *C) {
+	svc := scalingService(2, 1, 4)
+
+	// within tolerance of the target
+	c.Assert(svc.GetScaledInstances(105), Equals, 2)
+	c.Assert(svc.GetScaledInstances(95), Equals, 2)
+
+	// scales in proportion to the target
+	c.Assert(svc.GetScaledInstances(150), Equals, 3)
+	c.Assert(svc.GetScaledInstances(40), Equals, 1)
+
+	// stays within the instance limits
+	c.Assert(svc.GetScaledInstances(1000), Equals, 4)
+	c.Assert(svc.GetScaledInstances(0), Equals, 1)
+	svc = scalingService(4, 3, 0)
+	c.Assert(svc.GetScaledInstances(0), Equals, 3)
+	c.Assert(svc.GetScaledInstances(1000), Equals, 40)
+}
+
+func (s *ServiceDomainUnitTestSuite) TestScalingPolicyValidation(c *C) {
+	svc := scalingService(2, 1, 4)
+	svc.ID = "svc"
+	svc.Name = "svc"
+	svc.PoolID = "default"
+	svc.Launch = "auto"
+	c.Assert(svc.ValidEntity(), IsNil)
+
+	svc.ScalingPolicy.Metric = "missing"
+	c.Assert(svc.ValidEntity(), ErrorMatches, "(?s).*scaling policy metric missing is not in the monitoring profile.*")
+
+	svc.ScalingPolicy.Metric = "requests"
+	svc.ScalingPolicy.Target = 0
+	c.Assert(svc.ValidEntity(), NotNil)
+}
@@ -222,11 +222,14 @@ type Service struct {
 	Instances         int
 	InstanceLimits    domain.MinMax
 	ChangeOptions     []servicedefinition.ChangeOption
+	ScalingPolicy     *servicedefinition.ScalingPolicy
 	ImageID           string
 	PoolID            string
 	DesiredState      int
 	CurrentState      string
 	HostPolicy        servicedefinition.HostPolicy
+	HostLabels        *servicedefinition.LabelSelector
+	Affinity          *servicedefinition.Affinity
 	Hostname          string
 	Privileged        bool
 	Launch            string
@@ -251,6 +254,7 @@ type Service struct {
 	MonitoringProfile domain.MonitorProfile
 	MemoryLimit       float64
 	CPUShares         int64
+	CPUQuota          float64 // Hard limit on the cores each instance may use (CFS quota), 0 = unlimited
 	PIDFile           string
 	// StartLevel represents the order in which services are started and stopped
 	// in normal operations.  All services of a given level start before any services
@@ -365,11 +369,14 @@ func BuildService(sd servicedefinition.ServiceDefinition, parentServiceID string
 	}
 	svc.InstanceLimits = sd.Instances
 	svc.ChangeOptions = sd.ChangeOptions
+	svc.ScalingPolicy = sd.ScalingPolicy
 	svc.ImageID = sd.ImageID
 	svc.PoolID = poolID
 	svc.DesiredState = desiredState
 	svc.Launch = sd.Launch
 	svc.HostPolicy = sd.HostPolicy
+	svc.HostLabels = sd.HostLabels
+	svc.Affinity = sd.Affinity
 	svc.Hostname = sd.Hostname
 	svc.Privileged = sd.Privileged
 	svc.OriginalConfigs = sd.ConfigFiles
@@ -409,6 +416,7 @@ func BuildService(sd servicedefinition.ServiceDefinition, parentServiceID string
 	svc.MonitoringProfile = *profile
 	svc.MemoryLimit = sd.MemoryLimit
 	svc.CPUShares = sd.CPUShares
+	svc.CPUQuota = sd.CPUQuota
 
 	return &svc, nil
 }
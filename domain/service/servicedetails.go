@@ -36,6 +36,7 @@ type ServiceDetails struct {
 	InstanceLimits    domain.MinMax
 	RAMCommitment     utils.EngNotation
 	RAMThreshold      uint
+	CPUQuota          float64
 	Startup           string
 	HasChildren       bool
 	DeploymentID      string
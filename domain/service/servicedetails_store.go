@@ -558,6 +558,7 @@ var serviceDetailsFields = []string{
 	"InstanceLimits",
 	"RAMCommitment",
 	"RAMThreshold",
+	"CPUQuota",
 	"Startup",
 	"DeploymentID",
 	"Launch",
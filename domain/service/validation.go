@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/control-center/serviced/commons"
+	"github.com/control-center/serviced/domain/servicedefinition"
 	"github.com/control-center/serviced/validation"
 )
 
@@ -43,9 +44,30 @@ func (s *Service) ValidEntity() error {
 		}
 	}
 
+	if s.CPUQuota < 0 || (s.CPUQuota > 0 && s.CPUQuota < servicedefinition.MinCPUQuota) {
+		vErr.Add(fmt.Errorf("cpu quota (%g) must be 0 (unlimited) or at least %g cores", s.CPUQuota, servicedefinition.MinCPUQuota))
+	}
+
 	// validate the monitoring profile
 	vErr.Add(s.MonitoringProfile.ValidEntity())
 
+	// validate the scaling policy against the monitoring profile
+	if s.ScalingPolicy != nil {
+		if err := s.ScalingPolicy.ValidEntity(); err != nil {
+			vErr.Add(err)
+		} else if _, ok := s.GetScalingMetric(); !ok {
+			vErr.Add(fmt.Errorf("scaling policy metric %s is not in the monitoring profile", s.ScalingPolicy.Metric))
+		}
+	}
+
+	if s.HostLabels != nil {
+		vErr.Add(s.HostLabels.ValidEntity())
+	}
+
+	if s.Affinity != nil {
+		vErr.Add(s.Affinity.ValidEntity())
+	}
+
 	for _, ep := range s.Endpoints {
 		vErr.Add(ep.ValidEntity())
 	}
@@ -0,0 +1,64 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicedefinition
+
+import "fmt"
+
+// Affinity declares where instances of a service should be scheduled relative
+// to the instances of other services in the same deployment. Services are
+// referred to by name.
+type Affinity struct {
+	ColocateWith []string // services whose hosts are preferred for the service
+	SeparateFrom []string // services that must never share a host with the service
+}
+
+// ValidEntity checks that the affinity rules are valid
+func (a Affinity) ValidEntity() error {
+	colocated := make(map[string]struct{})
+	for _, name := range a.ColocateWith {
+		if name == "" {
+			return fmt.Errorf("affinity service name is required")
+		}
+		colocated[name] = struct{}{}
+	}
+	for _, name := range a.SeparateFrom {
+		if name == "" {
+			return fmt.Errorf("anti-affinity service name is required")
+		} else if _, ok := colocated[name]; ok {
+			return fmt.Errorf("service %s cannot be both colocated with and separated from", name)
+		}
+	}
+	return nil
+}
+
+// Colocates returns true if the service with the given name should be
+// colocated.
+func (a Affinity) Colocates(name string) bool {
+	return containsName(a.ColocateWith, name)
+}
+
+// Separates returns true if the service with the given name must not share
+// a host.
+func (a Affinity) Separates(name string) bool {
+	return containsName(a.SeparateFrom, name)
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package servicedefinition_test
+
+import (
+	"testing"
+
+	. "github.com/control-center/serviced/domain/servicedefinition"
+)
+
+func TestAffinityValidEntity(t *testing.T) {
+	valid := Affinity{
+		ColocateWith: []string{"redis"},
+		SeparateFrom: []string{"mariadb-backup"},
+	}
+	if err := valid.ValidEntity(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	for _, a := range []Affinity{
+		{ColocateWith: []string{""}},
+		{SeparateFrom: []string{""}},
+		{ColocateWith: []string{"redis"}, SeparateFrom: []string{"redis"}},
+	} {
+		if err := a.ValidEntity(); err == nil {
+			t.Errorf("Expected error for affinity %+v", a)
+		}
+	}
+}
+
+func TestAffinityMatches(t *testing.T) {
+	a := Affinity{
+		ColocateWith: []string{"redis"},
+		SeparateFrom: []string{"mariadb-backup"},
+	}
+	if !a.Colocates("redis") || a.Colocates("mariadb-backup") {
+		t.Errorf("Unexpected colocation for %+v", a)
+	}
+	if !a.Separates("mariadb-backup") || a.Separates("redis") {
+		t.Errorf("Unexpected separation for %+v", a)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicedefinition
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LabelSelector constrains the hosts on which instances of a service may be
+// scheduled according to the labels assigned to those hosts.
+type LabelSelector struct {
+	Required  map[string]string // labels a host must have to run the service
+	Preferred map[string]string // labels that make a host more desirable for the service
+}
+
+// ValidEntity checks that the label selector is valid
+func (s LabelSelector) ValidEntity() error {
+	for key, value := range s.Required {
+		if err := ValidLabel(key, value); err != nil {
+			return fmt.Errorf("required host label: %s", err)
+		}
+	}
+	for key, value := range s.Preferred {
+		if err := ValidLabel(key, value); err != nil {
+			return fmt.Errorf("preferred host label: %s", err)
+		}
+	}
+	return nil
+}
+
+// MatchesRequired returns true if the labels include all of the required
+// labels of the selector.
+func (s LabelSelector) MatchesRequired(labels map[string]string) bool {
+	return matchLabels(s.Required, labels)
+}
+
+// MatchesPreferred returns true if the labels include all of the preferred
+// labels of the selector.
+func (s LabelSelector) MatchesPreferred(labels map[string]string) bool {
+	return matchLabels(s.Preferred, labels)
+}
+
+func matchLabels(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidLabel checks that a label key and value can be assigned to a host.
+// Keys are required, and neither keys nor values may contain whitespace, nor
+// may keys contain '='.
+func ValidLabel(key, value string) error {
+	if key == "" {
+		return fmt.Errorf("label key is required")
+	} else if strings.ContainsAny(key, "= \t\r\n") {
+		return fmt.Errorf("invalid label key %q", key)
+	} else if strings.ContainsAny(value, " \t\r\n") {
+		return fmt.Errorf("invalid value %q for label %s", value, key)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package servicedefinition_test
+
+import (
+	"testing"
+
+	. "github.com/control-center/serviced/domain/servicedefinition"
+)
+
+func TestLabelSelectorValidEntity(t *testing.T) {
+	valid := LabelSelector{
+		Required:  map[string]string{"disk": "ssd"},
+		Preferred: map[string]string{"latency": ""},
+	}
+	if err := valid.ValidEntity(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	for _, s := range []LabelSelector{
+		{Required: map[string]string{"": "ssd"}},
+		{Required: map[string]string{"disk=ssd": ""}},
+		{Preferred: map[string]string{"disk type": "ssd"}},
+		{Preferred: map[string]string{"disk": "fast ssd"}},
+	} {
+		if err := s.ValidEntity(); err == nil {
+			t.Errorf("Expected error for selector %+v", s)
+		}
+	}
+}
+
+func TestLabelSelectorMatches(t *testing.T) {
+	s := LabelSelector{
+		Required:  map[string]string{"disk": "ssd"},
+		Preferred: map[string]string{"rack": "a", "latency": "low"},
+	}
+	labels := map[string]string{"disk": "ssd", "rack": "a"}
+	if !s.MatchesRequired(labels) {
+		t.Errorf("Expected %v to match required labels", labels)
+	}
+	if s.MatchesPreferred(labels) {
+		t.Errorf("Expected %v not to match preferred labels", labels)
+	}
+	labels["latency"] = "low"
+	if !s.MatchesPreferred(labels) {
+		t.Errorf("Expected %v to match preferred labels", labels)
+	}
+	if s.MatchesRequired(map[string]string{"disk": "hdd"}) {
+		t.Errorf("Expected disk=hdd not to match required labels")
+	}
+	if !(LabelSelector{}).MatchesRequired(nil) {
+		t.Errorf("Expected an empty selector to match any host")
+	}
+}
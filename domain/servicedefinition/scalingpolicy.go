@@ -0,0 +1,91 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicedefinition
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// DefaultScalingCooldown is the minimum time between changes to the number of
+// instances of a service when its scaling policy does not set a cooldown.
+const DefaultScalingCooldown = 5 * time.Minute
+
+// ScalingPolicy adjusts the number of instances of a service, within its
+// instance limits, to keep a metric from its monitoring profile near a target
+// value per instance.
+type ScalingPolicy struct {
+	Enabled  bool
+	Metric   string        // the ID of a metric in the monitoring profile of the service
+	Target   float64       // the desired average value of the metric per instance
+	Cooldown time.Duration // the minimum time between changes to the number of instances
+}
+
+// ValidEntity checks that the scaling policy is valid
+func (p ScalingPolicy) ValidEntity() error {
+	if p.Metric == "" {
+		return errors.New("scaling policy metric is required")
+	} else if p.Target <= 0 {
+		return errors.New("scaling policy target must be greater than 0")
+	} else if p.Cooldown < 0 {
+		return errors.New("scaling policy cooldown cannot be negative")
+	}
+	return nil
+}
+
+// GetCooldown returns the cooldown of the policy, or the default cooldown if
+// it is not set.
+func (p ScalingPolicy) GetCooldown() time.Duration {
+	if p.Cooldown == 0 {
+		return DefaultScalingCooldown
+	}
+	return p.Cooldown
+}
+
+// MarshalJSON implements json.Marshaller
+func (p ScalingPolicy) MarshalJSON() ([]byte, error) {
+	jp := struct {
+		Enabled  bool
+		Metric   string
+		Target   float64
+		Cooldown float64
+	}{
+		Enabled:  p.Enabled,
+		Metric:   p.Metric,
+		Target:   p.Target,
+		Cooldown: p.Cooldown.Seconds(),
+	}
+	return json.Marshal(jp)
+}
+
+// UnmarshalJSON implements json.Unmarshaller
+func (p *ScalingPolicy) UnmarshalJSON(data []byte) error {
+	jp := struct {
+		Enabled  bool
+		Metric   string
+		Target   float64
+		Cooldown float64
+	}{}
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return err
+	}
+	*p = ScalingPolicy{
+		Enabled:  jp.Enabled,
+		Metric:   jp.Metric,
+		Target:   jp.Target,
+		Cooldown: time.Duration(jp.Cooldown * float64(time.Second)),
+	}
+	return nil
+}
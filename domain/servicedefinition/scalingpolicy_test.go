@@ -0,0 +1,70 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package servicedefinition_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/control-center/serviced/domain/servicedefinition"
+)
+
+func TestScalingPolicyValidEntity(t *testing.T) {
+	valid := ScalingPolicy{Enabled: true, Metric: "requests", Target: 100, Cooldown: time.Minute}
+	if err := valid.ValidEntity(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	for _, p := range []ScalingPolicy{
+		{Target: 100},
+		{Metric: "requests"},
+		{Metric: "requests", Target: -1},
+		{Metric: "requests", Target: 100, Cooldown: -time.Second},
+	} {
+		if err := p.ValidEntity(); err == nil {
+			t.Errorf("Expected error for policy %+v", p)
+		}
+	}
+}
+
+func TestScalingPolicyJSON(t *testing.T) {
+	data := []byte(`{"Enabled": true, "Metric": "requests", "Target": 2.5, "Cooldown": 90}`)
+	var p ScalingPolicy
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := ScalingPolicy{Enabled: true, Metric: "requests", Target: 2.5, Cooldown: 90 * time.Second}
+	if p != expected {
+		t.Errorf("Expected %+v, got %+v", expected, p)
+	}
+
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var roundtrip ScalingPolicy
+	if err := json.Unmarshal(out, &roundtrip); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if roundtrip != expected {
+		t.Errorf("Expected %+v, got %+v", expected, roundtrip)
+	}
+
+	if (ScalingPolicy{}).GetCooldown() != DefaultScalingCooldown {
+		t.Errorf("Expected the default cooldown")
+	}
+}
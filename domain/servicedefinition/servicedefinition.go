@@ -40,8 +40,11 @@ type ServiceDefinition struct {
 	ImageID                string                 // Docker image hosting the service
 	Instances              domain.MinMax          // Constraints on the number of instances
 	ChangeOptions          []ChangeOption         // Control options for what happens when a running service is changed
+	ScalingPolicy          *ScalingPolicy         // Optional policy for adjusting Instances based on a metric
 	Launch                 string                 // Must be "AUTO", the default, or "MANUAL"
 	HostPolicy             HostPolicy             // Policy for starting up instances
+	HostLabels             *LabelSelector         // Optional host labels that constrain where instances are started
+	Affinity               *Affinity              // Optional rules for starting instances near or apart from other services
 	Hostname               string                 // Optional hostname which should be set on run
 	Privileged             bool                   // Whether to run the container with extended privileges
 	ConfigFiles            map[string]ConfigFile  // Config file templates
@@ -64,11 +67,16 @@ type ServiceDefinition struct {
 	MonitoringProfile      domain.MonitorProfile         // An optional list of queryable metrics, graphs, and thresholds
 	MemoryLimit            float64
 	CPUShares              int64
+	CPUQuota               float64
 	PIDFile                string // An optional path or command to generate a path for a PID file to which signals are relayed.
 	StartLevel             uint   // Services start in the order implied by this field (low to high) and stopped in reverse order
 	EmergencyShutdownLevel uint   // In case of low storage, Services stopped in the order implied by this field (low to high)
 }
 
+// MinCPUQuota is the smallest non-zero CPUQuota (in cores) that docker will
+// enforce, given the default CFS period of 100ms.
+const MinCPUQuota = 0.01
+
 // SnapshotCommands commands to be called during and after a snapshot
 type SnapshotCommands struct {
 	Pause  string // bash command to pause the volume  (quiesce)
@@ -169,6 +177,9 @@ const (
 	PreferSeparate = "PREFER_SEPARATE"
 	// RequireSeparate schedule instances of a service on separate hosts
 	RequireSeparate = "REQUIRE_SEPARATE"
+	// ExternalPrefix precedes the name of a strategy that is registered with
+	// the scheduler at runtime
+	ExternalPrefix = "external:"
 )
 
 // UnmarshalText implements the encoding/TextUnmarshaler interface
@@ -180,6 +191,10 @@ func (p *HostPolicy) UnmarshalText(b []byte) error {
 	case "":
 		*p = DEFAULT
 	default:
+		if len(s) > len(ExternalPrefix) && strings.HasPrefix(strings.ToLower(s), ExternalPrefix) {
+			*p = HostPolicy(s)
+			return nil
+		}
 		return errors.New("Invalid HostPolicy: " + s)
 	}
 	return nil
@@ -66,6 +66,28 @@ func (sd *ServiceDefinition) validate(context *validationContext) error {
 		return fmt.Errorf("service definition %v: invalid monitoring profile %s", sd.Name, err)
 	}
 
+	if sd.CPUQuota < 0 || (sd.CPUQuota > 0 && sd.CPUQuota < MinCPUQuota) {
+		return fmt.Errorf("service definition %v: cpu quota must be 0 (unlimited) or at least %g cores", sd.Name, MinCPUQuota)
+	}
+
+	if sd.ScalingPolicy != nil {
+		if err := sd.ScalingPolicy.ValidEntity(); err != nil {
+			return fmt.Errorf("service definition %v: %s", sd.Name, err)
+		}
+	}
+
+	if sd.HostLabels != nil {
+		if err := sd.HostLabels.ValidEntity(); err != nil {
+			return fmt.Errorf("service definition %v: %s", sd.Name, err)
+		}
+	}
+
+	if sd.Affinity != nil {
+		if err := sd.Affinity.ValidEntity(); err != nil {
+			return fmt.Errorf("service definition %v: %s", sd.Name, err)
+		}
+	}
+
 	return validServiceDefinitions(&sd.Services, context)
 }
 
@@ -137,3 +137,37 @@ func TestServiceDefinitionEmptyEndpointName(t *testing.T) {
 		t.Errorf("Unexpected Error %v", err)
 	}
 }
+
+func TestServiceDefinitionCPUQuota(t *testing.T) {
+	for _, quota := range []float64{0, 0.01, 0.5, 4} {
+		sd := CreateValidServiceDefinition()
+		sd.Services[0].CPUQuota = quota
+		if err := sd.ValidEntity(); err != nil {
+			t.Errorf("Unexpected error for cpu quota %g: %v", quota, err)
+		}
+	}
+	for _, quota := range []float64{-1, 0.001} {
+		sd := CreateValidServiceDefinition()
+		sd.Services[0].CPUQuota = quota
+		if err := sd.ValidEntity(); err == nil {
+			t.Errorf("Expected error for cpu quota %g", quota)
+		} else if !strings.Contains(err.Error(), "cpu quota") {
+			t.Errorf("Unexpected Error %v", err)
+		}
+	}
+}
+
+func TestHostPolicyUnmarshalText(t *testing.T) {
+	for _, s := range []string{"", "LEAST_COMMITTED", "PREFER_SEPARATE", "REQUIRE_SEPARATE", "external:site", "EXTERNAL:site"} {
+		var p HostPolicy
+		if err := p.UnmarshalText([]byte(s)); err != nil {
+			t.Errorf("Unexpected error for host policy %q: %v", s, err)
+		}
+	}
+	for _, s := range []string{"SPREAD", "external:"} {
+		var p HostPolicy
+		if err := p.UnmarshalText([]byte(s)); err == nil {
+			t.Errorf("Expected error for host policy %q", s)
+		}
+	}
+}
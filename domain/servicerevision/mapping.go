@@ -0,0 +1,55 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicerevision
+
+import (
+	"fmt"
+
+	"github.com/control-center/serviced/datastore/elastic"
+	"github.com/control-center/serviced/logging"
+)
+
+var (
+	kind          = "servicerevision"
+	plog          = logging.PackageLogger()
+	mappingString = fmt.Sprintf(`
+{
+     "%s": {
+      "properties":{
+        "ID":             {"type": "string", "index":"not_analyzed"},
+        "ServiceID":      {"type": "string", "index":"not_analyzed"},
+        "Revision":       {"type": "long"},
+        "Time":           {"type": "date", "format" : "dateOptionalTime"},
+        "User":           {"type": "string", "index":"not_analyzed"},
+        "Changes":        {
+          "properties": {
+            "Field":      {"type": "string", "index":"not_analyzed"},
+            "Before":     {"type": "string", "index":"no"},
+            "After":      {"type": "string", "index":"no"}
+          }
+        },
+        "Service":        {"type": "object", "enabled": false}
+      }
+    }
+}
+`, kind)
+	// MAPPING is the elastic mapping for a service revision
+	MAPPING, mappingError = elastic.NewMapping(mappingString)
+)
+
+func init() {
+	if mappingError != nil {
+		plog.WithError(mappingError).Fatal("error creating mapping for the servicerevision object")
+	}
+}
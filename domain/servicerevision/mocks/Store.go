@@ -0,0 +1,98 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mocks
+
+import (
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/servicerevision"
+	"github.com/stretchr/testify/mock"
+)
+
+type Store struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, serviceID, revision
+func (_m *Store) Delete(ctx datastore.Context, serviceID string, revision int) error {
+	ret := _m.Called(ctx, serviceID, revision)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, int) error); ok {
+		r0 = rf(ctx, serviceID, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, serviceID, revision
+func (_m *Store) Get(ctx datastore.Context, serviceID string, revision int) (*servicerevision.Revision, error) {
+	ret := _m.Called(ctx, serviceID, revision)
+
+	var r0 *servicerevision.Revision
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, int) *servicerevision.Revision); ok {
+		r0 = rf(ctx, serviceID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*servicerevision.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string, int) error); ok {
+		r1 = rf(ctx, serviceID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: ctx, serviceID
+func (_m *Store) GetRevisions(ctx datastore.Context, serviceID string) ([]servicerevision.Revision, error) {
+	ret := _m.Called(ctx, serviceID)
+
+	var r0 []servicerevision.Revision
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) []servicerevision.Revision); ok {
+		r0 = rf(ctx, serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]servicerevision.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Put provides a mock function with given fields: ctx, revision
+func (_m *Store) Put(ctx datastore.Context, revision *servicerevision.Revision) error {
+	ret := _m.Called(ctx, revision)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, *servicerevision.Revision) error); ok {
+		r0 = rf(ctx, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicerevision
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/auditlog"
+	"github.com/control-center/serviced/domain/service"
+)
+
+// Revision is a stored copy of a service definition, including its config
+// files, as it was after an update.  Revisions of a service are numbered
+// from 1 in the order in which they were recorded.
+type Revision struct {
+	ID        string            // unique identifier of the revision
+	ServiceID string            // the id of the service
+	Revision  int               // the revision number, unique per service
+	Time      time.Time         // when the revision was recorded
+	User      string            // the user or api token that made the update
+	Changes   []auditlog.Change // the fields changed since the previous revision
+	Service   service.Service   // the service as of this revision
+	datastore.VersionedEntity
+}
+
+// RevisionID returns the id of the given revision of a service
+func RevisionID(serviceID string, revision int) string {
+	return fmt.Sprintf("%s-%d", serviceID, revision)
+}
+
+// GetType returns the type of a Revision
+func GetType() string {
+	return kind
+}
+
+// GetType returns the Revision instance's type
+func (r *Revision) GetType() string {
+	return GetType()
+}
+
+// GetID returns the Revision instance's ID
+func (r *Revision) GetID() string {
+	return r.ID
+}
@@ -0,0 +1,55 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package servicerevision
+
+import (
+	"testing"
+	"time"
+
+	"github.com/control-center/serviced/domain/service"
+	. "gopkg.in/check.v1"
+)
+
+func TestServiceRevision(t *testing.T) { TestingT(t) }
+
+type ServiceRevisionSuite struct{}
+
+var _ = Suite(&ServiceRevisionSuite{})
+
+func (s *ServiceRevisionSuite) TestValidEntity(c *C) {
+	rev := Revision{}
+	c.Assert(rev.ValidEntity(), NotNil)
+
+	rev = Revision{
+		ID:        RevisionID("svc1", 2),
+		ServiceID: "svc1",
+		Revision:  2,
+		Time:      time.Now(),
+		Service:   service.Service{ID: "svc1"},
+	}
+	c.Assert(rev.ValidEntity(), IsNil)
+
+	rev.Revision = 3
+	c.Assert(rev.ValidEntity(), NotNil)
+
+	rev.ID = RevisionID("svc1", 3)
+	rev.Service.ID = "svc2"
+	c.Assert(rev.ValidEntity(), NotNil)
+
+	rev.Service.ID = "svc1"
+	rev.Time = time.Time{}
+	c.Assert(rev.ValidEntity(), NotNil)
+}
@@ -0,0 +1,105 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicerevision
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/zenoss/elastigo/search"
+)
+
+// Store is the database for service revisions
+type Store interface {
+	// Get a revision of a service.  Return ErrNoSuchEntity if not found
+	Get(ctx datastore.Context, serviceID string, revision int) (*Revision, error)
+
+	// Put adds a Revision
+	Put(ctx datastore.Context, revision *Revision) error
+
+	// Delete removes a revision of a service
+	Delete(ctx datastore.Context, serviceID string, revision int) error
+
+	// GetRevisions returns all revisions of a service, most recent first
+	GetRevisions(ctx datastore.Context, serviceID string) ([]Revision, error)
+}
+
+type storeImpl struct {
+	ds datastore.DataStore
+}
+
+// NewStore creates a Store for service revisions
+func NewStore() Store {
+	return &storeImpl{}
+}
+
+// Get a revision of a service.  Return ErrNoSuchEntity if not found
+func (s *storeImpl) Get(ctx datastore.Context, serviceID string, revision int) (*Revision, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("ServiceRevisionStore.Get"))
+	val := &Revision{}
+	if err := s.ds.Get(ctx, Key(RevisionID(serviceID, revision)), val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// Put adds a Revision
+func (s *storeImpl) Put(ctx datastore.Context, revision *Revision) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("ServiceRevisionStore.Put"))
+	return s.ds.Put(ctx, Key(revision.ID), revision)
+}
+
+// Delete removes a revision of a service
+func (s *storeImpl) Delete(ctx datastore.Context, serviceID string, revision int) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("ServiceRevisionStore.Delete"))
+	return s.ds.Delete(ctx, Key(RevisionID(serviceID, revision)))
+}
+
+// GetRevisions returns all revisions of a service, most recent first
+func (s *storeImpl) GetRevisions(ctx datastore.Context, serviceID string) ([]Revision, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("ServiceRevisionStore.GetRevisions"))
+	id := strings.TrimSpace(serviceID)
+	if id == "" {
+		return nil, errors.New("empty serviceID not allowed")
+	}
+	q := datastore.NewQuery(ctx)
+	query := search.Query().Term("ServiceID", id)
+	search := search.Search("controlplane").Type(kind).Size("50000").Query(query)
+	results, err := q.Execute(search)
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]Revision, results.Len())
+	for idx := range revisions {
+		if err := results.Get(idx, &revisions[idx]); err != nil {
+			return nil, err
+		}
+	}
+	sort.Sort(sort.Reverse(byRevision(revisions)))
+	return revisions, nil
+}
+
+type byRevision []Revision
+
+func (r byRevision) Len() int           { return len(r) }
+func (r byRevision) Less(i, j int) bool { return r[i].Revision < r[j].Revision }
+func (r byRevision) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+// Key creates a Key suitable for getting, putting and deleting Revisions
+func Key(id string) datastore.Key {
+	id = strings.TrimSpace(id)
+	return datastore.NewKey(kind, id)
+}
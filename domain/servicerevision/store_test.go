@@ -0,0 +1,93 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build integration
+
+package servicerevision
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/datastore/elastic"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	. "gopkg.in/check.v1"
+)
+
+var _ = Suite(&S{
+	ElasticTest: elastic.ElasticTest{
+		Index:    "controlplane",
+		Mappings: []elastic.Mapping{MAPPING},
+	}})
+
+type S struct {
+	elastic.ElasticTest
+	ctx   datastore.Context
+	store Store
+}
+
+func (s *S) SetUpTest(c *C) {
+	s.ElasticTest.SetUpTest(c)
+	datastore.Register(s.Driver())
+	s.ctx = datastore.Get()
+	s.store = NewStore()
+}
+
+func (s *S) Test_RevisionPutGetDelete(c *C) {
+	now := time.Now().UTC().Truncate(time.Second)
+	for i := 1; i <= 3; i++ {
+		rev := &Revision{
+			ID:        RevisionID("svc1", i),
+			ServiceID: "svc1",
+			Revision:  i,
+			Time:      now.Add(time.Duration(i) * time.Minute),
+			User:      "admin",
+			Service: service.Service{
+				ID:        "svc1",
+				Name:      "svc",
+				Instances: i,
+				ConfigFiles: map[string]servicedefinition.ConfigFile{
+					"/etc/my.cnf": {Filename: "/etc/my.cnf", Content: "revision"},
+				},
+			},
+		}
+		c.Assert(s.store.Put(s.ctx, rev), IsNil)
+	}
+	other := &Revision{
+		ID:        RevisionID("svc2", 1),
+		ServiceID: "svc2",
+		Revision:  1,
+		Time:      now,
+		Service:   service.Service{ID: "svc2", Name: "svc"},
+	}
+	c.Assert(s.store.Put(s.ctx, other), IsNil)
+
+	actual, err := s.store.Get(s.ctx, "svc1", 2)
+	c.Assert(err, IsNil)
+	c.Assert(actual.Service.Instances, Equals, 2)
+	c.Assert(actual.Service.ConfigFiles["/etc/my.cnf"].Content, Equals, "revision")
+
+	revisions, err := s.store.GetRevisions(s.ctx, "svc1")
+	c.Assert(err, IsNil)
+	c.Assert(revisions, HasLen, 3)
+	c.Assert(revisions[0].Revision, Equals, 3)
+	c.Assert(revisions[2].Revision, Equals, 1)
+
+	c.Assert(s.store.Delete(s.ctx, "svc1", 1), IsNil)
+	_, err = s.store.Get(s.ctx, "svc1", 1)
+	c.Assert(datastore.IsErrNoSuchEntity(err), Equals, true)
+
+	_, err = s.store.GetRevisions(s.ctx, "")
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,43 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicerevision
+
+import (
+	"fmt"
+
+	"github.com/control-center/serviced/validation"
+)
+
+// ValidEntity validates Revision fields
+func (r *Revision) ValidEntity() error {
+	violations := validation.NewValidationError()
+	violations.Add(validation.NotEmpty("Revision.ServiceID", r.ServiceID))
+	if r.Revision < 1 {
+		violations.Add(validation.NewViolation(fmt.Sprintf("invalid Revision.Revision %d", r.Revision)))
+	}
+	if r.ID != RevisionID(r.ServiceID, r.Revision) {
+		violations.Add(validation.NewViolation(fmt.Sprintf("Revision.ID %q does not match the service and revision", r.ID)))
+	}
+	if r.Service.ID != r.ServiceID {
+		violations.Add(validation.NewViolation("Revision.Service.ID does not match Revision.ServiceID"))
+	}
+	if r.Time.IsZero() {
+		violations.Add(validation.NewViolation("zero value for Revision.Time"))
+	}
+
+	if len(violations.Errors) > 0 {
+		return violations
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mocks
+
+import (
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/snapshotschedule"
+	"github.com/stretchr/testify/mock"
+)
+
+type Store struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, tenantID
+func (_m *Store) Delete(ctx datastore.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, tenantID
+func (_m *Store) Get(ctx datastore.Context, tenantID string) (*snapshotschedule.Schedule, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *snapshotschedule.Schedule
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) *snapshotschedule.Schedule); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*snapshotschedule.Schedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSchedules provides a mock function with given fields: ctx
+func (_m *Store) GetSchedules(ctx datastore.Context) ([]snapshotschedule.Schedule, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []snapshotschedule.Schedule
+	if rf, ok := ret.Get(0).(func(datastore.Context) []snapshotschedule.Schedule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]snapshotschedule.Schedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Put provides a mock function with given fields: ctx, schedule
+func (_m *Store) Put(ctx datastore.Context, schedule *snapshotschedule.Schedule) error {
+	ret := _m.Called(ctx, schedule)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, *snapshotschedule.Schedule) error); ok {
+		r0 = rf(ctx, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
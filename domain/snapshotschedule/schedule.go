@@ -0,0 +1,92 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshotschedule
+
+import (
+	"strings"
+	"time"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/utils"
+)
+
+// ScheduledMessage is the description of the snapshots taken by a schedule.
+const ScheduledMessage = "scheduled snapshot"
+
+// ScheduledTagPrefix begins the tag of each snapshot taken by a schedule.
+// Retention rules only remove snapshots whose only tag is their scheduled
+// tag, so manual snapshots and snapshots that have been tagged again are
+// always kept.
+const ScheduledTagPrefix = "scheduled-"
+
+// ScheduledTag returns the tag of a snapshot taken by a schedule at the given
+// time.  Tags are unique within a tenant, so each scheduled snapshot has its
+// own.
+func ScheduledTag(t time.Time) string {
+	return ScheduledTagPrefix + t.UTC().Format("20060102-150405")
+}
+
+// IsScheduled returns true if the tags are those of a scheduled snapshot that
+// has not been tagged since it was taken.
+func IsScheduled(tags []string) bool {
+	return len(tags) == 1 && strings.HasPrefix(tags[0], ScheduledTagPrefix)
+}
+
+// Schedule describes when to snapshot the application of a tenant, and which
+// of the scheduled snapshots to keep.  A tenant has at most one schedule.
+type Schedule struct {
+	TenantID     string                 // the id of the tenant service
+	Cron         string                 // when to take snapshots, as a cron expression
+	Disabled     bool                   // a disabled schedule neither takes nor removes snapshots
+	Retention    domain.RetentionPolicy // the scheduled snapshots to keep
+	LastRun      time.Time              // when a snapshot was last attempted
+	LastSnapshot string                 // the id of the snapshot taken by the last run
+	LastError    string                 // why the last run failed, if it did
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	datastore.VersionedEntity
+}
+
+// Next returns when the schedule should run next, which is the first time
+// after its last run (or its creation) that matches its cron expression.  A
+// run that was missed while no master was leading is due immediately.  Like
+// the scheduled backups, the cron expression is evaluated in the local time
+// zone of the master.
+func (s *Schedule) Next() (time.Time, error) {
+	cron, err := utils.ParseCronSchedule(s.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	from := s.LastRun
+	if from.IsZero() {
+		from = s.CreatedAt
+	}
+	return cron.Next(from.Local()), nil
+}
+
+// GetType returns the type of a Schedule
+func GetType() string {
+	return kind
+}
+
+// GetType returns the Schedule instance's type
+func (s *Schedule) GetType() string {
+	return GetType()
+}
+
+// GetID returns the Schedule instance's ID, which is the id of its tenant
+func (s *Schedule) GetID() string {
+	return s.TenantID
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package snapshotschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/control-center/serviced/domain"
+	. "gopkg.in/check.v1"
+)
+
+func TestSnapshotSchedule(t *testing.T) { TestingT(t) }
+
+type SnapshotScheduleSuite struct{}
+
+var _ = Suite(&SnapshotScheduleSuite{})
+
+func (s *SnapshotScheduleSuite) TestValidEntity(c *C) {
+	sched := Schedule{}
+	c.Assert(sched.ValidEntity(), NotNil)
+
+	sched = Schedule{TenantID: "tenant1", Cron: "0 * * * *"}
+	c.Assert(sched.ValidEntity(), IsNil)
+
+	sched.Cron = "0 * * *"
+	c.Assert(sched.ValidEntity(), NotNil)
+
+	sched.Cron = "@daily"
+	sched.Retention = domain.RetentionPolicy{KeepDaily: -1}
+	c.Assert(sched.ValidEntity(), NotNil)
+}
+
+func (s *SnapshotScheduleSuite) TestNext(c *C) {
+	created := time.Date(2019, 3, 4, 10, 30, 0, 0, time.Local)
+	sched := Schedule{TenantID: "tenant1", Cron: "0 * * * *", CreatedAt: created}
+	next, err := sched.Next()
+	c.Assert(err, IsNil)
+	c.Assert(next, Equals, time.Date(2019, 3, 4, 11, 0, 0, 0, time.Local))
+
+	sched.LastRun = time.Date(2019, 3, 5, 8, 0, 0, 0, time.Local)
+	next, err = sched.Next()
+	c.Assert(err, IsNil)
+	c.Assert(next, Equals, time.Date(2019, 3, 5, 9, 0, 0, 0, time.Local))
+
+	sched.Cron = "bogus"
+	_, err = sched.Next()
+	c.Assert(err, NotNil)
+}
+
+func (s *SnapshotScheduleSuite) TestNextLocalTime(c *C) {
+	// times are stored in UTC, but the schedule follows the local clock
+	sched := Schedule{
+		TenantID: "tenant1",
+		Cron:     "0 2 * * *",
+		LastRun:  time.Date(2019, 3, 5, 8, 0, 0, 0, time.Local).UTC(),
+	}
+	next, err := sched.Next()
+	c.Assert(err, IsNil)
+	c.Assert(next, Equals, time.Date(2019, 3, 6, 2, 0, 0, 0, time.Local))
+}
+
+func (s *SnapshotScheduleSuite) TestIsScheduled(c *C) {
+	tag := ScheduledTag(time.Date(2019, 3, 5, 8, 0, 0, 0, time.UTC))
+	c.Assert(tag, Equals, "scheduled-20190305-080000")
+	c.Assert(IsScheduled([]string{tag}), Equals, true)
+	c.Assert(IsScheduled([]string{tag, "keep"}), Equals, false)
+	c.Assert(IsScheduled([]string{"keep"}), Equals, false)
+	c.Assert(IsScheduled(nil), Equals, false)
+}
@@ -0,0 +1,92 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshotschedule
+
+import (
+	"strings"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/zenoss/elastigo/search"
+)
+
+// Store is the database for snapshot schedules
+type Store interface {
+	// Get the schedule of a tenant.  Return ErrNoSuchEntity if not found
+	Get(ctx datastore.Context, tenantID string) (*Schedule, error)
+
+	// Put adds or updates a Schedule
+	Put(ctx datastore.Context, schedule *Schedule) error
+
+	// Delete removes the schedule of a tenant
+	Delete(ctx datastore.Context, tenantID string) error
+
+	// GetSchedules returns the schedules of all tenants
+	GetSchedules(ctx datastore.Context) ([]Schedule, error)
+}
+
+type storeImpl struct {
+	ds datastore.DataStore
+}
+
+// NewStore creates a Store for snapshot schedules
+func NewStore() Store {
+	return &storeImpl{}
+}
+
+// Get the schedule of a tenant.  Return ErrNoSuchEntity if not found
+func (s *storeImpl) Get(ctx datastore.Context, tenantID string) (*Schedule, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("SnapshotScheduleStore.Get"))
+	val := &Schedule{}
+	if err := s.ds.Get(ctx, Key(tenantID), val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// Put adds or updates a Schedule
+func (s *storeImpl) Put(ctx datastore.Context, schedule *Schedule) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("SnapshotScheduleStore.Put"))
+	return s.ds.Put(ctx, Key(schedule.TenantID), schedule)
+}
+
+// Delete removes the schedule of a tenant
+func (s *storeImpl) Delete(ctx datastore.Context, tenantID string) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("SnapshotScheduleStore.Delete"))
+	return s.ds.Delete(ctx, Key(tenantID))
+}
+
+// GetSchedules returns the schedules of all tenants
+func (s *storeImpl) GetSchedules(ctx datastore.Context) ([]Schedule, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("SnapshotScheduleStore.GetSchedules"))
+	q := datastore.NewQuery(ctx)
+	query := search.Query().Search("_exists_:TenantID")
+	search := search.Search("controlplane").Type(kind).Size("50000").Query(query)
+	results, err := q.Execute(search)
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]Schedule, results.Len())
+	for idx := range schedules {
+		if err := results.Get(idx, &schedules[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return schedules, nil
+}
+
+// Key creates a Key suitable for getting, putting and deleting Schedules
+func Key(tenantID string) datastore.Key {
+	tenantID = strings.TrimSpace(tenantID)
+	return datastore.NewKey(kind, tenantID)
+}
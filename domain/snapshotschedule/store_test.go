@@ -0,0 +1,70 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build integration
+
+package snapshotschedule
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/datastore/elastic"
+	"github.com/control-center/serviced/domain"
+	. "gopkg.in/check.v1"
+)
+
+var _ = Suite(&S{
+	ElasticTest: elastic.ElasticTest{
+		Index:    "controlplane",
+		Mappings: []elastic.Mapping{MAPPING},
+	}})
+
+type S struct {
+	elastic.ElasticTest
+	ctx   datastore.Context
+	store Store
+}
+
+func (s *S) SetUpTest(c *C) {
+	s.ElasticTest.SetUpTest(c)
+	datastore.Register(s.Driver())
+	s.ctx = datastore.Get()
+	s.store = NewStore()
+}
+
+func (s *S) Test_SchedulePutGetDelete(c *C) {
+	now := time.Now().UTC().Truncate(time.Second)
+	sched := &Schedule{
+		TenantID:  "tenant1",
+		Cron:      "0 2 * * *",
+		Retention: domain.RetentionPolicy{KeepDaily: 7, KeepWeekly: 4},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	c.Assert(s.store.Put(s.ctx, sched), IsNil)
+	c.Assert(s.store.Put(s.ctx, &Schedule{TenantID: "tenant2", Cron: "@hourly"}), IsNil)
+
+	actual, err := s.store.Get(s.ctx, "tenant1")
+	c.Assert(err, IsNil)
+	c.Assert(actual.Cron, Equals, "0 2 * * *")
+	c.Assert(actual.Retention, Equals, domain.RetentionPolicy{KeepDaily: 7, KeepWeekly: 4})
+
+	schedules, err := s.store.GetSchedules(s.ctx)
+	c.Assert(err, IsNil)
+	c.Assert(schedules, HasLen, 2)
+
+	c.Assert(s.store.Delete(s.ctx, "tenant1"), IsNil)
+	_, err = s.store.Get(s.ctx, "tenant1")
+	c.Assert(datastore.IsErrNoSuchEntity(err), Equals, true)
+}
@@ -14,16 +14,28 @@
 package user
 
 import (
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/datastore"
 	"github.com/control-center/serviced/logging"
 )
 
 // User for the system???
 type User struct {
-	Name     string // the unique identifier for a user
-	Password string // no requirements on passwords yet
+	Name     string    // the unique identifier for a user
+	Password string    // no requirements on passwords yet
+	Role     auth.Role // the access granted to the user; empty for full access
+	Tenants  []string  // the tenants the user is restricted to; empty for all
 	datastore.VersionedEntity
 }
 
+// EffectiveRole returns the role the user is granted.  Users without an
+// explicit role predate role-based access and are cluster administrators.
+func (u User) EffectiveRole() auth.Role {
+	if u.Role == auth.RoleNone {
+		return auth.RoleClusterAdmin
+	}
+	return u.Role
+}
+
 // initialize the package logger
 var plog = logging.PackageLogger()
@@ -23,7 +23,9 @@ var (
      "user": {
       "properties":{
         "Name":           {"type": "string", "index":"not_analyzed"},
-        "Password":       {"type": "string", "index":"not_analyzed"}
+        "Password":       {"type": "string", "index":"not_analyzed"},
+        "Role":           {"type": "string", "index":"not_analyzed"},
+        "Tenants":        {"type": "string", "index":"not_analyzed"}
       }
     }
 }
@@ -14,8 +14,10 @@
 package user
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/validation"
 )
 
@@ -26,7 +28,13 @@ func (u *User) ValidEntity() error {
 	violations.Add(validation.NotEmpty("User.Name", u.Name))
 	violations.Add(validation.StringsEqual(u.Name, trimmed, "leading and trailing spaces not allowed for user name"))
 
-	violations.Add(validation.NotEmpty("User.Password", u.Password))
+	// Users that authenticate through PAM only need a record to carry their
+	// role or tenants, so a password is required only when there are neither.
+	if u.Role == auth.RoleNone && len(u.Tenants) == 0 {
+		violations.Add(validation.NotEmpty("User.Password", u.Password))
+	} else if u.Role != auth.RoleNone && !u.Role.Valid() {
+		violations.Add(fmt.Errorf("%s: %q", auth.ErrInvalidRole, u.Role))
+	}
 
 	if len(violations.Errors) > 0 {
 		return violations
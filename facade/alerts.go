@@ -0,0 +1,289 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/health"
+	"github.com/control-center/serviced/notify"
+)
+
+const (
+	// minMaxThresholdType is the type of a threshold on the last value of a
+	// metric
+	minMaxThresholdType = "MinMax"
+	// durationThresholdType is the type of a threshold on the share of
+	// values of a metric over a period of time
+	durationThresholdType = "Duration"
+)
+
+const (
+	// healthSeverity is the severity of a failing health check
+	healthSeverity = 4
+	// thresholdSeverity is the severity of a breached threshold without a
+	// Severity event tag
+	thresholdSeverity = 3
+)
+
+// alertWindow is the period over which the metric of a MinMax threshold is
+// queried.
+var alertWindow = 5 * time.Minute
+
+// EvaluateAlerts returns the state of the alert conditions of the running
+// services: one event per health check of each instance that has reported,
+// and one per data point of each threshold in the monitoring profile that
+// could be evaluated.  Only thresholds with numeric bounds are evaluated;
+// HoltWinters thresholds and bounds given as expressions are skipped.
+func (f *Facade) EvaluateAlerts(ctx datastore.Context) (*notify.Alerts, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.EvaluateAlerts"))
+	svcs, err := f.serviceStore.GetServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	alerts := &notify.Alerts{Running: make(map[string]bool)}
+	for i := range svcs {
+		svc := &svcs[i]
+		running := svc.DesiredState == int(service.SVCRun)
+		alerts.Running[svc.ID] = running
+		if !running {
+			continue
+		}
+		logger := plog.WithFields(log.Fields{
+			"serviceid":   svc.ID,
+			"servicename": svc.Name,
+		})
+		tenantID, err := f.GetTenantID(ctx, svc.ID)
+		if err != nil {
+			logger.WithError(err).Warn("Could not get the tenant of service")
+			continue
+		}
+		event := notify.Event{
+			TenantID:    tenantID,
+			ServiceID:   svc.ID,
+			ServiceName: svc.Name,
+			Timestamp:   now,
+		}
+		alerts.Events = append(alerts.Events, f.evaluateHealthAlerts(svc, event)...)
+		alerts.Events = append(alerts.Events, f.evaluateThresholdAlerts(svc, event)...)
+	}
+	return alerts, nil
+}
+
+// evaluateHealthAlerts returns an event for each health check result of the
+// instances of a service.  Checks that have not reported, or whose status
+// is unknown, are skipped.
+func (f *Facade) evaluateHealthAlerts(svc *service.Service, event notify.Event) []notify.Event {
+	var events []notify.Event
+	event.Kind = notify.HealthKind
+	event.Severity = healthSeverity
+	for i := 0; i < svc.Instances; i++ {
+		for name := range svc.HealthChecks {
+			result, ok := f.hcache.Get(health.HealthStatusKey{
+				ServiceID:       svc.ID,
+				InstanceID:      i,
+				HealthCheckName: name,
+			})
+			if !ok {
+				continue
+			}
+			e := event
+			e.InstanceID = i
+			e.Name = name
+			switch result.Status {
+			case health.OK:
+				e.State = notify.Resolved
+				e.Summary = fmt.Sprintf("Health check %s of %s instance %d is passing", name, svc.Name, i)
+			case health.Failed:
+				e.State = notify.Firing
+				e.Summary = fmt.Sprintf("Health check %s of %s instance %d is failing", name, svc.Name, i)
+				e.Output = result.Output
+			case health.Timeout:
+				e.State = notify.Firing
+				e.Summary = fmt.Sprintf("Health check %s of %s instance %d timed out", name, svc.Name, i)
+				e.Output = result.Output
+			default:
+				continue
+			}
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// evaluateThresholdAlerts returns an event for each data point of the
+// thresholds of a service that could be evaluated.
+func (f *Facade) evaluateThresholdAlerts(svc *service.Service, event notify.Event) []notify.Event {
+	var events []notify.Event
+	event.Kind = notify.ThresholdKind
+	event.InstanceID = -1
+	for _, tc := range svc.MonitoringProfile.ThresholdConfigs {
+		logger := plog.WithFields(log.Fields{
+			"serviceid": svc.ID,
+			"threshold": tc.ID,
+			"type":      tc.Type,
+		})
+		for _, dp := range tc.DataPoints {
+			metric, ok := getThresholdMetric(svc, tc.MetricSource, dp)
+			if !ok {
+				logger.WithField("datapoint", dp).Debug("Data point is not in the monitoring profile of the service")
+				continue
+			}
+			breached, value, err := f.evaluateThreshold(svc.ID, tc, metric)
+			if err == errThresholdSkipped {
+				logger.Debug("Threshold cannot be evaluated")
+				break
+			} else if err != nil {
+				logger.WithField("datapoint", dp).WithError(err).Debug("Could not evaluate threshold")
+				continue
+			}
+			e := event
+			e.Name = fmt.Sprintf("%s/%s", tc.ID, dp)
+			e.Severity = getThresholdSeverity(tc)
+			e.Value = value
+			e.Tags = tc.EventTags
+			if breached {
+				e.State = notify.Firing
+				e.Summary = fmt.Sprintf("Threshold %s of %s is breached by %s: %g", tc.Name, svc.Name, dp, value)
+			} else {
+				e.State = notify.Resolved
+				e.Summary = fmt.Sprintf("Threshold %s of %s is no longer breached by %s", tc.Name, svc.Name, dp)
+			}
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// errThresholdSkipped is returned when a threshold is not of a type, or does
+// not have bounds, that can be evaluated.
+var errThresholdSkipped = fmt.Errorf("threshold skipped")
+
+// evaluateThreshold returns true if a threshold is breached for a metric,
+// along with the value that was compared.  For a Duration threshold, the
+// value is the percentage of the points of the period that were out of
+// bounds.
+func (f *Facade) evaluateThreshold(serviceID string, tc domain.ThresholdConfig, metric domain.Metric) (bool, float64, error) {
+	switch tc.Type {
+	case minMaxThresholdType:
+		var t domain.MinMaxThreshold
+		if err := convertThreshold(tc.Threshold, &t); err != nil {
+			return false, 0, errThresholdSkipped
+		}
+		min, hasMin, err := parseThresholdBound(t.Min)
+		if err != nil {
+			return false, 0, errThresholdSkipped
+		}
+		max, hasMax, err := parseThresholdBound(t.Max)
+		if err != nil {
+			return false, 0, errThresholdSkipped
+		}
+		if !hasMin && !hasMax {
+			return false, 0, errThresholdSkipped
+		}
+		values, err := f.metricsClient.GetServiceMetricSeries(alertWindow, serviceID, metric.ID, metric.Counter)
+		if err != nil {
+			return false, 0, err
+		}
+		value := values[len(values)-1]
+		return (hasMin && value < min) || (hasMax && value > max), value, nil
+	case durationThresholdType:
+		var t domain.DurationThreshold
+		if err := convertThreshold(tc.Threshold, &t); err != nil {
+			return false, 0, errThresholdSkipped
+		}
+		if t.Min == nil && t.Max == nil {
+			return false, 0, errThresholdSkipped
+		}
+		period := t.TimePeriod
+		if period <= 0 {
+			period = alertWindow
+		}
+		values, err := f.metricsClient.GetServiceMetricSeries(period, serviceID, metric.ID, metric.Counter)
+		if err != nil {
+			return false, 0, err
+		}
+		violations := 0
+		for _, value := range values {
+			if (t.Min != nil && value < float64(*t.Min)) || (t.Max != nil && value > float64(*t.Max)) {
+				violations++
+			}
+		}
+		percent := float64(violations*100) / float64(len(values))
+		return violations > 0 && percent >= float64(t.Percentage), percent, nil
+	default:
+		return false, 0, errThresholdSkipped
+	}
+}
+
+// convertThreshold converts the threshold data of a config, which is
+// generic once it has been read from the database, into its type.
+func convertThreshold(threshold interface{}, t interface{}) error {
+	data, err := json.Marshal(threshold)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, t)
+}
+
+// parseThresholdBound parses a MinMax bound, which is empty for no bound.
+func parseThresholdBound(bound string) (float64, bool, error) {
+	if bound == "" {
+		return 0, false, nil
+	}
+	value, err := strconv.ParseFloat(bound, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return value, true, nil
+}
+
+// getThresholdMetric returns the metric of a threshold data point.
+func getThresholdMetric(svc *service.Service, source, dataPoint string) (domain.Metric, bool) {
+	for _, mc := range svc.MonitoringProfile.MetricConfigs {
+		if mc.ID != source {
+			continue
+		}
+		for _, metric := range mc.Metrics {
+			if metric.ID == dataPoint {
+				return metric, true
+			}
+		}
+	}
+	return domain.Metric{}, false
+}
+
+// getThresholdSeverity returns the Severity event tag of a threshold.
+func getThresholdSeverity(tc domain.ThresholdConfig) int {
+	switch severity := tc.EventTags["Severity"].(type) {
+	case int:
+		return severity
+	case float64:
+		return int(severity)
+	case string:
+		if value, err := strconv.Atoi(severity); err == nil {
+			return value
+		}
+	}
+	return thresholdSeverity
+}
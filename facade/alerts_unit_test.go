@@ -0,0 +1,152 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/health"
+	"github.com/control-center/serviced/notify"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func alertedService(id string) service.Service {
+	return service.Service{
+		ID:           id,
+		Name:         id,
+		Instances:    1,
+		DesiredState: int(service.SVCRun),
+		HealthChecks: map[string]health.HealthCheck{
+			"running": {Script: "true"},
+			"ready":   {Script: "true"},
+		},
+		MonitoringProfile: domain.MonitorProfile{
+			MetricConfigs: []domain.MetricConfig{{ID: "web", Metrics: []domain.Metric{{ID: "requests", Counter: true}, {ID: "latency"}}}},
+		},
+	}
+}
+
+func (ft *FacadeUnitTest) Test_EvaluateAlertsHealth(c *C) {
+	hcache := health.New()
+	ft.Facade.SetHealthCache(hcache)
+
+	svc := alertedService("tenant1")
+	stopped := alertedService("stopped")
+	stopped.DesiredState = int(service.SVCStop)
+	ft.serviceStore.On("GetServices", ft.ctx).Return([]service.Service{svc, stopped}, nil)
+	ft.serviceStore.On("GetServiceDetails", ft.ctx, "tenant1").Return(&service.ServiceDetails{ID: "tenant1"}, nil)
+
+	hcache.Set(health.HealthStatusKey{ServiceID: "tenant1", InstanceID: 0, HealthCheckName: "running"},
+		health.HealthStatus{Status: health.Status(health.Failed), Output: "connection refused", ExitCode: 1}, time.Minute)
+	hcache.Set(health.HealthStatusKey{ServiceID: "stopped", InstanceID: 0, HealthCheckName: "running"},
+		health.HealthStatus{Status: health.Status(health.Failed)}, time.Minute)
+
+	alerts, err := ft.Facade.EvaluateAlerts(ft.ctx)
+	c.Assert(err, IsNil)
+	c.Assert(alerts.Running, DeepEquals, map[string]bool{"tenant1": true, "stopped": false})
+	events := alerts.Events
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].Kind, Equals, notify.HealthKind)
+	c.Assert(events[0].State, Equals, notify.Firing)
+	c.Assert(events[0].TenantID, Equals, "tenant1")
+	c.Assert(events[0].InstanceID, Equals, 0)
+	c.Assert(events[0].Name, Equals, "running")
+	c.Assert(events[0].Output, Equals, "connection refused")
+
+	hcache.Set(health.HealthStatusKey{ServiceID: "tenant1", InstanceID: 0, HealthCheckName: "running"},
+		health.HealthStatus{Status: health.OK}, time.Minute)
+	alerts, err = ft.Facade.EvaluateAlerts(ft.ctx)
+	c.Assert(err, IsNil)
+	c.Assert(alerts.Events, HasLen, 1)
+	c.Assert(alerts.Events[0].State, Equals, notify.Resolved)
+}
+
+func (ft *FacadeUnitTest) Test_EvaluateAlertsThresholds(c *C) {
+	ft.Facade.SetHealthCache(health.New())
+
+	max := int64(500)
+	svc := alertedService("tenant1")
+	svc.MonitoringProfile.ThresholdConfigs = []domain.ThresholdConfig{
+		{
+			ID:           "busy",
+			Name:         "Busy",
+			Type:         "MinMax",
+			MetricSource: "web",
+			DataPoints:   []string{"requests"},
+			Threshold:    map[string]interface{}{"Min": "", "Max": "100"},
+			EventTags:    map[string]interface{}{"Severity": float64(5)},
+		}, {
+			ID:           "slow",
+			Name:         "Slow",
+			Type:         "Duration",
+			MetricSource: "web",
+			DataPoints:   []string{"latency"},
+			Threshold:    domain.DurationThreshold{Max: &max, TimePeriod: 10 * time.Minute, Percentage: 50},
+		}, {
+			ID:           "expression",
+			Type:         "MinMax",
+			MetricSource: "web",
+			DataPoints:   []string{"requests"},
+			Threshold:    domain.MinMaxThreshold{Max: "here.limit * 0.9"},
+		}, {
+			ID:           "predicted",
+			Type:         "HoltWinters",
+			MetricSource: "web",
+			DataPoints:   []string{"requests"},
+			Threshold:    domain.HoltWintersThreshold{Alpha: 0.5},
+		}, {
+			ID:           "missing",
+			Type:         "MinMax",
+			MetricSource: "web",
+			DataPoints:   []string{"missing"},
+			Threshold:    domain.MinMaxThreshold{Max: "1"},
+		},
+	}
+	ft.serviceStore.On("GetServices", ft.ctx).Return([]service.Service{svc}, nil)
+	ft.serviceStore.On("GetServiceDetails", ft.ctx, "tenant1").Return(&service.ServiceDetails{ID: "tenant1"}, nil)
+	ft.metricsClient.On("GetServiceMetricSeries", mock.AnythingOfType("time.Duration"), "tenant1", "requests", true).Return([]float64{150, 120}, nil)
+	ft.metricsClient.On("GetServiceMetricSeries", 10*time.Minute, "tenant1", "latency", false).Return([]float64{600, 400, 300, 700}, nil)
+
+	alerts, err := ft.Facade.EvaluateAlerts(ft.ctx)
+	c.Assert(err, IsNil)
+	events := alerts.Events
+	c.Assert(events, HasLen, 2)
+
+	c.Assert(events[0].Kind, Equals, notify.ThresholdKind)
+	c.Assert(events[0].Name, Equals, "busy/requests")
+	c.Assert(events[0].State, Equals, notify.Firing)
+	c.Assert(events[0].InstanceID, Equals, -1)
+	c.Assert(events[0].Value, Equals, 120.0)
+	c.Assert(events[0].Severity, Equals, 5)
+
+	c.Assert(events[1].Name, Equals, "slow/latency")
+	c.Assert(events[1].State, Equals, notify.Firing)
+	c.Assert(events[1].Value, Equals, 50.0)
+	c.Assert(events[1].Severity, Equals, 3)
+	ft.metricsClient.AssertNumberOfCalls(c, "GetServiceMetricSeries", 2)
+}
+
+func (ft *FacadeUnitTest) Test_EvaluateAlertsStoreError(c *C) {
+	ft.serviceStore.On("GetServices", ft.ctx).Return(nil, errors.New("store error"))
+
+	alerts, err := ft.Facade.EvaluateAlerts(ft.ctx)
+	c.Assert(err, ErrorMatches, "store error")
+	c.Assert(alerts, IsNil)
+}
@@ -0,0 +1,242 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/control-center/serviced/audit"
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/apitoken"
+	"github.com/control-center/serviced/utils"
+)
+
+var (
+	// ErrAPITokenExists is returned when a user already has a token with the
+	// requested name
+	ErrAPITokenExists = errors.New("facade: api token name already in use")
+	// ErrAPITokenRevoked is returned when an api token has been removed
+	ErrAPITokenRevoked = errors.New("facade: api token has been revoked")
+	// ErrAPITokenExpired is returned when an api token has expired
+	ErrAPITokenExpired = errors.New("facade: api token has expired")
+	// ErrAPITokenRoleTooHigh is returned when an api token would be granted
+	// a role above that of its user or of the caller
+	ErrAPITokenRoleTooHigh = errors.New("facade: api token role exceeds the role of its user")
+	// ErrAPITokenUserNotAllowed is returned when the user of an api token
+	// does not have a role and is not a member of the admin group
+	ErrAPITokenUserNotAllowed = errors.New("facade: api token user cannot log in")
+
+	// apiTokenLastUsedInterval limits how often the last used time of a
+	// token is written back to the datastore.
+	apiTokenLastUsedInterval = time.Minute
+)
+
+// AddAPIToken creates a named api token that acts on behalf of a user with at
+// most the given role and tenants.  The user must be able to log in, and the
+// role may not be above the role of the user or of the caller.  The tenants
+// must be granted to the caller.  A caller that is restricted to some of the
+// tenants may not create a token for all tenants; an empty list is replaced
+// by the caller's tenants.  It returns the token record and the signed bearer
+// token, which is not stored and cannot be retrieved again.  A zero
+// expiration creates a token that does not expire.
+func (f *Facade) AddAPIToken(ctx datastore.Context, userName, name string, role auth.Role, tenantIDs []string, expiration time.Duration) (apitoken.APIToken, string, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.AddAPIToken"))
+	alog := f.auditLogger.Message(ctx, "Adding API Token").Action(audit.Add).Type(apitoken.GetType()).
+		WithField("name", name).WithField("user", userName)
+
+	token := apitoken.APIToken{
+		Name:    strings.TrimSpace(name),
+		User:    strings.TrimSpace(userName),
+		Role:    role,
+		Tenants: append([]string{}, tenantIDs...),
+		Created: time.Now().UTC(),
+	}
+	if expiration > 0 {
+		token.ExpiresAt = token.Created.Add(expiration)
+	}
+	if err := f.validateTenantIDs(ctx, token.Tenants); err != nil {
+		return apitoken.APIToken{}, "", alog.Error(err)
+	}
+	grant, err := f.getTenantGrants(ctx)
+	if err != nil {
+		return apitoken.APIToken{}, "", alog.Error(err)
+	}
+	if grant != nil {
+		if len(token.Tenants) == 0 {
+			if token.Tenants = grant.tenants(); len(token.Tenants) == 0 {
+				return apitoken.APIToken{}, "", alog.Error(ErrTenantNotAuthorized)
+			}
+		}
+		for _, tenantID := range token.Tenants {
+			if !grant.allows(tenantID) {
+				return apitoken.APIToken{}, "", alog.Error(ErrTenantNotAuthorized)
+			}
+		}
+	}
+	userRole, err := f.getUserLoginRole(ctx, token.User)
+	if err != nil {
+		return apitoken.APIToken{}, "", alog.Error(err)
+	} else if userRole == auth.RoleNone {
+		return apitoken.APIToken{}, "", alog.Error(ErrAPITokenUserNotAllowed)
+	}
+	callerRole, err := f.getCallerRole(ctx)
+	if err != nil {
+		return apitoken.APIToken{}, "", alog.Error(err)
+	}
+	if !userRole.Satisfies(token.Role) || !callerRole.Satisfies(token.Role) {
+		return apitoken.APIToken{}, "", alog.Error(ErrAPITokenRoleTooHigh)
+	}
+
+	tokens, err := f.apiTokenStore.GetAPITokens(ctx)
+	if err != nil {
+		return apitoken.APIToken{}, "", alog.Error(err)
+	}
+	for _, t := range tokens {
+		if t.User == token.User && t.Name == token.Name {
+			return apitoken.APIToken{}, "", alog.Error(ErrAPITokenExists)
+		}
+	}
+
+	if token.ID, err = utils.NewUUID62(); err != nil {
+		return apitoken.APIToken{}, "", alog.Error(err)
+	}
+	alog = alog.ID(token.ID)
+	if err := token.ValidEntity(); err != nil {
+		return apitoken.APIToken{}, "", alog.Error(err)
+	}
+	bearer, err := auth.CreateAPIToken(token.ID, token.User, token.Role, token.Tenants, token.ExpiresAt)
+	if err != nil {
+		return apitoken.APIToken{}, "", alog.Error(err)
+	}
+	if err := f.apiTokenStore.Put(ctx, &token); err != nil {
+		return apitoken.APIToken{}, "", alog.Error(err)
+	}
+	alog.Succeeded()
+	return token, bearer, nil
+}
+
+// GetAPIToken returns the api token with the given id
+func (f *Facade) GetAPIToken(ctx datastore.Context, id string) (*apitoken.APIToken, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetAPIToken"))
+	return f.apiTokenStore.Get(ctx, id)
+}
+
+// GetAPITokens returns all of the api tokens
+func (f *Facade) GetAPITokens(ctx datastore.Context) ([]apitoken.APIToken, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetAPITokens"))
+	return f.apiTokenStore.GetAPITokens(ctx)
+}
+
+// RemoveAPIToken revokes an api token
+func (f *Facade) RemoveAPIToken(ctx datastore.Context, id string) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.RemoveAPIToken"))
+	alog := f.auditLogger.Message(ctx, "Removing API Token").Action(audit.Remove).Type(apitoken.GetType()).ID(id)
+	return alog.Error(f.apiTokenStore.Delete(ctx, id))
+}
+
+// AuthenticateAPIToken verifies a bearer token and returns the record of the
+// api token it was issued for, with its role lowered to the current role of
+// its user.  Returns auth.ErrNotAPIToken if the bearer token is some other
+// kind of token.
+func (f *Facade) AuthenticateAPIToken(ctx datastore.Context, bearer string) (*apitoken.APIToken, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.AuthenticateAPIToken"))
+	parsed, err := auth.ParseAPIToken(bearer)
+	if err != nil {
+		return nil, err
+	}
+	logger := plog.WithField("tokenid", parsed.ID())
+
+	token, err := f.apiTokenStore.Get(ctx, parsed.ID())
+	if datastore.IsErrNoSuchEntity(err) {
+		logger.Debug("API token has been revoked")
+		return nil, ErrAPITokenRevoked
+	} else if err != nil {
+		return nil, err
+	}
+	if token.Expired() {
+		logger.Debug("API token has expired")
+		return nil, ErrAPITokenExpired
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(token.LastUsed) >= apiTokenLastUsedInterval {
+		token.LastUsed = now
+		if err := f.apiTokenStore.Put(ctx, token); err != nil {
+			// a concurrent request may have updated the token first
+			logger.WithError(err).Debug("Could not update the last used time of the API token")
+		}
+	}
+
+	// the user may have been demoted since the token was created
+	userRole, err := f.getUserLoginRole(ctx, token.User)
+	if err != nil {
+		return nil, err
+	} else if userRole == auth.RoleNone {
+		logger.WithField("user", token.User).Debug("User of the API token can no longer log in")
+		return nil, ErrAPITokenUserNotAllowed
+	}
+	token.Role = lowerRole(token.Role, userRole)
+	return token, nil
+}
+
+// getUserLoginRole returns the role a user logs in with: the role stored for
+// the user, or cluster-admin for a member of the admin group without one.
+// Returns RoleNone for any other user, who cannot log in.
+func (f *Facade) getUserLoginRole(ctx datastore.Context, userName string) (auth.Role, error) {
+	role, err := f.GetUserRole(ctx, userName)
+	if err != nil || role != auth.RoleNone {
+		return role, err
+	}
+	member, err := f.adminGroupCheck(strings.TrimSpace(userName))
+	if err != nil {
+		return auth.RoleNone, err
+	} else if member {
+		return auth.RoleClusterAdmin, nil
+	}
+	return auth.RoleNone, nil
+}
+
+// getCallerRole returns the role of the user of the context.  Requests made
+// with an api token have the role of the token.
+func (f *Facade) getCallerRole(ctx datastore.Context) (auth.Role, error) {
+	name := ctx.User()
+	if id, ok := apitoken.ParsePrincipal(name); ok {
+		token, err := f.apiTokenStore.Get(ctx, id)
+		if datastore.IsErrNoSuchEntity(err) {
+			return auth.RoleNone, ErrAPITokenRevoked
+		} else if err != nil {
+			return auth.RoleNone, err
+		}
+		userRole, err := f.getUserLoginRole(ctx, token.User)
+		if err != nil {
+			return auth.RoleNone, err
+		}
+		return lowerRole(token.Role, userRole), nil
+	}
+	if name == "" || name == datastore.SystemUser || name == SYSTEM_USER_NAME {
+		return auth.RoleClusterAdmin, nil
+	}
+	return f.getUserLoginRole(ctx, name)
+}
+
+// lowerRole returns the less privileged of two roles
+func lowerRole(a, b auth.Role) auth.Role {
+	if a.Satisfies(b) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,211 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/datastore"
+	datastoremocks "github.com/control-center/serviced/datastore/mocks"
+	"github.com/control-center/serviced/domain/apitoken"
+	"github.com/control-center/serviced/domain/user"
+	"github.com/control-center/serviced/facade"
+	"github.com/control-center/serviced/metrics"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeUnitTest) Test_AddAPIToken(c *C) {
+	ft.userStore.On("Get", ft.ctx, user.Key("jenkins"), mock.AnythingOfType("*user.User")).
+		Return(datastore.ErrNoSuchEntity{})
+	ft.apiTokenStore.On("GetAPITokens", ft.ctx).Return([]apitoken.APIToken{}, nil)
+	ft.apiTokenStore.On("Put", ft.ctx, mock.AnythingOfType("*apitoken.APIToken")).Return(nil)
+
+	token, bearer, err := ft.Facade.AddAPIToken(ft.ctx, "jenkins", "ci", auth.RoleOperator, nil, time.Hour)
+
+	c.Assert(err, IsNil)
+	c.Assert(token.ID, Not(Equals), "")
+	c.Assert(token.User, Equals, "jenkins")
+	c.Assert(token.ExpiresAt.After(time.Now()), Equals, true)
+	parsed, err := auth.ParseAPIToken(bearer)
+	c.Assert(err, IsNil)
+	c.Assert(parsed.ID(), Equals, token.ID)
+	c.Assert(parsed.Role(), Equals, auth.RoleOperator)
+}
+
+func (ft *FacadeUnitTest) Test_AddAPITokenAboveUserRole(c *C) {
+	ft.userStore.On("Get", ft.ctx, user.Key("viewer"), mock.AnythingOfType("*user.User")).
+		Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(2).(*user.User) = user.User{Name: "viewer", Role: auth.RoleViewer}
+	})
+
+	_, _, err := ft.Facade.AddAPIToken(ft.ctx, "viewer", "ci", auth.RoleClusterAdmin, nil, 0)
+
+	c.Assert(err, Equals, facade.ErrAPITokenRoleTooHigh)
+	ft.apiTokenStore.AssertNotCalled(c, "Put", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_AddAPITokenAboveCallerRole(c *C) {
+	ctx := &datastoremocks.Context{}
+	ctx.On("Metrics").Return(metrics.NewMetrics())
+	ctx.On("User").Return("operator")
+	ft.userStore.On("Get", ctx, user.Key("operator"), mock.AnythingOfType("*user.User")).
+		Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(2).(*user.User) = user.User{Name: "operator", Role: auth.RoleOperator}
+	})
+	ft.userStore.On("Get", ctx, user.Key("jenkins"), mock.AnythingOfType("*user.User")).
+		Return(datastore.ErrNoSuchEntity{})
+
+	_, _, err := ft.Facade.AddAPIToken(ctx, "jenkins", "ci", auth.RoleTenantAdmin, nil, 0)
+
+	c.Assert(err, Equals, facade.ErrAPITokenRoleTooHigh)
+	ft.apiTokenStore.AssertNotCalled(c, "Put", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_AddAPITokenDuplicateName(c *C) {
+	existing := []apitoken.APIToken{{ID: "token1", Name: "ci", User: "jenkins", Role: auth.RoleViewer}}
+	ft.userStore.On("Get", ft.ctx, user.Key("jenkins"), mock.AnythingOfType("*user.User")).
+		Return(datastore.ErrNoSuchEntity{})
+	ft.apiTokenStore.On("GetAPITokens", ft.ctx).Return(existing, nil)
+
+	_, _, err := ft.Facade.AddAPIToken(ft.ctx, "jenkins", "ci", auth.RoleOperator, nil, 0)
+
+	c.Assert(err, Equals, facade.ErrAPITokenExists)
+	ft.apiTokenStore.AssertNotCalled(c, "Put", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_AddAPITokenOutsideCallerTenants(c *C) {
+	ctx := ft.setupTenantGrants()
+	ft.userStore.On("Get", ctx, user.Key("jenkins"), mock.AnythingOfType("*user.User")).
+		Return(datastore.ErrNoSuchEntity{})
+
+	_, _, err := ft.Facade.AddAPIToken(ctx, "jenkins", "ci", auth.RoleViewer, []string{"grantTenant2"}, 0)
+
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	ft.apiTokenStore.AssertNotCalled(c, "Put", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_AddAPITokenAllTenantsRestrictedCaller(c *C) {
+	ctx := ft.setupTenantGrants()
+	ft.userStore.On("Get", ctx, user.Key("jenkins"), mock.AnythingOfType("*user.User")).
+		Return(datastore.ErrNoSuchEntity{})
+	ft.apiTokenStore.On("GetAPITokens", ctx).Return([]apitoken.APIToken{}, nil)
+	ft.apiTokenStore.On("Put", ctx, mock.AnythingOfType("*apitoken.APIToken")).Return(nil)
+
+	token, _, err := ft.Facade.AddAPIToken(ctx, "jenkins", "ci", auth.RoleViewer, nil, 0)
+
+	c.Assert(err, IsNil)
+	c.Assert(token.Tenants, DeepEquals, []string{"grantTenant1"})
+}
+
+func (ft *FacadeUnitTest) Test_AuthenticateAPIToken(c *C) {
+	bearer, err := auth.CreateAPIToken("token1", "jenkins", auth.RoleOperator, nil, time.Time{})
+	c.Assert(err, IsNil)
+	record := &apitoken.APIToken{ID: "token1", Name: "ci", User: "jenkins", Role: auth.RoleOperator}
+	ft.apiTokenStore.On("Get", ft.ctx, "token1").Return(record, nil)
+	ft.apiTokenStore.On("Put", ft.ctx, record).Return(nil)
+	ft.userStore.On("Get", ft.ctx, user.Key("jenkins"), mock.AnythingOfType("*user.User")).
+		Return(datastore.ErrNoSuchEntity{})
+
+	token, err := ft.Facade.AuthenticateAPIToken(ft.ctx, bearer)
+
+	c.Assert(err, IsNil)
+	c.Assert(token.ID, Equals, "token1")
+	c.Assert(token.Role, Equals, auth.RoleOperator)
+	c.Assert(token.LastUsed.IsZero(), Equals, false)
+	ft.apiTokenStore.AssertCalled(c, "Put", ft.ctx, record)
+}
+
+func (ft *FacadeUnitTest) Test_AuthenticateAPITokenDemotedUser(c *C) {
+	bearer, err := auth.CreateAPIToken("token1", "jenkins", auth.RoleOperator, nil, time.Time{})
+	c.Assert(err, IsNil)
+	record := &apitoken.APIToken{ID: "token1", Name: "ci", User: "jenkins", Role: auth.RoleOperator, LastUsed: time.Now().UTC()}
+	ft.apiTokenStore.On("Get", ft.ctx, "token1").Return(record, nil)
+	ft.userStore.On("Get", ft.ctx, user.Key("jenkins"), mock.AnythingOfType("*user.User")).
+		Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(2).(*user.User) = user.User{Name: "jenkins", Role: auth.RoleViewer}
+	})
+
+	token, err := ft.Facade.AuthenticateAPIToken(ft.ctx, bearer)
+
+	c.Assert(err, IsNil)
+	c.Assert(token.Role, Equals, auth.RoleViewer)
+}
+
+func (ft *FacadeUnitTest) Test_AuthenticateAPITokenRevoked(c *C) {
+	bearer, err := auth.CreateAPIToken("token1", "jenkins", auth.RoleOperator, nil, time.Time{})
+	c.Assert(err, IsNil)
+	ft.apiTokenStore.On("Get", ft.ctx, "token1").Return(nil, datastore.ErrNoSuchEntity{})
+
+	token, err := ft.Facade.AuthenticateAPIToken(ft.ctx, bearer)
+
+	c.Assert(err, Equals, facade.ErrAPITokenRevoked)
+	c.Assert(token, IsNil)
+}
+
+func (ft *FacadeUnitTest) Test_AuthenticateAPITokenNotAPIToken(c *C) {
+	token, err := ft.Facade.AuthenticateAPIToken(ft.ctx, "not-a-token")
+
+	c.Assert(err, Equals, auth.ErrNotAPIToken)
+	c.Assert(token, IsNil)
+}
+
+func (ft *FacadeUnitTest) Test_APITokenTenantScope(c *C) {
+	ft.setupTenantGrants()
+	ctx := &datastoremocks.Context{}
+	ctx.On("Metrics").Return(metrics.NewMetrics())
+	ctx.On("User").Return(apitoken.Principal("token1"))
+	ft.apiTokenStore.On("Get", ctx, "token1").Return(&apitoken.APIToken{
+		ID:      "token1",
+		User:    "admin",
+		Role:    auth.RoleViewer,
+		Tenants: []string{"grantTenant2"},
+	}, nil)
+	ft.userStore.On("Get", ctx, user.Key("admin"), mock.AnythingOfType("*user.User")).
+		Return(datastore.ErrNoSuchEntity{})
+
+	_, err := ft.Facade.GetServiceDetails(ctx, "grantChild1")
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+
+	result, err := ft.Facade.GetServiceDetails(ctx, "grantChild2")
+	c.Assert(err, IsNil)
+	c.Assert(result.ID, Equals, "grantChild2")
+}
+
+func (ft *FacadeUnitTest) Test_AddAPITokenUnknownUser(c *C) {
+	ft.userStore.On("Get", ft.ctx, user.Key("nobody"), mock.AnythingOfType("*user.User")).
+		Return(datastore.ErrNoSuchEntity{})
+
+	_, _, err := ft.Facade.AddAPIToken(ft.ctx, "nobody", "ci", auth.RoleViewer, nil, 0)
+
+	c.Assert(err, Equals, facade.ErrAPITokenUserNotAllowed)
+	ft.apiTokenStore.AssertNotCalled(c, "Put", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_AuthenticateAPITokenUnknownUser(c *C) {
+	bearer, err := auth.CreateAPIToken("token1", "nobody", auth.RoleOperator, nil, time.Time{})
+	c.Assert(err, IsNil)
+	record := &apitoken.APIToken{ID: "token1", Name: "ci", User: "nobody", Role: auth.RoleOperator, LastUsed: time.Now().UTC()}
+	ft.apiTokenStore.On("Get", ft.ctx, "token1").Return(record, nil)
+	ft.userStore.On("Get", ft.ctx, user.Key("nobody"), mock.AnythingOfType("*user.User")).
+		Return(datastore.ErrNoSuchEntity{})
+
+	token, err := ft.Facade.AuthenticateAPIToken(ft.ctx, bearer)
+
+	c.Assert(err, Equals, facade.ErrAPITokenUserNotAllowed)
+	c.Assert(token, IsNil)
+}
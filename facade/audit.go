@@ -0,0 +1,34 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/auditlog"
+)
+
+// GetAuditEvents returns the events of the audit trail that match the query,
+// most recent first.  The audit trail covers every tenant, so users that are
+// restricted to some tenants may not search it.
+func (f *Facade) GetAuditEvents(ctx datastore.Context, query auditlog.Query) ([]auditlog.Event, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetAuditEvents"))
+	grant, err := f.getTenantGrants(ctx)
+	if err != nil {
+		return nil, err
+	} else if grant != nil {
+		plog.WithField("user", grant.principal).Debug("Denied access to the audit trail")
+		return nil, ErrTenantNotAuthorized
+	}
+	return f.auditStore.Search(ctx, query)
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	auditmocks "github.com/control-center/serviced/audit/mocks"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/auditlog"
+	"github.com/control-center/serviced/domain/serviceconfigfile"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/facade"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeUnitTest) Test_GetAuditEvents(c *C) {
+	query := auditlog.Query{User: "oncall", Limit: 10}
+	events := []auditlog.Event{{ID: "event1", User: "oncall", Action: "stop"}}
+	ft.auditStore.On("Search", ft.ctx, query).Return(events, nil)
+
+	result, err := ft.Facade.GetAuditEvents(ft.ctx, query)
+
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, events)
+}
+
+func (ft *FacadeUnitTest) Test_GetAuditEventsRestrictedUser(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	result, err := ft.Facade.GetAuditEvents(ctx, auditlog.Query{})
+
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	c.Assert(result, IsNil)
+	ft.auditStore.AssertNotCalled(c, "Search", mock.Anything, mock.Anything)
+}
+
+// recordChanges replaces the audit logger with one that records the changes
+// attached to audit entries.
+func (ft *FacadeUnitTest) recordChanges() *[]auditlog.Change {
+	changes := &[]auditlog.Change{}
+	logger := &auditmocks.Logger{}
+	logger.On("Message", mock.Anything, mock.AnythingOfType("string")).Return(logger)
+	logger.On("Action", mock.AnythingOfType("string")).Return(logger)
+	logger.On("Type", mock.AnythingOfType("string")).Return(logger)
+	logger.On("ID", mock.AnythingOfType("string")).Return(logger)
+	logger.On("Entity", mock.Anything).Return(logger)
+	logger.On("WithField", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(logger)
+	logger.On("Changes", mock.AnythingOfType("[]auditlog.Change")).Return(logger).Run(func(args mock.Arguments) {
+		*changes = append(*changes, args.Get(0).([]auditlog.Change)...)
+	})
+	logger.On("Error", mock.Anything)
+	logger.On("Succeeded")
+	ft.Facade.SetAuditLogger(logger)
+	return changes
+}
+
+func (ft *FacadeUnitTest) Test_UpdateServiceConfigRecordsChanges(c *C) {
+	changes := ft.recordChanges()
+	stored := servicedefinition.ConfigFile{Filename: "/etc/my.cnf", Owner: "mysql", Permissions: "0644", Content: "port=3306"}
+	ft.configStore.On("Get", ft.ctx, serviceconfigfile.Key("file1"), mock.AnythingOfType("*serviceconfigfile.SvcConfigFile")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			file := args.Get(2).(*serviceconfigfile.SvcConfigFile)
+			*file = serviceconfigfile.SvcConfigFile{ID: "file1", ServicePath: "/tenant", ConfFile: stored}
+		})
+	ft.configStore.On("Put", ft.ctx, serviceconfigfile.Key("file1"), mock.AnythingOfType("*serviceconfigfile.SvcConfigFile")).Return(nil)
+	ft.serviceStore.On("Get", ft.ctx, "tenant").Return(nil, datastore.ErrNoSuchEntity{Key: datastore.NewKey("service", "tenant")})
+
+	updated := stored
+	updated.Content = "port=3307"
+	err := ft.Facade.UpdateServiceConfig(ft.ctx, "file1", updated)
+
+	c.Assert(err, IsNil)
+	c.Assert(*changes, DeepEquals, []auditlog.Change{{Field: "Content", Before: "port=3306", After: "port=3307"}})
+}
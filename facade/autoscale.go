@@ -0,0 +1,168 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/control-center/serviced/audit"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/service"
+)
+
+// autoscaleWindow is the period over which the metric of a scaling policy is
+// averaged.
+var autoscaleWindow = 5 * time.Minute
+
+// autoscaler remembers when each service was last scaled, so that the
+// cooldown of its scaling policy can be honored.  It is kept in memory, so
+// the cooldowns start over when the master restarts.
+type autoscaler struct {
+	mutex    sync.Mutex
+	scaledAt map[string]time.Time
+}
+
+func newAutoscaler() *autoscaler {
+	return &autoscaler{scaledAt: make(map[string]time.Time)}
+}
+
+// inCooldown returns true if the service was scaled less than cooldown ago.
+func (a *autoscaler) inCooldown(serviceID string, cooldown time.Duration, now time.Time) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	scaledAt, ok := a.scaledAt[serviceID]
+	return ok && now.Sub(scaledAt) < cooldown
+}
+
+func (a *autoscaler) scaled(serviceID string, now time.Time) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.scaledAt[serviceID] = now
+}
+
+// AutoscaleServices evaluates the scaling policy of each running service
+// against the metrics backend, and changes the number of instances of the
+// services that are too far from their target.  It returns the number of
+// services that were scaled.
+func (f *Facade) AutoscaleServices(ctx datastore.Context) (int, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.AutoscaleServices"))
+	svcs, err := f.serviceStore.GetServices(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	count := 0
+	for i := range svcs {
+		svc := &svcs[i]
+		if svc.ScalingPolicy == nil || !svc.ScalingPolicy.Enabled {
+			continue
+		}
+		scaled, err := f.autoscaleService(ctx, svc, now)
+		if err != nil {
+			plog.WithError(err).WithFields(log.Fields{
+				"serviceid":   svc.ID,
+				"servicename": svc.Name,
+			}).Warn("Could not autoscale service")
+			continue
+		}
+		if scaled {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// autoscaleService changes the number of instances of a service if its
+// scaling metric is too far from the target.  It returns true if the service
+// was scaled.
+func (f *Facade) autoscaleService(ctx datastore.Context, svc *service.Service, now time.Time) (bool, error) {
+	policy := svc.ScalingPolicy
+	if svc.DesiredState != int(service.SVCRun) || svc.Instances == 0 {
+		return false, nil
+	}
+	if f.autoscaler.inCooldown(svc.ID, policy.GetCooldown(), now) {
+		return false, nil
+	}
+	metric, ok := svc.GetScalingMetric()
+	if !ok {
+		plog.WithFields(log.Fields{
+			"serviceid": svc.ID,
+			"metric":    policy.Metric,
+		}).Debug("Scaling metric is not in the monitoring profile of the service")
+		return false, nil
+	}
+
+	value, err := f.metricsClient.GetServiceMetricAverage(autoscaleWindow, svc.ID, metric.ID, metric.Counter)
+	if err != nil {
+		return false, err
+	}
+	instances := svc.GetScaledInstances(value)
+	if instances == svc.Instances {
+		return false, nil
+	}
+
+	alog := f.auditLogger.Message(ctx, "Autoscale Service").Action(audit.Update).ID(svc.ID).Type(service.GetType()).
+		WithFields(log.Fields{
+			"metric":    policy.Metric,
+			"value":     value,
+			"target":    policy.Target,
+			"instances": svc.Instances,
+			"scaledto":  instances,
+		})
+	if err := f.scaleService(ctx, svc.ID, instances); err != nil {
+		if _, ok := err.(PoolQuotaError); ok {
+			// wait for the cooldown before trying again, rather than on
+			// every pass while the pool is full
+			f.autoscaler.scaled(svc.ID, now)
+			plog.WithError(err).WithFields(log.Fields{
+				"serviceid":   svc.ID,
+				"servicename": svc.Name,
+				"poolid":      svc.PoolID,
+				"instances":   svc.Instances,
+				"scaledto":    instances,
+			}).Warn("Pool quota does not allow the service to be scaled up")
+			alog.Error(err)
+			return false, nil
+		}
+		return false, alog.Error(err)
+	}
+	f.autoscaler.scaled(svc.ID, now)
+	alog.Succeeded()
+
+	plog.WithFields(log.Fields{
+		"serviceid":   svc.ID,
+		"servicename": svc.Name,
+		"metric":      policy.Metric,
+		"value":       value,
+		"target":      policy.Target,
+		"instances":   instances,
+	}).Info("Autoscaled service")
+	return true, nil
+}
+
+// scaleService changes the number of instances of a service.  The service is
+// updated like any other change, so the update is validated against the
+// quota of its pool and recorded in the revisions and audit log of the
+// service.
+func (f *Facade) scaleService(ctx datastore.Context, serviceID string, instances int) error {
+	svc, err := f.GetService(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	svc.Instances = instances
+	return f.UpdateService(ctx, *svc)
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build integration
+
+package facade
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/facade/mocks"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeIntegrationTest) TestFacade_AutoscaleServices(c *C) {
+	svc := service.Service{
+		ID:             "autoscale-service",
+		Name:           "TestFacade_AutoscaleServices",
+		DeploymentID:   "deployment-id",
+		PoolID:         "pool-id",
+		Launch:         "auto",
+		Instances:      1,
+		InstanceLimits: domain.MinMax{Min: 1, Max: 3},
+		DesiredState:   int(service.SVCRun),
+		ScalingPolicy:  &servicedefinition.ScalingPolicy{Enabled: true, Metric: "requests", Target: 100, Cooldown: time.Hour},
+		MonitoringProfile: domain.MonitorProfile{
+			MetricConfigs: []domain.MetricConfig{{ID: "web", Metrics: []domain.Metric{{ID: "requests"}}}},
+		},
+	}
+	c.Assert(ft.Facade.AddService(ft.CTX, svc), IsNil)
+
+	metricsClient := &mocks.MetricsClient{}
+	ft.Facade.SetMetricsClient(metricsClient)
+	metricsClient.On("GetServiceMetricAverage", mock.AnythingOfType("time.Duration"), svc.ID, "requests", false).Return(250.0, nil)
+
+	// scales up, but not past the instance limits
+	count, err := ft.Facade.AutoscaleServices(ft.CTX)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, 1)
+	cursvc, err := ft.Facade.GetService(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(cursvc.Instances, Equals, 3)
+	// automatic scaling is not recorded as a revision of the service
+	revisions, err := ft.Facade.GetServiceRevisions(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(revisions, HasLen, 1)
+
+	// does not scale again during the cooldown
+	count, err = ft.Facade.AutoscaleServices(ft.CTX)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, 0)
+	metricsClient.AssertNumberOfCalls(c, "GetServiceMetricAverage", 1)
+}
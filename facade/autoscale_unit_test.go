@@ -0,0 +1,108 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"errors"
+
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/serviceconfigfile"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/domain/servicerevision"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func autoscaledService(id string, instances int) service.Service {
+	return service.Service{
+		ID:             id,
+		Name:           id,
+		PoolID:         "pool1",
+		Instances:      instances,
+		InstanceLimits: domain.MinMax{Min: 1, Max: 5},
+		DesiredState:   int(service.SVCRun),
+		ScalingPolicy:  &servicedefinition.ScalingPolicy{Enabled: true, Metric: "requests", Target: 100},
+		MonitoringProfile: domain.MonitorProfile{
+			MetricConfigs: []domain.MetricConfig{{ID: "web", Metrics: []domain.Metric{{ID: "requests", Counter: true}}}},
+		},
+	}
+}
+
+func (ft *FacadeUnitTest) Test_AutoscaleServicesSkipsServices(c *C) {
+	noPolicy := autoscaledService("as-nopolicy", 1)
+	noPolicy.ScalingPolicy = nil
+	disabled := autoscaledService("as-disabled", 1)
+	disabled.ScalingPolicy.Enabled = false
+	stopped := autoscaledService("as-stopped", 1)
+	stopped.DesiredState = int(service.SVCStop)
+	missingMetric := autoscaledService("as-missing", 1)
+	missingMetric.ScalingPolicy.Metric = "missing"
+	onTarget := autoscaledService("as-ontarget", 2)
+	noData := autoscaledService("as-nodata", 2)
+
+	svcs := []service.Service{noPolicy, disabled, stopped, missingMetric, onTarget, noData}
+	ft.serviceStore.On("GetServices", ft.ctx).Return(svcs, nil)
+	ft.metricsClient.On("GetServiceMetricAverage", mock.AnythingOfType("time.Duration"), "as-ontarget", "requests", true).Return(105.0, nil)
+	ft.metricsClient.On("GetServiceMetricAverage", mock.AnythingOfType("time.Duration"), "as-nodata", "requests", true).Return(0.0, errors.New("no data found"))
+
+	count, err := ft.Facade.AutoscaleServices(ft.ctx)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, 0)
+	ft.metricsClient.AssertNumberOfCalls(c, "GetServiceMetricAverage", 2)
+	ft.serviceStore.AssertNotCalled(c, "Get", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_AutoscaleServicesStoreError(c *C) {
+	ft.serviceStore.On("GetServices", ft.ctx).Return(nil, errors.New("store error"))
+
+	count, err := ft.Facade.AutoscaleServices(ft.ctx)
+	c.Assert(err, ErrorMatches, "store error")
+	c.Assert(count, Equals, 0)
+}
+
+func (ft *FacadeUnitTest) Test_AutoscaleServicesPoolQuota(c *C) {
+	ft.setupMockDFSLocking()
+	ft.setupMockQuotaPool("quotaPool", 6, 0)
+	svc := autoscaledService("svc1", 2)
+	svc.PoolID = "quotaPool"
+	svc.CPUCommitment = 1
+	ft.serviceStore.On("GetServices", ft.ctx).Return([]service.Service{svc}, nil)
+	ft.metricsClient.On("GetServiceMetricAverage", mock.AnythingOfType("time.Duration"), svc.ID, "requests", true).Return(450.0, nil)
+	ft.serviceStore.On("GetServiceDetails", ft.ctx, svc.ID).
+		Return(&service.ServiceDetails{ID: svc.ID, PoolID: svc.PoolID}, nil)
+	// the service that is scaled is not the one it is compared with
+	loaded, current := svc, svc
+	ft.serviceStore.On("Get", ft.ctx, svc.ID).Return(&loaded, nil).Once()
+	ft.serviceStore.On("Get", ft.ctx, svc.ID).Return(&current, nil)
+	ft.configStore.On("GetConfigFiles", ft.ctx, mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+		Return([]*serviceconfigfile.SvcConfigFile{}, nil)
+	ft.revisionStore.On("GetRevisions", ft.ctx, svc.ID).Return([]servicerevision.Revision{}, nil)
+	ft.revisionStore.On("Put", ft.ctx, mock.AnythingOfType("*servicerevision.Revision")).Return(nil)
+
+	// 5 instances of svc1 and the 2 cores of svc2 would exceed the limit of
+	// the pool
+	count, err := ft.Facade.AutoscaleServices(ft.ctx)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, 0)
+	ft.serviceStore.AssertNotCalled(c, "Put", mock.Anything, mock.Anything)
+
+	// the service is not evaluated again until the cooldown has passed
+	count, err = ft.Facade.AutoscaleServices(ft.ctx)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, 0)
+	ft.metricsClient.AssertNumberOfCalls(c, "GetServiceMetricAverage", 1)
+}
@@ -47,6 +47,12 @@ const (
 	upgradedMarkerFile                = "cc-upgraded"
 )
 
+var (
+	// ErrMissingParentBackup is returned when an incremental backup is
+	// restored without its parents.
+	ErrMissingParentBackup = errors.New("facade: incremental backup is missing its parent backups")
+)
+
 type registryVersionInfo struct {
 	version int
 	rootDir string
@@ -66,21 +72,47 @@ var registryVersionInfos = map[int]registryVersionInfo{
 	},
 }
 
+// BackupOptions describes a backup of all installed applications
+type BackupOptions struct {
+	Filename             string   // the path of the backup file
+	Excludes             []string // the volume paths to leave out of the backup
+	SnapshotSpacePercent int      // the percent of a tenant's volume that must be free to snapshot it
+	Incremental          bool     // only export the changes since the latest incremental backup
+}
+
 // Backup takes a backup of all installed applications
-func (f *Facade) Backup(ctx datastore.Context, w io.Writer, excludes []string, snapshotSpacePercent int, backupFilename string) error {
+//
+// An incremental backup keeps the snapshots it takes, so that the next backup
+// of its chain only exports the changes since.  It follows the latest
+// incremental backup in the directory of the backup file, and only exports the
+// changes since the snapshots of that backup that still exist, which are
+// deleted once the backup is complete.  A new chain is started with a full
+// backup once a chain is IncrementalBackupChain backups long, or if the
+// storage driver cannot export the changes since a snapshot.
+func (f *Facade) Backup(ctx datastore.Context, w io.Writer, opts BackupOptions) error {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.Backup"))
 	// Do not DFSLock here, ControlPlaneDao does that
 	stime := time.Now()
 	message := fmt.Sprintf("started backup at %s", stime.UTC())
-	plog.WithField("excludes", excludes).Info("Started backup")
+	plog.WithField("excludes", opts.Excludes).Info("Started backup")
 	alog := f.auditLogger.Message(ctx, "Started Backup").
 		Action(audit.Backup).
 		WithFields(logrus.Fields{
 				"starttime": stime.UTC().Format("2006-01-02-150405"),
-				"backupfile": backupFilename})
+				"backupfile": opts.Filename})
 	alog.Succeeded()
 	alog = f.auditLogger.Message(ctx, "Completed Backup").
 		Action(audit.Backup)
+	var index *dfs.BackupIndex
+	var parent *dfs.BackupInfo
+	if opts.Incremental {
+		var err error
+		if index, err = dfs.ReadBackupIndex(filepath.Dir(opts.Filename)); err != nil {
+			plog.WithError(err).Debug("Could not read the index of incremental backups")
+			return alog.Error(err)
+		}
+		parent = f.getLatestIncrementalBackup(filepath.Dir(opts.Filename), index)
+	}
 	templates, images, err := f.GetServiceTemplatesAndImages(ctx)
 	if err != nil {
 		plog.WithError(err).Debug("Could not get service templates and images")
@@ -100,16 +132,20 @@ func (f *Facade) Backup(ctx datastore.Context, w io.Writer, excludes []string, s
 	}
 	snapshots := make([]string, len(tenants))
 	snapshotExcludes := map[string][]string{}
+	keepSnapshots := false
 	for i, tenant := range tenants {
 		tenantLogger := plog.WithField("tenant", tenant)
 		tag := fmt.Sprintf("backup-%s-%s", tenant, stime)
-		snapshot, err := f.Snapshot(ctx, tenant, message, []string{tag}, snapshotSpacePercent)
+		snapshot, err := f.Snapshot(ctx, tenant, message, []string{tag}, opts.SnapshotSpacePercent)
 		if err != nil {
 			tenantLogger.WithError(err).Debug("Could not snapshot tenant")
 			return alog.Error(err)
 		}
 
 		defer func(tenant, snapshot, tag string) {
+			if keepSnapshots {
+				return
+			}
 			if err := f.DeleteSnapshot(ctx, snapshot); err != nil {
 				tenantLogger.WithError(err).Warn("Could not delete snapshot; untagging for consumption by TTL")
 				if _, err := f.RemoveSnapshotTag(ctx, tenant, tag); err != nil {
@@ -119,7 +155,7 @@ func (f *Facade) Backup(ctx datastore.Context, w io.Writer, excludes []string, s
 		}(tenant, snapshot, tag)
 
 		snapshots[i] = snapshot
-		snapshotExcludes[snapshot] = append(excludes, f.getExcludedVolumes(ctx, tenant)...)
+		snapshotExcludes[snapshot] = append(opts.Excludes, f.getExcludedVolumes(ctx, tenant)...)
 		tenantLogger.WithField("snapshot", snapshot).Info("Created a snapshot for tenant")
 	}
 	plog.WithField("elapsed", time.Since(stime)).Info("Loaded tenants")
@@ -132,20 +168,120 @@ func (f *Facade) Backup(ctx datastore.Context, w io.Writer, excludes []string, s
 		Timestamp:        stime,
 		BackupVersion:    1,
 	}
+	// the snapshots of the parent that still exist, by tenant
+	var parentSnapshots map[string]string
+	if opts.Incremental {
+		data.Incremental = true
+		data.BackupVersion = dfs.IncrementalBackupVersion
+		if parent != nil {
+			parentSnapshots = f.getExistingSnapshots(parent)
+		}
+		if f.continuesBackupChain(parent, index) {
+			data.Parent = filepath.Base(parent.Filename)
+			data.ParentSnapshots = make(map[string]string)
+			for i, tenant := range tenants {
+				if snapshot, ok := parentSnapshots[tenant]; ok {
+					data.ParentSnapshots[snapshots[i]] = snapshot
+				}
+			}
+			data.ParentImages = make(map[string]string)
+			for image, id := range parent.ParentImages {
+				data.ParentImages[image] = id
+			}
+			for image, id := range parent.Images {
+				data.ParentImages[image] = id
+			}
+		}
+	}
 	plog.WithField("data", data).Info("Calling dfs.Backup")
 	if err := f.dfs.Backup(data, w); err != nil {
 		plog.WithError(err).Debug("Could not backup")
 		return alog.Error(err)
 	}
+	if opts.Incremental {
+		// the snapshots of this backup replace those of its parent
+		keepSnapshots = true
+		for _, snapshot := range parentSnapshots {
+			if err := f.DeleteSnapshot(ctx, snapshot); err != nil {
+				plog.WithError(err).WithField("snapshot", snapshot).Warn("Could not delete snapshot of parent backup")
+			}
+		}
+		index.Add(filepath.Base(opts.Filename), data.Parent)
+		if err := index.Write(filepath.Dir(opts.Filename)); err != nil {
+			plog.WithError(err).Warn("Could not update the index of incremental backups; the next incremental backup will start a new chain")
+		}
+	}
 	duration := time.Since(stime)
 	plog.WithField("duration", duration).Info("Completed backup")
 	alog.WithFields(logrus.Fields{
-				"backupfile": backupFilename,
+				"backupfile": opts.Filename,
 				"elasped": fmt.Sprintf("%fsec", duration.Seconds()),
 			}).Succeeded()
 	return nil
 }
 
+// getLatestIncrementalBackup returns the info of the latest incremental backup
+// in the index of a directory, or nil if there is none.
+func (f *Facade) getLatestIncrementalBackup(dirpath string, index *dfs.BackupIndex) *dfs.BackupInfo {
+	if index.Latest == "" {
+		return nil
+	}
+	filename := filepath.Join(dirpath, index.Latest)
+	info, err := dfs.ExtractBackupInfo(filename)
+	if err != nil {
+		plog.WithError(err).WithField("backupfile", filename).Warn("Could not read the latest incremental backup, starting a new chain")
+		return nil
+	}
+	return info
+}
+
+// continuesBackupChain returns true if an incremental backup can be based on
+// the given parent, rather than starting a new chain with a full backup.
+func (f *Facade) continuesBackupChain(parent *dfs.BackupInfo, index *dfs.BackupIndex) bool {
+	if parent == nil {
+		return false
+	}
+	logger := plog.WithField("parent", filepath.Base(parent.Filename))
+	if !f.dfs.IncrementalExport() {
+		logger.Info("Storage driver cannot export the changes since a snapshot, starting a new chain of incremental backups")
+		return false
+	}
+	limit := config.GetOptions().IncrementalBackupChain
+	if length := index.Backups[filepath.Base(parent.Filename)].Length; limit > 0 && length >= limit {
+		logger.WithField("length", length).Info("Chain of incremental backups is complete, starting a new chain")
+		return false
+	}
+	return true
+}
+
+// getExistingSnapshots returns the snapshots of a backup that still exist, by
+// tenant.
+func (f *Facade) getExistingSnapshots(backup *dfs.BackupInfo) map[string]string {
+	snapshots := make(map[string]string)
+	for _, snapshot := range backup.Snapshots {
+		info, err := f.dfs.Info(snapshot)
+		if err != nil {
+			plog.WithError(err).WithField("snapshot", snapshot).Info("Snapshot of parent backup is gone")
+			continue
+		}
+		snapshots[info.TenantID] = snapshot
+	}
+	return snapshots
+}
+
+// RecordScheduledBackup writes an audit event for a run of the backup schedule
+// of the master.  Unlike the events written by Backup, it is also written when
+// the backup is refused before it starts, such as for lack of space.
+func (f *Facade) RecordScheduledBackup(ctx datastore.Context, backupFilename string, err error) {
+	alog := f.auditLogger.Message(ctx, "Scheduled Backup").
+		Action(audit.Backup).
+		WithField("backupfile", backupFilename)
+	if err != nil {
+		alog = alog.WithField("error", err.Error())
+	}
+	alog.Error(err)
+}
+
 // EstimateBackup estimates storage requirements to take a backup of all installed applications
 func (f *Facade) EstimateBackup(ctx datastore.Context, request dao.BackupRequest, estimate *dao.BackupEstimate) error {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.EstimateBackup"))
@@ -575,7 +711,12 @@ func (f *Facade) markLocalDockerRegistryUpgraded(version int) error {
 }
 
 // Restore restores application data from a backup.
-func (f *Facade) Restore(ctx datastore.Context, r io.Reader, backupInfo *dfs.BackupInfo, backupFilename string) error {
+//
+// An incremental backup is restored on top of its parents, which must have
+// been loaded with RestoreParent first, oldest first.  The snapshots loaded
+// from the parents are deleted once the restore is complete, while those of
+// an incremental backup are kept for the next backup of its chain.
+func (f *Facade) Restore(ctx datastore.Context, r io.Reader, backupInfo *dfs.BackupInfo, backupFilename string, parents []*dfs.BackupInfo) error {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.Restore"))
 	// Do not DFSLock here, ControlPlaneDao does that
 	stime := time.Now()
@@ -586,6 +727,10 @@ func (f *Facade) Restore(ctx datastore.Context, r io.Reader, backupInfo *dfs.Bac
 				"starttime": stime.UTC().Format("2006-01-02-150405"),
 			})
 	alog.Succeeded()
+	if backupInfo.Parent != "" && len(parents) == 0 {
+		plog.WithField("parent", backupInfo.Parent).Debug("Could not restore incremental backup without its parents")
+		return alog.Error(ErrMissingParentBackup)
+	}
 	if err := f.dfs.Restore(r, backupInfo.BackupVersion); err != nil {
 		plog.WithError(err).Debug("Could not restore from backup")
 		return alog.Error(err)
@@ -607,24 +752,17 @@ func (f *Facade) Restore(ctx datastore.Context, r io.Reader, backupInfo *dfs.Bac
 			return alog.Error(err)
 		} else {
 			logger.Info("Rolled back snapshot")
-			if err := f.dfs.Delete(snapshot); err != nil {
-				// if we couldn't delete, untag it so the TTL reaper will get it eventually
-				info, err := f.dfs.Info(snapshot)
-				if err != nil {
-					logger.WithError(err).Warning("Could not get info for snapshot.")
-				} else if len(info.Tags) > 0 {
-					if _, err := f.dfs.Untag(info.TenantID, info.Tags[0]); err != nil {
-						logger.WithError(err).Warning("Could not untag snapshot.  Snapshot must be deleted manually!")
-					} else {
-						logger.Info("Snapshot from backup untagged.")
-					}
-				}
-			} else {
-				logger.Info("Removed snapshot after rollback")
+			if !backupInfo.Incremental {
+				f.removeRestoredSnapshot(snapshot)
 			}
 		}
 
 	}
+	for _, parent := range parents {
+		for _, snapshot := range parent.Snapshots {
+			f.removeRestoredSnapshot(snapshot)
+		}
+	}
 	restoreDuration := time.Since(stime)
 	plog.Info("Completed restore from backup")
 	alog = f.auditLogger.Message(ctx, "Completed Restoring from Backup").Action(audit.Restore).
@@ -636,6 +774,42 @@ func (f *Facade) Restore(ctx datastore.Context, r io.Reader, backupInfo *dfs.Bac
 	return nil
 }
 
+// RestoreParent loads the snapshots and images of a parent of an incremental
+// backup, without rolling back to them.
+func (f *Facade) RestoreParent(ctx datastore.Context, r io.Reader, backupInfo *dfs.BackupInfo, backupFilename string) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.RestoreParent"))
+	// Do not DFSLock here, ControlPlaneDao does that
+	plog.WithField("backupfile", backupFilename).Info("Loading parent backup")
+	alog := f.auditLogger.Message(ctx, "Loaded Parent Backup").Action(audit.Restore).
+		WithField("backupfile", backupFilename)
+	if err := f.dfs.Restore(r, backupInfo.BackupVersion); err != nil {
+		plog.WithError(err).Debug("Could not load parent backup")
+		return alog.Error(err)
+	}
+	alog.Succeeded()
+	return nil
+}
+
+// removeRestoredSnapshot deletes a snapshot that was loaded from a backup
+func (f *Facade) removeRestoredSnapshot(snapshot string) {
+	logger := plog.WithField("snapshot", snapshot)
+	if err := f.dfs.Delete(snapshot); err != nil {
+		// if we couldn't delete, untag it so the TTL reaper will get it eventually
+		info, err := f.dfs.Info(snapshot)
+		if err != nil {
+			logger.WithError(err).Warning("Could not get info for snapshot.")
+		} else if len(info.Tags) > 0 {
+			if _, err := f.dfs.Untag(info.TenantID, info.Tags[0]); err != nil {
+				logger.WithError(err).Warning("Could not untag snapshot.  Snapshot must be deleted manually!")
+			} else {
+				logger.Info("Snapshot from backup untagged.")
+			}
+		}
+	} else {
+		logger.Info("Removed snapshot after rollback")
+	}
+}
+
 // Rollback rolls back an application to state described in the provided
 // snapshot.
 func (f *Facade) Rollback(ctx datastore.Context, snapshotID string, force bool) error {
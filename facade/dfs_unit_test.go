@@ -0,0 +1,184 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/control-center/serviced/config"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/dfs"
+	"github.com/control-center/serviced/domain/pool"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/servicetemplate"
+	"github.com/control-center/serviced/facade"
+	"github.com/control-center/serviced/volume"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+// writeTestBackup writes a backup file that only contains its metadata
+func writeTestBackup(c *C, filename string, info dfs.BackupInfo) {
+	data, err := json.Marshal(info)
+	c.Assert(err, IsNil)
+	fh, err := os.Create(filename)
+	c.Assert(err, IsNil)
+	defer fh.Close()
+	gz := gzip.NewWriter(fh)
+	defer gz.Close()
+	tarfile := tar.NewWriter(gz)
+	defer tarfile.Close()
+	c.Assert(tarfile.WriteHeader(&tar.Header{Name: dfs.BackupMetadataFile, Size: int64(len(data)), Mode: 0644}), IsNil)
+	_, err = tarfile.Write(data)
+	c.Assert(err, IsNil)
+}
+
+// setupIncrementalBackup mocks a system without tenants, whose previous
+// incremental backup has the given chain length and kept a snapshot.  It
+// returns the directory of the backups.
+func (ft *FacadeUnitTest) setupIncrementalBackup(c *C, length int) string {
+	dir := c.MkDir()
+	writeTestBackup(c, filepath.Join(dir, "backup-1.tgz"), dfs.BackupInfo{
+		Incremental:   true,
+		Snapshots:     []string{"tenant1_old"},
+		Images:        map[string]string{"image1": "id1"},
+		BackupVersion: dfs.IncrementalBackupVersion,
+	})
+	index, err := dfs.ReadBackupIndex(dir)
+	c.Assert(err, IsNil)
+	index.Backups["backup-1.tgz"] = dfs.IncrementalBackup{Length: length}
+	index.Latest = "backup-1.tgz"
+	c.Assert(index.Write(dir), IsNil)
+
+	ft.templateStore.On("GetServiceTemplates", ft.ctx).Return([]*servicetemplate.ServiceTemplate{}, nil)
+	ft.poolStore.On("GetResourcePools", ft.ctx).Return([]pool.ResourcePool{}, nil)
+	ft.serviceStore.On("GetServiceDetailsByParentID", ft.ctx, "", time.Duration(0)).Return([]service.ServiceDetails{}, nil)
+	ft.dfs.On("Info", "tenant1_old").Return(&dfs.SnapshotInfo{SnapshotInfo: &volume.SnapshotInfo{TenantID: "tenant1"}}, nil)
+	ft.dfs.On("Delete", "tenant1_old").Return(nil)
+	return dir
+}
+
+func (ft *FacadeUnitTest) Test_BackupIncrementalFollowsLatest(c *C) {
+	dir := ft.setupIncrementalBackup(c, 1)
+	ft.dfs.On("IncrementalExport").Return(true)
+	var data dfs.BackupInfo
+	ft.dfs.On("Backup", mock.AnythingOfType("dfs.BackupInfo"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		data = args.Get(0).(dfs.BackupInfo)
+	})
+
+	err := ft.Facade.Backup(ft.ctx, ioutil.Discard, facade.BackupOptions{Filename: filepath.Join(dir, "backup-2.tgz"), Incremental: true})
+	c.Assert(err, IsNil)
+	c.Assert(data.Parent, Equals, "backup-1.tgz")
+	c.Assert(data.ParentImages, DeepEquals, map[string]string{"image1": "id1"})
+	ft.dfs.AssertCalled(c, "Delete", "tenant1_old")
+
+	index, err := dfs.ReadBackupIndex(dir)
+	c.Assert(err, IsNil)
+	c.Assert(index.Latest, Equals, "backup-2.tgz")
+	c.Assert(index.Backups["backup-2.tgz"], Equals, dfs.IncrementalBackup{Parent: "backup-1.tgz", Length: 2})
+}
+
+func (ft *FacadeUnitTest) Test_BackupIncrementalChainComplete(c *C) {
+	defer config.LoadOptions(config.GetOptions())
+	options := config.GetOptions()
+	options.IncrementalBackupChain = 3
+	config.LoadOptions(options)
+
+	dir := ft.setupIncrementalBackup(c, 3)
+	ft.dfs.On("IncrementalExport").Return(true)
+	var data dfs.BackupInfo
+	ft.dfs.On("Backup", mock.AnythingOfType("dfs.BackupInfo"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		data = args.Get(0).(dfs.BackupInfo)
+	})
+
+	err := ft.Facade.Backup(ft.ctx, ioutil.Discard, facade.BackupOptions{Filename: filepath.Join(dir, "backup-2.tgz"), Incremental: true})
+	c.Assert(err, IsNil)
+	c.Assert(data.Incremental, Equals, true)
+	c.Assert(data.Parent, Equals, "")
+	c.Assert(data.ParentImages, HasLen, 0)
+	// the snapshots of the previous chain are no longer needed
+	ft.dfs.AssertCalled(c, "Delete", "tenant1_old")
+
+	index, err := dfs.ReadBackupIndex(dir)
+	c.Assert(err, IsNil)
+	c.Assert(index.Backups["backup-2.tgz"], Equals, dfs.IncrementalBackup{Length: 1})
+}
+
+func (ft *FacadeUnitTest) Test_BackupIncrementalUnsupportedDriver(c *C) {
+	dir := ft.setupIncrementalBackup(c, 1)
+	ft.dfs.On("IncrementalExport").Return(false)
+	var data dfs.BackupInfo
+	ft.dfs.On("Backup", mock.AnythingOfType("dfs.BackupInfo"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		data = args.Get(0).(dfs.BackupInfo)
+	})
+
+	err := ft.Facade.Backup(ft.ctx, ioutil.Discard, facade.BackupOptions{Filename: filepath.Join(dir, "backup-2.tgz"), Incremental: true})
+	c.Assert(err, IsNil)
+	c.Assert(data.Parent, Equals, "")
+	c.Assert(data.ParentSnapshots, HasLen, 0)
+
+	index, err := dfs.ReadBackupIndex(dir)
+	c.Assert(err, IsNil)
+	c.Assert(index.Backups["backup-2.tgz"], Equals, dfs.IncrementalBackup{Length: 1})
+}
+
+func (ft *FacadeUnitTest) Test_RestoreIncrementalWithoutParents(c *C) {
+	info := &dfs.BackupInfo{
+		Incremental:   true,
+		Parent:        "backup-1.tgz",
+		BackupVersion: dfs.IncrementalBackupVersion,
+	}
+	err := ft.Facade.Restore(ft.ctx, &bytes.Buffer{}, info, "/backups/backup-2.tgz", nil)
+	c.Assert(err, Equals, facade.ErrMissingParentBackup)
+	ft.dfs.AssertNotCalled(c, "Restore", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_RestoreIncrementalRemovesParentSnapshots(c *C) {
+	info := &dfs.BackupInfo{
+		Incremental:   true,
+		Parent:        "backup-1.tgz",
+		BackupVersion: dfs.IncrementalBackupVersion,
+	}
+	parents := []*dfs.BackupInfo{
+		{Incremental: true, Snapshots: []string{"tenant1_parent"}, BackupVersion: dfs.IncrementalBackupVersion},
+	}
+	ft.dfs.On("Restore", mock.Anything, dfs.IncrementalBackupVersion).Return(nil)
+	ft.templateStore.On("GetServiceTemplates", ft.ctx).Return([]*servicetemplate.ServiceTemplate{}, nil)
+	ft.dfs.On("Delete", "tenant1_parent").Return(nil)
+	reloader := facade.LogstashContainerReloader
+	defer func() { facade.LogstashContainerReloader = reloader }()
+	facade.LogstashContainerReloader = func(datastore.Context, facade.FacadeInterface) error { return nil }
+
+	err := ft.Facade.Restore(ft.ctx, &bytes.Buffer{}, info, "/backups/backup-2.tgz", parents)
+	c.Assert(err, IsNil)
+	ft.dfs.AssertCalled(c, "Delete", "tenant1_parent")
+}
+
+func (ft *FacadeUnitTest) Test_RestoreParent(c *C) {
+	info := &dfs.BackupInfo{Incremental: true, BackupVersion: dfs.IncrementalBackupVersion}
+	ft.dfs.On("Restore", mock.Anything, dfs.IncrementalBackupVersion).Return(nil)
+
+	err := ft.Facade.RestoreParent(ft.ctx, &bytes.Buffer{}, info, "/backups/backup-1.tgz")
+	c.Assert(err, IsNil)
+	ft.dfs.AssertCalled(c, "Restore", mock.Anything, dfs.IncrementalBackupVersion)
+}
@@ -0,0 +1,330 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/servicedefinition"
+)
+
+// serviceLiveFields are the fields of a service whose changes take effect
+// without restarting its running instances.
+var serviceLiveFields = map[string]bool{
+	"Name":                   true,
+	"Title":                  true,
+	"Description":            true,
+	"Tags":                   true,
+	"Instances":              true,
+	"InstanceLimits":         true,
+	"DesiredState":           true,
+	"Launch":                 true,
+	"ChangeOptions":          true,
+	"RAMCommitment":          true,
+	"RAMThreshold":           true,
+	"CPUCommitment":          true,
+	"StartLevel":             true,
+	"EmergencyShutdownLevel": true,
+	"MonitoringProfile":      true,
+	"Actions":                true,
+}
+
+// PlanServiceUpdate returns the changes that updating a service would make,
+// without making them.  The update is validated the same way as UpdateService.
+func (f *Facade) PlanServiceUpdate(ctx datastore.Context, svc service.Service) (*service.ChangePlan, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.PlanServiceUpdate"))
+	tenantID, err := f.GetTenantID(ctx, svc.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.authorizeTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	mutex := getTenantLock(tenantID)
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	cursvc, err := f.validateServiceUpdate(ctx, &svc)
+	if err != nil {
+		return nil, err
+	}
+	change, err := f.planServiceUpdate(ctx, cursvc, &svc)
+	if err != nil {
+		return nil, err
+	}
+	return &service.ChangePlan{Services: []service.PlannedChange{*change}}, nil
+}
+
+// PlanTemplateDeploy returns the services that deploying a template would
+// create, without creating them.  The deployment is validated the same way as
+// DeployTemplate, except that images are not downloaded.
+func (f *Facade) PlanTemplateDeploy(ctx datastore.Context, poolID string, templateID string, deploymentID string) (*service.ChangePlan, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.PlanTemplateDeploy"))
+	template, err := f.templateStore.Get(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if svcs, err := f.serviceStore.GetServicesByDeployment(ctx, deploymentID); err != nil {
+		return nil, err
+	} else if len(svcs) > 0 {
+		return nil, fmt.Errorf("deployment ID %s is already in use", deploymentID)
+	}
+	pool, err := f.GetResourcePool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, fmt.Errorf("poolid %s not found", poolID)
+	}
+
+	plan := &service.ChangePlan{}
+	planned := make(map[string]*service.Service)
+	for _, sd := range template.Services {
+		if err := f.planServiceDeploy(ctx, "", deploymentID, poolID, sd, planned, plan); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// planServiceDeploy adds the services that deploying a service definition
+// would create to the plan.  Planned services are kept so that the endpoint
+// templates of their children can refer to them.
+func (f *Facade) planServiceDeploy(ctx datastore.Context, parentServiceID, deploymentID, poolID string, svcDef servicedefinition.ServiceDefinition, planned map[string]*service.Service, plan *service.ChangePlan) error {
+	newsvc, err := service.BuildService(svcDef, parentServiceID, poolID, int(service.SVCStop), deploymentID)
+	if err != nil {
+		return err
+	}
+
+	getService := func(serviceID string) (service.Service, error) {
+		if s, ok := planned[serviceID]; ok {
+			return *s, nil
+		}
+		s, err := f.GetService(ctx, serviceID)
+		if err != nil {
+			return service.Service{}, err
+		}
+		return *s, nil
+	}
+	findChildService := func(parentID, serviceName string) (service.Service, error) {
+		for _, s := range planned {
+			if s.ParentServiceID == parentID && s.Name == serviceName {
+				return *s, nil
+			}
+		}
+		s, err := f.FindChildService(ctx, parentID, serviceName)
+		if err != nil {
+			return service.Service{}, err
+		}
+		return *s, nil
+	}
+	if err := newsvc.EvaluateEndpointTemplates(getService, findChildService, 0); err != nil {
+		return err
+	}
+
+	// validation disables public endpoints that are in use, so note the ones
+	// that were asked for first
+	requested := planPublicEndpoints(nil, newsvc.Endpoints)
+	if parentServiceID == "" {
+		err = f.validateServiceAdd(ctx, newsvc)
+	} else {
+		// the parent is only planned, so its name cannot collide
+		err = f.validateNewService(ctx, newsvc)
+	}
+	if err != nil {
+		return err
+	}
+	planned[newsvc.ID] = newsvc
+
+	change := service.PlannedChange{
+		ServiceID: newsvc.ID,
+		Name:      newsvc.Name,
+		Action:    service.PlanAdd,
+	}
+	change.AddressAssignments = planAddressAssignments(nil, newsvc.Endpoints)
+	change.PublicEndpoints = planPublicEndpoints(nil, newsvc.Endpoints)
+	change.Warnings = planPublicEndpointConflicts(requested, change.PublicEndpoints)
+	plan.Services = append(plan.Services, change)
+
+	for _, sd := range svcDef.Services {
+		if err := f.planServiceDeploy(ctx, newsvc.ID, deploymentID, poolID, sd, planned, plan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planServiceUpdate compares a validated update to the stored service and
+// the instances that are running.
+func (f *Facade) planServiceUpdate(ctx datastore.Context, cursvc, svc *service.Service) (*service.PlannedChange, error) {
+	change := &service.PlannedChange{
+		ServiceID: svc.ID,
+		Name:      svc.Name,
+		Action:    service.PlanUpdate,
+		Changes:   f.getServiceDiff(ctx, *svc),
+	}
+	change.AddressAssignments = planAddressAssignments(cursvc.Endpoints, svc.Endpoints)
+	change.PublicEndpoints = planPublicEndpoints(cursvc.Endpoints, svc.Endpoints)
+
+	states, err := f.zzk.GetServiceStates(ctx, cursvc.PoolID, cursvc.ID)
+	if err != nil {
+		return nil, err
+	}
+	running := make([]int, len(states))
+	for i, state := range states {
+		running[i] = state.InstanceID
+	}
+	sort.Ints(running)
+	if len(running) == 0 {
+		return change, nil
+	}
+
+	if svc.DesiredState == int(service.SVCStop) && cursvc.DesiredState != int(service.SVCStop) {
+		change.StopInstances = running
+		return change, nil
+	} else if svc.PoolID != cursvc.PoolID {
+		change.RestartInstances = running
+		change.Warnings = append(change.Warnings, fmt.Sprintf("instances move from pool %s to pool %s", cursvc.PoolID, svc.PoolID))
+		return change, nil
+	} else if svc.Instances != cursvc.Instances {
+		if servicedefinition.ChangeOptions(svc.ChangeOptions).Contains(servicedefinition.RestartAllOnInstanceChanged) {
+			change.RestartInstances = running
+			return change, nil
+		} else if svc.Instances < len(running) {
+			change.StopInstances = running[svc.Instances:]
+		} else {
+			change.StartInstances = svc.Instances - len(running)
+		}
+	}
+
+	for _, c := range change.Changes {
+		if !isLiveServiceField(c.Field) {
+			change.RestartRequired = true
+			break
+		}
+	}
+	return change, nil
+}
+
+// isLiveServiceField returns true if a change to the field, given as a path
+// from audit.Diff, takes effect without restarting the service.
+func isLiveServiceField(field string) bool {
+	name := field
+	if i := strings.IndexAny(name, ".["); i >= 0 {
+		name = name[:i]
+	}
+	if name == "Endpoints" {
+		// public endpoints are managed by the coordinator
+		return strings.Contains(field, ".VHostList") || strings.Contains(field, ".PortList")
+	}
+	return serviceLiveFields[name]
+}
+
+// planAddressAssignments returns the address assignments that would be
+// required or released by changing the endpoints of a service.
+func planAddressAssignments(before, after []service.ServiceEndpoint) []service.EndpointChange {
+	addresses := func(endpoints []service.ServiceEndpoint) map[string]string {
+		result := make(map[string]string)
+		for _, ep := range endpoints {
+			if ep.AddressConfig.Port > 0 {
+				result[ep.Name] = fmt.Sprintf("%s:%d", ep.AddressConfig.Protocol, ep.AddressConfig.Port)
+			}
+		}
+		return result
+	}
+	cur, next := addresses(before), addresses(after)
+
+	var changes []service.EndpointChange
+	for _, ep := range after {
+		if addr, ok := next[ep.Name]; ok && cur[ep.Name] != addr {
+			changes = append(changes, service.EndpointChange{Endpoint: ep.Name, Type: "address", Name: addr, Action: service.PlanAdd})
+		}
+	}
+	for _, ep := range before {
+		if addr, ok := cur[ep.Name]; ok && next[ep.Name] != addr {
+			changes = append(changes, service.EndpointChange{Endpoint: ep.Name, Type: "address", Name: addr, Action: service.PlanRemove})
+		}
+	}
+	return changes
+}
+
+// planPublicEndpoints returns the vhosts and ports that would be added,
+// removed, enabled or disabled by changing the endpoints of a service.
+func planPublicEndpoints(before, after []service.ServiceEndpoint) []service.EndpointChange {
+	type publicEndpoint struct {
+		endpoint, kind, name string
+	}
+	collect := func(endpoints []service.ServiceEndpoint) ([]publicEndpoint, map[publicEndpoint]bool) {
+		var order []publicEndpoint
+		enabled := make(map[publicEndpoint]bool)
+		for _, ep := range endpoints {
+			for _, vhost := range ep.VHostList {
+				key := publicEndpoint{ep.Name, "vhost", vhost.Name}
+				order = append(order, key)
+				enabled[key] = vhost.Enabled
+			}
+			for _, port := range ep.PortList {
+				key := publicEndpoint{ep.Name, "port", port.PortAddr}
+				order = append(order, key)
+				enabled[key] = port.Enabled
+			}
+		}
+		return order, enabled
+	}
+	curOrder, cur := collect(before)
+	nextOrder, next := collect(after)
+
+	var changes []service.EndpointChange
+	for _, key := range nextOrder {
+		action := ""
+		if wasEnabled, ok := cur[key]; !ok {
+			if next[key] {
+				action = service.PlanAdd
+			}
+		} else if !wasEnabled && next[key] {
+			action = service.PlanEnable
+		} else if wasEnabled && !next[key] {
+			action = service.PlanDisable
+		}
+		if action != "" {
+			changes = append(changes, service.EndpointChange{Endpoint: key.endpoint, Type: key.kind, Name: key.name, Action: action})
+		}
+	}
+	for _, key := range curOrder {
+		if _, ok := next[key]; !ok && cur[key] {
+			changes = append(changes, service.EndpointChange{Endpoint: key.endpoint, Type: key.kind, Name: key.name, Action: service.PlanRemove})
+		}
+	}
+	return changes
+}
+
+// planPublicEndpointConflicts returns a warning for each requested vhost or
+// port that validation disabled because it is in use by another application.
+func planPublicEndpointConflicts(requested, validated []service.EndpointChange) []string {
+	enabled := make(map[service.EndpointChange]bool)
+	for _, change := range validated {
+		enabled[change] = true
+	}
+	var warnings []string
+	for _, change := range requested {
+		if !enabled[change] {
+			warnings = append(warnings, fmt.Sprintf("%s %s of endpoint %s is already in use and will be disabled", change.Type, change.Name, change.Endpoint))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,156 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build integration
+
+package facade
+
+import (
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/pool"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/domain/servicetemplate"
+	zks "github.com/control-center/serviced/zzk/service"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeIntegrationTest) TestFacade_PlanServiceUpdate(c *C) {
+	svc := service.Service{
+		ID:           "plan-update-service",
+		Name:         "TestFacade_PlanServiceUpdate",
+		DeploymentID: "deployment-id",
+		PoolID:       "pool-id",
+		Launch:       "auto",
+		Instances:    3,
+		DesiredState: int(service.SVCRun),
+		Environment:  []string{"A=1"},
+	}
+	c.Assert(ft.Facade.AddService(ft.CTX, svc), IsNil)
+	states := []zks.State{
+		{ServiceID: svc.ID, InstanceID: 0},
+		{ServiceID: svc.ID, InstanceID: 2},
+		{ServiceID: svc.ID, InstanceID: 1},
+	}
+	ft.zzk.On("GetServiceStates", ft.CTX, svc.PoolID, svc.ID).Return(states, nil)
+
+	// reducing the instances stops the highest instances without a restart
+	cursvc, err := ft.Facade.GetService(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	cursvc.Instances = 1
+	plan, err := ft.Facade.PlanServiceUpdate(ft.CTX, *cursvc)
+	c.Assert(err, IsNil)
+	c.Assert(plan.Services, HasLen, 1)
+	change := plan.Services[0]
+	c.Assert(change.Action, Equals, service.PlanUpdate)
+	c.Assert(change.Changes, HasLen, 1)
+	c.Assert(change.Changes[0].Field, Equals, "Instances")
+	c.Assert(change.StopInstances, DeepEquals, []int{1, 2})
+	c.Assert(change.RestartInstances, HasLen, 0)
+	c.Assert(change.RestartRequired, Equals, false)
+
+	// restart all instances when the change options require it
+	cursvc.ChangeOptions = []servicedefinition.ChangeOption{servicedefinition.RestartAllOnInstanceChanged}
+	plan, err = ft.Facade.PlanServiceUpdate(ft.CTX, *cursvc)
+	c.Assert(err, IsNil)
+	c.Assert(plan.Services[0].RestartInstances, DeepEquals, []int{0, 1, 2})
+	c.Assert(plan.Services[0].StopInstances, HasLen, 0)
+
+	// changing the environment requires a restart
+	cursvc, err = ft.Facade.GetService(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	cursvc.Environment = []string{"A=2"}
+	plan, err = ft.Facade.PlanServiceUpdate(ft.CTX, *cursvc)
+	c.Assert(err, IsNil)
+	c.Assert(plan.Services[0].RestartRequired, Equals, true)
+
+	// nothing is written
+	cursvc, err = ft.Facade.GetService(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(cursvc.Instances, Equals, 3)
+	c.Assert(cursvc.Environment, DeepEquals, []string{"A=1"})
+	revisions, err := ft.Facade.GetServiceRevisions(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(revisions, HasLen, 1)
+}
+
+func (ft *FacadeIntegrationTest) TestFacade_PlanTemplateDeploy(c *C) {
+	c.Assert(ft.Facade.AddResourcePool(ft.CTX, &pool.ResourcePool{ID: "plan-pool"}), IsNil)
+	template := servicetemplate.ServiceTemplate{
+		Name: "plan-template",
+		Services: []servicedefinition.ServiceDefinition{
+			{
+				Name:      "parent",
+				Launch:    "manual",
+				Instances: domain.MinMax{Min: 1, Max: 1, Default: 1},
+				Endpoints: []servicedefinition.EndpointDefinition{
+					{
+						Name:        "www",
+						Application: "www",
+						Purpose:     "export",
+						Protocol:    "tcp",
+						PortNumber:  8080,
+						VHostList:   []servicedefinition.VHost{{Name: "plan-vhost", Enabled: true}},
+					},
+				},
+				Services: []servicedefinition.ServiceDefinition{
+					{
+						Name:      "child",
+						Launch:    "auto",
+						Instances: domain.MinMax{Min: 1, Max: 1, Default: 1},
+						Endpoints: []servicedefinition.EndpointDefinition{
+							{
+								Name:          "db",
+								Application:   "db",
+								Purpose:       "export",
+								Protocol:      "tcp",
+								PortNumber:    3306,
+								AddressConfig: servicedefinition.AddressResourceConfig{Port: 3306, Protocol: "tcp"},
+								PortList:      []servicedefinition.Port{{PortAddr: ":3306", Enabled: true}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	templateID, err := ft.Facade.AddServiceTemplate(ft.CTX, template, false)
+	c.Assert(err, IsNil)
+	ft.zzk.On("GetVHost", "plan-vhost").Return("", "", nil)
+	ft.zzk.On("GetPublicPort", ":3306").Return("other-service", "other", nil)
+
+	plan, err := ft.Facade.PlanTemplateDeploy(ft.CTX, "plan-pool", templateID, "plan-deployment")
+	c.Assert(err, IsNil)
+	c.Assert(plan.Services, HasLen, 2)
+
+	parent := plan.Services[0]
+	c.Assert(parent.Name, Equals, "parent")
+	c.Assert(parent.Action, Equals, service.PlanAdd)
+	c.Assert(parent.PublicEndpoints, DeepEquals, []service.EndpointChange{
+		{Endpoint: "www", Type: "vhost", Name: "plan-vhost", Action: service.PlanAdd},
+	})
+	c.Assert(parent.Warnings, HasLen, 0)
+
+	child := plan.Services[1]
+	c.Assert(child.Name, Equals, "child")
+	c.Assert(child.AddressAssignments, DeepEquals, []service.EndpointChange{
+		{Endpoint: "db", Type: "address", Name: "tcp:3306", Action: service.PlanAdd},
+	})
+	c.Assert(child.PublicEndpoints, HasLen, 0)
+	c.Assert(child.Warnings, DeepEquals, []string{"port :3306 of endpoint db is already in use and will be disabled"})
+
+	// nothing is deployed
+	svcs, err := ft.Facade.serviceStore.GetServicesByDeployment(ft.CTX, "plan-deployment")
+	c.Assert(err, IsNil)
+	c.Assert(svcs, HasLen, 0)
+}
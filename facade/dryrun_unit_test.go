@@ -0,0 +1,46 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/servicetemplate"
+	"github.com/control-center/serviced/facade"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeUnitTest) Test_PlanServiceUpdateUnauthorizedTenant(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	plan, err := ft.Facade.PlanServiceUpdate(ctx, service.Service{ID: "grantChild2"})
+
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	c.Assert(plan, IsNil)
+	ft.zzk.AssertNotCalled(c, "GetServiceStates", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_PlanTemplateDeployDeploymentInUse(c *C) {
+	template := &servicetemplate.ServiceTemplate{ID: "template1", Name: "template1"}
+	ft.templateStore.On("Get", ft.ctx, "template1").Return(template, nil)
+	ft.serviceStore.On("GetServicesByDeployment", ft.ctx, "deployment1").Return([]service.Service{{ID: "svc1"}}, nil)
+
+	plan, err := ft.Facade.PlanTemplateDeploy(ft.ctx, "pool1", "template1", "deployment1")
+
+	c.Assert(err, ErrorMatches, "deployment ID deployment1 is already in use")
+	c.Assert(plan, IsNil)
+	ft.poolStore.AssertNotCalled(c, "Get", mock.Anything, mock.Anything)
+}
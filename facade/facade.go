@@ -19,13 +19,17 @@ import (
 	"github.com/control-center/serviced/audit"
 	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/dfs"
+	"github.com/control-center/serviced/domain/apitoken"
+	"github.com/control-center/serviced/domain/auditlog"
 	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/hostkey"
 	"github.com/control-center/serviced/domain/pool"
 	"github.com/control-center/serviced/domain/registry"
 	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/serviceconfigfile"
+	"github.com/control-center/serviced/domain/servicerevision"
 	"github.com/control-center/serviced/domain/servicetemplate"
+	"github.com/control-center/serviced/domain/snapshotschedule"
 	"github.com/control-center/serviced/domain/user"
 	"github.com/control-center/serviced/health"
 	"github.com/control-center/serviced/logging"
@@ -37,6 +41,8 @@ import (
 type MetricsClient interface {
 	GetInstanceMemoryStats(time.Time, ...metrics.ServiceInstance) ([]metrics.MemoryUsageStats, error)
 	GetAvailableStorage(time.Duration, string, ...string) (*metrics.StorageMetrics, error)
+	GetServiceMetricAverage(time.Duration, string, string, bool) (float64, error)
+	GetServiceMetricSeries(time.Duration, string, string, bool) ([]float64, error)
 }
 
 // instantiate the package logger
@@ -47,22 +53,33 @@ var _ FacadeInterface = &Facade{}
 
 // New creates an initialized Facade instance
 func New() *Facade {
+	auditStore := auditlog.NewStore()
 	return &Facade{
-		auditLogger:    audit.NewLogger(),
-		hostStore:      host.NewStore(),
-		hostkeyStore:   hostkey.NewStore(),
-		registryStore:  registry.NewStore(),
-		poolStore:      pool.NewStore(),
-		serviceStore:   service.NewStore(),
-		configStore:    serviceconfigfile.NewStore(),
-		templateStore:  servicetemplate.NewStore(),
-		logFilterStore: logfilter.NewStore(),
-		userStore:      user.NewStore(),
-		serviceCache:   NewServiceCache(),
-		poolCache:      NewPoolCache(),
-		hostRegistry:   auth.NewHostExpirationRegistry(),
-		deployments:    NewPendingDeploymentMgr(),
-		zzk:            getZZK(),
+		auditLogger:     audit.NewStoreLogger(auditStore),
+		auditStore:      auditStore,
+		hostStore:       host.NewStore(),
+		hostkeyStore:    hostkey.NewStore(),
+		registryStore:   registry.NewStore(),
+		poolStore:       pool.NewStore(),
+		serviceStore:    service.NewStore(),
+		configStore:     serviceconfigfile.NewStore(),
+		revisionStore:   servicerevision.NewStore(),
+		scheduleStore:   snapshotschedule.NewStore(),
+		templateStore:   servicetemplate.NewStore(),
+		logFilterStore:  logfilter.NewStore(),
+		userStore:       user.NewStore(),
+		apiTokenStore:   apitoken.NewStore(),
+		serviceCache:    NewServiceCache(),
+		poolCache:       NewPoolCache(),
+		hostRegistry:    auth.NewHostExpirationRegistry(),
+		deployments:     NewPendingDeploymentMgr(),
+		rollingRestarts: newRollingRestartMgr(),
+		imageUpgrades:   newImageUpgradeMgr(),
+		autoscaler:      newAutoscaler(),
+		placements:      newPlacementLog(),
+		rebalancer:      newRebalancer(),
+		zzk:             getZZK(),
+		adminGroupCheck: isAdminGroupMember,
 	}
 }
 
@@ -76,19 +93,29 @@ type Facade struct {
 	logFilterStore logfilter.Store
 	serviceStore   service.Store
 	configStore    serviceconfigfile.Store
+	revisionStore  servicerevision.Store
+	scheduleStore  snapshotschedule.Store
 	userStore      user.Store
-
-	auditLogger   audit.Logger
-	zzk           ZZK
-	dfs           dfs.DFS
-	hcache        *health.HealthStatusCache
-	metricsClient MetricsClient
-	serviceCache  *serviceCache
-	poolCache     *poolCache
-	hostRegistry  auth.HostExpirationRegistryInterface
-	deployments   *PendingDeploymentMgr
-	ssm           servicestatemanager.ServiceStateManager
-	isvcsPath     string
+	apiTokenStore  apitoken.Store
+	auditStore     auditlog.Store
+
+	auditLogger     audit.Logger
+	zzk             ZZK
+	dfs             dfs.DFS
+	hcache          *health.HealthStatusCache
+	metricsClient   MetricsClient
+	serviceCache    *serviceCache
+	poolCache       *poolCache
+	hostRegistry    auth.HostExpirationRegistryInterface
+	deployments     *PendingDeploymentMgr
+	rollingRestarts *rollingRestartMgr
+	imageUpgrades   *imageUpgradeMgr
+	autoscaler      *autoscaler
+	placements      *placementLog
+	rebalancer      *rebalancer
+	ssm             servicestatemanager.ServiceStateManager
+	isvcsPath       string
+	adminGroupCheck func(userName string) (bool, error)
 
 	rollingRestartTimeout time.Duration
 }
@@ -122,8 +149,16 @@ func (f *Facade) SetServiceStore(store service.Store) {
 
 func (f *Facade) SetConfigStore(store serviceconfigfile.Store) { f.configStore = store }
 
+func (f *Facade) SetServiceRevisionStore(store servicerevision.Store) { f.revisionStore = store }
+
+func (f *Facade) SetSnapshotScheduleStore(store snapshotschedule.Store) { f.scheduleStore = store }
+
 func (f *Facade) SetUserStore(store user.Store) { f.userStore = store }
 
+func (f *Facade) SetAPITokenStore(store apitoken.Store) { f.apiTokenStore = store }
+
+func (f *Facade) SetAuditStore(store auditlog.Store) { f.auditStore = store }
+
 func (f *Facade) SetTemplateStore(store servicetemplate.Store) { f.templateStore = store }
 
 func (f *Facade) SetLogFilterStore(store logfilter.Store) { f.logFilterStore = store }
@@ -141,3 +176,7 @@ func (f *Facade) SetHostExpirationRegistry(hostRegistry auth.HostExpirationRegis
 func (f *Facade) SetDeploymentMgr(mgr *PendingDeploymentMgr) { f.deployments = mgr }
 
 func (f *Facade) SetRollingRestartTimeout(t time.Duration) { f.rollingRestartTimeout = t }
+
+func (f *Facade) SetAdminGroupCheck(check func(userName string) (bool, error)) {
+	f.adminGroupCheck = check
+}
@@ -21,16 +21,22 @@ import (
 	auditmocks "github.com/control-center/serviced/audit/mocks"
 	"github.com/control-center/serviced/auth"
 	authmocks "github.com/control-center/serviced/auth/mocks"
+	"github.com/control-center/serviced/datastore"
 	datastoremocks "github.com/control-center/serviced/datastore/mocks"
 	dfsmocks "github.com/control-center/serviced/dfs/mocks"
+	apitokenmocks "github.com/control-center/serviced/domain/apitoken/mocks"
+	auditlogmocks "github.com/control-center/serviced/domain/auditlog/mocks"
 	hostmocks "github.com/control-center/serviced/domain/host/mocks"
 	keymocks "github.com/control-center/serviced/domain/hostkey/mocks"
 	poolmocks "github.com/control-center/serviced/domain/pool/mocks"
 	registrymocks "github.com/control-center/serviced/domain/registry/mocks"
 	servicemocks "github.com/control-center/serviced/domain/service/mocks"
 	configmocks "github.com/control-center/serviced/domain/serviceconfigfile/mocks"
+	revisionmocks "github.com/control-center/serviced/domain/servicerevision/mocks"
 	templatemocks "github.com/control-center/serviced/domain/servicetemplate/mocks"
+	schedulemocks "github.com/control-center/serviced/domain/snapshotschedule/mocks"
 	logfiltermocks "github.com/control-center/serviced/domain/logfilter/mocks"
+	usermocks "github.com/control-center/serviced/domain/user/mocks"
 	"github.com/control-center/serviced/facade"
 	zzkmocks "github.com/control-center/serviced/facade/mocks"
 	"github.com/control-center/serviced/metrics"
@@ -51,8 +57,13 @@ type FacadeUnitTest struct {
 	registryStore    *registrymocks.ImageRegistryStore
 	serviceStore     *servicemocks.Store
 	configStore      *configmocks.Store
+	revisionStore    *revisionmocks.Store
+	scheduleStore    *schedulemocks.Store
 	templateStore    *templatemocks.Store
 	logFilterStore   *logfiltermocks.Store
+	userStore        *usermocks.Store
+	apiTokenStore    *apitokenmocks.Store
+	auditStore       *auditlogmocks.Store
 	metricsClient    *zzkmocks.MetricsClient
 	hostauthregistry *authmocks.HostExpirationRegistryInterface
 }
@@ -79,6 +90,7 @@ func (ft *FacadeUnitTest) SetUpTest(c *C) {
 	mockLogger.On("Entity", mock.AnythingOfType("*host.Host")).Return(mockLogger)
 	mockLogger.On("WithField", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(mockLogger)
 	mockLogger.On("WithFields", mock.AnythingOfType("logrus.Fields")).Return(mockLogger)
+	mockLogger.On("Changes", mock.AnythingOfType("[]auditlog.Change")).Return(mockLogger)
 	mockLogger.On("Error", mock.Anything)
 	mockLogger.On("Succeeded", mock.Anything)
 	mockLogger.On("SucceededIf", mock.AnythingOfType("bool"))
@@ -106,12 +118,27 @@ func (ft *FacadeUnitTest) SetUpTest(c *C) {
 	ft.configStore = &configmocks.Store{}
 	ft.Facade.SetConfigStore(ft.configStore)
 
+	ft.revisionStore = &revisionmocks.Store{}
+	ft.Facade.SetServiceRevisionStore(ft.revisionStore)
+
+	ft.scheduleStore = &schedulemocks.Store{}
+	ft.Facade.SetSnapshotScheduleStore(ft.scheduleStore)
+
 	ft.templateStore = &templatemocks.Store{}
 	ft.Facade.SetTemplateStore(ft.templateStore)
 
 	ft.logFilterStore = &logfiltermocks.Store{}
 	ft.Facade.SetLogFilterStore(ft.logFilterStore)
 
+	ft.userStore = &usermocks.Store{}
+	ft.Facade.SetUserStore(ft.userStore)
+
+	ft.apiTokenStore = &apitokenmocks.Store{}
+	ft.Facade.SetAPITokenStore(ft.apiTokenStore)
+
+	ft.auditStore = &auditlogmocks.Store{}
+	ft.Facade.SetAuditStore(ft.auditStore)
+
 	ft.zzk = &zzkmocks.ZZK{}
 	ft.Facade.SetZZK(ft.zzk)
 
@@ -123,7 +150,13 @@ func (ft *FacadeUnitTest) SetUpTest(c *C) {
 
 	ft.hostauthregistry.On("Remove", mock.AnythingOfType("string")).Return()
 
+	// users without a stored role are admins unless the test says otherwise
+	ft.Facade.SetAdminGroupCheck(func(userName string) (bool, error) {
+		return userName != "nobody", nil
+	})
+
 	ft.ctx.On("Metrics").Return(metrics.NewMetrics())
+	ft.ctx.On("User").Return(datastore.SystemUser)
 }
 
 // Mock all DFS locking operations into no-ops
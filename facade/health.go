@@ -25,7 +25,11 @@ import (
 
 // ReportHealthStatus writes the status of a health check to the cache.
 func (f *Facade) ReportHealthStatus(key health.HealthStatusKey, value health.HealthStatus, expires time.Duration) {
+	flapping := f.hcache.History(key).Flapping
 	f.hcache.Set(key, value, expires)
+	if !flapping && f.hcache.History(key).Flapping {
+		glog.Warningf("Health check %s of service %s instance %d is flapping", key.HealthCheckName, key.ServiceID, key.InstanceID)
+	}
 }
 
 // ReportInstanceDead removes all health checks of a particular instance from
@@ -65,6 +69,33 @@ func (f *Facade) GetServiceHealth(ctx datastore.Context, serviceID string) (map[
 	return f.getServiceHealth(ctx, *sh)
 }
 
+// GetServiceHealthHistory returns the recent results of the health checks of
+// each instance of a service.
+func (f *Facade) GetServiceHealthHistory(ctx datastore.Context, serviceID string) (map[int]map[string]health.HealthCheckHistory, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetServiceHealthHistory"))
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	sh, err := f.serviceStore.GetServiceHealth(ctx, serviceID)
+	if err != nil {
+		glog.Errorf("Could not look up service %s: %s", serviceID, err)
+		return nil, err
+	}
+	history := make(map[int]map[string]health.HealthCheckHistory)
+	for i := 0; i < sh.Instances; i++ {
+		checks := make(map[string]health.HealthCheckHistory)
+		for name := range sh.HealthChecks {
+			checks[name] = f.hcache.History(health.HealthStatusKey{
+				ServiceID:       sh.ID,
+				InstanceID:      i,
+				HealthCheckName: name,
+			})
+		}
+		history[i] = checks
+	}
+	return history, nil
+}
+
 func (f *Facade) getServiceHealth(ctx datastore.Context, sh service.ServiceHealth) (map[int]map[string]health.HealthStatus, error) {
 	states, err := f.zzk.GetServiceStates(ctx, sh.PoolID, sh.ID)
 	if err != nil {
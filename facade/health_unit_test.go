@@ -0,0 +1,55 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/health"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeUnitTest) Test_GetServiceHealthHistory(c *C) {
+	hcache := health.New()
+	ft.Facade.SetHealthCache(hcache)
+
+	ft.serviceStore.On("GetServiceHealth", ft.ctx, "svc1").Return(&service.ServiceHealth{
+		ID:        "svc1",
+		Instances: 2,
+		HealthChecks: map[string]health.HealthCheck{
+			"running": {Script: "true"},
+		},
+	}, nil)
+
+	key := health.HealthStatusKey{ServiceID: "svc1", InstanceID: 1, HealthCheckName: "running"}
+	started := time.Now()
+	for i := 0; i < health.FlapThreshold+1; i++ {
+		status := health.OK
+		if i%2 == 1 {
+			status = health.Status(health.Failed)
+		}
+		ft.Facade.ReportHealthStatus(key, health.HealthStatus{Status: status, StartedAt: started.Add(time.Duration(i) * time.Second)}, time.Minute)
+	}
+
+	history, err := ft.Facade.GetServiceHealthHistory(ft.ctx, "svc1")
+	c.Assert(err, IsNil)
+	c.Assert(history, HasLen, 2)
+	c.Assert(history[0]["running"].Results, HasLen, 0)
+	c.Assert(history[1]["running"].Results, HasLen, health.FlapThreshold+1)
+	c.Assert(history[1]["running"].Transitions, Equals, health.FlapThreshold)
+	c.Assert(history[1]["running"].Flapping, Equals, true)
+}
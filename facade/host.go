@@ -578,6 +578,7 @@ func toReadHost(h host.Host) host.ReadHost {
 			Release: h.ServiceD.Release,
 		},
 		IPs:       h.IPs,
+		Labels:    h.Labels,
 		CreatedAt: h.CreatedAt,
 		UpdatedAt: h.UpdatedAt,
 	}
@@ -0,0 +1,527 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/control-center/serviced/audit"
+	"github.com/control-center/serviced/commons"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/service"
+)
+
+var (
+	ErrImageUpgradeInProgress = errors.New("facade: an image upgrade is already in progress for the service")
+	ErrNoImageUpgrade         = errors.New("facade: no image upgrade found for the service")
+	ErrImageUpgradeNotPaused  = errors.New("facade: image upgrade is not paused")
+	ErrImageUpgradeFinished   = errors.New("facade: image upgrade has already finished")
+	ErrNoServicesToUpgrade    = errors.New("facade: no services use the images to replace")
+)
+
+// byUpgradeOrder sorts services in the order they are started, so services
+// with a start level of 0 are upgraded last.
+type byUpgradeOrder []service.ServiceImageUpgrade
+
+func (s byUpgradeOrder) Len() int      { return len(s) }
+func (s byUpgradeOrder) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byUpgradeOrder) Less(i, j int) bool {
+	return s[i].StartLevel-1 < s[j].StartLevel-1
+}
+
+// imageUpgrade is an image upgrade that is in progress or has finished.  Its
+// status may be read while the upgrade runs.
+type imageUpgrade struct {
+	mutex  sync.Mutex
+	status service.ImageUpgradeStatus
+	resume chan struct{}
+	abort  chan interface{}
+}
+
+func (iu *imageUpgrade) getStatus() service.ImageUpgradeStatus {
+	iu.mutex.Lock()
+	defer iu.mutex.Unlock()
+	status := iu.status
+	status.Services = make([]service.ServiceImageUpgrade, len(iu.status.Services))
+	for i, sim := range iu.status.Services {
+		sim.Upgraded = append([]int{}, sim.Upgraded...)
+		sim.Pending = append([]int{}, sim.Pending...)
+		sim.Unhealthy = append([]int{}, sim.Unhealthy...)
+		status.Services[i] = sim
+	}
+	return status
+}
+
+func (iu *imageUpgrade) isFinished() bool {
+	iu.mutex.Lock()
+	defer iu.mutex.Unlock()
+	return iu.status.State == service.ImageUpgradeCompleted || iu.status.State == service.ImageUpgradeRolledBack
+}
+
+// startService marks a service as the one being upgraded and returns its
+// progress.
+func (iu *imageUpgrade) startService(i int) service.ServiceImageUpgrade {
+	iu.mutex.Lock()
+	defer iu.mutex.Unlock()
+	iu.status.Current = i
+	iu.status.Updated = time.Now()
+	return iu.status.Services[i]
+}
+
+// updateService records that the definition of a service uses the new image
+// and which of its instances need to be restarted.
+func (iu *imageUpgrade) updateService(i int, instanceIDs []int) {
+	iu.mutex.Lock()
+	defer iu.mutex.Unlock()
+	iu.status.Services[i].ImageUpdated = true
+	iu.status.Services[i].Pending = instanceIDs
+	iu.status.Updated = time.Now()
+}
+
+// finishInstance moves an instance out of the pending instances of a service.
+func (iu *imageUpgrade) finishInstance(i, instanceID int, healthy bool) {
+	iu.mutex.Lock()
+	defer iu.mutex.Unlock()
+	sim := &iu.status.Services[i]
+	for j, id := range sim.Pending {
+		if id == instanceID {
+			sim.Pending = append(sim.Pending[:j], sim.Pending[j+1:]...)
+			break
+		}
+	}
+	if healthy {
+		sim.Upgraded = append(sim.Upgraded, instanceID)
+	} else {
+		sim.Unhealthy = append(sim.Unhealthy, instanceID)
+	}
+	iu.status.Updated = time.Now()
+}
+
+func (iu *imageUpgrade) pause(message string) {
+	iu.mutex.Lock()
+	defer iu.mutex.Unlock()
+	iu.status.State = service.ImageUpgradePaused
+	iu.status.Message = message
+	iu.status.Updated = time.Now()
+}
+
+func (iu *imageUpgrade) setMessage(message string) {
+	iu.mutex.Lock()
+	defer iu.mutex.Unlock()
+	iu.status.Message = message
+	iu.status.Updated = time.Now()
+}
+
+func (iu *imageUpgrade) complete() {
+	iu.mutex.Lock()
+	defer iu.mutex.Unlock()
+	iu.status.State = service.ImageUpgradeCompleted
+	iu.status.Message = ""
+	iu.status.Updated = time.Now()
+}
+
+func (iu *imageUpgrade) rolledBack() {
+	iu.mutex.Lock()
+	defer iu.mutex.Unlock()
+	iu.status.State = service.ImageUpgradeRolledBack
+	iu.status.Updated = time.Now()
+}
+
+func (iu *imageUpgrade) doResume() error {
+	iu.mutex.Lock()
+	defer iu.mutex.Unlock()
+	if iu.status.State != service.ImageUpgradePaused {
+		return ErrImageUpgradeNotPaused
+	}
+	iu.status.State = service.ImageUpgradeRunning
+	iu.status.Message = ""
+	iu.status.Updated = time.Now()
+	iu.resume <- struct{}{}
+	return nil
+}
+
+func (iu *imageUpgrade) doAbort() error {
+	iu.mutex.Lock()
+	defer iu.mutex.Unlock()
+	if iu.status.State != service.ImageUpgradeRunning && iu.status.State != service.ImageUpgradePaused {
+		return ErrImageUpgradeFinished
+	}
+	iu.status.State = service.ImageUpgradeRollingBack
+	iu.status.Updated = time.Now()
+	close(iu.abort)
+	return nil
+}
+
+func (iu *imageUpgrade) isAborted() bool {
+	select {
+	case <-iu.abort:
+		return true
+	default:
+		return false
+	}
+}
+
+// imageUpgradeMgr keeps the most recent image upgrade of each service tree.
+// Image upgrades are tracked in memory, so they do not survive a restart of
+// the master.
+type imageUpgradeMgr struct {
+	mutex    sync.Mutex
+	upgrades map[string]*imageUpgrade
+}
+
+func newImageUpgradeMgr() *imageUpgradeMgr {
+	return &imageUpgradeMgr{upgrades: make(map[string]*imageUpgrade)}
+}
+
+// add tracks a new image upgrade, unless one is already in progress for the
+// service.
+func (m *imageUpgradeMgr) add(iu *imageUpgrade) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if cur, ok := m.upgrades[iu.status.ServiceID]; ok && !cur.isFinished() {
+		return ErrImageUpgradeInProgress
+	}
+	m.upgrades[iu.status.ServiceID] = iu
+	return nil
+}
+
+func (m *imageUpgradeMgr) get(serviceID string) (*imageUpgrade, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	iu, ok := m.upgrades[serviceID]
+	if !ok {
+		return nil, ErrNoImageUpgrade
+	}
+	return iu, nil
+}
+
+// UpgradeServiceImage changes the image of the services in a service tree
+// that use one of the images being replaced.  Services are upgraded in start
+// level order, and the running instances of each service are restarted one
+// at a time.  If an instance does not pass its health checks within the
+// timeout, the upgrade pauses until it is resumed or aborted.
+func (f *Facade) UpgradeServiceImage(ctx datastore.Context, request service.ImageUpgradeRequest) (*service.ImageUpgradeStatus, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.UpgradeServiceImage"))
+	alog := f.auditLogger.Message(ctx, "Upgrade Service Image").Action(audit.Upgrade).ID(request.ServiceID).Type(service.GetType()).
+		WithField("imageid", request.ImageID)
+	if err := request.ValidEntity(); err != nil {
+		return nil, alog.Error(err)
+	}
+	if err := f.authorizeService(ctx, request.ServiceID); err != nil {
+		return nil, alog.Error(err)
+	}
+
+	newImg, err := commons.ParseImageID(request.ImageID)
+	if err != nil {
+		return nil, alog.Error(fmt.Errorf("error parsing image ID %s: %s", request.ImageID, err))
+	}
+	replaceRepos := map[string]struct{}{newImg.Repo: {}}
+	if len(request.Replace) > 0 {
+		replaceRepos = make(map[string]struct{})
+		for _, replaceImg := range request.Replace {
+			img, err := commons.ParseImageID(replaceImg)
+			if err != nil {
+				return nil, alog.Error(fmt.Errorf("error parsing image ID %s: %s", replaceImg, err))
+			}
+			replaceRepos[img.Repo] = struct{}{}
+		}
+	}
+
+	var matched []*service.Service
+	err = f.walkServices(ctx, request.ServiceID, true, func(svc *service.Service) error {
+		if svc.ImageID == "" {
+			return nil
+		}
+		img, err := commons.ParseImageID(svc.ImageID)
+		if err != nil {
+			return fmt.Errorf("error parsing image ID %s: %s", svc.ImageID, err)
+		}
+		if _, ok := replaceRepos[img.Repo]; ok {
+			matched = append(matched, svc)
+		}
+		return nil
+	}, "UpgradeServiceImage")
+	if err != nil {
+		return nil, alog.Error(err)
+	}
+	if len(matched) == 0 {
+		return nil, alog.Error(ErrNoServicesToUpgrade)
+	}
+
+	// Push the new image into the registry of the tenant
+	tenantID, err := f.GetTenantID(ctx, request.ServiceID)
+	if err != nil {
+		return nil, alog.Error(err)
+	}
+	imageID, err := f.dfs.Download(request.ImageID, tenantID, true)
+	if err != nil {
+		return nil, alog.Error(err)
+	}
+
+	var services []service.ServiceImageUpgrade
+	for _, svc := range matched {
+		if svc.ImageID == imageID {
+			continue
+		}
+		services = append(services, service.ServiceImageUpgrade{
+			ServiceID:       svc.ID,
+			Name:            svc.Name,
+			StartLevel:      svc.StartLevel,
+			PreviousImageID: svc.ImageID,
+		})
+	}
+	if len(services) == 0 {
+		return nil, alog.Error(ErrNoServicesToUpgrade)
+	}
+	sort.Stable(byUpgradeOrder(services))
+
+	timeout := request.Timeout
+	if timeout == 0 {
+		timeout = f.rollingRestartTimeout
+	}
+	if timeout == 0 {
+		timeout = defaultRollingRestartTimeout
+	}
+
+	now := time.Now()
+	iu := &imageUpgrade{
+		status: service.ImageUpgradeStatus{
+			ServiceID: request.ServiceID,
+			ImageID:   imageID,
+			State:     service.ImageUpgradeRunning,
+			Services:  services,
+			Started:   now,
+			Updated:   now,
+		},
+		resume: make(chan struct{}, 1),
+		abort:  make(chan interface{}),
+	}
+	if err := f.imageUpgrades.add(iu); err != nil {
+		return nil, alog.Error(err)
+	}
+	go f.runImageUpgrade(ctx, iu, timeout)
+
+	alog.WithFields(log.Fields{"services": len(services)}).Succeeded()
+	status := iu.getStatus()
+	return &status, nil
+}
+
+// GetImageUpgradeStatus returns the progress of the most recent image upgrade
+// of a service tree.
+func (f *Facade) GetImageUpgradeStatus(ctx datastore.Context, serviceID string) (*service.ImageUpgradeStatus, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetImageUpgradeStatus"))
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	iu, err := f.imageUpgrades.get(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	status := iu.getStatus()
+	return &status, nil
+}
+
+// ResumeImageUpgrade continues a paused image upgrade.  Unhealthy instances
+// keep running the new image.
+func (f *Facade) ResumeImageUpgrade(ctx datastore.Context, serviceID string) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.ResumeImageUpgrade"))
+	alog := f.auditLogger.Message(ctx, "Resume Image Upgrade").Action(audit.Resume).ID(serviceID).Type(service.GetType())
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return alog.Error(err)
+	}
+	iu, err := f.imageUpgrades.get(serviceID)
+	if err != nil {
+		return alog.Error(err)
+	}
+	if err := iu.doResume(); err != nil {
+		return alog.Error(err)
+	}
+	alog.Succeeded()
+	return nil
+}
+
+// AbortImageUpgrade stops a running or paused image upgrade and rolls the
+// services that were already upgraded back to their previous image.
+func (f *Facade) AbortImageUpgrade(ctx datastore.Context, serviceID string) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.AbortImageUpgrade"))
+	alog := f.auditLogger.Message(ctx, "Abort Image Upgrade").Action(audit.Revert).ID(serviceID).Type(service.GetType())
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return alog.Error(err)
+	}
+	iu, err := f.imageUpgrades.get(serviceID)
+	if err != nil {
+		return alog.Error(err)
+	}
+	if err := iu.doAbort(); err != nil {
+		return alog.Error(err)
+	}
+	alog.Succeeded()
+	return nil
+}
+
+// runImageUpgrade upgrades the services of an image upgrade in turn, and rolls
+// them back if the upgrade is aborted.
+func (f *Facade) runImageUpgrade(ctx datastore.Context, iu *imageUpgrade, timeout time.Duration) {
+	status := iu.getStatus()
+	logger := plog.WithFields(log.Fields{
+		"serviceid": status.ServiceID,
+		"imageid":   status.ImageID,
+	})
+
+	for i := range status.Services {
+		if !f.upgradeServiceImage(ctx, iu, i, status.ImageID, timeout) {
+			logger.Info("Image upgrade aborted")
+			f.rollbackImageUpgrade(ctx, iu)
+			return
+		}
+	}
+
+	iu.complete()
+	logger.Info("Image upgrade completed")
+}
+
+// upgradeServiceImage updates the image of a service and restarts each of its
+// running instances.  It returns false if the upgrade was aborted.
+func (f *Facade) upgradeServiceImage(ctx datastore.Context, iu *imageUpgrade, i int, imageID string, timeout time.Duration) bool {
+	sim := iu.startService(i)
+	logger := plog.WithFields(log.Fields{
+		"serviceid":   sim.ServiceID,
+		"servicename": sim.Name,
+		"imageid":     imageID,
+	})
+
+	svc, err := f.GetService(ctx, sim.ServiceID)
+	if err == nil {
+		svc.ImageID = imageID
+		err = f.UpdateService(ctx, *svc)
+	}
+	if err != nil {
+		logger.WithError(err).Warn("Could not update the image of the service")
+		return f.pauseImageUpgrade(ctx, iu, fmt.Sprintf("could not update service %s: %s", sim.Name, err))
+	}
+
+	states, err := f.zzk.GetServiceStates(ctx, svc.PoolID, svc.ID)
+	if err != nil {
+		iu.updateService(i, nil)
+		logger.WithError(err).Warn("Could not look up the instances of the service")
+		return f.pauseImageUpgrade(ctx, iu, fmt.Sprintf("could not look up instances of service %s: %s", sim.Name, err))
+	}
+	instanceIDs := make([]int, len(states))
+	for j, state := range states {
+		instanceIDs[j] = state.InstanceID
+	}
+	sort.Ints(instanceIDs)
+	iu.updateService(i, instanceIDs)
+
+	svch := service.BuildServiceHealth(*svc)
+	for _, instanceID := range instanceIDs {
+		logger.WithField("instanceid", instanceID).Debug("Restarting instance with the new image")
+		unhealthy, err := f.restartBatch(ctx, svc, svch, []int{instanceID}, true, timeout, iu.abort, func(int) {})
+		if iu.isAborted() {
+			iu.finishInstance(i, instanceID, false)
+			return false
+		}
+		healthy := err == nil && len(unhealthy) == 0
+		iu.finishInstance(i, instanceID, healthy)
+		if !healthy {
+			var message string
+			if err != nil {
+				message = fmt.Sprintf("could not restart instance %d of service %s: %s", instanceID, sim.Name, err)
+			} else {
+				message = fmt.Sprintf("instance %d of service %s did not pass its health checks within %s", instanceID, sim.Name, timeout)
+			}
+			logger.WithError(err).WithField("instanceid", instanceID).Warn("Pausing image upgrade")
+			if !f.pauseImageUpgrade(ctx, iu, message) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// pauseImageUpgrade pauses an image upgrade until it is resumed or aborted.
+// It returns false if the upgrade was aborted.
+func (f *Facade) pauseImageUpgrade(ctx datastore.Context, iu *imageUpgrade, message string) bool {
+	if iu.isAborted() {
+		return false
+	}
+	iu.pause(message)
+	status := iu.getStatus()
+	f.auditLogger.Message(ctx, "Image Upgrade Paused").Action(audit.Pause).ID(status.ServiceID).Type(service.GetType()).
+		WithField("reason", message).Failed()
+	select {
+	case <-iu.resume:
+		return true
+	case <-iu.abort:
+		return false
+	}
+}
+
+// rollbackImageUpgrade restores the previous image of each service that was
+// upgraded, in reverse order, and restarts the instances that were running
+// the new image.  Instances are not health checked during the rollback.
+func (f *Facade) rollbackImageUpgrade(ctx datastore.Context, iu *imageUpgrade) {
+	status := iu.getStatus()
+	var failed []string
+	for i := len(status.Services) - 1; i >= 0; i-- {
+		sim := status.Services[i]
+		if !sim.ImageUpdated {
+			continue
+		}
+		logger := plog.WithFields(log.Fields{
+			"serviceid":   sim.ServiceID,
+			"servicename": sim.Name,
+			"imageid":     sim.PreviousImageID,
+		})
+
+		svc, err := f.GetService(ctx, sim.ServiceID)
+		if err == nil {
+			svc.ImageID = sim.PreviousImageID
+			err = f.UpdateService(ctx, *svc)
+		}
+		if err != nil {
+			logger.WithError(err).Error("Could not restore the previous image of the service")
+			failed = append(failed, sim.Name)
+			continue
+		}
+
+		instanceIDs := append(append([]int{}, sim.Upgraded...), sim.Unhealthy...)
+		sort.Ints(instanceIDs)
+		for _, instanceID := range instanceIDs {
+			if err := f.zzk.UpdateInstanceCurrentState(ctx, svc.PoolID, svc.ID, instanceID, service.StatePendingRestart); err != nil {
+				logger.WithError(err).WithField("instanceid", instanceID).Warn("Could not mark instance for restart")
+			}
+			if err := f.zzk.RestartInstance(ctx, svc.PoolID, svc.ID, instanceID); err != nil {
+				logger.WithError(err).WithField("instanceid", instanceID).Warn("Could not restart instance")
+			}
+		}
+		logger.WithField("instances", instanceIDs).Info("Rolled back the image of the service")
+	}
+
+	alog := f.auditLogger.Message(ctx, "Roll Back Image Upgrade").Action(audit.Revert).ID(status.ServiceID).Type(service.GetType())
+	if len(failed) > 0 {
+		message := fmt.Sprintf("could not restore the previous image of %s", strings.Join(failed, ", "))
+		iu.setMessage(message)
+		alog.WithField("reason", message).Failed()
+	} else {
+		alog.Succeeded()
+	}
+	iu.rolledBack()
+}
@@ -0,0 +1,147 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build integration
+
+package facade
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/domain/service"
+	zks "github.com/control-center/serviced/zzk/service"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeIntegrationTest) setupImageUpgrade(c *C, tenantID string, healthy bool) {
+	tenant := service.Service{
+		ID:           tenantID,
+		Name:         tenantID,
+		DeploymentID: "deployment-id",
+		PoolID:       "pool-id",
+		Launch:       "auto",
+	}
+	c.Assert(ft.Facade.AddService(ft.CTX, tenant), IsNil)
+	for _, child := range []service.Service{
+		{ID: tenantID + "-web", Name: "web", StartLevel: 2},
+		{ID: tenantID + "-db", Name: "db", StartLevel: 1},
+		{ID: tenantID + "-other", Name: "other", StartLevel: 1},
+	} {
+		child.ParentServiceID = tenantID
+		child.DeploymentID = "deployment-id"
+		child.PoolID = "pool-id"
+		child.Launch = "auto"
+		child.Instances = 1
+		child.ImageID = "localhost:5000/" + tenantID + "/repo:1.0"
+		if child.Name == "other" {
+			child.ImageID = "localhost:5000/" + tenantID + "/other:1.0"
+		}
+		c.Assert(ft.Facade.AddService(ft.CTX, child), IsNil)
+
+		ft.zzk.On("GetServiceStates", ft.CTX, "pool-id", child.ID).Return([]zks.State{{ServiceID: child.ID, InstanceID: 0}}, nil)
+		ft.zzk.On("RestartInstance", ft.CTX, "pool-id", child.ID, 0).Return(nil)
+		old := &zks.State{ServiceID: child.ID, InstanceID: 0}
+		old.ContainerID = "old"
+		old.Status = service.StateRunning
+		if !healthy {
+			ft.zzk.On("GetServiceState", ft.CTX, "pool-id", child.ID, 0).Return(old, nil)
+			continue
+		}
+		ft.zzk.On("GetServiceState", ft.CTX, "pool-id", child.ID, 0).Return(old, nil).Once()
+		cur := &zks.State{ServiceID: child.ID, InstanceID: 0}
+		cur.ContainerID = "new"
+		cur.Status = service.StateRunning
+		ft.zzk.On("GetServiceState", ft.CTX, "pool-id", child.ID, 0).Return(cur, nil)
+	}
+	ft.zzk.On("WaitInstance", ft.CTX, mock.Anything, 0, mock.AnythingOfType("func(*service.State, bool) bool"), mock.AnythingOfType("<-chan struct {}")).
+		Run(func(args mock.Arguments) {
+			// the instance comes back in whatever container it reports next
+			svc := args.Get(1).(*service.Service)
+			check := args.Get(3).(func(*zks.State, bool) bool)
+			cancel := args.Get(4).(<-chan struct{})
+			state, err := ft.zzk.GetServiceState(ft.CTX, svc.PoolID, svc.ID, 0)
+			if err != nil || !check(state, true) {
+				<-cancel
+			}
+		}).Return(nil)
+	ft.dfs.On("Download", "repo:2.0", tenantID, true).Return("localhost:5000/"+tenantID+"/repo:2.0", nil)
+}
+
+func (ft *FacadeIntegrationTest) waitImageUpgrade(c *C, serviceID, state string) *service.ImageUpgradeStatus {
+	timeout := time.After(5 * time.Second)
+	for {
+		status, err := ft.Facade.GetImageUpgradeStatus(ft.CTX, serviceID)
+		c.Assert(err, IsNil)
+		if status.State == state {
+			return status
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("image upgrade of %s is %s, not %s", serviceID, status.State, state)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func (ft *FacadeIntegrationTest) TestFacade_UpgradeServiceImage(c *C) {
+	ft.setupImageUpgrade(c, "iu-tenant", true)
+
+	status, err := ft.Facade.UpgradeServiceImage(ft.CTX, service.ImageUpgradeRequest{ServiceID: "iu-tenant", ImageID: "repo:2.0"})
+	c.Assert(err, IsNil)
+	c.Assert(status.ImageID, Equals, "localhost:5000/iu-tenant/repo:2.0")
+	// services are upgraded in start level order, and only if they use the repo
+	c.Assert(status.Services, HasLen, 2)
+	c.Assert(status.Services[0].ServiceID, Equals, "iu-tenant-db")
+	c.Assert(status.Services[1].ServiceID, Equals, "iu-tenant-web")
+
+	status = ft.waitImageUpgrade(c, "iu-tenant", service.ImageUpgradeCompleted)
+	for _, sim := range status.Services {
+		c.Assert(sim.PreviousImageID, Equals, "localhost:5000/iu-tenant/repo:1.0")
+		c.Assert(sim.Upgraded, DeepEquals, []int{0})
+		svc, err := ft.Facade.GetService(ft.CTX, sim.ServiceID)
+		c.Assert(err, IsNil)
+		c.Assert(svc.ImageID, Equals, "localhost:5000/iu-tenant/repo:2.0")
+	}
+	svc, err := ft.Facade.GetService(ft.CTX, "iu-tenant-other")
+	c.Assert(err, IsNil)
+	c.Assert(svc.ImageID, Equals, "localhost:5000/iu-tenant/other:1.0")
+}
+
+func (ft *FacadeIntegrationTest) TestFacade_UpgradeServiceImageAbort(c *C) {
+	ft.setupImageUpgrade(c, "iu-abort", false)
+	ft.Facade.SetRollingRestartTimeout(100 * time.Millisecond)
+	defer ft.Facade.SetRollingRestartTimeout(0)
+
+	_, err := ft.Facade.UpgradeServiceImage(ft.CTX, service.ImageUpgradeRequest{ServiceID: "iu-abort", ImageID: "repo:2.0"})
+	c.Assert(err, IsNil)
+
+	// the first instance never passes its health checks
+	status := ft.waitImageUpgrade(c, "iu-abort", service.ImageUpgradePaused)
+	c.Assert(status.Services[0].ImageUpdated, Equals, true)
+	c.Assert(status.Services[0].Unhealthy, DeepEquals, []int{0})
+	c.Assert(status.Services[1].ImageUpdated, Equals, false)
+	c.Assert(ft.Facade.ResumeImageUpgrade(ft.CTX, "iu-abort"), IsNil)
+	ft.waitImageUpgrade(c, "iu-abort", service.ImageUpgradePaused)
+
+	c.Assert(ft.Facade.AbortImageUpgrade(ft.CTX, "iu-abort"), IsNil)
+	ft.waitImageUpgrade(c, "iu-abort", service.ImageUpgradeRolledBack)
+	for _, serviceID := range []string{"iu-abort-db", "iu-abort-web"} {
+		svc, err := ft.Facade.GetService(ft.CTX, serviceID)
+		c.Assert(err, IsNil)
+		c.Assert(svc.ImageID, Equals, "localhost:5000/iu-abort/repo:1.0")
+		ft.zzk.AssertCalled(c, "RestartInstance", ft.CTX, "pool-id", serviceID, 0)
+	}
+	c.Assert(ft.Facade.AbortImageUpgrade(ft.CTX, "iu-abort"), Equals, ErrImageUpgradeFinished)
+	ft.dfs.AssertCalled(c, "Download", "repo:2.0", "iu-abort", mock.AnythingOfType("bool"))
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/facade"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeUnitTest) Test_UpgradeServiceImageInvalidRequest(c *C) {
+	status, err := ft.Facade.UpgradeServiceImage(ft.ctx, service.ImageUpgradeRequest{ServiceID: "iu-invalid"})
+
+	c.Assert(err, NotNil)
+	c.Assert(status, IsNil)
+	ft.serviceStore.AssertNotCalled(c, "Get", mock.Anything, "iu-invalid")
+}
+
+func (ft *FacadeUnitTest) Test_UpgradeServiceImageUnauthorizedTenant(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	status, err := ft.Facade.UpgradeServiceImage(ctx, service.ImageUpgradeRequest{ServiceID: "grantChild2", ImageID: "repo:2.0"})
+
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	c.Assert(status, IsNil)
+	ft.dfs.AssertNotCalled(c, "Download", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_UpgradeServiceImageNoMatchingServices(c *C) {
+	svc := &service.Service{ID: "iu-nomatch", Name: "iu-nomatch", ImageID: "other:1.0"}
+	ft.serviceStore.On("Get", ft.ctx, svc.ID).Return(svc, nil)
+	ft.serviceStore.On("GetChildServices", ft.ctx, svc.ID).Return([]service.Service{}, nil)
+
+	status, err := ft.Facade.UpgradeServiceImage(ft.ctx, service.ImageUpgradeRequest{ServiceID: svc.ID, ImageID: "repo:2.0"})
+	c.Assert(err, Equals, facade.ErrNoServicesToUpgrade)
+	c.Assert(status, IsNil)
+
+	// images to replace are matched on their repo
+	request := service.ImageUpgradeRequest{ServiceID: svc.ID, ImageID: "repo:2.0", Replace: []string{"another:1.0"}}
+	status, err = ft.Facade.UpgradeServiceImage(ft.ctx, request)
+	c.Assert(err, Equals, facade.ErrNoServicesToUpgrade)
+	c.Assert(status, IsNil)
+	ft.dfs.AssertNotCalled(c, "Download", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_GetImageUpgradeStatusNotFound(c *C) {
+	status, err := ft.Facade.GetImageUpgradeStatus(ft.ctx, "iu-none")
+	c.Assert(err, Equals, facade.ErrNoImageUpgrade)
+	c.Assert(status, IsNil)
+
+	c.Assert(ft.Facade.ResumeImageUpgrade(ft.ctx, "iu-none"), Equals, facade.ErrNoImageUpgrade)
+	c.Assert(ft.Facade.AbortImageUpgrade(ft.ctx, "iu-none"), Equals, facade.ErrNoImageUpgrade)
+}
@@ -36,6 +36,10 @@ func (f *Facade) GetServiceInstances(ctx datastore.Context, since time.Time, ser
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetServiceInstances"))
 	logger := plog.WithField("serviceid", serviceID)
 
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+
 	// create an instance map to map instances to their memory usage
 	instanceMap := make(map[string]*service.Usage)
 
@@ -308,6 +312,15 @@ func (f *Facade) GetAggregateServices(ctx datastore.Context, since time.Time, se
 	for i, serviceID := range serviceIDs {
 		svclog := logger.WithField("serviceid", serviceID)
 
+		// services of tenants the user has not been granted are not found
+		if err := f.authorizeService(ctx, serviceID); err == ErrTenantNotAuthorized {
+			results[i] = service.AggregateService{
+				ServiceID: serviceID,
+				NotFound:  true,
+			}
+			continue
+		}
+
 		svc, err := f.serviceStore.GetServiceHealth(ctx, serviceID)
 		if datastore.IsErrNoSuchEntity(err) {
 
@@ -439,14 +452,19 @@ func (f *Facade) GetHostStrategyInstances(ctx datastore.Context, hosts []host.Ho
 				}
 				inst = service.StrategyInstance{
 					ServiceID:     s.ID,
+					ServiceName:   s.Name,
+					DeploymentID:  s.DeploymentID,
 					CPUCommitment: int(s.CPUCommitment),
 					RAMCommitment: s.RAMCommitment.Value,
 					HostPolicy:    s.HostPolicy,
+					HostLabels:    s.HostLabels,
+					Affinity:      s.Affinity,
 				}
 				svcMap[state.ServiceID] = inst
 			}
 
 			inst.HostID = state.HostID
+			inst.InstanceID = state.InstanceID
 			insts = append(insts, &inst)
 		}
 
@@ -464,6 +482,10 @@ func (f *Facade) StopServiceInstance(ctx datastore.Context, serviceID string, in
 		"instanceid": instanceID,
 	})
 
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return err
+	}
+
 	svc, err := f.serviceStore.Get(ctx, serviceID)
 	if err != nil {
 		logger.WithError(err).Debug("Could not look up service")
@@ -488,6 +510,10 @@ func (f *Facade) LocateServiceInstance(ctx datastore.Context, serviceID string,
 		"instanceid": instanceID,
 	})
 
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+
 	svc, err := f.serviceStore.Get(ctx, serviceID)
 	if err != nil {
 		logger.WithError(err).Debug("Could not look up service")
@@ -518,6 +544,10 @@ func (f *Facade) SendDockerAction(ctx datastore.Context, serviceID string, insta
 		"args":       args,
 	})
 
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return err
+	}
+
 	// get the service
 	svc, err := f.serviceStore.Get(ctx, serviceID)
 	if err != nil {
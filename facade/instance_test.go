@@ -420,11 +420,18 @@ func (ft *FacadeUnitTest) TestGetHostStrategyInstances(c *C) {
 		ID:            "testservice",
 		PoolID:        "default",
 		Name:          "serviceA",
+		DeploymentID:  "deploymentA",
 		CPUCommitment: 10,
 		RAMCommitment: utils.EngNotation{
 			Value: uint64(1000),
 		},
 		HostPolicy: servicedefinition.Pack,
+		HostLabels: &servicedefinition.LabelSelector{
+			Required: map[string]string{"disk": "ssd"},
+		},
+		Affinity: &servicedefinition.Affinity{
+			SeparateFrom: []string{"serviceB"},
+		},
 	}
 	ft.serviceStore.On("Get", ft.ctx, "testservice").Return(svc, nil)
 
@@ -432,16 +439,26 @@ func (ft *FacadeUnitTest) TestGetHostStrategyInstances(c *C) {
 		hst1.ID: {
 			HostID:        hst1.ID,
 			ServiceID:     svc.ID,
+			InstanceID:    1,
+			ServiceName:   svc.Name,
+			DeploymentID:  svc.DeploymentID,
 			CPUCommitment: int(svc.CPUCommitment),
 			RAMCommitment: svc.RAMCommitment.Value,
 			HostPolicy:    svc.HostPolicy,
+			HostLabels:    svc.HostLabels,
+			Affinity:      svc.Affinity,
 		},
 		hst2.ID: {
 			HostID:        hst2.ID,
 			ServiceID:     svc.ID,
+			InstanceID:    2,
+			ServiceName:   svc.Name,
+			DeploymentID:  svc.DeploymentID,
 			CPUCommitment: int(svc.CPUCommitment),
 			RAMCommitment: svc.RAMCommitment.Value,
 			HostPolicy:    svc.HostPolicy,
+			HostLabels:    svc.HostLabels,
+			Affinity:      svc.Affinity,
 		},
 	}
 	actual, err := ft.Facade.GetHostStrategyInstances(ft.ctx, []host.Host{hst1, hst2})
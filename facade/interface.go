@@ -16,17 +16,23 @@ package facade
 import (
 	"time"
 
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/dao"
 	"github.com/control-center/serviced/datastore"
 	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/apitoken"
+	"github.com/control-center/serviced/domain/auditlog"
 	"github.com/control-center/serviced/health"
+	"github.com/control-center/serviced/notify"
 
 	"github.com/control-center/serviced/domain/addressassignment"
 	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/pool"
 	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/domain/servicerevision"
 	"github.com/control-center/serviced/domain/servicetemplate"
+	"github.com/control-center/serviced/domain/snapshotschedule"
 	"github.com/control-center/serviced/domain/user"
 	"github.com/control-center/serviced/utils"
 )
@@ -58,6 +64,44 @@ type FacadeInterface interface {
 
 	WaitService(ctx datastore.Context, dstate service.DesiredState, timeout time.Duration, recursive bool, serviceIDs ...string) error
 
+	GetServiceRevisions(ctx datastore.Context, serviceID string) ([]servicerevision.Revision, error)
+
+	GetServiceRevision(ctx datastore.Context, serviceID string, revision int) (*servicerevision.Revision, error)
+
+	RevertService(ctx datastore.Context, serviceID string, revision int) error
+
+	GetSnapshotSchedules(ctx datastore.Context) ([]snapshotschedule.Schedule, error)
+
+	GetSnapshotSchedule(ctx datastore.Context, tenantID string) (*snapshotschedule.Schedule, error)
+
+	SetSnapshotSchedule(ctx datastore.Context, sched snapshotschedule.Schedule) error
+
+	RemoveSnapshotSchedule(ctx datastore.Context, tenantID string) error
+
+	PlanServiceUpdate(ctx datastore.Context, svc service.Service) (*service.ChangePlan, error)
+
+	RollingRestartService(ctx datastore.Context, request service.RollingRestartRequest) (*service.RollingRestartStatus, error)
+
+	GetRollingRestartStatus(ctx datastore.Context, serviceID string) (*service.RollingRestartStatus, error)
+
+	ResumeRollingRestart(ctx datastore.Context, serviceID string) error
+
+	CancelRollingRestart(ctx datastore.Context, serviceID string) error
+
+	UpgradeServiceImage(ctx datastore.Context, request service.ImageUpgradeRequest) (*service.ImageUpgradeStatus, error)
+
+	GetImageUpgradeStatus(ctx datastore.Context, serviceID string) (*service.ImageUpgradeStatus, error)
+
+	ResumeImageUpgrade(ctx datastore.Context, serviceID string) error
+
+	AbortImageUpgrade(ctx datastore.Context, serviceID string) error
+
+	RecordPlacementDecision(ctx datastore.Context, decision service.PlacementDecision)
+
+	GetPlacementDecisions(ctx datastore.Context, serviceID string) ([]service.PlacementDecision, error)
+
+	GetMigrationTarget(ctx datastore.Context, serviceID string, instanceID int) (string, bool)
+
 	AssignIPs(ctx datastore.Context, assignmentRequest addressassignment.AssignmentRequest) (err error)
 
 	RemoveIPs(ctx datastore.Context, args []string) error
@@ -74,6 +118,8 @@ type FacadeInterface interface {
 
 	DeployTemplate(ctx datastore.Context, poolID string, templateID string, deploymentID string) ([]string, error)
 
+	PlanTemplateDeploy(ctx datastore.Context, poolID string, templateID string, deploymentID string) (*service.ChangePlan, error)
+
 	DeployTemplateActive() (active []map[string]string, err error)
 
 	DeployTemplateStatus(deploymentID string, lastStatus string, timeout time.Duration) (status string, err error)
@@ -120,6 +166,10 @@ type FacadeInterface interface {
 
 	UpdateResourcePool(ctx datastore.Context, entity *pool.ResourcePool) error
 
+	RebalancePool(ctx datastore.Context, poolID string, limit int, dryRun bool) ([]service.Migration, error)
+
+	GetPoolUsage(ctx datastore.Context, poolID string) (*pool.PoolUsage, error)
+
 	GetHealthChecksForService(ctx datastore.Context, id string) (map[string]health.HealthCheck, error)
 
 	AddPublicEndpointPort(ctx datastore.Context, serviceid, endpointName, portAddr string, usetls bool, protocol string, isEnabled bool, restart bool) (*servicedefinition.Port, error)
@@ -178,8 +228,32 @@ type FacadeInterface interface {
 
 	ValidateCredentials(ctx datastore.Context, u user.User) (bool, error)
 
+	GetUsers(ctx datastore.Context) ([]user.User, error)
+
+	GetUserRole(ctx datastore.Context, userName string) (auth.Role, error)
+
+	SetUserRole(ctx datastore.Context, userName string, role auth.Role) error
+
+	SetUserTenants(ctx datastore.Context, userName string, tenantIDs []string) error
+
+	IsTenantRestricted(ctx datastore.Context) (bool, error)
+
+	AddAPIToken(ctx datastore.Context, userName, name string, role auth.Role, tenantIDs []string, expiration time.Duration) (apitoken.APIToken, string, error)
+
+	GetAPIToken(ctx datastore.Context, id string) (*apitoken.APIToken, error)
+
+	GetAPITokens(ctx datastore.Context) ([]apitoken.APIToken, error)
+
+	RemoveAPIToken(ctx datastore.Context, id string) error
+
+	AuthenticateAPIToken(ctx datastore.Context, bearer string) (*apitoken.APIToken, error)
+
+	GetAuditEvents(ctx datastore.Context, query auditlog.Query) ([]auditlog.Event, error)
+
 	GetServicesHealth(ctx datastore.Context) (map[string]map[int]map[string]health.HealthStatus, error)
 
+	GetServiceHealthHistory(ctx datastore.Context, serviceID string) (map[int]map[string]health.HealthCheckHistory, error)
+
 	ReportHealthStatus(key health.HealthStatusKey, value health.HealthStatus, expires time.Duration)
 
 	ReportInstanceDead(serviceID string, instanceID int)
@@ -208,6 +282,10 @@ type FacadeInterface interface {
 
 	PredictStorageAvailability(ctx datastore.Context, lookahead time.Duration) (map[string]float64, error)
 
+	AutoscaleServices(ctx datastore.Context) (int, error)
+
+	EvaluateAlerts(ctx datastore.Context) (*notify.Alerts, error)
+
 	QueryServiceDetails(ctx datastore.Context, query service.Query) ([]service.ServiceDetails, error)
 
 	GetServiceNamePath(ctx datastore.Context, serviceID string) (tenantID string, servicePath string, err error)
@@ -1,6 +1,9 @@
 package mocks
 
 import addressassignment "github.com/control-center/serviced/domain/addressassignment"
+import apitoken "github.com/control-center/serviced/domain/apitoken"
+import auditlog "github.com/control-center/serviced/domain/auditlog"
+import auth "github.com/control-center/serviced/auth"
 import dao "github.com/control-center/serviced/dao"
 import datastore "github.com/control-center/serviced/datastore"
 import domain "github.com/control-center/serviced/domain"
@@ -8,10 +11,13 @@ import domain "github.com/control-center/serviced/domain"
 import health "github.com/control-center/serviced/health"
 import host "github.com/control-center/serviced/domain/host"
 import mock "github.com/stretchr/testify/mock"
+import notify "github.com/control-center/serviced/notify"
 import pool "github.com/control-center/serviced/domain/pool"
 import service "github.com/control-center/serviced/domain/service"
 import servicedefinition "github.com/control-center/serviced/domain/servicedefinition"
+import servicerevision "github.com/control-center/serviced/domain/servicerevision"
 import servicetemplate "github.com/control-center/serviced/domain/servicetemplate"
+import snapshotschedule "github.com/control-center/serviced/domain/snapshotschedule"
 import time "time"
 import user "github.com/control-center/serviced/domain/user"
 import "github.com/control-center/serviced/utils"
@@ -21,6 +27,48 @@ type FacadeInterface struct {
 	mock.Mock
 }
 
+// AbortImageUpgrade provides a mock function with given fields: ctx, serviceID
+func (_m *FacadeInterface) AbortImageUpgrade(ctx datastore.Context, serviceID string) error {
+	ret := _m.Called(ctx, serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) error); ok {
+		r0 = rf(ctx, serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddAPIToken provides a mock function with given fields: ctx, userName, name, role, tenantIDs, expiration
+func (_m *FacadeInterface) AddAPIToken(ctx datastore.Context, userName string, name string, role auth.Role, tenantIDs []string, expiration time.Duration) (apitoken.APIToken, string, error) {
+	ret := _m.Called(ctx, userName, name, role, tenantIDs, expiration)
+
+	var r0 apitoken.APIToken
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, string, auth.Role, []string, time.Duration) apitoken.APIToken); ok {
+		r0 = rf(ctx, userName, name, role, tenantIDs, expiration)
+	} else {
+		r0 = ret.Get(0).(apitoken.APIToken)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(datastore.Context, string, string, auth.Role, []string, time.Duration) string); ok {
+		r1 = rf(ctx, userName, name, role, tenantIDs, expiration)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(datastore.Context, string, string, auth.Role, []string, time.Duration) error); ok {
+		r2 = rf(ctx, userName, name, role, tenantIDs, expiration)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // AddHost provides a mock function with given fields: ctx, entity
 func (_m *FacadeInterface) AddHost(ctx datastore.Context, entity *host.Host) ([]byte, error) {
 	ret := _m.Called(ctx, entity)
@@ -44,22 +92,535 @@ func (_m *FacadeInterface) AddHost(ctx datastore.Context, entity *host.Host) ([]
 	return r0, r1
 }
 
-// AddHostPrivate provides a mock function with given fields: ctx, entity
-func (_m *FacadeInterface) AddHostPrivate(ctx datastore.Context, entity *host.Host) ([]byte, error) {
-	ret := _m.Called(ctx, entity)
+// AddHostPrivate provides a mock function with given fields: ctx, entity
+func (_m *FacadeInterface) AddHostPrivate(ctx datastore.Context, entity *host.Host) ([]byte, error) {
+	ret := _m.Called(ctx, entity)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(datastore.Context, *host.Host) []byte); ok {
+		r0 = rf(ctx, entity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, *host.Host) error); ok {
+		r1 = rf(ctx, entity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddPublicEndpointPort provides a mock function with given fields: ctx, serviceid, endpointName, portAddr, usetls, protocol, isEnabled, restart
+func (_m *FacadeInterface) AddPublicEndpointPort(ctx datastore.Context, serviceid string, endpointName string, portAddr string, usetls bool, protocol string, isEnabled bool, restart bool) (*servicedefinition.Port, error) {
+	ret := _m.Called(ctx, serviceid, endpointName, portAddr, usetls, protocol, isEnabled, restart)
+
+	var r0 *servicedefinition.Port
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, string, string, bool, string, bool, bool) *servicedefinition.Port); ok {
+		r0 = rf(ctx, serviceid, endpointName, portAddr, usetls, protocol, isEnabled, restart)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*servicedefinition.Port)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string, string, string, bool, string, bool, bool) error); ok {
+		r1 = rf(ctx, serviceid, endpointName, portAddr, usetls, protocol, isEnabled, restart)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddPublicEndpointVHost provides a mock function with given fields: ctx, serviceid, endpointName, vhost, isEnabled, restart
+func (_m *FacadeInterface) AddPublicEndpointVHost(ctx datastore.Context, serviceid string, endpointName string, vhost string, isEnabled bool, restart bool) (*servicedefinition.VHost, error) {
+	ret := _m.Called(ctx, serviceid, endpointName, vhost, isEnabled, restart)
+
+	var r0 *servicedefinition.VHost
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, string, string, bool, bool) *servicedefinition.VHost); ok {
+		r0 = rf(ctx, serviceid, endpointName, vhost, isEnabled, restart)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*servicedefinition.VHost)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string, string, string, bool, bool) error); ok {
+		r1 = rf(ctx, serviceid, endpointName, vhost, isEnabled, restart)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddResourcePool provides a mock function with given fields: ctx, entity
+func (_m *FacadeInterface) AddResourcePool(ctx datastore.Context, entity *pool.ResourcePool) error {
+	ret := _m.Called(ctx, entity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, *pool.ResourcePool) error); ok {
+		r0 = rf(ctx, entity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddService provides a mock function with given fields: ctx, svc
+func (_m *FacadeInterface) AddService(ctx datastore.Context, svc service.Service) error {
+	ret := _m.Called(ctx, svc)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, service.Service) error); ok {
+		r0 = rf(ctx, svc)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddServiceConfig provides a mock function with given fields: ctx, serviceID, conf
+func (_m *FacadeInterface) AddServiceConfig(ctx datastore.Context, serviceID string, conf servicedefinition.ConfigFile) error {
+	ret := _m.Called(ctx, serviceID, conf)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, servicedefinition.ConfigFile) error); ok {
+		r0 = rf(ctx, serviceID, conf)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddServiceTemplate provides a mock function with given fields: ctx, serviceTemplate, reloadLogstashConfig
+func (_m *FacadeInterface) AddServiceTemplate(ctx datastore.Context, serviceTemplate servicetemplate.ServiceTemplate, reloadLogstashConfig bool) (string, error) {
+	ret := _m.Called(ctx, serviceTemplate, reloadLogstashConfig)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(datastore.Context, servicetemplate.ServiceTemplate, bool) string); ok {
+		r0 = rf(ctx, serviceTemplate, reloadLogstashConfig)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, servicetemplate.ServiceTemplate, bool) error); ok {
+		r1 = rf(ctx, serviceTemplate, reloadLogstashConfig)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddUser provides a mock function with given fields: ctx, newUser
+func (_m *FacadeInterface) AddUser(ctx datastore.Context, newUser user.User) error {
+	ret := _m.Called(ctx, newUser)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, user.User) error); ok {
+		r0 = rf(ctx, newUser)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AssignIPs provides a mock function with given fields: ctx, assignmentRequest
+func (_m *FacadeInterface) AssignIPs(ctx datastore.Context, assignmentRequest addressassignment.AssignmentRequest) error {
+	ret := _m.Called(ctx, assignmentRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, addressassignment.AssignmentRequest) error); ok {
+		r0 = rf(ctx, assignmentRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthenticateAPIToken provides a mock function with given fields: ctx, bearer
+func (_m *FacadeInterface) AuthenticateAPIToken(ctx datastore.Context, bearer string) (*apitoken.APIToken, error) {
+	ret := _m.Called(ctx, bearer)
+
+	var r0 *apitoken.APIToken
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) *apitoken.APIToken); ok {
+		r0 = rf(ctx, bearer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apitoken.APIToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, bearer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AutoscaleServices provides a mock function with given fields: ctx
+func (_m *FacadeInterface) AutoscaleServices(ctx datastore.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(datastore.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelRollingRestart provides a mock function with given fields: ctx, serviceID
+func (_m *FacadeInterface) CancelRollingRestart(ctx datastore.Context, serviceID string) error {
+	ret := _m.Called(ctx, serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) error); ok {
+		r0 = rf(ctx, serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAPIToken provides a mock function with given fields: ctx, id
+func (_m *FacadeInterface) GetAPIToken(ctx datastore.Context, id string) (*apitoken.APIToken, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *apitoken.APIToken
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) *apitoken.APIToken); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apitoken.APIToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAPITokens provides a mock function with given fields: ctx
+func (_m *FacadeInterface) GetAPITokens(ctx datastore.Context) ([]apitoken.APIToken, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []apitoken.APIToken
+	if rf, ok := ret.Get(0).(func(datastore.Context) []apitoken.APIToken); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]apitoken.APIToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuditEvents provides a mock function with given fields: ctx, query
+func (_m *FacadeInterface) GetAuditEvents(ctx datastore.Context, query auditlog.Query) ([]auditlog.Event, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []auditlog.Event
+	if rf, ok := ret.Get(0).(func(datastore.Context, auditlog.Query) []auditlog.Event); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auditlog.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, auditlog.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetImageUpgradeStatus provides a mock function with given fields: ctx, serviceID
+func (_m *FacadeInterface) GetImageUpgradeStatus(ctx datastore.Context, serviceID string) (*service.ImageUpgradeStatus, error) {
+	ret := _m.Called(ctx, serviceID)
+
+	var r0 *service.ImageUpgradeStatus
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) *service.ImageUpgradeStatus); ok {
+		r0 = rf(ctx, serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ImageUpgradeStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMigrationTarget provides a mock function with given fields: ctx, serviceID, instanceID
+func (_m *FacadeInterface) GetMigrationTarget(ctx datastore.Context, serviceID string, instanceID int) (string, bool) {
+	ret := _m.Called(ctx, serviceID, instanceID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, int) string); ok {
+		r0 = rf(ctx, serviceID, instanceID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(datastore.Context, string, int) bool); ok {
+		r1 = rf(ctx, serviceID, instanceID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// GetPlacementDecisions provides a mock function with given fields: ctx, serviceID
+func (_m *FacadeInterface) GetPlacementDecisions(ctx datastore.Context, serviceID string) ([]service.PlacementDecision, error) {
+	ret := _m.Called(ctx, serviceID)
+
+	var r0 []service.PlacementDecision
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) []service.PlacementDecision); ok {
+		r0 = rf(ctx, serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.PlacementDecision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPoolUsage provides a mock function with given fields: ctx, poolID
+func (_m *FacadeInterface) GetPoolUsage(ctx datastore.Context, poolID string) (*pool.PoolUsage, error) {
+	ret := _m.Called(ctx, poolID)
+
+	var r0 *pool.PoolUsage
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) *pool.PoolUsage); ok {
+		r0 = rf(ctx, poolID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.PoolUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, poolID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRollingRestartStatus provides a mock function with given fields: ctx, serviceID
+func (_m *FacadeInterface) GetRollingRestartStatus(ctx datastore.Context, serviceID string) (*service.RollingRestartStatus, error) {
+	ret := _m.Called(ctx, serviceID)
+
+	var r0 *service.RollingRestartStatus
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) *service.RollingRestartStatus); ok {
+		r0 = rf(ctx, serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.RollingRestartStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceHealthHistory provides a mock function with given fields: ctx, serviceID
+func (_m *FacadeInterface) GetServiceHealthHistory(ctx datastore.Context, serviceID string) (map[int]map[string]health.HealthCheckHistory, error) {
+	ret := _m.Called(ctx, serviceID)
+
+	var r0 map[int]map[string]health.HealthCheckHistory
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) map[int]map[string]health.HealthCheckHistory); ok {
+		r0 = rf(ctx, serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]map[string]health.HealthCheckHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceRevision provides a mock function with given fields: ctx, serviceID, revision
+func (_m *FacadeInterface) GetServiceRevision(ctx datastore.Context, serviceID string, revision int) (*servicerevision.Revision, error) {
+	ret := _m.Called(ctx, serviceID, revision)
+
+	var r0 *servicerevision.Revision
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, int) *servicerevision.Revision); ok {
+		r0 = rf(ctx, serviceID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*servicerevision.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string, int) error); ok {
+		r1 = rf(ctx, serviceID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceRevisions provides a mock function with given fields: ctx, serviceID
+func (_m *FacadeInterface) GetServiceRevisions(ctx datastore.Context, serviceID string) ([]servicerevision.Revision, error) {
+	ret := _m.Called(ctx, serviceID)
+
+	var r0 []servicerevision.Revision
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) []servicerevision.Revision); ok {
+		r0 = rf(ctx, serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]servicerevision.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSnapshotSchedule provides a mock function with given fields: ctx, tenantID
+func (_m *FacadeInterface) GetSnapshotSchedule(ctx datastore.Context, tenantID string) (*snapshotschedule.Schedule, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *snapshotschedule.Schedule
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) *snapshotschedule.Schedule); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*snapshotschedule.Schedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSnapshotSchedules provides a mock function with given fields: ctx
+func (_m *FacadeInterface) GetSnapshotSchedules(ctx datastore.Context) ([]snapshotschedule.Schedule, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []snapshotschedule.Schedule
+	if rf, ok := ret.Get(0).(func(datastore.Context) []snapshotschedule.Schedule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]snapshotschedule.Schedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserRole provides a mock function with given fields: ctx, userName
+func (_m *FacadeInterface) GetUserRole(ctx datastore.Context, userName string) (auth.Role, error) {
+	ret := _m.Called(ctx, userName)
 
-	var r0 []byte
-	if rf, ok := ret.Get(0).(func(datastore.Context, *host.Host) []byte); ok {
-		r0 = rf(ctx, entity)
+	var r0 auth.Role
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) auth.Role); ok {
+		r0 = rf(ctx, userName)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
+		r0 = ret.Get(0).(auth.Role)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(datastore.Context, *host.Host) error); ok {
-		r1 = rf(ctx, entity)
+	if rf, ok := ret.Get(1).(func(datastore.Context, string) error); ok {
+		r1 = rf(ctx, userName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -67,22 +628,22 @@ func (_m *FacadeInterface) AddHostPrivate(ctx datastore.Context, entity *host.Ho
 	return r0, r1
 }
 
-// AddPublicEndpointPort provides a mock function with given fields: ctx, serviceid, endpointName, portAddr, usetls, protocol, isEnabled, restart
-func (_m *FacadeInterface) AddPublicEndpointPort(ctx datastore.Context, serviceid string, endpointName string, portAddr string, usetls bool, protocol string, isEnabled bool, restart bool) (*servicedefinition.Port, error) {
-	ret := _m.Called(ctx, serviceid, endpointName, portAddr, usetls, protocol, isEnabled, restart)
+// GetUsers provides a mock function with given fields: ctx
+func (_m *FacadeInterface) GetUsers(ctx datastore.Context) ([]user.User, error) {
+	ret := _m.Called(ctx)
 
-	var r0 *servicedefinition.Port
-	if rf, ok := ret.Get(0).(func(datastore.Context, string, string, string, bool, string, bool, bool) *servicedefinition.Port); ok {
-		r0 = rf(ctx, serviceid, endpointName, portAddr, usetls, protocol, isEnabled, restart)
+	var r0 []user.User
+	if rf, ok := ret.Get(0).(func(datastore.Context) []user.User); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*servicedefinition.Port)
+			r0 = ret.Get(0).([]user.User)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(datastore.Context, string, string, string, bool, string, bool, bool) error); ok {
-		r1 = rf(ctx, serviceid, endpointName, portAddr, usetls, protocol, isEnabled, restart)
+	if rf, ok := ret.Get(1).(func(datastore.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -90,22 +651,22 @@ func (_m *FacadeInterface) AddPublicEndpointPort(ctx datastore.Context, servicei
 	return r0, r1
 }
 
-// AddPublicEndpointVHost provides a mock function with given fields: ctx, serviceid, endpointName, vhost, isEnabled, restart
-func (_m *FacadeInterface) AddPublicEndpointVHost(ctx datastore.Context, serviceid string, endpointName string, vhost string, isEnabled bool, restart bool) (*servicedefinition.VHost, error) {
-	ret := _m.Called(ctx, serviceid, endpointName, vhost, isEnabled, restart)
+// PlanServiceUpdate provides a mock function with given fields: ctx, svc
+func (_m *FacadeInterface) PlanServiceUpdate(ctx datastore.Context, svc service.Service) (*service.ChangePlan, error) {
+	ret := _m.Called(ctx, svc)
 
-	var r0 *servicedefinition.VHost
-	if rf, ok := ret.Get(0).(func(datastore.Context, string, string, string, bool, bool) *servicedefinition.VHost); ok {
-		r0 = rf(ctx, serviceid, endpointName, vhost, isEnabled, restart)
+	var r0 *service.ChangePlan
+	if rf, ok := ret.Get(0).(func(datastore.Context, service.Service) *service.ChangePlan); ok {
+		r0 = rf(ctx, svc)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*servicedefinition.VHost)
+			r0 = ret.Get(0).(*service.ChangePlan)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(datastore.Context, string, string, string, bool, bool) error); ok {
-		r1 = rf(ctx, serviceid, endpointName, vhost, isEnabled, restart)
+	if rf, ok := ret.Get(1).(func(datastore.Context, service.Service) error); ok {
+		r1 = rf(ctx, svc)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -113,62 +674,45 @@ func (_m *FacadeInterface) AddPublicEndpointVHost(ctx datastore.Context, service
 	return r0, r1
 }
 
-// AddResourcePool provides a mock function with given fields: ctx, entity
-func (_m *FacadeInterface) AddResourcePool(ctx datastore.Context, entity *pool.ResourcePool) error {
-	ret := _m.Called(ctx, entity)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(datastore.Context, *pool.ResourcePool) error); ok {
-		r0 = rf(ctx, entity)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// AddService provides a mock function with given fields: ctx, svc
-func (_m *FacadeInterface) AddService(ctx datastore.Context, svc service.Service) error {
-	ret := _m.Called(ctx, svc)
+// PlanTemplateDeploy provides a mock function with given fields: ctx, poolID, templateID, deploymentID
+func (_m *FacadeInterface) PlanTemplateDeploy(ctx datastore.Context, poolID string, templateID string, deploymentID string) (*service.ChangePlan, error) {
+	ret := _m.Called(ctx, poolID, templateID, deploymentID)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(datastore.Context, service.Service) error); ok {
-		r0 = rf(ctx, svc)
+	var r0 *service.ChangePlan
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, string, string) *service.ChangePlan); ok {
+		r0 = rf(ctx, poolID, templateID, deploymentID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ChangePlan)
+		}
 	}
 
-	return r0
-}
-
-// AddServiceConfig provides a mock function with given fields: ctx, serviceID, conf
-func (_m *FacadeInterface) AddServiceConfig(ctx datastore.Context, serviceID string, conf servicedefinition.ConfigFile) error {
-	ret := _m.Called(ctx, serviceID, conf)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(datastore.Context, string, servicedefinition.ConfigFile) error); ok {
-		r0 = rf(ctx, serviceID, conf)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, string, string, string) error); ok {
+		r1 = rf(ctx, poolID, templateID, deploymentID)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// AddServiceTemplate provides a mock function with given fields: ctx, serviceTemplate, reloadLogstashConfig
-func (_m *FacadeInterface) AddServiceTemplate(ctx datastore.Context, serviceTemplate servicetemplate.ServiceTemplate, reloadLogstashConfig bool) (string, error) {
-	ret := _m.Called(ctx, serviceTemplate, reloadLogstashConfig)
+// RebalancePool provides a mock function with given fields: ctx, poolID, limit, dryRun
+func (_m *FacadeInterface) RebalancePool(ctx datastore.Context, poolID string, limit int, dryRun bool) ([]service.Migration, error) {
+	ret := _m.Called(ctx, poolID, limit, dryRun)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(datastore.Context, servicetemplate.ServiceTemplate, bool) string); ok {
-		r0 = rf(ctx, serviceTemplate, reloadLogstashConfig)
+	var r0 []service.Migration
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, int, bool) []service.Migration); ok {
+		r0 = rf(ctx, poolID, limit, dryRun)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.Migration)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(datastore.Context, servicetemplate.ServiceTemplate, bool) error); ok {
-		r1 = rf(ctx, serviceTemplate, reloadLogstashConfig)
+	if rf, ok := ret.Get(1).(func(datastore.Context, string, int, bool) error); ok {
+		r1 = rf(ctx, poolID, limit, dryRun)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -176,27 +720,18 @@ func (_m *FacadeInterface) AddServiceTemplate(ctx datastore.Context, serviceTemp
 	return r0, r1
 }
 
-// AddUser provides a mock function with given fields: ctx, newUser
-func (_m *FacadeInterface) AddUser(ctx datastore.Context, newUser user.User) error {
-	ret := _m.Called(ctx, newUser)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(datastore.Context, user.User) error); ok {
-		r0 = rf(ctx, newUser)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
+// RecordPlacementDecision provides a mock function with given fields: ctx, decision
+func (_m *FacadeInterface) RecordPlacementDecision(ctx datastore.Context, decision service.PlacementDecision) {
+	_m.Called(ctx, decision)
 }
 
-// AssignIPs provides a mock function with given fields: ctx, assignmentRequest
-func (_m *FacadeInterface) AssignIPs(ctx datastore.Context, assignmentRequest addressassignment.AssignmentRequest) error {
-	ret := _m.Called(ctx, assignmentRequest)
+// RemoveAPIToken provides a mock function with given fields: ctx, id
+func (_m *FacadeInterface) RemoveAPIToken(ctx datastore.Context, id string) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(datastore.Context, addressassignment.AssignmentRequest) error); ok {
-		r0 = rf(ctx, assignmentRequest)
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -406,6 +941,29 @@ func (_m *FacadeInterface) EnablePublicEndpointVHost(ctx datastore.Context, serv
 	return r0
 }
 
+// EvaluateAlerts provides a mock function with given fields: ctx
+func (_m *FacadeInterface) EvaluateAlerts(ctx datastore.Context) (*notify.Alerts, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *notify.Alerts
+	if rf, ok := ret.Get(0).(func(datastore.Context) *notify.Alerts); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*notify.Alerts)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindHostsInPool provides a mock function with given fields: ctx, poolID
 func (_m *FacadeInterface) FindHostsInPool(ctx datastore.Context, poolID string) ([]host.Host, error) {
 	ret := _m.Called(ctx, poolID)
@@ -1270,6 +1828,27 @@ func (_m *FacadeInterface) HostIsAuthenticated(ctx datastore.Context, hostid str
 	return r0, r1
 }
 
+// IsTenantRestricted provides a mock function with given fields: ctx
+func (_m *FacadeInterface) IsTenantRestricted(ctx datastore.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(datastore.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListTenants provides a mock function with given fields: _a0
 func (_m *FacadeInterface) ListTenants(_a0 datastore.Context) ([]string, error) {
 	ret := _m.Called(_a0)
@@ -1447,6 +2026,20 @@ func (_m *FacadeInterface) RemoveServiceTemplate(ctx datastore.Context, template
 	return r0
 }
 
+// RemoveSnapshotSchedule provides a mock function with given fields: ctx, tenantID
+func (_m *FacadeInterface) RemoveSnapshotSchedule(ctx datastore.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveUser provides a mock function with given fields: ctx, userName
 func (_m *FacadeInterface) RemoveUser(ctx datastore.Context, userName string) error {
 	ret := _m.Called(ctx, userName)
@@ -1494,6 +2087,71 @@ func (_m *FacadeInterface) ResetHostKey(ctx datastore.Context, hostID string) ([
 	return r0, r1
 }
 
+// ResumeImageUpgrade provides a mock function with given fields: ctx, serviceID
+func (_m *FacadeInterface) ResumeImageUpgrade(ctx datastore.Context, serviceID string) error {
+	ret := _m.Called(ctx, serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) error); ok {
+		r0 = rf(ctx, serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResumeRollingRestart provides a mock function with given fields: ctx, serviceID
+func (_m *FacadeInterface) ResumeRollingRestart(ctx datastore.Context, serviceID string) error {
+	ret := _m.Called(ctx, serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, string) error); ok {
+		r0 = rf(ctx, serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevertService provides a mock function with given fields: ctx, serviceID, revision
+func (_m *FacadeInterface) RevertService(ctx datastore.Context, serviceID string, revision int) error {
+	ret := _m.Called(ctx, serviceID, revision)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, int) error); ok {
+		r0 = rf(ctx, serviceID, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RollingRestartService provides a mock function with given fields: ctx, request
+func (_m *FacadeInterface) RollingRestartService(ctx datastore.Context, request service.RollingRestartRequest) (*service.RollingRestartStatus, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *service.RollingRestartStatus
+	if rf, ok := ret.Get(0).(func(datastore.Context, service.RollingRestartRequest) *service.RollingRestartStatus); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.RollingRestartStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, service.RollingRestartRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ScheduleService provides a mock function with given fields: ctx, serviceID, autoLaunch, synchronous, desiredState
 func (_m *FacadeInterface) ScheduleServices(ctx datastore.Context, serviceIDs []string, autoLaunch bool, synchronous bool, desiredState service.DesiredState, emergency bool) (int, error) {
 	ret := _m.Called(ctx, serviceIDs, autoLaunch, synchronous, desiredState, emergency)
@@ -1608,6 +2266,48 @@ func (_m *FacadeInterface) RestartService(ctx datastore.Context, request dao.Sch
 	return r0, r1
 }
 
+// SetSnapshotSchedule provides a mock function with given fields: ctx, sched
+func (_m *FacadeInterface) SetSnapshotSchedule(ctx datastore.Context, sched snapshotschedule.Schedule) error {
+	ret := _m.Called(ctx, sched)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, snapshotschedule.Schedule) error); ok {
+		r0 = rf(ctx, sched)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserRole provides a mock function with given fields: ctx, userName, role
+func (_m *FacadeInterface) SetUserRole(ctx datastore.Context, userName string, role auth.Role) error {
+	ret := _m.Called(ctx, userName, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, auth.Role) error); ok {
+		r0 = rf(ctx, userName, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserTenants provides a mock function with given fields: ctx, userName, tenantIDs
+func (_m *FacadeInterface) SetUserTenants(ctx datastore.Context, userName string, tenantIDs []string) error {
+	ret := _m.Called(ctx, userName, tenantIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(datastore.Context, string, []string) error); ok {
+		r0 = rf(ctx, userName, tenantIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SyncServiceRegistry provides a mock function with given fields: ctx, svc
 func (_m *FacadeInterface) SyncServiceRegistry(ctx datastore.Context, svc *service.Service) error {
 	ret := _m.Called(ctx, svc)
@@ -1720,6 +2420,29 @@ func (_m *FacadeInterface) UpdateUser(ctx datastore.Context, u user.User) error
 	return r0
 }
 
+// UpgradeServiceImage provides a mock function with given fields: ctx, request
+func (_m *FacadeInterface) UpgradeServiceImage(ctx datastore.Context, request service.ImageUpgradeRequest) (*service.ImageUpgradeStatus, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *service.ImageUpgradeStatus
+	if rf, ok := ret.Get(0).(func(datastore.Context, service.ImageUpgradeRequest) *service.ImageUpgradeStatus); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ImageUpgradeStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(datastore.Context, service.ImageUpgradeRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateCredentials provides a mock function with given fields: ctx, u
 func (_m *FacadeInterface) ValidateCredentials(ctx datastore.Context, u user.User) (bool, error) {
 	ret := _m.Called(ctx, u)
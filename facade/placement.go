@@ -0,0 +1,132 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/service"
+)
+
+// byInstanceID sorts placement decisions by instance id
+type byInstanceID []service.PlacementDecision
+
+func (s byInstanceID) Len() int           { return len(s) }
+func (s byInstanceID) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byInstanceID) Less(i, j int) bool { return s[i].InstanceID < s[j].InstanceID }
+
+// placementLogSize is the number of placement decisions that are kept for
+// each resource pool.
+var placementLogSize = 1000
+
+// placementRing holds the most recent placement decisions made in a resource
+// pool, overwriting the oldest decision once it is full.
+type placementRing struct {
+	decisions []service.PlacementDecision
+	next      int // the oldest decision, once the ring is full
+}
+
+func (r *placementRing) add(decision service.PlacementDecision) {
+	if len(r.decisions) < placementLogSize {
+		r.decisions = append(r.decisions, decision)
+		return
+	}
+	r.decisions[r.next] = decision
+	r.next = (r.next + 1) % len(r.decisions)
+}
+
+// ordered returns the decisions of the ring from oldest to newest.
+func (r *placementRing) ordered() []service.PlacementDecision {
+	return append(append([]service.PlacementDecision{}, r.decisions[r.next:]...), r.decisions[:r.next]...)
+}
+
+// placementLog remembers the most recent placement decisions made by the
+// scheduler in each resource pool.  It is kept in memory, so it starts over
+// when the master restarts.
+type placementLog struct {
+	mutex sync.Mutex
+	pools map[string]*placementRing
+}
+
+func newPlacementLog() *placementLog {
+	return &placementLog{pools: make(map[string]*placementRing)}
+}
+
+func (l *placementLog) record(decision service.PlacementDecision) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	ring, ok := l.pools[decision.PoolID]
+	if !ok {
+		ring = &placementRing{}
+		l.pools[decision.PoolID] = ring
+	}
+	ring.add(decision)
+}
+
+// get returns the most recent decision for each instance of a service that
+// is still in the log.
+func (l *placementLog) get(serviceID string) []service.PlacementDecision {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	instances := make(map[int]service.PlacementDecision)
+	for _, ring := range l.pools {
+		for _, decision := range ring.ordered() {
+			if decision.ServiceID == serviceID {
+				instances[decision.InstanceID] = decision
+			}
+		}
+	}
+	decisions := []service.PlacementDecision{}
+	for _, decision := range instances {
+		decisions = append(decisions, decision)
+	}
+	sort.Sort(byInstanceID(decisions))
+	return decisions
+}
+
+func (l *placementLog) remove(serviceID string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for _, ring := range l.pools {
+		decisions := []service.PlacementDecision{}
+		for _, decision := range ring.ordered() {
+			if decision.ServiceID != serviceID {
+				decisions = append(decisions, decision)
+			}
+		}
+		ring.decisions, ring.next = decisions, 0
+	}
+}
+
+// RecordPlacementDecision saves the placement decision made by the scheduler
+// for an instance of a service, replacing any previous decision for that
+// instance.
+func (f *Facade) RecordPlacementDecision(ctx datastore.Context, decision service.PlacementDecision) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.RecordPlacementDecision"))
+	f.placements.record(decision)
+}
+
+// GetPlacementDecisions returns the most recent placement decision for each
+// instance of a service that the scheduler has placed since the master
+// started, ordered by instance id.  Only the latest decisions of each pool are
+// kept, so instances that were placed long ago may be missing.
+func (f *Facade) GetPlacementDecisions(ctx datastore.Context, serviceID string) ([]service.PlacementDecision, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetPlacementDecisions"))
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	return f.placements.get(serviceID), nil
+}
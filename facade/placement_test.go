@@ -0,0 +1,80 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade
+
+import (
+	"github.com/control-center/serviced/domain/service"
+	. "gopkg.in/check.v1"
+)
+
+var _ = Suite(&PlacementLogTest{})
+
+type PlacementLogTest struct {
+	size int
+}
+
+func (t *PlacementLogTest) SetUpTest(c *C) {
+	t.size = placementLogSize
+	placementLogSize = 3
+}
+
+func (t *PlacementLogTest) TearDownTest(c *C) {
+	placementLogSize = t.size
+}
+
+func (t *PlacementLogTest) TestPlacementLogKeepsLatestDecisionsPerPool(c *C) {
+	l := newPlacementLog()
+	for instanceID := 0; instanceID < 5; instanceID++ {
+		l.record(service.PlacementDecision{ServiceID: "busy", PoolID: "pool1", InstanceID: instanceID})
+	}
+	l.record(service.PlacementDecision{ServiceID: "quiet", PoolID: "pool2", InstanceID: 0})
+
+	// only the newest decisions of the busy pool are kept
+	decisions := l.get("busy")
+	c.Assert(decisions, HasLen, 3)
+	for i, decision := range decisions {
+		c.Assert(decision.InstanceID, Equals, i+2)
+	}
+	c.Assert(l.get("quiet"), HasLen, 1)
+
+	// a newer decision for an instance replaces the older one
+	l.record(service.PlacementDecision{ServiceID: "busy", PoolID: "pool1", InstanceID: 3, HostID: "host2"})
+	decisions = l.get("busy")
+	c.Assert(decisions, HasLen, 2)
+	c.Assert(decisions[0].InstanceID, Equals, 3)
+	c.Assert(decisions[0].HostID, Equals, "host2")
+	c.Assert(decisions[1].InstanceID, Equals, 4)
+}
+
+func (t *PlacementLogTest) TestPlacementLogRemove(c *C) {
+	l := newPlacementLog()
+	for instanceID := 0; instanceID < 4; instanceID++ {
+		l.record(service.PlacementDecision{ServiceID: "removed", PoolID: "pool1", InstanceID: instanceID})
+	}
+	l.record(service.PlacementDecision{ServiceID: "kept", PoolID: "pool1", InstanceID: 0})
+
+	l.remove("removed")
+	c.Assert(l.get("removed"), HasLen, 0)
+	c.Assert(l.get("kept"), HasLen, 1)
+
+	// the ring fills up again after the removal
+	for instanceID := 1; instanceID < 4; instanceID++ {
+		l.record(service.PlacementDecision{ServiceID: "kept", PoolID: "pool1", InstanceID: instanceID})
+	}
+	decisions := l.get("kept")
+	c.Assert(decisions, HasLen, 3)
+	c.Assert(decisions[0].InstanceID, Equals, 1)
+}
@@ -0,0 +1,39 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"github.com/control-center/serviced/domain/service"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeUnitTest) Test_GetPlacementDecisions(c *C) {
+	ft.Facade.RecordPlacementDecision(ft.ctx, service.PlacementDecision{ServiceID: "placed", InstanceID: 1, Message: "no hosts available"})
+	ft.Facade.RecordPlacementDecision(ft.ctx, service.PlacementDecision{ServiceID: "placed", InstanceID: 0, HostID: "host1"})
+	ft.Facade.RecordPlacementDecision(ft.ctx, service.PlacementDecision{ServiceID: "placed", InstanceID: 1, HostID: "host2"})
+	ft.Facade.RecordPlacementDecision(ft.ctx, service.PlacementDecision{ServiceID: "other", InstanceID: 0, HostID: "host1"})
+
+	decisions, err := ft.Facade.GetPlacementDecisions(ft.ctx, "placed")
+	c.Assert(err, IsNil)
+	c.Assert(decisions, DeepEquals, []service.PlacementDecision{
+		{ServiceID: "placed", InstanceID: 0, HostID: "host1"},
+		{ServiceID: "placed", InstanceID: 1, HostID: "host2"},
+	})
+
+	decisions, err = ft.Facade.GetPlacementDecisions(ft.ctx, "unplaced")
+	c.Assert(err, IsNil)
+	c.Assert(decisions, HasLen, 0)
+}
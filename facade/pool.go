@@ -19,6 +19,7 @@ import (
 	"github.com/control-center/serviced/domain/addressassignment"
 	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/pool"
+	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/validation"
 	"github.com/zenoss/glog"
 
@@ -44,6 +45,19 @@ var (
 	ErrDefaultPool   = errors.New("facade: cannot delete default resource pool")
 )
 
+// PoolQuotaError is returned when a change would commit more of a resource to
+// the services in a pool than the pool's limit allows.
+type PoolQuotaError struct {
+	PoolID     string
+	Resource   string
+	Commitment uint64
+	Limit      uint64
+}
+
+func (err PoolQuotaError) Error() string {
+	return fmt.Sprintf("facade: resource pool %s quota exceeded: %d %s would be committed, the limit is %d", err.PoolID, err.Commitment, err.Resource, err.Limit)
+}
+
 // AddResourcePool adds a new resource pool
 func (f *Facade) AddResourcePool(ctx datastore.Context, entity *pool.ResourcePool) error {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.AddResourcePool"))
@@ -522,6 +536,104 @@ func (f *Facade) calcPoolCommitment(ctx datastore.Context, pool *pool.ResourcePo
 	return nil
 }
 
+// commitment is the amount of cores and memory reserved by service instances.
+type commitment struct {
+	cores  int
+	memory uint64
+}
+
+func (c commitment) add(other commitment) commitment {
+	return commitment{cores: c.cores + other.cores, memory: c.memory + other.memory}
+}
+
+// serviceCommitment returns the resources reserved by all of the instances of
+// a service.
+func serviceCommitment(svc *service.Service) commitment {
+	if svc.Instances <= 0 {
+		return commitment{}
+	}
+	return commitment{
+		cores:  int(svc.CPUCommitment) * svc.Instances,
+		memory: svc.RAMCommitment.Value * uint64(svc.Instances),
+	}
+}
+
+// poolCommitment returns the resources reserved by the services in a pool, as
+// well as the portion of that reserved by the service with the given id.
+func (f *Facade) poolCommitment(ctx datastore.Context, poolID, serviceID string) (total, current commitment, err error) {
+	services, err := f.serviceStore.GetServicesByPool(ctx, poolID)
+	if err != nil {
+		glog.Errorf("Unable to find services on %s: %v", poolID, err)
+		return commitment{}, commitment{}, err
+	}
+	for i := range services {
+		c := serviceCommitment(&services[i])
+		total = total.add(c)
+		if services[i].ID == serviceID {
+			current = current.add(c)
+		}
+	}
+	return total, current, nil
+}
+
+// validatePoolQuota verifies that replacing the resources currently reserved
+// by a service (if any) with the requested resources does not exceed the
+// limits of the pool.  Changes that do not increase the commitment of a pool
+// are always allowed, so that a pool whose limit was lowered can still be
+// scaled down.
+func (f *Facade) validatePoolQuota(ctx datastore.Context, poolID, serviceID string, requested commitment) error {
+	if poolID == "" {
+		return nil
+	}
+	var entity pool.ResourcePool
+	if err := f.poolStore.Get(ctx, pool.Key(poolID), &entity); datastore.IsErrNoSuchEntity(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if entity.CoreLimit <= 0 && entity.MemoryLimit == 0 {
+		return nil
+	}
+	total, current, err := f.poolCommitment(ctx, poolID, serviceID)
+	if err != nil {
+		return err
+	}
+	cores := total.cores - current.cores + requested.cores
+	if entity.CoreLimit > 0 && cores > entity.CoreLimit && cores > total.cores {
+		return PoolQuotaError{PoolID: poolID, Resource: "cores", Commitment: uint64(cores), Limit: uint64(entity.CoreLimit)}
+	}
+	memory := total.memory - current.memory + requested.memory
+	if entity.MemoryLimit > 0 && memory > entity.MemoryLimit && memory > total.memory {
+		return PoolQuotaError{PoolID: poolID, Resource: "bytes of memory", Commitment: memory, Limit: entity.MemoryLimit}
+	}
+	return nil
+}
+
+// GetPoolUsage reports the resources committed to the services in a pool
+// against the capacity of its hosts and its limits.
+func (f *Facade) GetPoolUsage(ctx datastore.Context, poolID string) (*pool.PoolUsage, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetPoolUsage"))
+	entity, err := f.GetResourcePool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	} else if entity == nil {
+		return nil, ErrPoolNotExists
+	}
+	total, _, err := f.poolCommitment(ctx, poolID, "")
+	if err != nil {
+		return nil, err
+	}
+	return &pool.PoolUsage{
+		PoolID:           poolID,
+		CoreCommitment:   total.cores,
+		CoreCapacity:     entity.CoreCapacity,
+		CoreLimit:        entity.CoreLimit,
+		MemoryCommitment: total.memory,
+		MemoryCapacity:   entity.MemoryCapacity,
+		MemoryLimit:      entity.MemoryLimit,
+	}, nil
+}
+
 // GetPoolIPs gets all IPs available to a resource pool
 func (f *Facade) GetPoolIPs(ctx datastore.Context, poolID string) (*pool.PoolIPs, error) {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetPoolIPs"))
@@ -23,6 +23,7 @@ import (
 	"github.com/control-center/serviced/domain/pool"
 	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/serviceconfigfile"
+	"github.com/control-center/serviced/domain/servicerevision"
 	"github.com/control-center/serviced/domain/servicetemplate"
 	"github.com/control-center/serviced/utils"
 	"github.com/stretchr/testify/mock"
@@ -184,6 +185,12 @@ func (ft *FacadeUnitTest) Test_PoolCacheEditService(c *C) {
 	ft.zzk.On("UpdateService", ft.ctx, mock.AnythingOfType("string"), mock.AnythingOfType("*service.Service"), false, false).
 		Return(nil)
 
+	ft.revisionStore.On("GetRevisions", ft.ctx, pc.firstService.ID).
+		Return([]servicerevision.Revision{}, nil)
+
+	ft.revisionStore.On("Put", ft.ctx, mock.AnythingOfType("*servicerevision.Revision")).
+		Return(nil)
+
 
 	pools, err := ft.Facade.GetReadPools(ft.ctx)
 	c.Assert(err, IsNil)
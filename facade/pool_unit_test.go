@@ -19,9 +19,15 @@ import (
 	"time"
 
 	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain"
 	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/pool"
 	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/serviceconfigfile"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/domain/servicerevision"
+	"github.com/control-center/serviced/domain/servicetemplate"
+	"github.com/control-center/serviced/facade"
 	"github.com/control-center/serviced/utils"
 	"github.com/stretchr/testify/mock"
 	. "gopkg.in/check.v1"
@@ -167,3 +173,104 @@ func (ft *FacadeUnitTest) Test_GetReadPoolsShouldReturnCorrectValues(c *C) {
 	c.Assert(p.UpdatedAt, TimeEqual, resourcePool.UpdatedAt)
 	c.Assert(p.Permissions, Equals, resourcePool.Permissions)
 }
+
+func (ft *FacadeUnitTest) setupMockQuotaPool(poolID string, coreLimit int, memoryLimit uint64) {
+	ft.poolStore.On("Get", ft.ctx, pool.Key(poolID), mock.AnythingOfType("*pool.ResourcePool")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			p := args.Get(2).(*pool.ResourcePool)
+			*p = pool.ResourcePool{ID: poolID, CoreLimit: coreLimit, MemoryLimit: memoryLimit}
+		})
+	ft.hostStore.On("FindHostsWithPoolID", ft.ctx, poolID).
+		Return([]host.Host{
+			{ID: "host1", Cores: 8, Memory: 8000},
+			{ID: "host2", Cores: 4, Memory: 4000},
+		}, nil)
+	ft.serviceStore.On("GetServicesByPool", ft.ctx, poolID).
+		Return([]service.Service{
+			{ID: "svc1", Instances: 2, CPUCommitment: 1, RAMCommitment: utils.NewEngNotation(1000)},
+			{ID: "svc2", Instances: 1, CPUCommitment: 2, RAMCommitment: utils.NewEngNotation(500)},
+			{ID: "svc3", Instances: 0, CPUCommitment: 4, RAMCommitment: utils.NewEngNotation(4000)},
+		}, nil)
+}
+
+func (ft *FacadeUnitTest) Test_GetPoolUsage(c *C) {
+	ft.setupMockQuotaPool("quotaPool", 6, 3000)
+
+	usage, err := ft.Facade.GetPoolUsage(ft.ctx, "quotaPool")
+	c.Assert(err, IsNil)
+	c.Assert(*usage, DeepEquals, pool.PoolUsage{
+		PoolID:           "quotaPool",
+		CoreCommitment:   4,
+		CoreCapacity:     12,
+		CoreLimit:        6,
+		MemoryCommitment: 2500,
+		MemoryCapacity:   12000,
+		MemoryLimit:      3000,
+	})
+}
+
+func (ft *FacadeUnitTest) Test_GetPoolUsageNotFound(c *C) {
+	ft.poolStore.On("Get", ft.ctx, pool.Key("missing"), mock.AnythingOfType("*pool.ResourcePool")).
+		Return(datastore.ErrNoSuchEntity{})
+
+	usage, err := ft.Facade.GetPoolUsage(ft.ctx, "missing")
+	c.Assert(usage, IsNil)
+	c.Assert(err, Equals, facade.ErrPoolNotExists)
+}
+
+func (ft *FacadeUnitTest) Test_DeployTemplateExceedsPoolQuota(c *C) {
+	ft.setupMockQuotaPool("quotaPool", 6, 0)
+	template := &servicetemplate.ServiceTemplate{
+		ID: "template1",
+		Services: []servicedefinition.ServiceDefinition{
+			{
+				Name:          "app",
+				Instances:     domain.MinMax{Min: 1, Default: 2},
+				CPUCommitment: 1,
+				Services: []servicedefinition.ServiceDefinition{
+					{Name: "worker", Instances: domain.MinMax{Min: 1}, CPUCommitment: 1},
+				},
+			},
+		},
+	}
+	ft.templateStore.On("Get", ft.ctx, "template1").Return(template, nil)
+	ft.serviceStore.On("GetServicesByDeployment", ft.ctx, "deployment1").Return([]service.Service{}, nil)
+
+	tenantIDs, err := ft.Facade.DeployTemplate(ft.ctx, "quotaPool", "template1", "deployment1")
+	c.Assert(tenantIDs, IsNil)
+	c.Assert(err, DeepEquals, facade.PoolQuotaError{PoolID: "quotaPool", Resource: "cores", Commitment: 7, Limit: 6})
+	ft.serviceStore.AssertNotCalled(c, "FindChildService", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_UpdateServiceSkipsPoolQuota(c *C) {
+	ft.setupMockDFSLocking()
+	current := service.Service{
+		ID:            "svc1",
+		PoolID:        "quotaPool",
+		Instances:     2,
+		CPUCommitment: 1,
+		RAMCommitment: utils.NewEngNotation(1000),
+	}
+	ft.serviceStore.On("GetServiceDetails", ft.ctx, current.ID).
+		Return(&service.ServiceDetails{ID: current.ID, PoolID: current.PoolID}, nil)
+	ft.serviceStore.On("Get", ft.ctx, current.ID).Return(&current, nil)
+	ft.serviceStore.On("Put", ft.ctx, mock.AnythingOfType("*service.Service")).Return(nil)
+	ft.serviceStore.On("GetServiceDetailsByParentID", ft.ctx, mock.AnythingOfType("string"), mock.AnythingOfType("time.Duration")).
+		Return([]service.ServiceDetails{}, nil)
+	ft.configStore.On("GetConfigFiles", ft.ctx, mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+		Return([]*serviceconfigfile.SvcConfigFile{}, nil)
+	ft.templateStore.On("GetServiceTemplates", ft.ctx).Return([]*servicetemplate.ServiceTemplate{}, nil)
+	ft.zzk.On("UpdateService", ft.ctx, mock.AnythingOfType("string"), mock.AnythingOfType("*service.Service"), false, false).
+		Return(nil)
+	ft.revisionStore.On("GetRevisions", ft.ctx, current.ID).Return([]servicerevision.Revision{}, nil)
+	ft.revisionStore.On("Put", ft.ctx, mock.AnythingOfType("*servicerevision.Revision")).Return(nil)
+
+	// the resources reserved by the service do not change, so the pool is
+	// not consulted
+	svc := current
+	svc.Description = "an updated description"
+	err := ft.Facade.UpdateService(ft.ctx, svc)
+	c.Assert(err, IsNil)
+	ft.serviceStore.AssertNotCalled(c, "GetServicesByPool", ft.ctx, "quotaPool")
+}
@@ -0,0 +1,420 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/control-center/serviced/audit"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/host"
+	"github.com/control-center/serviced/domain/pool"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/health"
+	"github.com/control-center/serviced/scheduler/strategy"
+)
+
+var ErrRebalanceInProgress = errors.New("facade: a rebalance is already in progress for the pool")
+
+var (
+	_ strategy.Host          = &rebalanceHost{}
+	_ strategy.ServiceConfig = &rebalanceInstance{}
+)
+
+// rebalancer remembers which pools are being rebalanced, and the host that
+// each migrating instance should be started on.  It is kept in memory, so a
+// rebalance that is interrupted by a restart of the master is not resumed.
+type rebalancer struct {
+	mutex   sync.Mutex
+	pools   map[string]struct{}
+	targets map[string]map[int]string
+}
+
+func newRebalancer() *rebalancer {
+	return &rebalancer{
+		pools:   make(map[string]struct{}),
+		targets: make(map[string]map[int]string),
+	}
+}
+
+func (r *rebalancer) start(poolID string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.pools[poolID]; ok {
+		return ErrRebalanceInProgress
+	}
+	r.pools[poolID] = struct{}{}
+	return nil
+}
+
+func (r *rebalancer) finish(poolID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.pools, poolID)
+}
+
+func (r *rebalancer) setTarget(serviceID string, instanceID int, hostID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	instances, ok := r.targets[serviceID]
+	if !ok {
+		instances = make(map[int]string)
+		r.targets[serviceID] = instances
+	}
+	instances[instanceID] = hostID
+}
+
+func (r *rebalancer) clearTarget(serviceID string, instanceID int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.targets[serviceID], instanceID)
+	if len(r.targets[serviceID]) == 0 {
+		delete(r.targets, serviceID)
+	}
+}
+
+func (r *rebalancer) target(serviceID string, instanceID int) (string, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	hostID, ok := r.targets[serviceID][instanceID]
+	return hostID, ok
+}
+
+// rebalanceHost and rebalanceInstance describe the hosts of a pool and the
+// instances running on them to the rebalance planner.
+type rebalanceHost struct {
+	host      host.Host
+	instances []strategy.ServiceConfig
+}
+
+func (h *rebalanceHost) HostID() string {
+	return h.host.ID
+}
+
+func (h *rebalanceHost) TotalCores() int {
+	return h.host.Cores
+}
+
+func (h *rebalanceHost) TotalMemory() uint64 {
+	return h.host.TotalRAM()
+}
+
+func (h *rebalanceHost) Labels() map[string]string {
+	return h.host.Labels
+}
+
+func (h *rebalanceHost) RunningServices() []strategy.ServiceConfig {
+	return h.instances
+}
+
+type rebalanceInstance struct {
+	inst service.StrategyInstance
+}
+
+func (i *rebalanceInstance) GetServiceID() string {
+	return i.inst.ServiceID
+}
+
+func (i *rebalanceInstance) GetServiceName() string {
+	return i.inst.ServiceName
+}
+
+func (i *rebalanceInstance) GetDeploymentID() string {
+	return i.inst.DeploymentID
+}
+
+func (i *rebalanceInstance) RequestedCorePercent() int {
+	return i.inst.CPUCommitment
+}
+
+func (i *rebalanceInstance) RequestedMemoryBytes() uint64 {
+	return i.inst.RAMCommitment
+}
+
+func (i *rebalanceInstance) HostPolicy() servicedefinition.HostPolicy {
+	return i.inst.HostPolicy
+}
+
+func (i *rebalanceInstance) HostLabels() *servicedefinition.LabelSelector {
+	return i.inst.HostLabels
+}
+
+func (i *rebalanceInstance) Affinity() *servicedefinition.Affinity {
+	return i.inst.Affinity
+}
+
+// RebalancePool plans up to limit migrations of running service instances
+// from the most committed hosts of a pool to the least committed, scoring the
+// hosts the way the scheduler does.  Unless dryRun is set, the migrations are
+// done one at a time: the instance is stopped, the scheduler starts it on the
+// target host, and the next migration waits until the instance passes its
+// health checks there.  If a migration fails, the rest are not done.
+func (f *Facade) RebalancePool(ctx datastore.Context, poolID string, limit int, dryRun bool) ([]service.Migration, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.RebalancePool"))
+	logger := plog.WithField("poolid", poolID)
+
+	if p, err := f.GetResourcePool(ctx, poolID); err != nil {
+		return nil, err
+	} else if p == nil {
+		return nil, fmt.Errorf("pool %s not found", poolID)
+	}
+	if err := f.rebalancer.start(poolID); err != nil {
+		return nil, err
+	}
+	defer f.rebalancer.finish(poolID)
+
+	migrations, err := f.planRebalance(ctx, poolID, limit)
+	if err != nil || dryRun || len(migrations) == 0 {
+		return migrations, err
+	}
+
+	alog := f.auditLogger.Message(ctx, "Rebalance Pool").Action(audit.Update).ID(poolID).Type(pool.GetType()).
+		WithField("migrations", fmt.Sprintf("%d", len(migrations)))
+	timeout := f.rollingRestartTimeout
+	if timeout == 0 {
+		timeout = defaultRollingRestartTimeout
+	}
+	for i := range migrations {
+		m := &migrations[i]
+		mlogger := logger.WithFields(log.Fields{
+			"serviceid":  m.ServiceID,
+			"instanceid": m.InstanceID,
+			"from":       m.FromHostID,
+			"to":         m.ToHostID,
+		})
+		if err := f.migrateInstance(ctx, m, timeout); err != nil {
+			mlogger.WithError(err).Warn("Could not migrate instance, stopping rebalance")
+			m.Message = err.Error()
+			for j := i + 1; j < len(migrations); j++ {
+				migrations[j].Message = "an earlier migration failed"
+			}
+			alog.WithField("reason", m.Message).Failed()
+			return migrations, nil
+		}
+		m.Migrated = true
+		mlogger.Info("Migrated instance")
+	}
+	alog.Succeeded()
+	return migrations, nil
+}
+
+// planRebalance returns the migrations that would even out the commitment of
+// the active hosts in the pool.
+func (f *Facade) planRebalance(ctx datastore.Context, poolID string, limit int) ([]service.Migration, error) {
+	migrations := []service.Migration{}
+	if limit <= 0 {
+		return migrations, nil
+	}
+
+	var active []string
+	if err := f.zzk.GetActiveHosts(ctx, poolID, &active); err != nil {
+		return nil, err
+	}
+	isActive := make(map[string]bool)
+	for _, hostID := range active {
+		isActive[hostID] = true
+	}
+	allHosts, err := f.hostStore.FindHostsWithPoolID(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	hosts := []host.Host{}
+	for _, h := range allHosts {
+		if isActive[h.ID] {
+			hosts = append(hosts, h)
+		}
+	}
+
+	insts, err := f.GetHostStrategyInstances(ctx, hosts)
+	if err != nil {
+		return nil, err
+	}
+	hostmap := make(map[string]*rebalanceHost)
+	shosts := []strategy.Host{}
+	for _, h := range hosts {
+		hostmap[h.ID] = &rebalanceHost{host: h, instances: []strategy.ServiceConfig{}}
+		shosts = append(shosts, hostmap[h.ID])
+	}
+	movable := make(map[string]bool)
+	for _, inst := range insts {
+		if h, ok := hostmap[inst.HostID]; ok {
+			h.instances = append(h.instances, &rebalanceInstance{*inst})
+		}
+		if _, ok := movable[inst.ServiceID]; !ok {
+			svc, err := f.serviceStore.Get(ctx, inst.ServiceID)
+			if err != nil {
+				return nil, err
+			}
+			movable[inst.ServiceID] = canMigrate(svc)
+		}
+	}
+
+	canMove := func(svc strategy.ServiceConfig) bool {
+		return movable[svc.GetServiceID()]
+	}
+	// each migration is checked against the host policy of the service on
+	// the target host as it will be once the earlier migrations are done,
+	// because the scheduler starts the instance on the target host without
+	// consulting the policy
+	planned := make(map[string]*rebalanceHost)
+	for id, h := range hostmap {
+		planned[id] = &rebalanceHost{host: h.host, instances: append([]strategy.ServiceConfig{}, h.instances...)}
+	}
+	for _, m := range strategy.PlanRebalance(shosts, limit, canMove) {
+		inst := m.Service.(*rebalanceInstance).inst
+		from, to := planned[m.From.HostID()], planned[m.To.HostID()]
+		if err := checkHostPolicy(m.Service, to); err != nil {
+			plog.WithError(err).WithFields(log.Fields{
+				"serviceid":  inst.ServiceID,
+				"instanceid": inst.InstanceID,
+				"hostpolicy": inst.HostPolicy,
+				"from":       from.HostID(),
+				"to":         to.HostID(),
+			}).Debug("Host policy does not allow the migration, skipping")
+			continue
+		}
+		for i, svc := range from.instances {
+			if svc == m.Service {
+				from.instances = append(from.instances[:i], from.instances[i+1:]...)
+				break
+			}
+		}
+		to.instances = append(to.instances, m.Service)
+		migrations = append(migrations, service.Migration{
+			ServiceID:   inst.ServiceID,
+			ServiceName: inst.ServiceName,
+			InstanceID:  inst.InstanceID,
+			FromHostID:  m.From.HostID(),
+			ToHostID:    m.To.HostID(),
+			FromScore:   m.FromScore,
+			ToScore:     m.ToScore,
+		})
+	}
+	return migrations, nil
+}
+
+// checkHostPolicy returns an error if the host policy of a service does not
+// allow it to be started on a host.
+func checkHostPolicy(svc strategy.ServiceConfig, h strategy.Host) error {
+	strat, err := strategy.Get(string(svc.HostPolicy()))
+	if err != nil {
+		return err
+	}
+	selected, err := strat.SelectHost(svc, []strategy.Host{h})
+	if err != nil {
+		return err
+	} else if selected == nil {
+		return fmt.Errorf("host %s is not valid for host policy %s", h.HostID(), svc.HostPolicy())
+	}
+	return nil
+}
+
+// canMigrate returns false if the instances of a service cannot be moved
+// without disrupting the service, because the service has an address
+// assignment that ties it to a host, or because stopping one of its instances
+// restarts them all.
+func canMigrate(svc *service.Service) bool {
+	for _, ep := range svc.Endpoints {
+		if ep.IsConfigurable() {
+			return false
+		}
+	}
+	options := servicedefinition.ChangeOptions(svc.ChangeOptions)
+	return !options.Contains(servicedefinition.RestartAllOnInstanceChanged) &&
+		!options.Contains(servicedefinition.RestartAllOnInstanceZeroDown)
+}
+
+// migrateInstance stops an instance on its host, and waits for the scheduler
+// to start it on the target host and for it to pass its health checks.
+func (f *Facade) migrateInstance(ctx datastore.Context, m *service.Migration, timeout time.Duration) error {
+	svc, err := f.serviceStore.Get(ctx, m.ServiceID)
+	if err != nil {
+		return err
+	}
+	state, err := f.zzk.GetServiceState(ctx, svc.PoolID, svc.ID, m.InstanceID)
+	if err != nil {
+		return err
+	}
+	if state.HostID != m.FromHostID {
+		return fmt.Errorf("instance is no longer running on host %s", m.FromHostID)
+	}
+
+	f.rebalancer.setTarget(svc.ID, m.InstanceID, m.ToHostID)
+	defer f.rebalancer.clearTarget(svc.ID, m.InstanceID)
+	stopped := time.Now()
+	if err := f.zzk.StopServiceInstance(svc.PoolID, svc.ID, m.InstanceID); err != nil {
+		return err
+	}
+
+	svch := service.BuildServiceHealth(*svc)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(rollingRestartPollInterval)
+	defer ticker.Stop()
+	for {
+		if f.isInstanceRestarted(ctx, svc, svch, m.InstanceID, state.ContainerID, stopped) {
+			current, err := f.zzk.GetServiceState(ctx, svc.PoolID, svc.ID, m.InstanceID)
+			if err != nil {
+				return err
+			}
+			if current.HostID != m.ToHostID {
+				return fmt.Errorf("instance was started on host %s instead of host %s", current.HostID, m.ToHostID)
+			}
+			return nil
+		}
+
+		select {
+		case <-timer.C:
+			return fmt.Errorf("instance did not pass its health checks on host %s within %s", m.ToHostID, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// isInstanceRestarted returns true if an instance is running in a new
+// container and all of its health checks have passed since it was restarted.
+func (f *Facade) isInstanceRestarted(ctx datastore.Context, svc *service.Service, svch *service.ServiceHealth, instanceID int, oldContainer string, restarted time.Time) bool {
+	state, err := f.zzk.GetServiceState(ctx, svc.PoolID, svc.ID, instanceID)
+	if err != nil {
+		// the instance may be between containers
+		return false
+	}
+	if state.ContainerID == "" || state.ContainerID == oldContainer || service.InstanceCurrentState(state.Status) != service.StateRunning {
+		return false
+	}
+	for name := range svch.HealthChecks {
+		key := health.HealthStatusKey{
+			ServiceID:       svc.ID,
+			InstanceID:      instanceID,
+			HealthCheckName: name,
+		}
+		result, ok := f.hcache.Get(key)
+		if !ok || result.Status != health.OK || result.StartedAt.Before(restarted) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetMigrationTarget returns the host that an instance of a service should be
+// started on because it is being migrated to rebalance its pool.
+func (f *Facade) GetMigrationTarget(ctx datastore.Context, serviceID string, instanceID int) (string, bool) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetMigrationTarget"))
+	return f.rebalancer.target(serviceID, instanceID)
+}
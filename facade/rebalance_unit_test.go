@@ -0,0 +1,164 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/host"
+	"github.com/control-center/serviced/domain/pool"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/utils"
+	zkservice "github.com/control-center/serviced/zzk/service"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeUnitTest) setupRebalancePool(poolID string, hosts []host.Host, active []string) {
+	ft.poolStore.On("Get", ft.ctx, pool.Key(poolID), mock.AnythingOfType("*pool.ResourcePool")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			p := args.Get(2).(*pool.ResourcePool)
+			*p = pool.ResourcePool{ID: poolID}
+		})
+	ft.hostStore.On("FindHostsWithPoolID", ft.ctx, poolID).Return(hosts, nil)
+	ft.zzk.On("GetActiveHosts", ft.ctx, poolID, mock.AnythingOfType("*[]string")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*[]string) = active
+		})
+}
+
+func (ft *FacadeUnitTest) Test_RebalancePoolDryRun(c *C) {
+	poolID := "rebalance-pool"
+	hosts := []host.Host{
+		{ID: "loaded", PoolID: poolID, Cores: 4, Memory: 4 * 1024},
+		{ID: "empty", PoolID: poolID, Cores: 4, Memory: 4 * 1024},
+		{ID: "offline", PoolID: poolID, Cores: 4, Memory: 4 * 1024},
+	}
+	ft.setupRebalancePool(poolID, hosts, []string{"loaded", "empty"})
+
+	states := []zkservice.State{
+		{HostID: "loaded", ServiceID: "pinned", InstanceID: 0},
+		{HostID: "loaded", ServiceID: "movable", InstanceID: 0},
+		{HostID: "loaded", ServiceID: "movable", InstanceID: 1},
+	}
+	ft.zzk.On("GetHostStates", ft.ctx, poolID, "loaded").Return(states, nil)
+	ft.zzk.On("GetHostStates", ft.ctx, poolID, "empty").Return([]zkservice.State{}, nil)
+
+	pinned := &service.Service{
+		ID:            "pinned",
+		Name:          "pinned",
+		PoolID:        poolID,
+		CPUCommitment: 200,
+		RAMCommitment: utils.EngNotation{Value: 2 * 1024},
+		Endpoints: []service.ServiceEndpoint{
+			{
+				Name:          "pinned",
+				AddressConfig: servicedefinition.AddressResourceConfig{Port: 8080, Protocol: "tcp"},
+			},
+		},
+	}
+	movable := &service.Service{
+		ID:            "movable",
+		Name:          "movable",
+		PoolID:        poolID,
+		CPUCommitment: 100,
+		RAMCommitment: utils.EngNotation{Value: 1024},
+	}
+	ft.serviceStore.On("Get", ft.ctx, "pinned").Return(pinned, nil)
+	ft.serviceStore.On("Get", ft.ctx, "movable").Return(movable, nil)
+
+	migrations, err := ft.Facade.RebalancePool(ft.ctx, poolID, 5, true)
+	c.Assert(err, IsNil)
+	c.Assert(migrations, HasLen, 2)
+	for _, m := range migrations {
+		c.Assert(m.ServiceID, Equals, "movable")
+		c.Assert(m.FromHostID, Equals, "loaded")
+		c.Assert(m.ToHostID, Equals, "empty")
+		c.Assert(m.Migrated, Equals, false)
+	}
+	c.Assert(migrations[0].InstanceID, Not(Equals), migrations[1].InstanceID)
+	c.Assert(migrations[0].FromScore, Equals, 200)
+	c.Assert(migrations[0].ToScore, Equals, 50)
+	c.Assert(migrations[1].FromScore, Equals, 150)
+	c.Assert(migrations[1].ToScore, Equals, 100)
+	ft.zzk.AssertNotCalled(c, "GetHostStates", ft.ctx, poolID, "offline")
+	ft.zzk.AssertNotCalled(c, "StopServiceInstance", poolID, mock.Anything, mock.Anything)
+
+	_, ok := ft.Facade.GetMigrationTarget(ft.ctx, "movable", migrations[0].InstanceID)
+	c.Assert(ok, Equals, false)
+}
+
+func (ft *FacadeUnitTest) Test_RebalancePoolRequireSeparate(c *C) {
+	poolID := "rebalance-pool"
+	hosts := []host.Host{
+		{ID: "loaded", PoolID: poolID, Cores: 4, Memory: 4 * 1024},
+		{ID: "running", PoolID: poolID, Cores: 4, Memory: 4 * 1024},
+		{ID: "empty", PoolID: poolID, Cores: 4, Memory: 4 * 1024},
+	}
+	ft.setupRebalancePool(poolID, hosts, []string{"loaded", "running", "empty"})
+
+	ft.zzk.On("GetHostStates", ft.ctx, poolID, "loaded").Return([]zkservice.State{
+		{HostID: "loaded", ServiceID: "separate", InstanceID: 0},
+		{HostID: "loaded", ServiceID: "separate", InstanceID: 1},
+		{HostID: "loaded", ServiceID: "filler", InstanceID: 0},
+	}, nil)
+	ft.zzk.On("GetHostStates", ft.ctx, poolID, "running").Return([]zkservice.State{
+		{HostID: "running", ServiceID: "separate", InstanceID: 2},
+	}, nil)
+	ft.zzk.On("GetHostStates", ft.ctx, poolID, "empty").Return([]zkservice.State{}, nil)
+
+	separate := &service.Service{
+		ID:            "separate",
+		Name:          "separate",
+		PoolID:        poolID,
+		CPUCommitment: 100,
+		RAMCommitment: utils.EngNotation{Value: 1024},
+		HostPolicy:    servicedefinition.RequireSeparate,
+	}
+	filler := &service.Service{
+		ID:            "filler",
+		Name:          "filler",
+		PoolID:        poolID,
+		CPUCommitment: 100,
+		RAMCommitment: utils.EngNotation{Value: 1024},
+	}
+	ft.serviceStore.On("Get", ft.ctx, "separate").Return(separate, nil)
+	ft.serviceStore.On("Get", ft.ctx, "filler").Return(filler, nil)
+
+	// an instance can only be moved to the host that is not running the
+	// service, and only one of them
+	migrations, err := ft.Facade.RebalancePool(ft.ctx, poolID, 5, true)
+	c.Assert(err, IsNil)
+	moved := 0
+	for _, m := range migrations {
+		if m.ServiceID == "separate" {
+			c.Assert(m.ToHostID, Equals, "empty")
+			moved++
+		}
+	}
+	c.Assert(moved, Equals, 1)
+}
+
+func (ft *FacadeUnitTest) Test_RebalancePoolNotFound(c *C) {
+	ft.poolStore.On("Get", ft.ctx, pool.Key("missing"), mock.AnythingOfType("*pool.ResourcePool")).
+		Return(datastore.ErrNoSuchEntity{})
+
+	migrations, err := ft.Facade.RebalancePool(ft.ctx, "missing", 5, true)
+	c.Assert(err, ErrorMatches, "pool missing not found")
+	c.Assert(migrations, IsNil)
+}
@@ -0,0 +1,372 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/control-center/serviced/audit"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/service"
+)
+
+var (
+	ErrRollingRestartInProgress = errors.New("facade: a rolling restart is already in progress for the service")
+	ErrNoRollingRestart         = errors.New("facade: no rolling restart found for the service")
+	ErrRollingRestartNotPaused  = errors.New("facade: rolling restart is not paused")
+	ErrRollingRestartFinished   = errors.New("facade: rolling restart has already finished")
+	ErrNoRunningInstances       = errors.New("facade: service has no running instances")
+)
+
+// defaultRollingRestartTimeout is how long to wait for a batch to become
+// healthy when neither the request nor the facade set a timeout.
+const defaultRollingRestartTimeout = 10 * time.Minute
+
+// rollingRestartPollInterval is how often the instances of a batch are checked
+// while waiting for them to become healthy.
+var rollingRestartPollInterval = 500 * time.Millisecond
+
+// rollingRestart is a rolling restart that is in progress or has finished.
+// Its status may be read while the restart runs.
+type rollingRestart struct {
+	mutex          sync.Mutex
+	status         service.RollingRestartStatus
+	pauseUnhealthy bool // pause when a batch is not healthy in time, instead of moving on
+	resume         chan struct{}
+	cancel         chan interface{}
+}
+
+// newRollingRestart returns a rolling restart of the given instances of a
+// service, in batches of batchSize instances.
+func newRollingRestart(svc *service.Service, instanceIDs []int, batchSize int) *rollingRestart {
+	now := time.Now()
+	return &rollingRestart{
+		status: service.RollingRestartStatus{
+			ServiceID:   svc.ID,
+			ServiceName: svc.Name,
+			State:       service.RollingRestartRunning,
+			BatchSize:   batchSize,
+			Batches:     (len(instanceIDs) + batchSize - 1) / batchSize,
+			Pending:     instanceIDs,
+			Started:     now,
+			Updated:     now,
+		},
+		resume: make(chan struct{}, 1),
+		cancel: make(chan interface{}),
+	}
+}
+
+func (rr *rollingRestart) getStatus() service.RollingRestartStatus {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	status := rr.status
+	status.Restarted = append([]int{}, rr.status.Restarted...)
+	status.Pending = append([]int{}, rr.status.Pending...)
+	status.Unhealthy = append([]int{}, rr.status.Unhealthy...)
+	return status
+}
+
+func (rr *rollingRestart) isFinished() bool {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	return rr.status.State == service.RollingRestartCompleted || rr.status.State == service.RollingRestartCancelled
+}
+
+// nextBatch returns the instances of the next batch, or nil if all of the
+// instances have been restarted.
+func (rr *rollingRestart) nextBatch() []int {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	size := rr.status.BatchSize
+	if size > len(rr.status.Pending) {
+		size = len(rr.status.Pending)
+	}
+	if size == 0 {
+		return nil
+	}
+	rr.status.Batch++
+	rr.status.Updated = time.Now()
+	return append([]int{}, rr.status.Pending[:size]...)
+}
+
+// finishBatch marks the instances of a batch as restarted.
+func (rr *rollingRestart) finishBatch(batch []int) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	rr.status.Restarted = append(rr.status.Restarted, batch...)
+	rr.status.Pending = rr.status.Pending[len(batch):]
+	rr.status.Unhealthy = nil
+	rr.status.Message = ""
+	rr.status.Updated = time.Now()
+}
+
+func (rr *rollingRestart) pause(unhealthy []int, message string) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	rr.status.State = service.RollingRestartPaused
+	rr.status.Unhealthy = unhealthy
+	rr.status.Message = message
+	rr.status.Updated = time.Now()
+}
+
+func (rr *rollingRestart) complete() {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	if rr.status.State != service.RollingRestartCancelled {
+		rr.status.State = service.RollingRestartCompleted
+		rr.status.Updated = time.Now()
+	}
+}
+
+// abort stops a rolling restart that could not continue.
+func (rr *rollingRestart) abort(message string) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	if rr.status.State != service.RollingRestartCancelled {
+		rr.status.State = service.RollingRestartCancelled
+		rr.status.Message = message
+		rr.status.Updated = time.Now()
+		close(rr.cancel)
+	}
+}
+
+func (rr *rollingRestart) doResume() error {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	if rr.status.State != service.RollingRestartPaused {
+		return ErrRollingRestartNotPaused
+	}
+	rr.status.State = service.RollingRestartRunning
+	rr.status.Updated = time.Now()
+	rr.resume <- struct{}{}
+	return nil
+}
+
+func (rr *rollingRestart) doCancel() error {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	if rr.status.State == service.RollingRestartCompleted || rr.status.State == service.RollingRestartCancelled {
+		return ErrRollingRestartFinished
+	}
+	rr.status.State = service.RollingRestartCancelled
+	rr.status.Updated = time.Now()
+	close(rr.cancel)
+	return nil
+}
+
+// rollingRestartMgr keeps the most recent rolling restart of each service.
+// Rolling restarts are tracked in memory, so they do not survive a restart of
+// the master.
+type rollingRestartMgr struct {
+	mutex    sync.Mutex
+	restarts map[string]*rollingRestart
+}
+
+func newRollingRestartMgr() *rollingRestartMgr {
+	return &rollingRestartMgr{restarts: make(map[string]*rollingRestart)}
+}
+
+// add tracks a new rolling restart, unless one is already in progress for
+// the service.
+func (m *rollingRestartMgr) add(rr *rollingRestart) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if cur, ok := m.restarts[rr.status.ServiceID]; ok && !cur.isFinished() {
+		return ErrRollingRestartInProgress
+	}
+	m.restarts[rr.status.ServiceID] = rr
+	return nil
+}
+
+func (m *rollingRestartMgr) get(serviceID string) (*rollingRestart, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	rr, ok := m.restarts[serviceID]
+	if !ok {
+		return nil, ErrNoRollingRestart
+	}
+	return rr, nil
+}
+
+// RollingRestartService restarts the running instances of a service in
+// batches.  Each batch must pass its health checks before the next batch is
+// restarted; if it does not within the timeout, the rolling restart pauses
+// until it is resumed or cancelled.  If request.AutoLaunch is set, the running
+// child services of the service are restarted in the same way, each with a
+// rolling restart of its own.
+func (f *Facade) RollingRestartService(ctx datastore.Context, request service.RollingRestartRequest) (*service.RollingRestartStatus, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.RollingRestartService"))
+	alog := f.auditLogger.Message(ctx, "Rolling Restart Service").Action(audit.Restart).ID(request.ServiceID).Type(service.GetType())
+	if err := request.ValidEntity(); err != nil {
+		return nil, alog.Error(err)
+	}
+	if err := f.authorizeService(ctx, request.ServiceID); err != nil {
+		return nil, alog.Error(err)
+	}
+	var svcs []*service.Service
+	visitor := func(svc *service.Service) error {
+		svcs = append(svcs, svc)
+		return nil
+	}
+	if err := f.walkServices(ctx, request.ServiceID, request.AutoLaunch, visitor, "RollingRestartService"); err != nil {
+		return nil, alog.Error(err)
+	}
+
+	timeout := request.Timeout
+	if timeout == 0 {
+		timeout = f.rollingRestartTimeout
+	}
+	if timeout == 0 {
+		timeout = defaultRollingRestartTimeout
+	}
+
+	var status service.RollingRestartStatus
+	for _, svc := range svcs {
+		logger := plog.WithFields(log.Fields{
+			"serviceid":   svc.ID,
+			"servicename": svc.Name,
+		})
+		// the requested service must be restarted, but its children are
+		// skipped when they cannot be
+		requested := svc.ID == request.ServiceID
+
+		states, err := f.zzk.GetServiceStates(ctx, svc.PoolID, svc.ID)
+		if err != nil {
+			if requested {
+				return nil, alog.Error(err)
+			}
+			logger.WithError(err).Warn("Could not get the instances of child service, skipping rolling restart")
+			continue
+		}
+		if len(states) == 0 {
+			if requested {
+				return nil, alog.Error(ErrNoRunningInstances)
+			}
+			continue
+		}
+		instanceIDs := make([]int, len(states))
+		for i, state := range states {
+			instanceIDs[i] = state.InstanceID
+		}
+		sort.Ints(instanceIDs)
+
+		rr := newRollingRestart(svc, instanceIDs, request.GetBatchSize(len(instanceIDs)))
+		rr.pauseUnhealthy = true
+		if err := f.rollingRestarts.add(rr); err != nil {
+			if requested {
+				return nil, alog.Error(err)
+			}
+			logger.WithError(err).Warn("Skipping rolling restart of child service")
+			continue
+		}
+		go f.runRollingRestart(ctx, svc, rr, timeout)
+
+		if requested {
+			status = rr.getStatus()
+		}
+	}
+
+	alog.WithFields(log.Fields{"batchsize": status.BatchSize, "instances": len(status.Pending), "autolaunch": request.AutoLaunch}).Succeeded()
+	return &status, nil
+}
+
+// GetRollingRestartStatus returns the progress of the most recent rolling
+// restart of a service.
+func (f *Facade) GetRollingRestartStatus(ctx datastore.Context, serviceID string) (*service.RollingRestartStatus, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetRollingRestartStatus"))
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	rr, err := f.rollingRestarts.get(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	status := rr.getStatus()
+	return &status, nil
+}
+
+// ResumeRollingRestart continues a paused rolling restart with the next batch.
+// The unhealthy instances of the paused batch are left as they are.
+func (f *Facade) ResumeRollingRestart(ctx datastore.Context, serviceID string) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.ResumeRollingRestart"))
+	alog := f.auditLogger.Message(ctx, "Resume Rolling Restart").Action(audit.Resume).ID(serviceID).Type(service.GetType())
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return alog.Error(err)
+	}
+	rr, err := f.rollingRestarts.get(serviceID)
+	if err != nil {
+		return alog.Error(err)
+	}
+	if err := rr.doResume(); err != nil {
+		return alog.Error(err)
+	}
+	alog.Succeeded()
+	return nil
+}
+
+// CancelRollingRestart stops a rolling restart before its next batch.
+// Instances that have already been restarted are not affected.
+func (f *Facade) CancelRollingRestart(ctx datastore.Context, serviceID string) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.CancelRollingRestart"))
+	alog := f.auditLogger.Message(ctx, "Cancel Rolling Restart").Action(audit.Stop).ID(serviceID).Type(service.GetType())
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return alog.Error(err)
+	}
+	rr, err := f.rollingRestarts.get(serviceID)
+	if err != nil {
+		return alog.Error(err)
+	}
+	if err := rr.doCancel(); err != nil {
+		return alog.Error(err)
+	}
+	alog.Succeeded()
+	return nil
+}
+
+// runRollingRestart runs a rolling restart that pauses on unhealthy batches
+// and records its outcome.
+func (f *Facade) runRollingRestart(ctx datastore.Context, svc *service.Service, rr *rollingRestart, timeout time.Duration) {
+	logger := plog.WithFields(log.Fields{
+		"serviceid":   svc.ID,
+		"servicename": svc.Name,
+	})
+
+	if err := f.rollingRestart(ctx, svc, rr, timeout, rr.cancel); err != nil {
+		logger.WithError(err).Warn("Could not perform rolling restart for service")
+		rr.abort(fmt.Sprintf("could not restart instances: %s", err))
+		return
+	}
+
+	select {
+	case <-rr.cancel:
+		logger.Info("Rolling restart cancelled")
+	default:
+		rr.complete()
+		logger.Info("Rolling restart completed")
+	}
+}
+
+func joinInts(ids []int) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = fmt.Sprintf("%d", id)
+	}
+	return strings.Join(s, ",")
+}
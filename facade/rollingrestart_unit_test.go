@@ -0,0 +1,244 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/facade"
+	zks "github.com/control-center/serviced/zzk/service"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeUnitTest) setupRollingRestartService(serviceID string, instanceIDs ...int) *service.Service {
+	svc := &service.Service{ID: serviceID, Name: serviceID, PoolID: "pool1", Instances: len(instanceIDs)}
+	ft.serviceStore.On("Get", ft.ctx, serviceID).Return(svc, nil)
+	states := make([]zks.State, len(instanceIDs))
+	for i, instanceID := range instanceIDs {
+		states[i] = zks.State{ServiceID: serviceID, InstanceID: instanceID}
+	}
+	ft.zzk.On("GetServiceStates", ft.ctx, "pool1", serviceID).Return(states, nil)
+	ft.zzk.On("UpdateInstanceCurrentState", ft.ctx, "pool1", serviceID, mock.AnythingOfType("int"), service.StatePendingRestart).Return(nil)
+	ft.zzk.On("UpdateInstanceCurrentState", ft.ctx, "pool1", serviceID, mock.AnythingOfType("int"), service.StateRunning).Return(nil)
+	ft.zzk.On("RestartInstance", ft.ctx, "pool1", serviceID, mock.AnythingOfType("int")).Return(nil)
+	ft.zzk.On("WaitInstance", ft.ctx, mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("func(*service.State, bool) bool"), mock.AnythingOfType("<-chan struct {}")).
+		Run(func(args mock.Arguments) {
+			// the instance comes back in whatever container it reports next
+			svc := args.Get(1).(*service.Service)
+			check := args.Get(3).(func(*zks.State, bool) bool)
+			cancel := args.Get(4).(<-chan struct{})
+			state, err := ft.zzk.GetServiceState(ft.ctx, svc.PoolID, svc.ID, args.Int(2))
+			if err != nil || !check(state, true) {
+				<-cancel
+			}
+		}).Return(nil)
+	ft.serviceStore.On("UpdateCurrentState", ft.ctx, serviceID, string(service.SVCCSRunning)).Return(nil)
+	return svc
+}
+
+func (ft *FacadeUnitTest) mockInstanceContainer(serviceID string, instanceID int, containerID string, once bool) {
+	state := &zks.State{ServiceID: serviceID, InstanceID: instanceID}
+	state.ContainerID = containerID
+	state.Status = service.StateRunning
+	call := ft.zzk.On("GetServiceState", ft.ctx, "pool1", serviceID, instanceID).Return(state, nil)
+	if once {
+		call.Once()
+	}
+}
+
+func (ft *FacadeUnitTest) waitRollingRestart(c *C, serviceID string, state string) *service.RollingRestartStatus {
+	timeout := time.After(5 * time.Second)
+	for {
+		status, err := ft.Facade.GetRollingRestartStatus(ft.ctx, serviceID)
+		c.Assert(err, IsNil)
+		if status.State == state {
+			return status
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("rolling restart of %s is %s, not %s", serviceID, status.State, state)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func (ft *FacadeUnitTest) Test_RollingRestartServiceInvalidRequest(c *C) {
+	request := service.RollingRestartRequest{ServiceID: "rr-invalid", BatchSize: 1, BatchPercent: 50}
+
+	status, err := ft.Facade.RollingRestartService(ft.ctx, request)
+
+	c.Assert(err, NotNil)
+	c.Assert(status, IsNil)
+	ft.serviceStore.AssertNotCalled(c, "Get", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_RollingRestartServiceUnauthorizedTenant(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	status, err := ft.Facade.RollingRestartService(ctx, service.RollingRestartRequest{ServiceID: "grantChild2", BatchSize: 1})
+
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	c.Assert(status, IsNil)
+	ft.zzk.AssertNotCalled(c, "RestartInstance", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_RollingRestartServiceNoRunningInstances(c *C) {
+	ft.setupRollingRestartService("rr-stopped")
+
+	status, err := ft.Facade.RollingRestartService(ft.ctx, service.RollingRestartRequest{ServiceID: "rr-stopped", BatchSize: 1})
+
+	c.Assert(err, Equals, facade.ErrNoRunningInstances)
+	c.Assert(status, IsNil)
+}
+
+func (ft *FacadeUnitTest) Test_GetRollingRestartStatusNotFound(c *C) {
+	status, err := ft.Facade.GetRollingRestartStatus(ft.ctx, "rr-none")
+
+	c.Assert(err, Equals, facade.ErrNoRollingRestart)
+	c.Assert(status, IsNil)
+}
+
+func (ft *FacadeUnitTest) Test_RollingRestartServiceCompletes(c *C) {
+	ft.setupRollingRestartService("rr-complete", 2, 0, 1)
+	for _, instanceID := range []int{0, 1, 2} {
+		ft.mockInstanceContainer("rr-complete", instanceID, "old", true)
+		ft.mockInstanceContainer("rr-complete", instanceID, "new", false)
+	}
+
+	status, err := ft.Facade.RollingRestartService(ft.ctx, service.RollingRestartRequest{ServiceID: "rr-complete", BatchSize: 2})
+	c.Assert(err, IsNil)
+	c.Assert(status.State, Equals, service.RollingRestartRunning)
+	c.Assert(status.BatchSize, Equals, 2)
+	c.Assert(status.Batches, Equals, 2)
+	c.Assert(status.Pending, DeepEquals, []int{0, 1, 2})
+
+	status = ft.waitRollingRestart(c, "rr-complete", service.RollingRestartCompleted)
+	c.Assert(status.Batch, Equals, 2)
+	c.Assert(status.Restarted, DeepEquals, []int{0, 1, 2})
+	c.Assert(status.Pending, HasLen, 0)
+	for _, instanceID := range []int{0, 1, 2} {
+		ft.zzk.AssertCalled(c, "RestartInstance", ft.ctx, "pool1", "rr-complete", instanceID)
+	}
+}
+
+func (ft *FacadeUnitTest) Test_RollingRestartServicePausesWhenUnhealthy(c *C) {
+	ft.setupRollingRestartService("rr-pause", 0, 1)
+	// the instance never comes back in a new container
+	ft.mockInstanceContainer("rr-pause", 0, "old", false)
+	ft.mockInstanceContainer("rr-pause", 1, "old", false)
+
+	request := service.RollingRestartRequest{ServiceID: "rr-pause", BatchSize: 1, Timeout: 100 * time.Millisecond}
+	_, err := ft.Facade.RollingRestartService(ft.ctx, request)
+	c.Assert(err, IsNil)
+
+	status := ft.waitRollingRestart(c, "rr-pause", service.RollingRestartPaused)
+	c.Assert(status.Batch, Equals, 1)
+	c.Assert(status.Unhealthy, DeepEquals, []int{0})
+	c.Assert(status.Pending, DeepEquals, []int{0, 1})
+	c.Assert(status.Message, Not(Equals), "")
+	ft.zzk.AssertNotCalled(c, "RestartInstance", ft.ctx, "pool1", "rr-pause", 1)
+
+	// only one rolling restart may run at a time
+	_, err = ft.Facade.RollingRestartService(ft.ctx, request)
+	c.Assert(err, Equals, facade.ErrRollingRestartInProgress)
+
+	// resuming moves on to the next batch, which also pauses
+	c.Assert(ft.Facade.ResumeRollingRestart(ft.ctx, "rr-pause"), IsNil)
+	c.Assert(ft.Facade.ResumeRollingRestart(ft.ctx, "rr-pause"), Equals, facade.ErrRollingRestartNotPaused)
+	status = ft.waitRollingRestart(c, "rr-pause", service.RollingRestartPaused)
+	c.Assert(status.Batch, Equals, 2)
+	c.Assert(status.Restarted, DeepEquals, []int{0})
+	c.Assert(status.Unhealthy, DeepEquals, []int{1})
+
+	c.Assert(ft.Facade.CancelRollingRestart(ft.ctx, "rr-pause"), IsNil)
+	status = ft.waitRollingRestart(c, "rr-pause", service.RollingRestartCancelled)
+	c.Assert(status.Pending, DeepEquals, []int{1})
+	c.Assert(ft.Facade.CancelRollingRestart(ft.ctx, "rr-pause"), Equals, facade.ErrRollingRestartFinished)
+}
+
+func (ft *FacadeUnitTest) Test_RollingRestartServicePausesOnError(c *C) {
+	// reverting the instance is the last thing the cancelled restart does
+	reverted := make(chan struct{})
+	ft.zzk.On("UpdateInstanceCurrentState", ft.ctx, "pool1", "rr-error", 0, service.StateRunning).Return(nil).
+		Run(func(mock.Arguments) { close(reverted) }).Once()
+	ft.setupRollingRestartService("rr-error", 0)
+	ft.zzk.On("GetServiceState", ft.ctx, "pool1", "rr-error", 0).Return(nil, errors.New("no such node"))
+
+	_, err := ft.Facade.RollingRestartService(ft.ctx, service.RollingRestartRequest{ServiceID: "rr-error", BatchSize: 1})
+	c.Assert(err, IsNil)
+
+	status := ft.waitRollingRestart(c, "rr-error", service.RollingRestartPaused)
+	c.Assert(status.Unhealthy, DeepEquals, []int{0})
+	c.Assert(status.Message, Equals, "could not restart instances: no such node")
+	ft.zzk.AssertNotCalled(c, "RestartInstance", ft.ctx, "pool1", "rr-error", 0)
+	c.Assert(ft.Facade.CancelRollingRestart(ft.ctx, "rr-error"), IsNil)
+	select {
+	case <-reverted:
+	case <-time.After(5 * time.Second):
+		c.Fatalf("instance of rr-error was not reverted after the rolling restart was cancelled")
+	}
+}
+
+func (ft *FacadeUnitTest) Test_RollingRestartServiceRevertsSkippedInstances(c *C) {
+	ft.zzk.On("RestartInstance", ft.ctx, "pool1", "rr-revert", 1).Return(errors.New("no such node"))
+	ft.setupRollingRestartService("rr-revert", 0, 1)
+	ft.mockInstanceContainer("rr-revert", 0, "old", true)
+	ft.mockInstanceContainer("rr-revert", 0, "new", false)
+	ft.mockInstanceContainer("rr-revert", 1, "old", false)
+
+	_, err := ft.Facade.RollingRestartService(ft.ctx, service.RollingRestartRequest{ServiceID: "rr-revert", BatchSize: 2})
+	c.Assert(err, IsNil)
+
+	status := ft.waitRollingRestart(c, "rr-revert", service.RollingRestartPaused)
+	c.Assert(status.Unhealthy, DeepEquals, []int{0, 1})
+	c.Assert(status.Message, Equals, "could not restart instances: no such node")
+
+	// the instance that was not restarted is no longer pending a restart
+	c.Assert(ft.Facade.ResumeRollingRestart(ft.ctx, "rr-revert"), IsNil)
+	ft.waitRollingRestart(c, "rr-revert", service.RollingRestartCompleted)
+	ft.zzk.AssertCalled(c, "UpdateInstanceCurrentState", ft.ctx, "pool1", "rr-revert", 1, service.StateRunning)
+	ft.zzk.AssertNotCalled(c, "UpdateInstanceCurrentState", ft.ctx, "pool1", "rr-revert", 0, service.StateRunning)
+	ft.serviceStore.AssertNotCalled(c, "UpdateCurrentState", ft.ctx, "rr-revert", string(service.SVCCSRunning))
+}
+
+func (ft *FacadeUnitTest) Test_RollingRestartServiceAutoLaunch(c *C) {
+	parent := ft.setupRollingRestartService("rr-parent", 0)
+	child := ft.setupRollingRestartService("rr-child", 0, 1)
+	child.ParentServiceID = parent.ID
+	stopped := ft.setupRollingRestartService("rr-child-stopped")
+	stopped.ParentServiceID = parent.ID
+	ft.serviceStore.On("GetChildServices", ft.ctx, "rr-parent").Return([]service.Service{*child, *stopped}, nil)
+	ft.serviceStore.On("GetChildServices", ft.ctx, mock.AnythingOfType("string")).Return([]service.Service{}, nil)
+	for _, instanceID := range []int{0, 1} {
+		ft.mockInstanceContainer("rr-child", instanceID, "old", true)
+		ft.mockInstanceContainer("rr-child", instanceID, "new", false)
+	}
+	ft.mockInstanceContainer("rr-parent", 0, "old", true)
+	ft.mockInstanceContainer("rr-parent", 0, "new", false)
+
+	status, err := ft.Facade.RollingRestartService(ft.ctx, service.RollingRestartRequest{ServiceID: "rr-parent", BatchSize: 1, AutoLaunch: true})
+	c.Assert(err, IsNil)
+	c.Assert(status.ServiceID, Equals, "rr-parent")
+
+	ft.waitRollingRestart(c, "rr-parent", service.RollingRestartCompleted)
+	status = ft.waitRollingRestart(c, "rr-child", service.RollingRestartCompleted)
+	c.Assert(status.Restarted, DeepEquals, []int{0, 1})
+	_, err = ft.Facade.GetRollingRestartStatus(ft.ctx, "rr-child-stopped")
+	c.Assert(err, Equals, facade.ErrNoRollingRestart)
+}
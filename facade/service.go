@@ -36,12 +36,14 @@ import (
 	"github.com/control-center/serviced/domain"
 	"github.com/control-center/serviced/domain/addressassignment"
 	"github.com/control-center/serviced/domain/applicationendpoint"
+	"github.com/control-center/serviced/domain/auditlog"
 	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/servicedefinition"
 	"github.com/control-center/serviced/health"
 	"github.com/control-center/serviced/metrics"
 	"github.com/control-center/serviced/scheduler/servicestatemanager"
+	"github.com/control-center/serviced/scheduler/strategy"
 	"github.com/control-center/serviced/utils"
 	zkservice "github.com/control-center/serviced/zzk/service"
 )
@@ -89,10 +91,18 @@ func (f *Facade) AddService(ctx datastore.Context, svc service.Service) (err err
 	} else if tenantID, err = f.GetTenantID(ctx, svc.ParentServiceID); err != nil {
 		return alog.Error(err)
 	}
+	if err = f.authorizeTenant(ctx, tenantID); err != nil {
+		return alog.Error(err)
+	}
 	mutex := getTenantLock(tenantID)
 	mutex.RLock()
 	defer mutex.RUnlock()
-	return alog.Error(f.addService(ctx, tenantID, svc, false))
+	if err = f.addService(ctx, tenantID, svc, false); err != nil {
+		return alog.Error(err)
+	}
+	f.recordServiceRevision(ctx, svc.ID)
+	alog.Succeeded()
+	return nil
 }
 
 func (f *Facade) addService(ctx datastore.Context, tenantID string, svc service.Service, setLockOnCreate bool) error {
@@ -159,6 +169,17 @@ func (f *Facade) validateServiceAdd(ctx datastore.Context, svc *service.Service)
 		logger.WithField("parentserviceid", svc.ParentServiceID).WithError(err).Error("Could not add service with parent")
 		return err
 	}
+	return f.validateNewService(ctx, svc)
+}
+
+// validateNewService checks and sets the defaults of a service that is about
+// to be added, once its id and name are known not to collide.
+func (f *Facade) validateNewService(ctx datastore.Context, svc *service.Service) error {
+	logger := plog.WithFields(log.Fields{
+		"name": svc.Name,
+		"id": svc.ID,
+		"parentserviceid": svc.ParentServiceID,
+	})
 
 	// disable ports and vhosts that are already in use by another application
 	for i, ep := range svc.Endpoints {
@@ -204,6 +225,16 @@ func (f *Facade) validateServiceAdd(ctx datastore.Context, svc *service.Service)
 		return err
 	}
 
+	if err := f.validateCPUQuota(ctx, svc); err != nil {
+		logger.WithError(err).Error("Could not add service")
+		return err
+	}
+
+	if err := f.validatePoolQuota(ctx, svc.PoolID, svc.ID, serviceCommitment(svc)); err != nil {
+		logger.WithError(err).WithField("poolid", svc.PoolID).Error("Could not add service")
+		return err
+	}
+
 	// remove any BuiltIn enabled monitoring configs
 	metricConfigs := []domain.MetricConfig{}
 	for _, mc := range svc.MonitoringProfile.MetricConfigs {
@@ -255,6 +286,41 @@ func validateServiceOptions(svc *service.Service) error {
 			error: "HostPolicy RequireSeparate cannot be used with ChangeOption RestartAllOnInstanceChanged",
 		}
 	}
+	// An external HostPolicy must name a strategy that is registered with the scheduler.
+	if policy := string(svc.HostPolicy); strings.HasPrefix(strings.ToLower(policy), servicedefinition.ExternalPrefix) {
+		if _, err := strategy.Get(policy); err != nil {
+			return ErrInvalidServiceOption{
+				error: fmt.Sprintf("HostPolicy %s does not name a registered external strategy", policy),
+			}
+		}
+	}
+	return nil
+}
+
+// validateCPUQuota verifies that at least one host in the service's pool has
+// enough cores to honor the service's cpu quota.  Pools without hosts are not
+// checked, since hosts may be added later.
+func (f *Facade) validateCPUQuota(ctx datastore.Context, svc *service.Service) error {
+	if svc.CPUQuota <= 0 || svc.PoolID == "" {
+		return nil
+	}
+	hosts, err := f.hostStore.FindHostsWithPoolID(ctx, svc.PoolID)
+	if err != nil {
+		return err
+	} else if len(hosts) == 0 {
+		return nil
+	}
+	maxCores := 0
+	for _, h := range hosts {
+		if h.Cores > maxCores {
+			maxCores = h.Cores
+		}
+	}
+	if svc.CPUQuota > float64(maxCores) {
+		return ErrInvalidServiceOption{
+			error: fmt.Sprintf("CPUQuota of %g cores exceeds the %d cores of the largest host in pool %s", svc.CPUQuota, maxCores, svc.PoolID),
+		}
+	}
 	return nil
 }
 
@@ -291,6 +357,36 @@ func (f * Facade) getChanges(ctx datastore.Context, svc service.Service) string
 	return updates
 }
 
+// serviceDiffIgnoredFields are the fields of a service that are maintained by
+// the facade rather than edited, and so are left out of its audit diff.
+var serviceDiffIgnoredFields = []string{"DatabaseVersion", "CreatedAt", "UpdatedAt", "OriginalConfigs"}
+
+// getServiceDiff returns the field-level changes that an update makes to the
+// stored service.  Config files are only compared if the update includes them.
+func (f *Facade) getServiceDiff(ctx datastore.Context, svc service.Service) []auditlog.Change {
+	logger := plog.WithFields(log.Fields{
+		"serviceid":   svc.ID,
+		"servicename": svc.Name,
+	})
+	cursvc, err := f.serviceStore.Get(ctx, svc.ID)
+	if err != nil {
+		logger.WithError(err).Debug("Could not load service to compare changes")
+		return nil
+	}
+	compareConfigs := svc.ConfigFiles != nil
+	if compareConfigs {
+		if err := f.fillServiceConfigs(ctx, cursvc); err != nil {
+			logger.WithError(err).Debug("Could not load config files to compare changes")
+			compareConfigs = false
+		}
+	}
+	ignore := append([]string{}, serviceDiffIgnoredFields...)
+	if !compareConfigs {
+		ignore = append(ignore, "ConfigFiles")
+	}
+	return audit.Diff(cursvc, &svc, ignore...)
+}
+
 // UpdateService updates an existing service; return error if the service does
 // not exist.
 func (f *Facade) UpdateService(ctx datastore.Context, svc service.Service) error {
@@ -300,12 +396,22 @@ func (f *Facade) UpdateService(ctx datastore.Context, svc service.Service) error
 	if err != nil {
 		return alog.Error(err)
 	}
+	if err := f.authorizeTenant(ctx, tenantID); err != nil {
+		return alog.Error(err)
+	}
 	mutex := getTenantLock(tenantID)
 	mutex.RLock()
 	defer mutex.RUnlock()
 	updates := f.getChanges(ctx, svc)
-	alog = alog.WithField("updates", updates)
-	return alog.Error(f.updateService(ctx, tenantID, svc, false, false))
+	alog = alog.WithField("updates", updates).Changes(f.getServiceDiff(ctx, svc))
+	// keep the service as it was before this update, if it is not yet stored
+	f.recordServiceRevision(ctx, svc.ID)
+	if err := f.updateService(ctx, tenantID, svc, false, false); err != nil {
+		return alog.Error(err)
+	}
+	f.recordServiceRevision(ctx, svc.ID)
+	alog.Succeeded()
+	return nil
 }
 
 // MigrateService migrates an existing service; return error if the service does
@@ -317,10 +423,20 @@ func (f *Facade) MigrateService(ctx datastore.Context, svc service.Service) erro
 	if err != nil {
 		return alog.Error(err)
 	}
+	if err := f.authorizeTenant(ctx, tenantID); err != nil {
+		return alog.Error(err)
+	}
 	mutex := getTenantLock(tenantID)
 	mutex.RLock()
 	defer mutex.RUnlock()
-	return alog.Error(f.updateService(ctx, tenantID, svc, true, false))
+	// keep the service as it was before this migration, if it is not yet stored
+	f.recordServiceRevision(ctx, svc.ID)
+	if err := f.updateService(ctx, tenantID, svc, true, false); err != nil {
+		return alog.Error(err)
+	}
+	f.recordServiceRevision(ctx, svc.ID)
+	alog.Succeeded()
+	return nil
 }
 
 func (f *Facade) updateService(ctx datastore.Context, tenantID string, svc service.Service, migrate, setLockOnUpdate bool) error {
@@ -494,6 +610,19 @@ func (f *Facade) validateServiceUpdate(ctx datastore.Context, svc *service.Servi
 		return nil, err
 	}
 
+	if err := f.validateCPUQuota(ctx, svc); err != nil {
+		logger.WithError(err).Error("Could not validate service for update")
+		return nil, err
+	}
+
+	// only check the pool quota if the resources reserved by the service changed
+	if svc.PoolID != cursvc.PoolID || serviceCommitment(svc) != serviceCommitment(cursvc) {
+		if err := f.validatePoolQuota(ctx, svc.PoolID, svc.ID, serviceCommitment(svc)); err != nil {
+			logger.WithError(err).WithField("poolid", svc.PoolID).Error("Could not validate service for update")
+			return nil, err
+		}
+	}
+
 	// verify the desired state of the service
 	if svc.DesiredState != int(service.SVCStop) {
 		if err := f.validateServiceStart(ctx, svc); err != nil {
@@ -693,6 +822,7 @@ func (f *Facade) RestoreServices(ctx datastore.Context, tenantID string, svcs []
 			if err := f.restoreIPs(ctx, &svc); err != nil {
 				glog.Warningf("Could not restore address assignments for service %s (%s): %s", svc.Name, svc.ID, err)
 			}
+			f.recordServiceRevision(ctx, svc.ID)
 			if err := traverse(svc.ID); err != nil {
 				return alog.Error(err)
 			}
@@ -981,6 +1111,9 @@ func (f *Facade) RemoveService(ctx datastore.Context, id string) error {
 	if err != nil {
 		return alog.Error(err)
 	}
+	if err := f.authorizeTenant(ctx, tenantID); err != nil {
+		return alog.Error(err)
+	}
 	if err := f.lockTenant(ctx, tenantID); err != nil {
 		return alog.Error(err)
 	}
@@ -994,6 +1127,7 @@ func (f *Facade) RemoveService(ctx datastore.Context, id string) error {
 		}
 		f.zzk.RemoveTenantExports(tenantID)
 		f.zzk.DeleteRegistryLibrary(tenantID)
+		f.removeSnapshotSchedule(ctx, tenantID)
 	}
 	alog.Succeeded()
 	return nil
@@ -1038,6 +1172,8 @@ func (f *Facade) removeService(ctx datastore.Context, id string) error {
 			logger.WithError(err).Error("Error while removing service %s")
 			return err
 		}
+		f.removeServiceRevisions(ctx, svc.ID)
+		f.placements.remove(svc.ID)
 
 		f.poolCache.SetDirty()
 
@@ -1100,6 +1236,9 @@ func (f *Facade) GetService(ctx datastore.Context, id string) (*service.Service,
 	if err != nil {
 		return nil, err
 	}
+	if err = f.authorizeService(ctx, id); err != nil {
+		return nil, err
+	}
 	if err = f.fillOutService(ctx, svc); err != nil {
 		return nil, err
 	}
@@ -1214,6 +1353,11 @@ func (f *Facade) GetServices(ctx datastore.Context, request dao.EntityRequest) (
 		}
 	}
 
+	if services, err = f.filterServicesByTenantGrants(ctx, services); err != nil {
+		logger.WithError(err).Error("Unable to filter services by tenant grants")
+		return nil, err
+	}
+
 	if err = f.fillOutServices(ctx, services); err != nil {
 		return nil, err
 	}
@@ -1237,7 +1381,7 @@ func (f *Facade) GetAllServices(ctx datastore.Context) ([]service.Service, error
 	if err != nil {
 		return nil, err
 	}
-	return svcs, nil
+	return f.filterServicesByTenantGrants(ctx, svcs)
 }
 
 // GetServicesByPool looks up all services in a particular pool
@@ -1302,6 +1446,10 @@ func (f *Facade) GetTaggedServices(ctx datastore.Context, request dao.EntityRequ
 		logger.WithError(err).Error("Unable to get tagged services")
 		return nil, err
 	}
+	if services, err = f.filterServicesByTenantGrants(ctx, services); err != nil {
+		logger.WithError(err).Error("Unable to filter services by tenant grants")
+		return nil, err
+	}
 	if err = f.fillOutServices(ctx, services); err != nil {
 		return nil, err
 	}
@@ -1354,7 +1502,7 @@ func (f *Facade) GetTenantID(ctx datastore.Context, serviceID string) (string, e
 	defer logger.Debug("Finished Facade.GetTenantID")
 
 	gs := func(id string) (*service.ServiceDetails, error) {
-		return f.GetServiceDetails(ctx, id)
+		return f.serviceStore.GetServiceDetails(ctx, id)
 	}
 	return f.serviceCache.GetTenantID(serviceID, gs)
 }
@@ -1496,6 +1644,9 @@ func (f *Facade) ScheduleServices(ctx datastore.Context, serviceIDs []string, au
 		if err != nil {
 			return 0, err
 		}
+		if err := f.authorizeTenant(ctx, serviceTenant); err != nil {
+			return 0, err
+		}
 		tenantServices[serviceTenant] = append(tenantServices[serviceTenant], sid)
 	}
 
@@ -1802,7 +1953,12 @@ func (f *Facade) updateDesiredState(ctx datastore.Context, svc *servicestatemana
 	}
 
 	if desiredState == service.SVCRestart {
-		if err := f.rollingRestart(ctx, svc.Service, f.rollingRestartTimeout, svc.C); err != nil {
+		instanceIDs := make([]int, svc.Instances)
+		for i := range instanceIDs {
+			instanceIDs[i] = i
+		}
+		rr := newRollingRestart(svc.Service, instanceIDs, 1)
+		if err := f.rollingRestart(ctx, svc.Service, rr, f.rollingRestartTimeout, svc.C); err != nil {
 			logger.WithError(err).Debug("Could not perform rolling restart for service")
 			return err
 		}
@@ -1824,15 +1980,20 @@ func (f *Facade) updateDesiredState(ctx datastore.Context, svc *servicestatemana
 	return nil
 }
 
-// rollingRestart restarts a service one instance at a time and waits for it to reach pass health checks.
-func (f *Facade) rollingRestart(ctx datastore.Context, svc *service.Service, timeout time.Duration, cancel <-chan interface{}) error {
+// rollingRestart restarts the pending instances of a service one batch at a
+// time and waits for each batch to pass health checks.  If a batch does not
+// pass before the timeout, the rolling restart either moves on to the next
+// batch or, if rr.pauseUnhealthy is set, pauses until it is resumed or
+// cancelled.
+func (f *Facade) rollingRestart(ctx datastore.Context, svc *service.Service, rr *rollingRestart, timeout time.Duration, cancel <-chan interface{}) error {
 	logger := plog.WithFields(log.Fields{
 		"serviceid":   svc.ID,
 		"servicename": svc.Name,
 	})
 
 	// Run through and set all instances to "Pending Restart"
-	for instanceID := 0; instanceID < svc.Instances; instanceID++ {
+	pending := rr.getStatus().Pending
+	for _, instanceID := range pending {
 		err := f.zzk.UpdateInstanceCurrentState(ctx, svc.PoolID, svc.ID, instanceID, service.StatePendingRestart)
 		if err != nil {
 			logger.WithError(err).Debug("Failed to update instance current state to pending restart")
@@ -1841,25 +2002,32 @@ func (f *Facade) rollingRestart(ctx datastore.Context, svc *service.Service, tim
 	}
 
 	// Keep track of which instances haven't been restarted yet, so we can revert the current state if we exit
-	// prematurely
-	nextInstance := 0
-	defer func() {
-		for instance := nextInstance; instance < svc.Instances; instance++ {
+	// prematurely or skip them
+	notRestarted := make(map[int]struct{})
+	for _, instanceID := range pending {
+		notRestarted[instanceID] = struct{}{}
+	}
+	revert := func(instanceIDs []int) {
+		for _, instance := range instanceIDs {
+			if _, ok := notRestarted[instance]; !ok {
+				continue
+			}
+			delete(notRestarted, instance)
 			err := f.zzk.UpdateInstanceCurrentState(ctx, svc.PoolID, svc.ID, instance, service.StateRunning)
 			if err != nil {
 				logger.WithField("instance", instance).WithError(err).Error("Failed to revert instance current state to started")
 			}
 		}
-	}()
+	}
+	defer revert(pending)
 
 	// Build the service health object to use for getting instance health
 	svch := service.BuildServiceHealth(*svc)
-	var punctualLock sync.RWMutex
-	punctualInstances := 0
+	punctual := true
 
-	for instanceID := 0; instanceID < svc.Instances; instanceID++ {
-		ilogger := logger.WithField("instance", instanceID)
-		ilogger.Debug("Restarting instance")
+	for batch := rr.nextBatch(); batch != nil; batch = rr.nextBatch() {
+		blogger := logger.WithField("instances", batch)
+		blogger.Debug("Restarting instances")
 
 		select {
 		case <-cancel:
@@ -1867,45 +2035,115 @@ func (f *Facade) rollingRestart(ctx datastore.Context, svc *service.Service, tim
 		default:
 		}
 
-		// Set up the timeout
-		cancelWait := make(chan struct{})
-		done := make(chan struct{})
-		timer := time.NewTimer(timeout)
-		go func() {
-			select {
-			case <-timer.C:
-				ilogger.Warn("Timeout waiting for instance to restart")
-			case <-done:
-				punctualLock.Lock()
-				punctualInstances++
-				punctualLock.Unlock()
-			case <-cancel:
-				ilogger.Debug("Rolling restart cancelled")
+		// I don't care if it is the last batch, unless the restart waits on
+		// unhealthy batches
+		checkHealth := rr.pauseUnhealthy || len(rr.getStatus().Pending) > len(batch)
+		markRestarted := func(instanceID int) { delete(notRestarted, instanceID) }
+		unhealthy, err := f.restartBatch(ctx, svc, svch, batch, checkHealth, timeout, cancel, markRestarted)
+
+		select {
+		case <-cancel:
+			blogger.Debug("Rolling restart cancelled")
+			return nil
+		default:
+		}
+
+		if err != nil || len(unhealthy) > 0 {
+			punctual = false
+			if !rr.pauseUnhealthy {
+				if err != nil {
+					blogger.WithError(err).Debug("Failed to restart instances")
+					return err
+				}
+				blogger.WithField("unhealthy", unhealthy).Warn("Timeout waiting for instances to restart")
+			} else {
+				var message string
+				if err != nil {
+					message = fmt.Sprintf("could not restart instances: %s", err)
+					unhealthy = batch
+				} else {
+					message = fmt.Sprintf("instances %s did not pass their health checks within %s", joinInts(unhealthy), timeout)
+				}
+				blogger.WithError(err).WithField("unhealthy", unhealthy).Warn("Pausing rolling restart")
+				rr.pause(unhealthy, message)
+				f.auditLogger.Message(ctx, "Rolling Restart Paused").Action(audit.Pause).ID(svc.ID).Type(service.GetType()).
+					WithField("reason", message).Failed()
+
+				select {
+				case <-rr.resume:
+					blogger.Info("Rolling restart resumed")
+				case <-cancel:
+					blogger.Info("Rolling restart cancelled")
+					return nil
+				}
 			}
-			timer.Stop()
-			close(cancelWait)
-		}()
+			// the instances of the batch that were not restarted are skipped
+			revert(batch)
+		}
+		rr.finishBatch(batch)
+		blogger.Debug("Done restarting instances")
+	}
+
+	if punctual {
+		f.SetServicesCurrentState(ctx, service.SVCCSRunning, svc.ID)
+	}
 
-		// Before we restart, check the current instance's container ID
+	return nil
+}
+
+// restartBatch restarts a batch of instances of a service together, and waits
+// for each of them to come back in a new container and, if checkHealth is
+// set, pass its health checks.  It returns the instances that were not ready
+// before the timeout.
+func (f *Facade) restartBatch(ctx datastore.Context, svc *service.Service, svch *service.ServiceHealth, batch []int, checkHealth bool, timeout time.Duration, cancel <-chan interface{}, markRestarted func(int)) ([]int, error) {
+	logger := plog.WithFields(log.Fields{
+		"serviceid":   svc.ID,
+		"servicename": svc.Name,
+		"instances":   batch,
+	})
+
+	// Set up the timeout
+	cancelWait := make(chan struct{})
+	done := make(chan struct{})
+	timer := time.NewTimer(timeout)
+	go func() {
+		select {
+		case <-timer.C:
+			logger.Warn("Timeout waiting for instances to restart")
+		case <-done:
+		case <-cancel:
+			logger.Debug("Rolling restart cancelled")
+		}
+		timer.Stop()
+		close(cancelWait)
+	}()
+	defer func() {
+		close(done)
+		<-cancelWait
+	}()
+
+	// Before we restart, check the current container ID of each instance
+	oldContainers := make(map[int]string)
+	for _, instanceID := range batch {
 		state, err := f.zzk.GetServiceState(ctx, svc.PoolID, svc.ID, instanceID)
 		if err != nil {
-			close(done)
-			logger.WithError(err).Debug("Failed to get service's current container ID")
-			return err
+			logger.WithField("instance", instanceID).WithError(err).Debug("Failed to get service's current container ID")
+			return nil, err
 		}
+		oldContainers[instanceID] = state.ContainerID
+	}
 
-		oldContainer := state.ContainerID
-
-		if err = f.zzk.RestartInstance(ctx, svc.PoolID, svc.ID, instanceID); err != nil {
-			close(done)
-			logger.WithError(err).Debug("Failed to restart instance")
-			return err
+	for _, instanceID := range batch {
+		if err := f.zzk.RestartInstance(ctx, svc.PoolID, svc.ID, instanceID); err != nil {
+			logger.WithField("instance", instanceID).WithError(err).Debug("Failed to restart instance")
+			return nil, err
 		}
+		markRestarted(instanceID)
+	}
 
-		// Increment nextInstance now, since we have started the restart process for this instance
-		nextInstance++
-
-		// Wait for the instance's containerID to change
+	// Wait for the containerID of each instance to change
+	for i, instanceID := range batch {
+		oldContainer := oldContainers[instanceID]
 		checkContainer := func(s *zkservice.State, exists bool) bool {
 			if !exists {
 				return true
@@ -1915,68 +2153,61 @@ func (f *Facade) rollingRestart(ctx datastore.Context, svc *service.Service, tim
 			}
 			return false
 		}
-		if err = f.zzk.WaitInstance(ctx, svc, instanceID, checkContainer, cancelWait); err != nil {
-			close(done)
-			logger.WithError(err).Debug("Failed to wait on instance")
-			return err
-		}
-
-		// Check if we're ready to move on to the next instance on an interval
-		ready := func() bool {
-			// I don't care if it is the last instance
-			if instanceID == svc.Instances-1 {
-				return true
-			}
-
-			// Wait for health checks to pass
-			statuses := f.getInstanceHealth(svch, instanceID)
-			for key, status := range statuses {
-				ilogger.WithFields(log.Fields{
-					"status": status,
-					"key":    key,
-				}).Debug("Got health status for instance")
-				if status != health.OK {
-					return false
-				}
-			}
-
-			return true
+		if err := f.zzk.WaitInstance(ctx, svc, instanceID, checkContainer, cancelWait); err != nil {
+			logger.WithField("instance", instanceID).WithError(err).Debug("Failed to wait on instance")
+			return nil, err
 		}
 
-		hctimer := time.NewTimer(500 * time.Millisecond)
-
 		// See if we have timed out
-		cancelled := false
 		select {
 		case <-cancelWait:
-			cancelled = true
+			return batch[i:], nil
 		default:
 		}
+	}
+
+	// Check if we're ready to move on to the next batch on an interval
+	ready := func(instanceID int) bool {
+		if !checkHealth {
+			return true
+		}
 
-		for !cancelled && !ready() {
-			ilogger.Debug("Instance not ready yet, checking again in 500 ms")
-			select {
-			case <-cancelWait:
-				cancelled = true
-			case <-hctimer.C:
-				hctimer.Stop()
-				hctimer.Reset(500 * time.Millisecond)
+		// Wait for health checks to pass
+		statuses := f.getInstanceHealth(svch, instanceID)
+		for key, status := range statuses {
+			logger.WithFields(log.Fields{
+				"instance": instanceID,
+				"status":   status,
+				"key":      key,
+			}).Debug("Got health status for instance")
+			if status != health.OK {
+				return false
 			}
 		}
-		hctimer.Stop()
-		ilogger.Debug("Done restarting instance")
-		close(done)
-		<-cancelWait
+		return true
 	}
 
-	punctualLock.RLock()
-	instances := punctualInstances
-	punctualLock.RUnlock()
-	if instances == svc.Instances {
-		f.SetServicesCurrentState(ctx, service.SVCCSRunning, svc.ID)
-	}
+	hctimer := time.NewTimer(rollingRestartPollInterval)
+	defer hctimer.Stop()
+	for {
+		var unhealthy []int
+		for _, instanceID := range batch {
+			if !ready(instanceID) {
+				unhealthy = append(unhealthy, instanceID)
+			}
+		}
+		if len(unhealthy) == 0 {
+			return nil, nil
+		}
 
-	return nil
+		logger.WithField("unhealthy", unhealthy).Debugf("Instances not ready yet, checking again in %s", rollingRestartPollInterval)
+		select {
+		case <-cancelWait:
+			return unhealthy, nil
+		case <-hctimer.C:
+			hctimer.Reset(rollingRestartPollInterval)
+		}
+	}
 }
 
 // Update the serviceCache with values from ZK.
@@ -2070,6 +2301,9 @@ func (f *Facade) ListTenants(ctx datastore.Context) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	if tenants, err = f.filterDetailsByTenantGrants(ctx, tenants); err != nil {
+		return nil, err
+	}
 	for _, t := range tenants {
 		tenantIDs = append(tenantIDs, t.ID)
 	}
@@ -2161,6 +2395,9 @@ func (f *Facade) ClearEmergencyStopFlag(ctx datastore.Context, serviceID string)
 	if err != nil {
 		return 0, alog.Error(err)
 	}
+	if err := f.authorizeTenant(ctx, tenantID); err != nil {
+		return 0, alog.Error(err)
+	}
 	alog = alog.WithField("tenantid", tenantID)
 	mutex := getTenantLock(tenantID)
 	mutex.RLock()
@@ -2341,6 +2578,10 @@ func IpVisitorFn(portmap Ports, request addressassignment.AssignmentRequest, f *
 func (f *Facade) AssignIPs(ctx datastore.Context, request addressassignment.AssignmentRequest) error {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.AssignIPs"))
 
+	if err := f.authorizeService(ctx, request.ServiceID); err != nil {
+		return err
+	}
+
 	svc, err := f.GetService(ctx, request.ServiceID)
 	if err != nil {
 		return err
@@ -2356,6 +2597,9 @@ func (f *Facade) AssignIPs(ctx datastore.Context, request addressassignment.Assi
 // to latest, making sure to push changes to the registry
 func (f *Facade) ServiceUse(ctx datastore.Context, serviceID, imageName, registryName string, replaceImgs []string, noOp bool) error {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.ServiceUse"))
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return err
+	}
 	glog.Infof("Pushing image %s for tenant %s into elastic", imageName, serviceID)
 	// Push into elastic
 	if err := f.Download(imageName, serviceID); err != nil {
@@ -2778,7 +3022,14 @@ func (f *Facade) GetInstanceMemoryStats(startTime time.Time, instances ...metric
 // GetServiceDetails returns the details of a particular service
 func (f *Facade) GetServiceDetails(ctx datastore.Context, serviceID string) (*service.ServiceDetails, error) {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetServiceDetails"))
-	return f.serviceStore.GetServiceDetails(ctx, serviceID)
+	details, err := f.serviceStore.GetServiceDetails(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	return details, nil
 }
 
 // GetServiceDetailsAncestry returns a service and its ancestors
@@ -2788,6 +3039,9 @@ func (f *Facade) GetServiceDetailsAncestry(ctx datastore.Context, serviceID stri
 	if err != nil {
 		return nil, err
 	}
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
 
 	if s.ParentServiceID != "" {
 		ps, err := f.GetServiceDetailsAncestry(ctx, s.ParentServiceID)
@@ -2803,12 +3057,19 @@ func (f *Facade) GetServiceDetailsAncestry(ctx datastore.Context, serviceID stri
 // Get the details of the child services for the given parent
 func (f *Facade) GetServiceDetailsByParentID(ctx datastore.Context, parentID string, since time.Duration) ([]service.ServiceDetails, error) {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetServiceDetailsByParentID"))
-	return f.serviceStore.GetServiceDetailsByParentID(ctx, parentID, since)
+	details, err := f.serviceStore.GetServiceDetailsByParentID(ctx, parentID, since)
+	if err != nil {
+		return nil, err
+	}
+	return f.filterDetailsByTenantGrants(ctx, details)
 }
 
 // Get the details of all services for the specified tenant
 func (f *Facade) GetServiceDetailsByTenantID(ctx datastore.Context, tenantID string) ([]service.ServiceDetails, error) {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetServiceDetailsByTenantID"))
+	if err := f.authorizeTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
 	svcs, err := f.serviceStore.Query(ctx, service.Query{})
 	if err != nil {
 		return nil, err
@@ -2819,6 +3080,9 @@ func (f *Facade) GetServiceDetailsByTenantID(ctx datastore.Context, tenantID str
 // Get the monitoring profile of a given service
 func (f *Facade) GetServiceMonitoringProfile(ctx datastore.Context, serviceID string) (*domain.MonitorProfile, error) {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetServiceMonitoringProfile"))
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
 	svc, err := f.serviceStore.Get(ctx, serviceID)
 	if err != nil {
 		return nil, err
@@ -2830,6 +3094,9 @@ func (f *Facade) GetServiceMonitoringProfile(ctx datastore.Context, serviceID st
 // and its children if enabled.
 func (f *Facade) GetServiceExportedEndpoints(ctx datastore.Context, serviceID string, children bool) ([]service.ExportedEndpoint, error) {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetServiceExportedEndpoints"))
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
 
 	// get all the exported endpoints and map them to their service
 	alleps, err := f.serviceStore.GetAllExportedEndpoints(ctx)
@@ -2875,6 +3142,9 @@ func (f *Facade) GetServiceExportedEndpoints(ctx datastore.Context, serviceID st
 // children if enabled.
 func (f *Facade) GetServicePublicEndpoints(ctx datastore.Context, serviceID string, children bool) ([]service.PublicEndpoint, error) {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetServicePublicEndpoints"))
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
 
 	pubeps := []service.PublicEndpoint{}
 	if children {
@@ -2956,6 +3226,9 @@ func (f *Facade) getServicePublicEndpoints(svc service.Service) []service.Public
 // use by the UI, so that it can know how many descendants a start/stop action
 // will affect.
 func (f *Facade) CountDescendantStates(ctx datastore.Context, serviceID string) (map[string]map[string]int, error) {
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
 	result := make(map[string]map[string]int)
 	f.walkServices(ctx, serviceID, true, func(svc *service.Service) error {
 		if svc.ID == serviceID {
@@ -3071,7 +3344,11 @@ func isEmptyPath(p string) bool {
 
 func (f *Facade) QueryServiceDetails(ctx datastore.Context, request service.Query) ([]service.ServiceDetails, error) {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.QueryServiceDetails"))
-	return f.serviceStore.Query(ctx, request)
+	details, err := f.serviceStore.Query(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return f.filterDetailsByTenantGrants(ctx, details)
 }
 
 func (f *Facade) GetServiceNamePath(ctx datastore.Context, serviceID string) (tenantID string, serviceNamePath string, err error) {
@@ -27,6 +27,8 @@ import (
 	"github.com/control-center/serviced/datastore"
 	"github.com/control-center/serviced/domain"
 	"github.com/control-center/serviced/domain/addressassignment"
+	"github.com/control-center/serviced/domain/auditlog"
+	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/pool"
 	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/serviceconfigfile"
@@ -429,6 +431,43 @@ func (ft *FacadeIntegrationTest) TestFacade_validateServiceAdd_InvalidServiceOpt
 	t.Assert(err, NotNil)
 }
 
+// We should get an error if the cpu quota is larger than every host in the pool.
+func (ft *FacadeIntegrationTest) TestFacade_validateServiceAdd_CPUQuota(t *C) {
+	t.Assert(ft.Facade.AddResourcePool(ft.CTX, &pool.ResourcePool{ID: "pool_id"}), IsNil)
+	h := &host.Host{
+		ID:      "deadb11f",
+		PoolID:  "pool_id",
+		Name:    "h1",
+		IPAddr:  "12.27.36.45",
+		RPCPort: 65535,
+		Cores:   4,
+		IPs: []host.HostIPResource{
+			{
+				HostID:    "deadb11f",
+				IPAddress: "12.27.36.45",
+			},
+		},
+	}
+	_, err := ft.Facade.AddHost(ft.CTX, h)
+	t.Assert(err, IsNil)
+
+	svc := service.Service{
+		ID:           "svc1",
+		Name:         "TestFacade_CPUQuota",
+		DeploymentID: "deployment_id",
+		PoolID:       "pool_id",
+		Launch:       "auto",
+		DesiredState: int(service.SVCStop),
+		CPUQuota:     8,
+	}
+	err = ft.Facade.AddService(ft.CTX, svc)
+	_, ok := err.(ErrInvalidServiceOption)
+	t.Assert(ok, Equals, true)
+
+	svc.CPUQuota = 2.5
+	t.Assert(ft.Facade.AddService(ft.CTX, svc), IsNil)
+}
+
 // We should get an error if we try to update a service with an invalid set of options.
 func (ft *FacadeIntegrationTest) TestFacade_validateServiceUpdate_InvalidServiceOptions(t *C) {
 	svc := service.Service{
@@ -451,6 +490,22 @@ func (ft *FacadeIntegrationTest) TestFacade_validateServiceUpdate_InvalidService
 	t.Assert(err, NotNil) // This should have returned an ErrInvalidServiceOption error.
 }
 
+// An external HostPolicy must name a registered strategy.
+func (ft *FacadeIntegrationTest) TestFacade_validateServiceAdd_UnknownExternalStrategy(t *C) {
+	svc := service.Service{
+		ID:           "svc1",
+		Name:         "TestFacade_UnknownExternalStrategy",
+		DeploymentID: "deployment_id",
+		PoolID:       "pool_id",
+		Launch:       "auto",
+		DesiredState: int(service.SVCStop),
+		HostPolicy:   servicedefinition.HostPolicy("external:missing"),
+	}
+
+	err := ft.Facade.AddService(ft.CTX, svc)
+	t.Assert(err, FitsTypeOf, ErrInvalidServiceOption{})
+}
+
 func (ft *FacadeIntegrationTest) TestFacade_migrateServiceConfigs_noConfigs(t *C) {
 	_, newSvc, err := ft.setupMigrationServices(t, nil)
 	t.Assert(err, IsNil)
@@ -3008,6 +3063,16 @@ func (ft *FacadeIntegrationTest) TestFacade_ClearEmergencyStopFlag(c *C) {
 	}
 }
 
+// restartEachInstance returns a rolling restart of all the instances of a
+// service, one at a time
+func restartEachInstance(svc *service.Service) *rollingRestart {
+	instanceIDs := make([]int, svc.Instances)
+	for i := range instanceIDs {
+		instanceIDs[i] = i
+	}
+	return newRollingRestart(svc, instanceIDs, 1)
+}
+
 func (ft *FacadeIntegrationTest) TestFacade_rollingRestart_Pass(c *C) {
 	svc := service.Service{
 		ID:                "serviceID",
@@ -3090,7 +3155,7 @@ func (ft *FacadeIntegrationTest) TestFacade_rollingRestart_Pass(c *C) {
 
 	done := make(chan struct{})
 	go func() {
-		err := ft.Facade.rollingRestart(ft.CTX, &svc, 30*time.Second, make(chan interface{}))
+		err := ft.Facade.rollingRestart(ft.CTX, &svc, restartEachInstance(&svc), 30*time.Second, make(chan interface{}))
 		c.Assert(err, IsNil)
 		close(done)
 	}()
@@ -3206,7 +3271,7 @@ func (ft *FacadeIntegrationTest) TestFacade_rollingRestart_TimeoutWait(c *C) {
 
 	done := make(chan struct{})
 	go func() {
-		err := ft.Facade.rollingRestart(ft.CTX, &svc, timeout, make(chan interface{}))
+		err := ft.Facade.rollingRestart(ft.CTX, &svc, restartEachInstance(&svc), timeout, make(chan interface{}))
 		c.Assert(err, IsNil)
 		close(done)
 	}()
@@ -3296,7 +3361,7 @@ func (ft *FacadeIntegrationTest) TestFacade_rollingRestart_TimeoutHealthcheck(c
 
 	done := make(chan struct{})
 	go func() {
-		err := ft.Facade.rollingRestart(ft.CTX, &svc, timeout, make(chan interface{}))
+		err := ft.Facade.rollingRestart(ft.CTX, &svc, restartEachInstance(&svc), timeout, make(chan interface{}))
 		c.Assert(err, IsNil)
 		close(done)
 	}()
@@ -3361,7 +3426,7 @@ func (ft *FacadeIntegrationTest) TestFacade_rollingRestart_FailWait(c *C) {
 	ft.zzk.On("WaitInstance", ft.CTX, &svc, 0, mock.AnythingOfType("func(*service.State, bool) bool"), mock.AnythingOfType("<-chan struct {}")).Return(nil).Once()
 	ft.zzk.On("WaitInstance", ft.CTX, &svc, 1, mock.AnythingOfType("func(*service.State, bool) bool"), mock.AnythingOfType("<-chan struct {}")).Return(testerr).Once()
 
-	err := ft.Facade.rollingRestart(ft.CTX, &svc, 30*time.Second, make(chan interface{}))
+	err := ft.Facade.rollingRestart(ft.CTX, &svc, restartEachInstance(&svc), 30*time.Second, make(chan interface{}))
 	// Make sure our rollingRestart bailed after it failed for one instance
 	c.Assert(err, Equals, testerr)
 }
@@ -3395,7 +3460,7 @@ func (ft *FacadeIntegrationTest) TestFacade_rollingRestart_FailRestartInstance(c
 
 	// Make sure we call WaitInstance once for each insance of svc that gets called
 	ft.zzk.On("WaitInstance", ft.CTX, &svc, 0, mock.AnythingOfType("func(*service.State, bool) bool"), mock.AnythingOfType("<-chan struct {}")).Return(nil).Once()
-	err := ft.Facade.rollingRestart(ft.CTX, &svc, 30*time.Second, make(chan interface{}))
+	err := ft.Facade.rollingRestart(ft.CTX, &svc, restartEachInstance(&svc), 30*time.Second, make(chan interface{}))
 	// Make sure our rollingRestart bailed after it failed for one instance
 	c.Assert(err, Equals, testerr)
 }
@@ -3422,7 +3487,7 @@ func (ft *FacadeIntegrationTest) TestFacade_rollingRestart_FailGetState(c *C) {
 	testerr := errors.New("test error")
 	ft.zzk.On("GetServiceState", ft.CTX, svc.PoolID, svc.ID, 0).Return(nil, testerr).Once()
 
-	err := ft.Facade.rollingRestart(ft.CTX, &svc, 30*time.Second, make(chan interface{}))
+	err := ft.Facade.rollingRestart(ft.CTX, &svc, restartEachInstance(&svc), 30*time.Second, make(chan interface{}))
 	// Make sure our rollingRestart bailed after it failed for one instance
 	c.Assert(err, Equals, testerr)
 }
@@ -3810,3 +3875,24 @@ func (ft *FacadeIntegrationTest) TestFacade_getChanges(c *C) {
 	expected := "Name:TestFacade_getChanges_Updated;"
 	c.Assert(expected, Equals, updates)
 }
+
+func (ft *FacadeIntegrationTest) TestFacade_getServiceDiff(c *C) {
+	cursvc := service.Service{
+		ID:           "get-service-diff-service",
+		Name:         "TestFacade_getServiceDiff",
+		DeploymentID: "deployment-id",
+		PoolID:       "pool-id",
+		Launch:       "auto",
+		Instances:    1,
+		Environment:  []string{"A=1"},
+	}
+	c.Assert(ft.Facade.AddService(ft.CTX, cursvc), IsNil)
+	svc, _ := ft.Facade.getService(ft.CTX, cursvc.ID)
+	svc.Instances = 2
+	svc.Environment = []string{"A=2"}
+	changes := ft.Facade.getServiceDiff(ft.CTX, svc)
+	c.Assert(changes, DeepEquals, []auditlog.Change{
+		{Field: "Environment[0]", Before: "A=1", After: "A=2"},
+		{Field: "Instances", Before: "1", After: "2"},
+	})
+}
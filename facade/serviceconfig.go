@@ -16,6 +16,7 @@ package facade
 import (
 	"errors"
 	"os"
+	"path"
 	"reflect"
 
 	log "github.com/Sirupsen/logrus"
@@ -33,6 +34,10 @@ func (f *Facade) GetServiceConfigs(ctx datastore.Context, serviceID string) ([]s
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetServiceConfigs"))
 	logger := plog.WithField("serviceid", serviceID)
 
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+
 	tenantID, servicePath, err := f.getServicePath(ctx, serviceID)
 	if err != nil {
 		logger.WithError(err).Debug("Could not trace service path")
@@ -73,6 +78,10 @@ func (f *Facade) GetServiceConfig(ctx datastore.Context, fileID string) (*servic
 		return nil, err
 	}
 
+	if err := f.authorizeService(ctx, configFileServiceID(file)); err != nil {
+		return nil, err
+	}
+
 	return &file.ConfFile, nil
 }
 
@@ -88,6 +97,10 @@ func (f *Facade) AddServiceConfig(ctx datastore.Context, serviceID string, conf
 		Action(audit.Add).Type(servicedefinition.GetConfigFileType()).
 		WithField("path", conf.Filename).WithField("serviceid", serviceID)
 
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return alog.Error(err)
+	}
+
 	tenantID, servicePath, err := f.getServicePath(ctx, serviceID)
 	if err != nil {
 		logger.WithError(err).Debug("Could not trace service path")
@@ -120,11 +133,15 @@ func (f *Facade) AddServiceConfig(ctx datastore.Context, serviceID string, conf
 
 	alog = alog.ID(file.ID)
 
+	// keep the service as it was before this change, if it is not yet stored
+	f.recordServiceRevision(ctx, serviceID)
+
 	// write the record into the database
 	if err := f.configStore.Put(ctx, serviceconfigfile.Key(file.ID), file); err != nil {
 		logger.WithField("fileid", file.ID).WithError(err).Debug("Could not add record to the database")
 		return alog.Error(err)
 	}
+	f.recordServiceRevision(ctx, serviceID)
 
 	logger.Debug("Created new service config file")
 	alog.Succeeded()
@@ -149,7 +166,15 @@ func (f *Facade) UpdateServiceConfig(ctx datastore.Context, fileID string, conf
 		return alog.Error(err)
 	}
 
-	alog = alog.WithField("servicepath", file.ServicePath)
+	alog = alog.WithField("servicepath", file.ServicePath).Changes(audit.Diff(file.ConfFile, conf))
+
+	serviceID := configFileServiceID(file)
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return alog.Error(err)
+	}
+
+	// keep the service as it was before this change, if it is not yet stored
+	f.recordServiceRevision(ctx, serviceID)
 
 	// update the database record for the file
 	file.ConfFile = conf
@@ -159,6 +184,7 @@ func (f *Facade) UpdateServiceConfig(ctx datastore.Context, fileID string, conf
 		logger.WithError(err).Debug("Could not update record in database")
 		return alog.Error(err)
 	}
+	f.recordServiceRevision(ctx, serviceID)
 
 	logger.Debug("Updated service config file")
 	alog.Succeeded()
@@ -173,16 +199,37 @@ func (f *Facade) DeleteServiceConfig(ctx datastore.Context, fileID string) error
 	alog := f.auditLogger.Message(ctx, "Removing Service Configuration").
 		Action(audit.Remove).ID(fileID).Type(servicedefinition.GetConfigFileType())
 
+	file := &serviceconfigfile.SvcConfigFile{}
+	if err := f.configStore.Get(ctx, serviceconfigfile.Key(fileID), file); err != nil {
+		logger.WithError(err).Debug("Could not get service config file")
+		return alog.Error(err)
+	}
+
+	serviceID := configFileServiceID(file)
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return alog.Error(err)
+	}
+
+	// keep the service as it was before this change, if it is not yet stored
+	f.recordServiceRevision(ctx, serviceID)
+
 	if err := f.configStore.Delete(ctx, serviceconfigfile.Key(fileID)); err != nil {
 		logger.WithError(err).Debug("Could not delete service config file")
 		return alog.Error(err)
 	}
+	f.recordServiceRevision(ctx, serviceID)
 
 	logger.Debug("Deleted service config file")
 	alog.Succeeded()
 	return nil
 }
 
+// configFileServiceID returns the id of the service that a config file
+// belongs to, which is the last element of its service path.
+func configFileServiceID(file *serviceconfigfile.SvcConfigFile) string {
+	return path.Base(file.ServicePath)
+}
+
 // getServicePath returns the tenantID and the full path of the service
 // TODO: update function to include deploymentID in the service path
 func (f *Facade) getServicePath(ctx datastore.Context, serviceID string) (tenantID string, servicePath string, err error) {
@@ -0,0 +1,181 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/control-center/serviced/audit"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/domain/servicerevision"
+)
+
+// maxServiceRevisions is the number of revisions kept for each service.  Older
+// revisions are removed as new ones are recorded.
+const maxServiceRevisions = 50
+
+// serviceRevisionIgnoredFields are the fields of a service that change as it
+// runs rather than when it is edited, so they do not make a new revision.
+var serviceRevisionIgnoredFields = append([]string{"DesiredState", "CurrentState", "EmergencyShutdown"}, serviceDiffIgnoredFields...)
+
+// revisionLocks serializes the recording of revisions of each service, so
+// that concurrent updates do not take the same revision number.
+var revisionLocks = struct {
+	sync.Mutex
+	services map[string]*sync.Mutex
+}{services: make(map[string]*sync.Mutex)}
+
+// getServiceRevisionLock returns the lock for recording revisions of a service
+func getServiceRevisionLock(serviceID string) *sync.Mutex {
+	revisionLocks.Lock()
+	defer revisionLocks.Unlock()
+	mutex, ok := revisionLocks.services[serviceID]
+	if !ok {
+		mutex = &sync.Mutex{}
+		revisionLocks.services[serviceID] = mutex
+	}
+	return mutex
+}
+
+// GetServiceRevisions returns the stored revisions of a service, most recent
+// first.
+func (f *Facade) GetServiceRevisions(ctx datastore.Context, serviceID string) ([]servicerevision.Revision, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetServiceRevisions"))
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	return f.revisionStore.GetRevisions(ctx, serviceID)
+}
+
+// GetServiceRevision returns a revision of a service.  Returns ErrNoSuchEntity
+// if the revision does not exist.
+func (f *Facade) GetServiceRevision(ctx datastore.Context, serviceID string, revision int) (*servicerevision.Revision, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetServiceRevision"))
+	if err := f.authorizeService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	return f.revisionStore.Get(ctx, serviceID, revision)
+}
+
+// RevertService updates a service to the definition and config files stored
+// in one of its revisions.  The state of the running service is kept, and the
+// revert is itself recorded as a new revision.
+func (f *Facade) RevertService(ctx datastore.Context, serviceID string, revision int) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.RevertService"))
+	alog := f.auditLogger.Message(ctx, "Reverting Service").Action(audit.Revert).
+		ID(serviceID).Type(service.GetType()).WithField("revision", strconv.Itoa(revision))
+
+	rev, err := f.GetServiceRevision(ctx, serviceID, revision)
+	if err != nil {
+		return alog.Error(err)
+	}
+	cursvc, err := f.serviceStore.Get(ctx, serviceID)
+	if err != nil {
+		return alog.Error(err)
+	}
+
+	svc := rev.Service
+	svc.DesiredState = cursvc.DesiredState
+	svc.CurrentState = cursvc.CurrentState
+	svc.EmergencyShutdown = cursvc.EmergencyShutdown
+	svc.CreatedAt = cursvc.CreatedAt
+	svc.DatabaseVersion = cursvc.DatabaseVersion
+	if svc.ConfigFiles == nil {
+		svc.ConfigFiles = make(map[string]servicedefinition.ConfigFile)
+	}
+	return alog.Error(f.UpdateService(ctx, svc))
+}
+
+// recordServiceRevision stores the current definition of a service as a new
+// revision, unless it has not changed since the latest revision.  Revisions
+// are a record of updates made elsewhere, so failures are logged rather than
+// returned.
+func (f *Facade) recordServiceRevision(ctx datastore.Context, serviceID string) {
+	logger := plog.WithField("serviceid", serviceID)
+	mutex := getServiceRevisionLock(serviceID)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	svc, err := f.serviceStore.Get(ctx, serviceID)
+	if err != nil {
+		logger.WithError(err).Warn("Could not load service to record a revision")
+		return
+	}
+	if err := f.fillServiceConfigs(ctx, svc); err != nil {
+		logger.WithError(err).Warn("Could not load config files to record a revision")
+		return
+	}
+	svc.DatabaseVersion = 0
+
+	revisions, err := f.revisionStore.GetRevisions(ctx, serviceID)
+	if err != nil {
+		logger.WithError(err).Warn("Could not load revisions of service")
+		return
+	}
+
+	rev := &servicerevision.Revision{
+		ServiceID: serviceID,
+		Revision:  1,
+		Time:      time.Now().UTC(),
+		User:      ctx.User(),
+		Service:   *svc,
+	}
+	if len(revisions) > 0 {
+		latest := revisions[0]
+		rev.Changes = audit.Diff(&latest.Service, svc, serviceRevisionIgnoredFields...)
+		if len(rev.Changes) == 0 {
+			return
+		}
+		rev.Revision = latest.Revision + 1
+	}
+	rev.ID = servicerevision.RevisionID(serviceID, rev.Revision)
+	logger = logger.WithField("revision", rev.Revision)
+
+	if err := rev.ValidEntity(); err != nil {
+		logger.WithError(err).Warn("Could not validate revision of service")
+		return
+	}
+	if err := f.revisionStore.Put(ctx, rev); err != nil {
+		logger.WithError(err).Warn("Could not record revision of service")
+		return
+	}
+	logger.Debug("Recorded revision of service")
+
+	if len(revisions) >= maxServiceRevisions {
+		for _, old := range revisions[maxServiceRevisions-1:] {
+			if err := f.revisionStore.Delete(ctx, serviceID, old.Revision); err != nil {
+				logger.WithError(err).WithField("oldrevision", old.Revision).Warn("Could not remove old revision of service")
+			}
+		}
+	}
+}
+
+// removeServiceRevisions deletes all of the revisions of a removed service
+func (f *Facade) removeServiceRevisions(ctx datastore.Context, serviceID string) {
+	logger := plog.WithField("serviceid", serviceID)
+	revisions, err := f.revisionStore.GetRevisions(ctx, serviceID)
+	if err != nil {
+		logger.WithError(err).Warn("Could not load revisions of removed service")
+		return
+	}
+	for _, rev := range revisions {
+		if err := f.revisionStore.Delete(ctx, serviceID, rev.Revision); err != nil {
+			logger.WithError(err).WithField("revision", rev.Revision).Warn("Could not remove revision of removed service")
+		}
+	}
+}
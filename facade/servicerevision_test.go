@@ -0,0 +1,127 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build integration
+
+package facade
+
+import (
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeIntegrationTest) TestFacade_ServiceRevisions(c *C) {
+	svc := service.Service{
+		ID:           "service-revision-service",
+		Name:         "TestFacade_ServiceRevisions",
+		DeploymentID: "deployment-id",
+		PoolID:       "pool-id",
+		Launch:       "auto",
+		Instances:    1,
+		Environment:  []string{"A=1"},
+		ConfigFiles: map[string]servicedefinition.ConfigFile{
+			"/etc/app.conf": {Filename: "/etc/app.conf", Content: "first"},
+		},
+	}
+	c.Assert(ft.Facade.AddService(ft.CTX, svc), IsNil)
+
+	revisions, err := ft.Facade.GetServiceRevisions(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(revisions, HasLen, 1)
+	c.Assert(revisions[0].Revision, Equals, 1)
+	c.Assert(revisions[0].Changes, HasLen, 0)
+
+	// an update records a revision with the changes
+	cursvc, err := ft.Facade.GetService(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	cursvc.Instances = 2
+	cursvc.ConfigFiles = map[string]servicedefinition.ConfigFile{
+		"/etc/app.conf": {Filename: "/etc/app.conf", Content: "second"},
+	}
+	c.Assert(ft.Facade.UpdateService(ft.CTX, *cursvc), IsNil)
+
+	revisions, err = ft.Facade.GetServiceRevisions(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(revisions, HasLen, 2)
+	c.Assert(revisions[0].Revision, Equals, 2)
+	c.Assert(revisions[0].Service.Instances, Equals, 2)
+	c.Assert(revisions[0].Service.ConfigFiles["/etc/app.conf"].Content, Equals, "second")
+	fields := []string{}
+	for _, change := range revisions[0].Changes {
+		fields = append(fields, change.Field)
+	}
+	c.Assert(fields, DeepEquals, []string{"ConfigFiles[/etc/app.conf].Content", "Instances"})
+
+	// an update that only changes the state does not record a revision
+	cursvc, err = ft.Facade.GetService(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	cursvc.DesiredState = int(service.SVCRun)
+	c.Assert(ft.Facade.UpdateService(ft.CTX, *cursvc), IsNil)
+	revisions, err = ft.Facade.GetServiceRevisions(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(revisions, HasLen, 2)
+
+	// reverting restores the definition and config files, but not the state
+	c.Assert(ft.Facade.RevertService(ft.CTX, svc.ID, 1), IsNil)
+	cursvc, err = ft.Facade.GetService(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(cursvc.Instances, Equals, 1)
+	c.Assert(cursvc.DesiredState, Equals, int(service.SVCRun))
+	c.Assert(ft.Facade.fillServiceConfigs(ft.CTX, cursvc), IsNil)
+	c.Assert(cursvc.ConfigFiles["/etc/app.conf"].Content, Equals, "first")
+
+	revisions, err = ft.Facade.GetServiceRevisions(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(revisions, HasLen, 3)
+	c.Assert(revisions[0].Revision, Equals, 3)
+	c.Assert(revisions[0].Service.Instances, Equals, 1)
+
+	// removing the service removes its revisions
+	c.Assert(ft.Facade.RemoveService(ft.CTX, svc.ID), IsNil)
+	revisions, err = ft.Facade.revisionStore.GetRevisions(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(revisions, HasLen, 0)
+}
+
+func (ft *FacadeIntegrationTest) TestFacade_ServiceRevisionsConfigFiles(c *C) {
+	svc := service.Service{
+		ID:           "service-revision-config-service",
+		Name:         "TestFacade_ServiceRevisionsConfigFiles",
+		DeploymentID: "deployment-id",
+		PoolID:       "pool-id",
+		Launch:       "auto",
+		ConfigFiles: map[string]servicedefinition.ConfigFile{
+			"/etc/app.conf": {Filename: "/etc/app.conf", Content: "first"},
+		},
+	}
+	c.Assert(ft.Facade.AddService(ft.CTX, svc), IsNil)
+
+	confs, err := ft.Facade.GetServiceConfigs(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(confs, HasLen, 1)
+
+	// editing a config file records a revision of its service
+	err = ft.Facade.UpdateServiceConfig(ft.CTX, confs[0].ID, servicedefinition.ConfigFile{Filename: "/etc/app.conf", Content: "second"})
+	c.Assert(err, IsNil)
+	revisions, err := ft.Facade.GetServiceRevisions(ft.CTX, svc.ID)
+	c.Assert(err, IsNil)
+	c.Assert(revisions, HasLen, 2)
+	c.Assert(revisions[0].Service.ConfigFiles["/etc/app.conf"].Content, Equals, "second")
+
+	// reverting restores the config file
+	c.Assert(ft.Facade.RevertService(ft.CTX, svc.ID, 1), IsNil)
+	conf, err := ft.Facade.GetServiceConfig(ft.CTX, confs[0].ID)
+	c.Assert(err, IsNil)
+	c.Assert(conf.Content, Equals, "first")
+}
@@ -0,0 +1,65 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/servicerevision"
+	"github.com/control-center/serviced/facade"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeUnitTest) Test_GetServiceRevisions(c *C) {
+	revisions := []servicerevision.Revision{
+		{ID: servicerevision.RevisionID("svc1", 2), ServiceID: "svc1", Revision: 2},
+		{ID: servicerevision.RevisionID("svc1", 1), ServiceID: "svc1", Revision: 1},
+	}
+	ft.revisionStore.On("GetRevisions", ft.ctx, "svc1").Return(revisions, nil)
+
+	result, err := ft.Facade.GetServiceRevisions(ft.ctx, "svc1")
+
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, revisions)
+}
+
+func (ft *FacadeUnitTest) Test_GetServiceRevisionsUnauthorizedTenant(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	result, err := ft.Facade.GetServiceRevisions(ctx, "grantChild2")
+
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	c.Assert(result, IsNil)
+	ft.revisionStore.AssertNotCalled(c, "GetRevisions", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_RevertServiceNoSuchRevision(c *C) {
+	ft.revisionStore.On("Get", ft.ctx, "svc1", 5).Return(nil, datastore.ErrNoSuchEntity{})
+
+	err := ft.Facade.RevertService(ft.ctx, "svc1", 5)
+
+	c.Assert(datastore.IsErrNoSuchEntity(err), Equals, true)
+	ft.serviceStore.AssertNotCalled(c, "Put", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_RevertServiceUnauthorizedTenant(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	err := ft.Facade.RevertService(ctx, "grantChild2", 1)
+
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	ft.revisionStore.AssertNotCalled(c, "Get", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -337,6 +337,13 @@ func (f *Facade) DeployTemplate(ctx datastore.Context, poolID string, templateID
 		return nil, alog.Error(fmt.Errorf("poolid %s not found", poolID))
 	}
 
+	// reject the deployment up front rather than leaving a partially
+	// deployed application behind
+	if err := f.validatePoolQuota(ctx, poolID, "", templateCommitment(template.Services)); err != nil {
+		logger.WithError(err).Error("Template exceeds the quota of the resource pool")
+		return nil, alog.Error(err)
+	}
+
 	var statusUpdater = func(status string) {
 		deployment.UpdateStatus(status)
 	}
@@ -494,6 +501,26 @@ func (f *Facade) deployService(ctx datastore.Context, tenantID string, parentSer
 	return newsvc.ID, nil
 }
 
+// templateCommitment returns the resources reserved by the default number of
+// instances of each service in a template.
+func templateCommitment(sds []servicedefinition.ServiceDefinition) commitment {
+	var c commitment
+	for _, sd := range sds {
+		instances := sd.Instances.Default
+		if instances == 0 {
+			instances = sd.Instances.Min
+		}
+		if instances > 0 {
+			c = c.add(commitment{
+				cores:  int(sd.CPUCommitment) * instances,
+				memory: sd.RAMCommitment.Value * uint64(instances),
+			})
+		}
+		c = c.add(templateCommitment(sd.Services))
+	}
+	return c
+}
+
 func (f *Facade) evaluateEndpointTemplates(ctx datastore.Context, newsvc *service.Service) error {
 	//for each endpoint, evaluate its Application
 	getService := func(serviceID string) (service.Service, error) {
@@ -0,0 +1,124 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/audit"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/snapshotschedule"
+)
+
+// GetSnapshotSchedules returns the snapshot schedules of the tenants the user
+// of the context may act on.
+func (f *Facade) GetSnapshotSchedules(ctx datastore.Context) ([]snapshotschedule.Schedule, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetSnapshotSchedules"))
+	schedules, err := f.scheduleStore.GetSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	grant, err := f.getTenantGrants(ctx)
+	if err != nil || grant == nil {
+		return schedules, err
+	}
+	allowed := []snapshotschedule.Schedule{}
+	for _, sched := range schedules {
+		if grant.allows(sched.TenantID) {
+			allowed = append(allowed, sched)
+		}
+	}
+	return allowed, nil
+}
+
+// GetSnapshotSchedule returns the snapshot schedule of a tenant.  Returns
+// ErrNoSuchEntity if the tenant has no schedule.
+func (f *Facade) GetSnapshotSchedule(ctx datastore.Context, tenantID string) (*snapshotschedule.Schedule, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetSnapshotSchedule"))
+	if err := f.authorizeTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	return f.scheduleStore.Get(ctx, tenantID)
+}
+
+// SetSnapshotSchedule adds or replaces the snapshot schedule of a tenant.  The
+// record of the last run of an existing schedule is kept.
+func (f *Facade) SetSnapshotSchedule(ctx datastore.Context, sched snapshotschedule.Schedule) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.SetSnapshotSchedule"))
+	alog := f.auditLogger.Message(ctx, "Setting Snapshot Schedule").Action(audit.Add).
+		ID(sched.TenantID).Type(snapshotschedule.GetType()).WithField("cron", sched.Cron)
+
+	if err := f.authorizeTenant(ctx, sched.TenantID); err != nil {
+		return alog.Error(err)
+	}
+	if err := f.validateTenantIDs(ctx, []string{sched.TenantID}); err != nil {
+		return alog.Error(err)
+	}
+
+	now := time.Now().UTC()
+	sched.CreatedAt, sched.UpdatedAt = now, now
+	sched.LastRun, sched.LastSnapshot, sched.LastError = time.Time{}, "", ""
+	if cur, err := f.scheduleStore.Get(ctx, sched.TenantID); err == nil {
+		alog = alog.Action(audit.Update)
+		sched.CreatedAt = cur.CreatedAt
+		sched.LastRun = cur.LastRun
+		sched.LastSnapshot = cur.LastSnapshot
+		sched.LastError = cur.LastError
+		sched.DatabaseVersion = cur.DatabaseVersion
+	} else if !datastore.IsErrNoSuchEntity(err) {
+		return alog.Error(err)
+	}
+
+	if err := sched.ValidEntity(); err != nil {
+		return alog.Error(err)
+	}
+	return alog.Error(f.scheduleStore.Put(ctx, &sched))
+}
+
+// RemoveSnapshotSchedule removes the snapshot schedule of a tenant.  The
+// snapshots it has taken are kept.
+func (f *Facade) RemoveSnapshotSchedule(ctx datastore.Context, tenantID string) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.RemoveSnapshotSchedule"))
+	alog := f.auditLogger.Message(ctx, "Removing Snapshot Schedule").Action(audit.Remove).
+		ID(tenantID).Type(snapshotschedule.GetType())
+	if err := f.authorizeTenant(ctx, tenantID); err != nil {
+		return alog.Error(err)
+	}
+	return alog.Error(f.scheduleStore.Delete(ctx, tenantID))
+}
+
+// RecordSnapshotScheduleRun stores the outcome of a run of the snapshot
+// schedule of a tenant.  An empty errMsg means the run succeeded and took the
+// given snapshot.
+func (f *Facade) RecordSnapshotScheduleRun(ctx datastore.Context, tenantID string, ran time.Time, snapshotID, errMsg string) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.RecordSnapshotScheduleRun"))
+	sched, err := f.scheduleStore.Get(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	sched.LastRun = ran.UTC()
+	sched.LastError = errMsg
+	if errMsg == "" {
+		sched.LastSnapshot = snapshotID
+	}
+	return f.scheduleStore.Put(ctx, sched)
+}
+
+// removeSnapshotSchedule deletes the snapshot schedule of a removed tenant, if
+// it has one.
+func (f *Facade) removeSnapshotSchedule(ctx datastore.Context, tenantID string) {
+	if err := f.scheduleStore.Delete(ctx, tenantID); err != nil && !datastore.IsErrNoSuchEntity(err) {
+		plog.WithError(err).WithField("tenantid", tenantID).Warn("Could not remove snapshot schedule of removed tenant")
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/snapshotschedule"
+	"github.com/control-center/serviced/facade"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (ft *FacadeUnitTest) Test_SetSnapshotScheduleNew(c *C) {
+	ft.serviceStore.On("GetServiceDetails", ft.ctx, "tenant1").Return(&service.ServiceDetails{ID: "tenant1"}, nil)
+	ft.scheduleStore.On("Get", ft.ctx, "tenant1").Return(nil, datastore.ErrNoSuchEntity{Key: snapshotschedule.Key("tenant1")})
+	ft.scheduleStore.On("Put", ft.ctx, mock.AnythingOfType("*snapshotschedule.Schedule")).Return(nil)
+
+	sched := snapshotschedule.Schedule{
+		TenantID:  "tenant1",
+		Cron:      "@daily",
+		Retention: domain.RetentionPolicy{KeepDaily: 7},
+		LastError: "ignored",
+	}
+	err := ft.Facade.SetSnapshotSchedule(ft.ctx, sched)
+
+	c.Assert(err, IsNil)
+	stored := ft.scheduleStore.Calls[1].Arguments.Get(1).(*snapshotschedule.Schedule)
+	c.Assert(stored.Cron, Equals, "@daily")
+	c.Assert(stored.CreatedAt.IsZero(), Equals, false)
+	c.Assert(stored.LastError, Equals, "")
+}
+
+func (ft *FacadeUnitTest) Test_SetSnapshotScheduleKeepsLastRun(c *C) {
+	lastRun := time.Now().Add(-time.Hour).UTC()
+	created := lastRun.Add(-24 * time.Hour)
+	existing := &snapshotschedule.Schedule{
+		TenantID:     "tenant1",
+		Cron:         "@hourly",
+		LastRun:      lastRun,
+		LastSnapshot: "tenant1_snap",
+		CreatedAt:    created,
+	}
+	ft.serviceStore.On("GetServiceDetails", ft.ctx, "tenant1").Return(&service.ServiceDetails{ID: "tenant1"}, nil)
+	ft.scheduleStore.On("Get", ft.ctx, "tenant1").Return(existing, nil)
+	ft.scheduleStore.On("Put", ft.ctx, mock.AnythingOfType("*snapshotschedule.Schedule")).Return(nil)
+
+	err := ft.Facade.SetSnapshotSchedule(ft.ctx, snapshotschedule.Schedule{TenantID: "tenant1", Cron: "@daily"})
+
+	c.Assert(err, IsNil)
+	stored := ft.scheduleStore.Calls[1].Arguments.Get(1).(*snapshotschedule.Schedule)
+	c.Assert(stored.Cron, Equals, "@daily")
+	c.Assert(stored.LastRun, Equals, lastRun)
+	c.Assert(stored.LastSnapshot, Equals, "tenant1_snap")
+	c.Assert(stored.CreatedAt, Equals, created)
+}
+
+func (ft *FacadeUnitTest) Test_SetSnapshotScheduleInvalidCron(c *C) {
+	ft.serviceStore.On("GetServiceDetails", ft.ctx, "tenant1").Return(&service.ServiceDetails{ID: "tenant1"}, nil)
+	ft.scheduleStore.On("Get", ft.ctx, "tenant1").Return(nil, datastore.ErrNoSuchEntity{Key: snapshotschedule.Key("tenant1")})
+
+	err := ft.Facade.SetSnapshotSchedule(ft.ctx, snapshotschedule.Schedule{TenantID: "tenant1", Cron: "61 * * * *"})
+
+	c.Assert(err, NotNil)
+	ft.scheduleStore.AssertNotCalled(c, "Put", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_SetSnapshotScheduleNotTenant(c *C) {
+	details := &service.ServiceDetails{ID: "child1", ParentServiceID: "tenant1"}
+	ft.serviceStore.On("GetServiceDetails", ft.ctx, "child1").Return(details, nil)
+
+	err := ft.Facade.SetSnapshotSchedule(ft.ctx, snapshotschedule.Schedule{TenantID: "child1", Cron: "@daily"})
+
+	c.Assert(err, NotNil)
+	ft.scheduleStore.AssertNotCalled(c, "Put", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_GetSnapshotSchedulesFiltersTenants(c *C) {
+	ctx := ft.setupTenantGrants()
+	schedules := []snapshotschedule.Schedule{
+		{TenantID: "grantTenant1", Cron: "@daily"},
+		{TenantID: "grantTenant2", Cron: "@daily"},
+	}
+	ft.scheduleStore.On("GetSchedules", ctx).Return(schedules, nil)
+
+	result, err := ft.Facade.GetSnapshotSchedules(ctx)
+
+	c.Assert(err, IsNil)
+	c.Assert(result, HasLen, 1)
+	c.Assert(result[0].TenantID, Equals, "grantTenant1")
+
+	_, err = ft.Facade.GetSnapshotSchedule(ctx, "grantTenant2")
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+}
+
+func (ft *FacadeUnitTest) Test_RecordSnapshotScheduleRun(c *C) {
+	existing := &snapshotschedule.Schedule{TenantID: "tenant1", Cron: "@hourly", LastSnapshot: "tenant1_old"}
+	ft.scheduleStore.On("Get", ft.ctx, "tenant1").Return(existing, nil)
+	ft.scheduleStore.On("Put", ft.ctx, existing).Return(nil)
+	ran := time.Now()
+
+	err := ft.Facade.RecordSnapshotScheduleRun(ft.ctx, "tenant1", ran, "", "out of space")
+
+	c.Assert(err, IsNil)
+	c.Assert(existing.LastRun, Equals, ran.UTC())
+	c.Assert(existing.LastError, Equals, "out of space")
+	c.Assert(existing.LastSnapshot, Equals, "tenant1_old")
+}
@@ -0,0 +1,185 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package facade
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/apitoken"
+	"github.com/control-center/serviced/domain/service"
+	userdomain "github.com/control-center/serviced/domain/user"
+	"github.com/control-center/serviced/utils"
+)
+
+// ErrTenantNotAuthorized is returned when the user of the context has not
+// been granted access to the tenant of a service.
+var ErrTenantNotAuthorized = errors.New("facade: user is not authorized for tenant")
+
+// tenantGrant is the set of tenants that a context may act on.  A tenant is
+// granted only if it is in every one of the scopes.
+type tenantGrant struct {
+	principal string
+	scopes    [][]string
+}
+
+func (g *tenantGrant) allows(tenantID string) bool {
+	for _, tenants := range g.scopes {
+		if !utils.StringInSlice(tenantID, tenants) {
+			return false
+		}
+	}
+	return true
+}
+
+// tenants returns the tenants that are in every one of the scopes.
+func (g *tenantGrant) tenants() []string {
+	tenants := []string{}
+	for _, tenantID := range g.scopes[0] {
+		if g.allows(tenantID) && !utils.StringInSlice(tenantID, tenants) {
+			tenants = append(tenants, tenantID)
+		}
+	}
+	return tenants
+}
+
+// getTenantGrants returns the tenants the context is restricted to, or nil if
+// the context may act on every tenant.  Requests made with an api token are
+// restricted to the tenants of both the token and its user.
+func (f *Facade) getTenantGrants(ctx datastore.Context) (*tenantGrant, error) {
+	name := ctx.User()
+	grant := &tenantGrant{principal: name}
+	if id, ok := apitoken.ParsePrincipal(name); ok {
+		token, err := f.apiTokenStore.Get(ctx, id)
+		if datastore.IsErrNoSuchEntity(err) {
+			// the token was revoked after the request was authenticated
+			return nil, ErrTenantNotAuthorized
+		} else if err != nil {
+			return nil, err
+		}
+		if len(token.Tenants) > 0 {
+			grant.scopes = append(grant.scopes, token.Tenants)
+		}
+		name = token.User
+	}
+	if name != "" && name != datastore.SystemUser && name != SYSTEM_USER_NAME {
+		var user userdomain.User
+		if err := f.userStore.Get(ctx, userdomain.Key(name), &user); err != nil && !datastore.IsErrNoSuchEntity(err) {
+			return nil, err
+		} else if err == nil && len(user.Tenants) > 0 {
+			grant.scopes = append(grant.scopes, user.Tenants)
+		}
+	}
+	if len(grant.scopes) == 0 {
+		return nil, nil
+	}
+	return grant, nil
+}
+
+// IsTenantRestricted returns true if the user of the context may only act on
+// some of the tenants.
+func (f *Facade) IsTenantRestricted(ctx datastore.Context) (bool, error) {
+	grant, err := f.getTenantGrants(ctx)
+	if err != nil {
+		return false, err
+	}
+	return grant != nil, nil
+}
+
+// authorizeTenant returns ErrTenantNotAuthorized if the user of the context
+// may not act on the tenant.
+func (f *Facade) authorizeTenant(ctx datastore.Context, tenantID string) error {
+	grant, err := f.getTenantGrants(ctx)
+	if err != nil {
+		return err
+	}
+	if grant != nil && !grant.allows(tenantID) {
+		plog.WithField("user", grant.principal).WithField("tenantid", tenantID).Debug("Denied access to tenant")
+		return ErrTenantNotAuthorized
+	}
+	return nil
+}
+
+// authorizeService returns ErrTenantNotAuthorized if the user of the context
+// may not act on the tenant of the service.
+func (f *Facade) authorizeService(ctx datastore.Context, serviceID string) error {
+	grant, err := f.getTenantGrants(ctx)
+	if err != nil || grant == nil {
+		return err
+	}
+	tenantID, err := f.GetTenantID(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	if !grant.allows(tenantID) {
+		plog.WithField("user", grant.principal).WithField("serviceid", serviceID).Debug("Denied access to service")
+		return ErrTenantNotAuthorized
+	}
+	return nil
+}
+
+// filterDetailsByTenantGrants removes the services the user of the context
+// has not been granted access to.
+func (f *Facade) filterDetailsByTenantGrants(ctx datastore.Context, details []service.ServiceDetails) ([]service.ServiceDetails, error) {
+	grant, err := f.getTenantGrants(ctx)
+	if err != nil || grant == nil {
+		return details, err
+	}
+	matches := []service.ServiceDetails{}
+	for _, d := range details {
+		tenantID, err := f.GetTenantID(ctx, d.ID)
+		if err != nil {
+			return nil, err
+		}
+		if grant.allows(tenantID) {
+			matches = append(matches, d)
+		}
+	}
+	return matches, nil
+}
+
+// filterServicesByTenantGrants removes the services the user of the context
+// has not been granted access to.
+func (f *Facade) filterServicesByTenantGrants(ctx datastore.Context, svcs []service.Service) ([]service.Service, error) {
+	grant, err := f.getTenantGrants(ctx)
+	if err != nil || grant == nil {
+		return svcs, err
+	}
+	matches := []service.Service{}
+	for _, svc := range svcs {
+		tenantID, err := f.GetTenantID(ctx, svc.ID)
+		if err != nil {
+			return nil, err
+		}
+		if grant.allows(tenantID) {
+			matches = append(matches, svc)
+		}
+	}
+	return matches, nil
+}
+
+// validateTenantIDs returns an error if any of the ids is not the id of a
+// tenant.
+func (f *Facade) validateTenantIDs(ctx datastore.Context, tenantIDs []string) error {
+	for _, tenantID := range tenantIDs {
+		svc, err := f.serviceStore.GetServiceDetails(ctx, tenantID)
+		if err != nil {
+			return err
+		} else if svc.ParentServiceID != "" {
+			return fmt.Errorf("service %s is not a tenant", tenantID)
+		}
+	}
+	return nil
+}
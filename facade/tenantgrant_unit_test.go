@@ -0,0 +1,190 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package facade_test
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/dao"
+	"github.com/control-center/serviced/datastore"
+	datastoremocks "github.com/control-center/serviced/datastore/mocks"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/domain/serviceconfigfile"
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/domain/user"
+	"github.com/control-center/serviced/facade"
+	"github.com/control-center/serviced/metrics"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+// setupTenantGrants mocks two tenants, each with a child service, and returns
+// the context of a user that is restricted to the first tenant.
+func (ft *FacadeUnitTest) setupTenantGrants() *datastoremocks.Context {
+	details := []service.ServiceDetails{
+		{ID: "grantTenant1"},
+		{ID: "grantChild1", ParentServiceID: "grantTenant1"},
+		{ID: "grantTenant2"},
+		{ID: "grantChild2", ParentServiceID: "grantTenant2"},
+	}
+	for i := range details {
+		ft.serviceStore.On("GetServiceDetails", mock.Anything, details[i].ID).Return(&details[i], nil)
+	}
+	ft.serviceStore.On("Query", mock.Anything, service.Query{}).Return(details, nil)
+
+	ctx := &datastoremocks.Context{}
+	ctx.On("Metrics").Return(metrics.NewMetrics())
+	ctx.On("User").Return("tenant1user")
+	ft.userStore.On("Get", ctx, user.Key("tenant1user"), mock.AnythingOfType("*user.User")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			u := args.Get(2).(*user.User)
+			*u = user.User{Name: "tenant1user", Tenants: []string{"grantTenant1"}}
+		})
+	return ctx
+}
+
+func (ft *FacadeUnitTest) Test_GetServiceDetailsAuthorizedTenant(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	result, err := ft.Facade.GetServiceDetails(ctx, "grantChild1")
+
+	c.Assert(err, IsNil)
+	c.Assert(result.ID, Equals, "grantChild1")
+}
+
+func (ft *FacadeUnitTest) Test_GetServiceDetailsUnauthorizedTenant(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	result, err := ft.Facade.GetServiceDetails(ctx, "grantChild2")
+
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	c.Assert(result, IsNil)
+}
+
+func (ft *FacadeUnitTest) Test_QueryServiceDetailsFiltersTenants(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	result, err := ft.Facade.QueryServiceDetails(ctx, service.Query{})
+
+	c.Assert(err, IsNil)
+	c.Assert(result, HasLen, 2)
+	c.Assert(result[0].ID, Equals, "grantTenant1")
+	c.Assert(result[1].ID, Equals, "grantChild1")
+}
+
+func (ft *FacadeUnitTest) Test_QueryServiceDetailsUnrestrictedUser(c *C) {
+	ft.setupTenantGrants()
+	ctx := &datastoremocks.Context{}
+	ctx.On("Metrics").Return(metrics.NewMetrics())
+	ctx.On("User").Return("admin")
+	ft.userStore.On("Get", ctx, user.Key("admin"), mock.AnythingOfType("*user.User")).
+		Return(datastore.ErrNoSuchEntity{})
+
+	result, err := ft.Facade.QueryServiceDetails(ctx, service.Query{})
+
+	c.Assert(err, IsNil)
+	c.Assert(result, HasLen, 4)
+}
+
+func (ft *FacadeUnitTest) Test_QueryServiceDetailsSystemUser(c *C) {
+	ft.setupTenantGrants()
+
+	result, err := ft.Facade.QueryServiceDetails(ft.ctx, service.Query{})
+
+	c.Assert(err, IsNil)
+	c.Assert(result, HasLen, 4)
+	ft.userStore.AssertNotCalled(c, "Get", ft.ctx, mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_StartServiceUnauthorizedTenant(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	_, err := ft.Facade.StartService(ctx, dao.ScheduleServiceRequest{ServiceIDs: []string{"grantChild2"}})
+
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+}
+
+func (ft *FacadeUnitTest) Test_IsTenantRestricted(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	restricted, err := ft.Facade.IsTenantRestricted(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(restricted, Equals, true)
+
+	restricted, err = ft.Facade.IsTenantRestricted(ft.ctx)
+	c.Assert(err, IsNil)
+	c.Assert(restricted, Equals, false)
+}
+
+func (ft *FacadeUnitTest) Test_ServiceConfigsUnauthorizedTenant(c *C) {
+	ctx := ft.setupTenantGrants()
+	ft.configStore.On("Get", ctx, serviceconfigfile.Key("file2"), mock.AnythingOfType("*serviceconfigfile.SvcConfigFile")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			file := args.Get(2).(*serviceconfigfile.SvcConfigFile)
+			*file = serviceconfigfile.SvcConfigFile{ID: "file2", ServiceTenantID: "grantTenant2", ServicePath: "/grantTenant2/grantChild2"}
+		})
+	conf := servicedefinition.ConfigFile{Filename: "/etc/app.conf", Content: "changed"}
+
+	_, err := ft.Facade.GetServiceConfigs(ctx, "grantChild2")
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	_, err = ft.Facade.GetServiceConfig(ctx, "file2")
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	err = ft.Facade.AddServiceConfig(ctx, "grantChild2", conf)
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	err = ft.Facade.UpdateServiceConfig(ctx, "file2", conf)
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	err = ft.Facade.DeleteServiceConfig(ctx, "file2")
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+
+	ft.configStore.AssertNotCalled(c, "GetConfigFiles", mock.Anything, mock.Anything, mock.Anything)
+	ft.configStore.AssertNotCalled(c, "Put", mock.Anything, mock.Anything, mock.Anything)
+	ft.configStore.AssertNotCalled(c, "Delete", mock.Anything, mock.Anything)
+}
+
+func (ft *FacadeUnitTest) Test_GetAllServicesFiltersTenants(c *C) {
+	ctx := ft.setupTenantGrants()
+	ft.serviceStore.On("GetServices", ctx).Return([]service.Service{
+		{ID: "grantTenant1"},
+		{ID: "grantChild1", ParentServiceID: "grantTenant1"},
+		{ID: "grantTenant2"},
+		{ID: "grantChild2", ParentServiceID: "grantTenant2"},
+	}, nil)
+
+	result, err := ft.Facade.GetAllServices(ctx)
+
+	c.Assert(err, IsNil)
+	c.Assert(result, HasLen, 2)
+	c.Assert(result[0].ID, Equals, "grantTenant1")
+	c.Assert(result[1].ID, Equals, "grantChild1")
+}
+
+func (ft *FacadeUnitTest) Test_ServiceInstancesUnauthorizedTenant(c *C) {
+	ctx := ft.setupTenantGrants()
+
+	_, err := ft.Facade.GetServiceInstances(ctx, time.Now(), "grantChild2")
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	err = ft.Facade.StopServiceInstance(ctx, "grantChild2", 0)
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	err = ft.Facade.SendDockerAction(ctx, "grantChild2", 0, "debug", nil)
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+	_, err = ft.Facade.CountDescendantStates(ctx, "grantTenant2")
+	c.Assert(err, Equals, facade.ErrTenantNotAuthorized)
+
+	ft.serviceStore.AssertNotCalled(c, "Get", mock.Anything, mock.Anything)
+	ft.zzk.AssertNotCalled(c, "StopServiceInstance", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -25,12 +25,16 @@ import (
 	"github.com/control-center/serviced/datastore/elastic"
 	dfsmocks "github.com/control-center/serviced/dfs/mocks"
 	"github.com/control-center/serviced/domain/addressassignment"
+	"github.com/control-center/serviced/domain/apitoken"
+	"github.com/control-center/serviced/domain/auditlog"
 	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/pool"
 	"github.com/control-center/serviced/domain/registry"
 	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/serviceconfigfile"
+	"github.com/control-center/serviced/domain/servicerevision"
 	"github.com/control-center/serviced/domain/servicetemplate"
+	"github.com/control-center/serviced/domain/snapshotschedule"
 	"github.com/control-center/serviced/domain/user"
 	zzkmocks "github.com/control-center/serviced/facade/mocks"
 	"github.com/control-center/serviced/scheduler/servicestatemanager"
@@ -65,6 +69,10 @@ func (ft *FacadeIntegrationTest) SetUpSuite(c *gocheck.C) {
 	ft.Mappings = append(ft.Mappings, addressassignment.MAPPING)
 	ft.Mappings = append(ft.Mappings, serviceconfigfile.MAPPING)
 	ft.Mappings = append(ft.Mappings, user.MAPPING)
+	ft.Mappings = append(ft.Mappings, apitoken.MAPPING)
+	ft.Mappings = append(ft.Mappings, auditlog.MAPPING)
+	ft.Mappings = append(ft.Mappings, servicerevision.MAPPING)
+	ft.Mappings = append(ft.Mappings, snapshotschedule.MAPPING)
 	ft.Mappings = append(ft.Mappings, registry.MAPPING)
 
 	ft.ElasticTest.SetUpSuite(c)
@@ -86,6 +94,7 @@ func (ft *FacadeIntegrationTest) SetUpSuite(c *gocheck.C) {
 	mockLogger.On("Entity", mock.AnythingOfType("*addressassignment.AddressAssignment")).Return(mockLogger)
 	mockLogger.On("WithField", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(mockLogger)
 	mockLogger.On("WithFields", mock.AnythingOfType("logrus.Fields")).Return(mockLogger)
+	mockLogger.On("Changes", mock.AnythingOfType("[]auditlog.Change")).Return(mockLogger)
 	mockLogger.On("Error", mock.Anything)
 	mockLogger.On("Succeeded", mock.Anything)
 	mockLogger.On("SucceededIf", mock.AnythingOfType("bool"))
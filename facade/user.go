@@ -20,6 +20,8 @@ package facade
 
 import (
 	log "github.com/Sirupsen/logrus"
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/config"
 	"github.com/control-center/serviced/datastore"
 	userdomain "github.com/control-center/serviced/domain/user"
 	"github.com/control-center/serviced/utils"
@@ -28,6 +30,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os/user"
 	"strings"
 )
 
@@ -109,6 +112,102 @@ func (f *Facade) RemoveUser(ctx datastore.Context, userName string) error {
 	return err
 }
 
+// GetUsers returns all of the stored user records
+func (f *Facade) GetUsers(ctx datastore.Context) ([]userdomain.User, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetUsers"))
+	return f.userStore.GetUsers(ctx)
+}
+
+// GetUserRole returns the role explicitly granted to a user, or RoleNone if
+// the user has no stored role.
+func (f *Facade) GetUserRole(ctx datastore.Context, userName string) (auth.Role, error) {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.GetUserRole"))
+	user, err := f.GetUser(ctx, strings.TrimSpace(userName))
+	if datastore.IsErrNoSuchEntity(err) {
+		return auth.RoleNone, nil
+	} else if err != nil {
+		return auth.RoleNone, err
+	}
+	return user.Role, nil
+}
+
+// SetUserRole grants a role to a user, creating a user record if one does not
+// already exist.  Setting RoleNone clears the role.
+func (f *Facade) SetUserRole(ctx datastore.Context, userName string, role auth.Role) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.SetUserRole"))
+	var err error
+	logger := plog.WithFields(log.Fields{
+		"userName": userName,
+		"role":     role,
+	})
+	logger.Debug("Started Facade.SetUserRole")
+	defer func() {
+		logger.WithError(err).Debug("Finished Facade.SetUserRole")
+	}()
+
+	if role != auth.RoleNone && !role.Valid() {
+		err = auth.ErrInvalidRole
+		return err
+	}
+	err = f.updateUserGrants(ctx, userName, func(user *userdomain.User) {
+		user.Role = role
+	})
+	return err
+}
+
+// SetUserTenants restricts a user to the given tenants, creating a user record
+// if one does not already exist.  An empty list grants access to all tenants.
+func (f *Facade) SetUserTenants(ctx datastore.Context, userName string, tenantIDs []string) error {
+	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.SetUserTenants"))
+	var err error
+	logger := plog.WithFields(log.Fields{
+		"userName":  userName,
+		"tenantIDs": tenantIDs,
+	})
+	logger.Debug("Started Facade.SetUserTenants")
+	defer func() {
+		logger.WithError(err).Debug("Finished Facade.SetUserTenants")
+	}()
+
+	if err = f.validateTenantIDs(ctx, tenantIDs); err != nil {
+		return err
+	}
+	tenants := append([]string{}, tenantIDs...)
+	err = f.updateUserGrants(ctx, userName, func(user *userdomain.User) {
+		user.Tenants = tenants
+	})
+	return err
+}
+
+// updateUserGrants applies a change to the access granted to a user.  The
+// record is removed once it has no password, role or tenants left.
+func (f *Facade) updateUserGrants(ctx datastore.Context, userName string, update func(*userdomain.User)) error {
+	name := strings.TrimSpace(userName)
+	if name == "" {
+		return errors.New("empty User.Name not allowed")
+	}
+	if name == SYSTEM_USER_NAME {
+		return errors.New("cannot change the access of the system user")
+	}
+
+	// the password is already hashed, so write straight to the store
+	user, err := f.GetUser(ctx, name)
+	if datastore.IsErrNoSuchEntity(err) {
+		user = userdomain.User{Name: name}
+	} else if err != nil {
+		return err
+	}
+
+	update(&user)
+	if user.Password == "" && user.Role == auth.RoleNone && len(user.Tenants) == 0 {
+		if err := f.userStore.Delete(ctx, userdomain.Key(name)); err != nil && !datastore.IsErrNoSuchEntity(err) {
+			return err
+		}
+		return nil
+	}
+	return f.userStore.Put(ctx, userdomain.Key(name), &user)
+}
+
 // ValidateCredentials takes a user name and password and validates them against a stored user
 func (f *Facade) ValidateCredentials(ctx datastore.Context, user userdomain.User) (bool, error) {
 	defer ctx.Metrics().Stop(ctx.Metrics().Start("Facade.ValidateCredentials"))
@@ -123,6 +222,11 @@ func (f *Facade) ValidateCredentials(ctx datastore.Context, user userdomain.User
 		return false, err
 	}
 
+	// users that only carry a role cannot authenticate against the api
+	if storedUser.Password == "" {
+		return false, nil
+	}
+
 	// hash the passed in password
 	hashedPassword := hashPassword(user.Password)
 
@@ -174,3 +278,33 @@ func (f *Facade) CreateSystemUser(ctx datastore.Context) error {
 	INSTANCE_PASSWORD = password
 	return f.UpdateUser(ctx, user)
 }
+
+// isAdminGroupMember returns true if the system user belongs to the group
+// whose members may log in to control center.  Returns false if the user or
+// the group does not exist.
+func isAdminGroupMember(userName string) (bool, error) {
+	groupName := config.GetOptions().AdminGroup
+	if groupName == "" {
+		return false, nil
+	}
+	u, err := user.Lookup(userName)
+	if _, ok := err.(user.UnknownUserError); ok {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	group, err := user.LookupGroup(groupName)
+	if _, ok := err.(user.UnknownGroupError); ok {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if u.Gid == group.Gid {
+		return true, nil
+	}
+	gids, err := u.GroupIds()
+	if err != nil {
+		return false, err
+	}
+	return utils.StringInSlice(group.Gid, gids), nil
+}
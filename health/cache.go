@@ -41,20 +41,25 @@ func (item *HealthStatusItem) Expired() bool {
 	return time.Now().After(item.expires)
 }
 
-// HealthStatusCache keeps track of the health status items in memory.
+// HealthStatusCache keeps track of the health status items in memory, as well
+// as a bounded history of the results of each health check.
 type HealthStatusCache struct {
-	mu   *sync.Mutex
-	data map[HealthStatusKey]HealthStatusItem
-	stop chan struct{}
-	wg   *sync.WaitGroup
+	mu          *sync.Mutex
+	data        map[HealthStatusKey]HealthStatusItem
+	history     map[HealthStatusKey][]HealthStatus
+	historySize int
+	stop        chan struct{}
+	wg          *sync.WaitGroup
 }
 
 // New returns a new HealthStatusCache instance
 func New() *HealthStatusCache {
 	cache := &HealthStatusCache{
-		mu:   &sync.Mutex{},
-		data: make(map[HealthStatusKey]HealthStatusItem),
-		wg:   &sync.WaitGroup{},
+		mu:          &sync.Mutex{},
+		data:        make(map[HealthStatusKey]HealthStatusItem),
+		history:     make(map[HealthStatusKey][]HealthStatus),
+		historySize: DefaultHistorySize,
+		wg:          &sync.WaitGroup{},
 	}
 	return cache
 }
@@ -128,6 +133,7 @@ func (cache *HealthStatusCache) Set(key HealthStatusKey, value HealthStatus, exp
 	cache.mu.Lock()
 	defer cache.mu.Unlock()
 	cache.set(key, value, time.Now().Add(expire))
+	cache.addHistory(key, value)
 }
 
 // set is non thread-safe
@@ -153,6 +159,12 @@ func (cache *HealthStatusCache) DeleteExpired() {
 			cache.delete(key)
 		}
 	}
+	cutoff := time.Now().Add(-HistoryRetention)
+	for key, results := range cache.history {
+		if results[len(results)-1].StartedAt.Before(cutoff) {
+			delete(cache.history, key)
+		}
+	}
 }
 
 // DeleteInstance removes all health checks per instance.
@@ -40,6 +40,15 @@ const DefaultTimeout time.Duration = 30 * time.Second
 // updates.
 const DefaultExpiration time.Duration = time.Minute
 
+// LivenessRole is the role of a health check that restarts the container
+// when it fails (see KillCountLimit).  Checks without a role are liveness
+// checks.
+const LivenessRole = "liveness"
+
+// ReadinessRole is the role of a health check that withdraws the instance's
+// exported endpoints until it passes, so that no traffic is routed to it.
+const ReadinessRole = "readiness"
+
 // Status is the status of a health check
 type Status int
 
@@ -97,17 +106,31 @@ func (s *Status) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// HealthStatus is the output from a provided health check.
+// MaxOutputSize is the number of bytes of health check output that are kept
+// with the health status.
+const MaxOutputSize = 4096
+
+// HealthStatus is the output from a provided health check.  Output is the
+// tail of the output of a script, or the error of an http, tcp or grpc check.
+// ExitCode is the exit code of a script, or -1 if the script did not exit on
+// its own.
 type HealthStatus struct {
 	Status    Status
 	StartedAt time.Time
 	Duration  time.Duration
 	KillFlag  bool
+	Output    string `json:",omitempty"`
+	ExitCode  int    `json:",omitempty"`
 }
 
-// HealthCheck is the health check object.
+// HealthCheck is the health check object.  It runs the script, unless one of
+// the HTTP, TCP or GRPC checks is set, which are run natively instead.
 type HealthCheck struct {
 	Script    string
+	HTTP      *HTTPCheck
+	TCP       *TCPCheck
+	GRPC      *GRPCCheck
+	Role      string
 	Timeout   time.Duration
 	Interval  time.Duration
 	Tolerance int
@@ -123,6 +146,10 @@ type HealthCheck struct {
 func (hc HealthCheck) MarshalJSON() ([]byte, error) {
 	jhc := struct {
 		Script         string
+		HTTP           *HTTPCheck `json:",omitempty"`
+		TCP            *TCPCheck  `json:",omitempty"`
+		GRPC           *GRPCCheck `json:",omitempty"`
+		Role           string     `json:",omitempty"`
 		Timeout        float64
 		Interval       float64
 		Tolerance      int
@@ -130,6 +157,10 @@ func (hc HealthCheck) MarshalJSON() ([]byte, error) {
 		KillCountLimit int   `json:",omitempty"`
 	}{
 		Script:         hc.Script,
+		HTTP:           hc.HTTP,
+		TCP:            hc.TCP,
+		GRPC:           hc.GRPC,
+		Role:           hc.Role,
 		Timeout:        hc.Timeout.Seconds(),
 		Interval:       hc.Interval.Seconds(),
 		Tolerance:      hc.Tolerance,
@@ -143,6 +174,10 @@ func (hc HealthCheck) MarshalJSON() ([]byte, error) {
 func (hc *HealthCheck) UnmarshalJSON(data []byte) error {
 	jhc := struct {
 		Script         string
+		HTTP           *HTTPCheck `json:",omitempty"`
+		TCP            *TCPCheck  `json:",omitempty"`
+		GRPC           *GRPCCheck `json:",omitempty"`
+		Role           string     `json:",omitempty"`
 		Timeout        float64
 		Interval       float64
 		Tolerance      int
@@ -154,6 +189,10 @@ func (hc *HealthCheck) UnmarshalJSON(data []byte) error {
 	}
 	*hc = HealthCheck{
 		Script:         jhc.Script,
+		HTTP:           jhc.HTTP,
+		TCP:            jhc.TCP,
+		GRPC:           jhc.GRPC,
+		Role:           jhc.Role,
 		Timeout:        time.Duration(jhc.Timeout) * time.Second,
 		Interval:       time.Duration(jhc.Interval) * time.Second,
 		Tolerance:      jhc.Tolerance,
@@ -163,6 +202,12 @@ func (hc *HealthCheck) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// IsReadiness returns true if the health check gates the instance's exported
+// endpoints rather than its liveness.
+func (hc *HealthCheck) IsReadiness() bool {
+	return hc.Role == ReadinessRole
+}
+
 // GetTimeout returns the timeout duration.
 func (hc *HealthCheck) GetTimeout() time.Duration {
 	timeout := hc.Timeout
@@ -172,13 +217,19 @@ func (hc *HealthCheck) GetTimeout() time.Duration {
 	return timeout
 }
 
-// Expires calculates the time to live on the cache item.
-func (hc *HealthCheck) Expires() time.Duration {
+// GetTolerance returns the number of consecutive results before a change in
+// the health check is trusted.
+func (hc *HealthCheck) GetTolerance() int {
 	tolerance := hc.Tolerance
 	if tolerance <= 0 {
 		tolerance = DefaultTolerance
 	}
-	return time.Duration(tolerance) * (hc.GetTimeout() + hc.Interval)
+	return tolerance
+}
+
+// Expires calculates the time to live on the cache item.
+func (hc *HealthCheck) Expires() time.Duration {
+	return time.Duration(hc.GetTolerance()) * (hc.GetTimeout() + hc.Interval)
 }
 
 // NotRunning returns the health status for a service instance that is not
@@ -200,8 +251,11 @@ func (hc *HealthCheck) Unknown() HealthStatus {
 }
 
 // Run returns the health status as a result of running the health check
-// script.
+// script, or the http, tcp or grpc check.
 func (hc *HealthCheck) Run(key HealthStatusKey) (stat HealthStatus) {
+	if hc.Type() != ScriptCheckType {
+		return hc.runNativeCheck(key)
+	}
 	logger := plog.WithFields(log.Fields{
 		"service":     key.ServiceID,
 		"instance":    key.InstanceID,
@@ -209,6 +263,12 @@ func (hc *HealthCheck) Run(key HealthStatusKey) (stat HealthStatus) {
 	})
 	stat.StartedAt = time.Now()
 	cmd := exec.Command("sh", "-c", hc.Script)
+	output := &tailBuffer{size: MaxOutputSize}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	// run the script in its own process group, so that the whole group can be
+	// killed on timeout and nothing is left holding the output pipes.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 	cmd.Start()
 	timer := time.NewTimer(hc.GetTimeout())
 	errC := make(chan error)
@@ -220,6 +280,10 @@ func (hc *HealthCheck) Run(key HealthStatusKey) (stat HealthStatus) {
 			// If the command gives an error, the healthcheck status is Failed (curl command failed, connection
 			// refused, or any other error message including one that might contribute to the kill count)
 			stat.Status = Failed
+			stat.ExitCode = -1
+			if exitError, ok := err.(*exec.ExitError); ok {
+				stat.ExitCode = exitError.Sys().(syscall.WaitStatus).ExitStatus()
+			}
 			if hc.KillCountLimit > 0 {
 				logger.Debug("Healthcheck has a KillCount.. checking the exit code")
 
@@ -261,10 +325,12 @@ func (hc *HealthCheck) Run(key HealthStatusKey) (stat HealthStatus) {
 			stat.Status = OK
 		}
 	case <-timer.C:
-		cmd.Process.Kill()
+		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
 		<-errC
 		stat.Status = Timeout
+		stat.ExitCode = -1
 	}
+	stat.Output = output.String()
 	stat.Duration = time.Since(stat.StartedAt)
 	return
 }
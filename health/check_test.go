@@ -17,6 +17,8 @@ package health_test
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	. "github.com/control-center/serviced/health"
@@ -219,6 +221,7 @@ func (s *HealthCheckTestSuite) TestRun_Timeout(c *C) {
 	}
 	stat := check.Run(hcKey)
 	c.Check(int(stat.Status), Equals, Timeout)
+	c.Check(stat.ExitCode, Equals, -1)
 	c.Check(stat.Duration >= check.Timeout, Equals, true)
 	c.Check(stat.Duration < 5*time.Second, Equals, true)
 }
@@ -413,3 +416,23 @@ func (s *HealthCheckTestSuite) TestKillScript_KillCountResetsOnOtherErrors(c *C)
 		}
 	})
 }
+
+func (s *HealthCheckTestSuite) TestRun_Output(c *C) {
+	hc := HealthCheck{
+		Script:   "echo starting; echo not ready >&2; exit 1",
+		Timeout:  time.Second,
+		Interval: time.Second,
+	}
+	stat := hc.Run(hcKey)
+	c.Assert(stat.Status, Equals, Status(Failed))
+	c.Assert(stat.Output, Equals, "starting\nnot ready\n")
+	c.Assert(stat.ExitCode, Equals, 1)
+
+	// only the tail of the output is kept
+	hc.Script = fmt.Sprintf("head -c %d /dev/zero; echo done", 2*MaxOutputSize)
+	stat = hc.Run(hcKey)
+	c.Assert(stat.Status, Equals, OK)
+	c.Assert(stat.ExitCode, Equals, 0)
+	c.Assert(stat.Output, HasLen, MaxOutputSize)
+	c.Assert(strings.HasSuffix(stat.Output, "done\n"), Equals, true)
+}
@@ -0,0 +1,253 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The gRPC health checking protocol is a unary call of
+// grpc.health.v1.Health/Check over HTTP/2.  Health checks speak just enough
+// HTTP/2 to send the request and read the response message, so that they do
+// not need a gRPC library.  Response headers are never decoded; the outcome
+// is taken from the response message alone.
+
+const (
+	grpcHealthPath = "/grpc.health.v1.Health/Check"
+
+	// grpcServing is the SERVING status of a HealthCheckResponse
+	grpcServing = 1
+
+	http2Preface      = "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n"
+	http2MaxFrameSize = 1 << 20
+
+	http2FrameData      = 0x0
+	http2FrameHeaders   = 0x1
+	http2FrameRSTStream = 0x3
+	http2FrameSettings  = 0x4
+	http2FramePing      = 0x6
+	http2FrameGoAway    = 0x7
+
+	http2FlagEndStream  = 0x1
+	http2FlagAck        = 0x1
+	http2FlagEndHeaders = 0x4
+	http2FlagPadded     = 0x8
+
+	// the stream of the health check request
+	grpcStreamID = 1
+)
+
+var (
+	errGRPCGoAway     = errors.New("server closed the connection")
+	errGRPCReset      = errors.New("server reset the stream")
+	errGRPCNoResponse = errors.New("no response message")
+)
+
+// grpcHealthCheck asks the server on the connection for the health of a
+// service and returns the status of the response.  secure is set if the
+// connection uses TLS.
+func grpcHealthCheck(conn io.ReadWriter, authority, service string, secure bool) (int, error) {
+	w := bufio.NewWriter(conn)
+	w.WriteString(http2Preface)
+	writeHTTP2Frame(w, http2FrameSettings, 0, 0, nil)
+
+	scheme := "http"
+	if secure {
+		scheme = "https"
+	}
+	var headers []byte
+	for _, field := range [][2]string{
+		{":method", "POST"},
+		{":scheme", scheme},
+		{":path", grpcHealthPath},
+		{":authority", authority},
+		{"content-type", "application/grpc"},
+		{"te", "trailers"},
+	} {
+		headers = appendHPACKLiteral(headers, field[0], field[1])
+	}
+	writeHTTP2Frame(w, http2FrameHeaders, http2FlagEndHeaders, grpcStreamID, headers)
+
+	// HealthCheckRequest has the service name as field 1
+	var request []byte
+	if service != "" {
+		request = append(request, 0x0a)
+		request = appendVarint(request, uint64(len(service)))
+		request = append(request, service...)
+	}
+	message := make([]byte, 5, 5+len(request))
+	binary.BigEndian.PutUint32(message[1:], uint32(len(request)))
+	message = append(message, request...)
+	writeHTTP2Frame(w, http2FrameData, http2FlagEndStream, grpcStreamID, message)
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+
+	r := bufio.NewReader(conn)
+	var body []byte
+	for done := false; !done; {
+		typ, flags, stream, payload, err := readHTTP2Frame(r)
+		if err != nil {
+			return 0, err
+		}
+		switch typ {
+		case http2FrameSettings:
+			if flags&http2FlagAck == 0 {
+				writeHTTP2Frame(w, http2FrameSettings, http2FlagAck, 0, nil)
+			}
+		case http2FramePing:
+			if flags&http2FlagAck == 0 {
+				writeHTTP2Frame(w, http2FramePing, http2FlagAck, 0, payload)
+			}
+		case http2FrameGoAway:
+			return 0, errGRPCGoAway
+		case http2FrameRSTStream:
+			if stream == grpcStreamID {
+				return 0, errGRPCReset
+			}
+		case http2FrameData:
+			if stream == grpcStreamID {
+				if flags&http2FlagPadded != 0 && len(payload) > 0 {
+					padding := int(payload[0])
+					if padding >= len(payload) {
+						return 0, errors.New("invalid padding")
+					}
+					payload = payload[1 : len(payload)-padding]
+				}
+				body = append(body, payload...)
+				done = flags&http2FlagEndStream != 0
+			}
+		case http2FrameHeaders:
+			done = stream == grpcStreamID && flags&http2FlagEndStream != 0
+		}
+		if err := w.Flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	// a failed call has no response message, only an error in the trailers
+	if len(body) < 5 {
+		return 0, errGRPCNoResponse
+	}
+	size := binary.BigEndian.Uint32(body[1:5])
+	if uint32(len(body)-5) < size {
+		return 0, errGRPCNoResponse
+	}
+	return parseHealthCheckResponse(body[5 : 5+size])
+}
+
+// parseHealthCheckResponse returns the status, field 1, of a
+// HealthCheckResponse
+func parseHealthCheckResponse(msg []byte) (int, error) {
+	status := 0
+	for len(msg) > 0 {
+		key, n := binary.Uvarint(msg)
+		if n <= 0 {
+			return 0, errors.New("invalid response message")
+		}
+		msg = msg[n:]
+		switch key & 7 {
+		case 0: // varint
+			value, n := binary.Uvarint(msg)
+			if n <= 0 {
+				return 0, errors.New("invalid response message")
+			}
+			if key>>3 == 1 {
+				status = int(value)
+			}
+			msg = msg[n:]
+		case 1: // 64-bit
+			if len(msg) < 8 {
+				return 0, errors.New("invalid response message")
+			}
+			msg = msg[8:]
+		case 2: // length delimited
+			size, n := binary.Uvarint(msg)
+			if n <= 0 || uint64(len(msg)-n) < size {
+				return 0, errors.New("invalid response message")
+			}
+			msg = msg[n+int(size):]
+		case 5: // 32-bit
+			if len(msg) < 4 {
+				return 0, errors.New("invalid response message")
+			}
+			msg = msg[4:]
+		default:
+			return 0, fmt.Errorf("unsupported wire type %d in response message", key&7)
+		}
+	}
+	return status, nil
+}
+
+func writeHTTP2Frame(w *bufio.Writer, typ, flags byte, stream uint32, payload []byte) {
+	header := make([]byte, 9)
+	header[0] = byte(len(payload) >> 16)
+	header[1] = byte(len(payload) >> 8)
+	header[2] = byte(len(payload))
+	header[3] = typ
+	header[4] = flags
+	binary.BigEndian.PutUint32(header[5:], stream)
+	w.Write(header)
+	w.Write(payload)
+}
+
+func readHTTP2Frame(r io.Reader) (typ, flags byte, stream uint32, payload []byte, err error) {
+	header := make([]byte, 9)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return
+	}
+	size := int(header[0])<<16 | int(header[1])<<8 | int(header[2])
+	if size > http2MaxFrameSize {
+		err = fmt.Errorf("frame of %d bytes is too large", size)
+		return
+	}
+	typ, flags = header[3], header[4]
+	stream = binary.BigEndian.Uint32(header[5:]) & 0x7fffffff
+	payload = make([]byte, size)
+	_, err = io.ReadFull(r, payload)
+	return
+}
+
+// appendHPACKLiteral appends a header field that is neither indexed nor
+// huffman encoded
+func appendHPACKLiteral(b []byte, name, value string) []byte {
+	b = append(b, 0x00)
+	b = appendHPACKString(b, name)
+	return appendHPACKString(b, value)
+}
+
+func appendHPACKString(b []byte, s string) []byte {
+	// the length is an integer with a 7-bit prefix
+	if n := uint64(len(s)); n < 127 {
+		b = append(b, byte(n))
+	} else {
+		b = append(b, 127)
+		b = appendVarint(b, n-127)
+	}
+	return append(b, s...)
+}
+
+// appendVarint appends an integer in 7-bit groups, least significant first,
+// as used by both protocol buffers and HPACK integer continuations
+func appendVarint(b []byte, n uint64) []byte {
+	for n >= 0x80 {
+		b = append(b, byte(n)|0x80)
+		n >>= 7
+	}
+	return append(b, byte(n))
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import "time"
+
+// DefaultHistorySize is the default number of results kept per health check.
+const DefaultHistorySize = 20
+
+// HistoryRetention is how long the history of a health check is kept after
+// its last result.
+const HistoryRetention = time.Hour
+
+// FlapThreshold is the number of changes of status within the history of a
+// health check at which the health check is considered to be flapping.
+const FlapThreshold = 5
+
+// HealthCheckHistory is the recent results of a health check, oldest first.
+type HealthCheckHistory struct {
+	Results     []HealthStatus
+	Transitions int
+	Flapping    bool
+}
+
+// NewHealthCheckHistory counts the changes of status in the results, which
+// are ordered oldest first.
+func NewHealthCheckHistory(results []HealthStatus) HealthCheckHistory {
+	history := HealthCheckHistory{Results: results}
+	for i := 1; i < len(results); i++ {
+		if results[i].Status != results[i-1].Status {
+			history.Transitions++
+		}
+	}
+	history.Flapping = history.Transitions >= FlapThreshold
+	return history
+}
+
+// SetHistorySize sets the number of results kept per health check.
+func (cache *HealthStatusCache) SetHistorySize(size int) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+	cache.historySize = size
+	for key, results := range cache.history {
+		if len(results) > size {
+			cache.history[key] = append([]HealthStatus{}, results[len(results)-size:]...)
+		}
+	}
+}
+
+// History returns the recent results of a health check.
+func (cache *HealthStatusCache) History(key HealthStatusKey) HealthCheckHistory {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return NewHealthCheckHistory(append([]HealthStatus{}, cache.history[key]...))
+}
+
+// addHistory is non thread-safe
+func (cache *HealthStatusCache) addHistory(key HealthStatusKey, value HealthStatus) {
+	results := append(cache.history[key], value)
+	if len(results) > cache.historySize {
+		results = append(results[:0], results[len(results)-cache.historySize:]...)
+	}
+	cache.history[key] = results
+}
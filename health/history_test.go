@@ -0,0 +1,83 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package health_test
+
+import (
+	"time"
+
+	. "github.com/control-center/serviced/health"
+	. "gopkg.in/check.v1"
+)
+
+var _ = Suite(&HealthHistoryTestSuite{})
+
+type HealthHistoryTestSuite struct{}
+
+func (s *HealthHistoryTestSuite) TestHistory_Bounded(c *C) {
+	cache := New()
+	cache.SetHistorySize(3)
+	key := HealthStatusKey{
+		ServiceID:       "test-service",
+		InstanceID:      0,
+		HealthCheckName: "test-health-0",
+	}
+	c.Assert(cache.History(key).Results, HasLen, 0)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		cache.Set(key, HealthStatus{Status: OK, StartedAt: start.Add(time.Duration(i) * time.Second)}, time.Minute)
+	}
+	history := cache.History(key)
+	c.Assert(history.Results, HasLen, 3)
+	c.Assert(history.Results[0].StartedAt, Equals, start.Add(2*time.Second))
+	c.Assert(history.Results[2].StartedAt, Equals, start.Add(4*time.Second))
+	c.Assert(history.Transitions, Equals, 0)
+	c.Assert(history.Flapping, Equals, false)
+
+	// the history outlives the instance
+	cache.DeleteInstance("test-service", 0)
+	c.Assert(cache.History(key).Results, HasLen, 3)
+}
+
+func (s *HealthHistoryTestSuite) TestHistory_Expired(c *C) {
+	cache := New()
+	key := HealthStatusKey{
+		ServiceID:       "test-service",
+		InstanceID:      0,
+		HealthCheckName: "test-health-0",
+	}
+	old := key
+	old.InstanceID = 1
+	cache.Set(key, HealthStatus{Status: OK, StartedAt: time.Now()}, -time.Minute)
+	cache.Set(old, HealthStatus{Status: OK, StartedAt: time.Now().Add(-2 * HistoryRetention)}, time.Minute)
+	cache.DeleteExpired()
+	c.Assert(cache.History(key).Results, HasLen, 1)
+	c.Assert(cache.History(old).Results, HasLen, 0)
+}
+
+func (s *HealthHistoryTestSuite) TestHistory_Flapping(c *C) {
+	results := []HealthStatus{}
+	for i := 0; i < FlapThreshold; i++ {
+		results = append(results, HealthStatus{Status: OK}, HealthStatus{Status: Failed})
+	}
+	history := NewHealthCheckHistory(results[:FlapThreshold])
+	c.Assert(history.Transitions, Equals, FlapThreshold-1)
+	c.Assert(history.Flapping, Equals, false)
+
+	history = NewHealthCheckHistory(results)
+	c.Assert(history.Transitions, Equals, 2*FlapThreshold-1)
+	c.Assert(history.Flapping, Equals, true)
+}
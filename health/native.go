@@ -0,0 +1,252 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// Types of health checks
+const (
+	ScriptCheckType = "script"
+	HTTPCheckType   = "http"
+	TCPCheckType    = "tcp"
+	GRPCCheckType   = "grpc"
+)
+
+// maxHTTPBody is the most of a response body that is matched against the
+// expected body of an http health check.
+const maxHTTPBody = 64 * 1024
+
+// HTTPCheck passes when a GET of the URL answers with the expected status
+// and a body that matches the expected regular expression.  Certificates of
+// https URLs are not verified.
+type HTTPCheck struct {
+	URL            string
+	ExpectedStatus int    `json:",omitempty"` // any 2xx or 3xx status if not set
+	ExpectedBody   string `json:",omitempty"` // a regular expression
+}
+
+// TCPCheck passes when a connection can be opened to the address.
+type TCPCheck struct {
+	Address string // host:port
+}
+
+// GRPCCheck passes when the server at the address reports that the service
+// is serving, according to the gRPC health checking protocol.  An empty
+// service asks about the server as a whole.  Certificates are not verified
+// if TLS is set.
+type GRPCCheck struct {
+	Address string // host:port
+	Service string `json:",omitempty"`
+	TLS     bool   `json:",omitempty"`
+}
+
+// Type returns the type of the health check
+func (hc *HealthCheck) Type() string {
+	switch {
+	case hc.HTTP != nil:
+		return HTTPCheckType
+	case hc.TCP != nil:
+		return TCPCheckType
+	case hc.GRPC != nil:
+		return GRPCCheckType
+	default:
+		return ScriptCheckType
+	}
+}
+
+// runNative runs an http, tcp or grpc health check within the timeout
+func (hc *HealthCheck) runNative(timeout time.Duration) error {
+	switch hc.Type() {
+	case HTTPCheckType:
+		return hc.HTTP.run(timeout)
+	case TCPCheckType:
+		return hc.TCP.run(timeout)
+	case GRPCCheckType:
+		return hc.GRPC.run(timeout)
+	default:
+		return fmt.Errorf("health check type %s is not native", hc.Type())
+	}
+}
+
+// runNativeCheck returns the health status as a result of running the http,
+// tcp or grpc check.  Every failure counts toward the kill count.
+func (hc *HealthCheck) runNativeCheck(key HealthStatusKey) (stat HealthStatus) {
+	logger := plog.WithFields(log.Fields{
+		"service":     key.ServiceID,
+		"instance":    key.InstanceID,
+		"healthcheck": key.HealthCheckName,
+		"type":        hc.Type(),
+	})
+	stat.StartedAt = time.Now()
+	err := hc.runNative(hc.GetTimeout())
+	switch {
+	case err == nil:
+		if hc.KillCounter > 0 {
+			logger.Infof("Resetting KillCounter. KillCounter was %d", hc.KillCounter)
+			hc.KillCounter = 0
+		}
+		stat.Status = OK
+	case isTimeout(err):
+		logger.WithError(err).Debug("Health check timed out")
+		stat.Status = Timeout
+		stat.Output = err.Error()
+	default:
+		logger.WithError(err).Debug("Health check failed")
+		stat.Status = Failed
+		stat.Output = err.Error()
+		if hc.KillCountLimit > 0 {
+			hc.KillCounter++
+			logger.Debugf("KillCounter is now %d", hc.KillCounter)
+		}
+	}
+	stat.Duration = time.Since(stat.StartedAt)
+	return
+}
+
+// isTimeout returns true if the error is a network timeout
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
+
+// Validate checks the URL and the expected body
+func (c *HTTPCheck) Validate() error {
+	if !isTemplate(c.URL) {
+		req, err := http.NewRequest("GET", c.URL, nil)
+		if err != nil {
+			return fmt.Errorf("invalid url %q: %s", c.URL, err)
+		} else if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+			return fmt.Errorf("invalid url %q: scheme must be http or https", c.URL)
+		}
+	}
+	if c.ExpectedStatus != 0 && (c.ExpectedStatus < 100 || c.ExpectedStatus > 599) {
+		return fmt.Errorf("invalid expected status %d", c.ExpectedStatus)
+	}
+	if _, err := regexp.Compile(c.ExpectedBody); err != nil {
+		return fmt.Errorf("invalid expected body %q: %s", c.ExpectedBody, err)
+	}
+	return nil
+}
+
+func (c *HTTPCheck) run(timeout time.Duration) error {
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+	}
+	resp, err := client.Get(c.URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if c.ExpectedStatus != 0 {
+		if resp.StatusCode != c.ExpectedStatus {
+			return fmt.Errorf("got status %d, expected %d", resp.StatusCode, c.ExpectedStatus)
+		}
+	} else if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return fmt.Errorf("got status %d", resp.StatusCode)
+	}
+	if c.ExpectedBody != "" {
+		re, err := regexp.Compile(c.ExpectedBody)
+		if err != nil {
+			return err
+		}
+		body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxHTTPBody))
+		if err != nil {
+			return err
+		}
+		if !re.Match(body) {
+			return fmt.Errorf("body does not match %q", c.ExpectedBody)
+		}
+	}
+	return nil
+}
+
+// Validate checks the address
+func (c *TCPCheck) Validate() error {
+	return validateAddress(c.Address)
+}
+
+func (c *TCPCheck) run(timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", c.Address, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// Validate checks the address
+func (c *GRPCCheck) Validate() error {
+	return validateAddress(c.Address)
+}
+
+func (c *GRPCCheck) run(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Deadline: deadline}
+	if c.TLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", c.Address, &tls.Config{
+			InsecureSkipVerify: true,
+			NextProtos:         []string{"h2"},
+		})
+	} else {
+		conn, err = dialer.Dial("tcp", c.Address)
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(deadline)
+	status, err := grpcHealthCheck(conn, c.Address, c.Service, c.TLS)
+	if err != nil {
+		return err
+	} else if status != grpcServing {
+		return fmt.Errorf("service is not serving (status %d)", status)
+	}
+	return nil
+}
+
+// isTemplate returns true if a value is a template that is evaluated for each
+// instance, such as "localhost:{{plus 8080 .InstanceID}}", and so cannot be
+// checked until it is.
+func isTemplate(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+func validateAddress(address string) error {
+	if isTemplate(address) {
+		return nil
+	}
+	if _, port, err := net.SplitHostPort(address); err != nil {
+		return fmt.Errorf("invalid address %q: %s", address, err)
+	} else if port == "" {
+		return fmt.Errorf("invalid address %q: missing port", address)
+	}
+	return nil
+}
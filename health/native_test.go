@@ -0,0 +1,232 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package health_test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/control-center/serviced/health"
+	. "gopkg.in/check.v1"
+)
+
+var _ = Suite(&NativeCheckTestSuite{})
+
+type NativeCheckTestSuite struct{}
+
+func (s *NativeCheckTestSuite) TestMarshalJSON(c *C) {
+	check := HealthCheck{
+		HTTP:     &HTTPCheck{URL: "http://localhost:8080/ping", ExpectedStatus: 200, ExpectedBody: "ok"},
+		Interval: 5 * time.Second,
+	}
+	data, err := json.Marshal(&check)
+	c.Assert(err, IsNil)
+	var actual HealthCheck
+	err = json.Unmarshal(data, &actual)
+	c.Assert(err, IsNil)
+	c.Assert(actual, DeepEquals, check)
+	c.Assert(actual.Type(), Equals, HTTPCheckType)
+
+	err = json.Unmarshal([]byte(`{"TCP": {"Address": "localhost:22"}, "Interval": 5}`), &actual)
+	c.Assert(err, IsNil)
+	c.Assert(actual.Type(), Equals, TCPCheckType)
+	c.Assert(actual.TCP.Address, Equals, "localhost:22")
+}
+
+func (s *NativeCheckTestSuite) TestHTTP(c *C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			fmt.Fprint(w, "status: ok")
+		case "/slow":
+			time.Sleep(500 * time.Millisecond)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	check := HealthCheck{HTTP: &HTTPCheck{URL: server.URL + "/ok"}, Timeout: 100 * time.Millisecond}
+	c.Check(check.Run(hcKey).Status, Equals, OK)
+	check.HTTP.ExpectedBody = "status: (ok|warn)"
+	c.Check(check.Run(hcKey).Status, Equals, OK)
+	check.HTTP.ExpectedBody = "status: failing"
+	c.Check(check.Run(hcKey).Status, Equals, Status(Failed))
+	check.HTTP = &HTTPCheck{URL: server.URL + "/missing"}
+	c.Check(check.Run(hcKey).Status, Equals, Status(Failed))
+	check.HTTP.ExpectedStatus = http.StatusNotFound
+	c.Check(check.Run(hcKey).Status, Equals, OK)
+	check.HTTP = &HTTPCheck{URL: server.URL + "/slow"}
+	c.Check(check.Run(hcKey).Status, Equals, Status(Timeout))
+}
+
+func (s *NativeCheckTestSuite) TestTCP(c *C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	address := listener.Addr().String()
+
+	check := HealthCheck{TCP: &TCPCheck{Address: address}, KillCountLimit: 2}
+	c.Check(check.Run(hcKey).Status, Equals, OK)
+	listener.Close()
+	c.Check(check.Run(hcKey).Status, Equals, Status(Failed))
+	c.Check(check.KillCounter, Equals, 1)
+}
+
+func (s *NativeCheckTestSuite) TestGRPC(c *C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer listener.Close()
+	requests := make(chan grpcRequest, 2)
+	go serveGRPCHealth(listener, requests, map[string]byte{"": 1, "warming": 2})
+
+	check := HealthCheck{GRPC: &GRPCCheck{Address: listener.Addr().String()}}
+	c.Check(check.Run(hcKey).Status, Equals, OK)
+	c.Check(<-requests, Equals, grpcRequest{scheme: "http", service: ""})
+	check.GRPC.Service = "warming"
+	c.Check(check.Run(hcKey).Status, Equals, Status(Failed))
+	c.Check(<-requests, Equals, grpcRequest{scheme: "http", service: "warming"})
+}
+
+func (s *NativeCheckTestSuite) TestGRPCTLS(c *C) {
+	// borrow the test certificate of an https server
+	srv := httptest.NewUnstartedServer(nil)
+	srv.StartTLS()
+	config := &tls.Config{Certificates: srv.TLS.Certificates, NextProtos: []string{"h2"}}
+	srv.Close()
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	c.Assert(err, IsNil)
+	defer listener.Close()
+	requests := make(chan grpcRequest, 1)
+	go serveGRPCHealth(listener, requests, map[string]byte{"": 1})
+
+	check := HealthCheck{GRPC: &GRPCCheck{Address: listener.Addr().String(), TLS: true}}
+	c.Check(check.Run(hcKey).Status, Equals, OK)
+	c.Check(<-requests, Equals, grpcRequest{scheme: "https", service: ""})
+}
+
+func (s *NativeCheckTestSuite) TestGRPCServer(c *C) {
+	statuses := map[string]byte{"": 1, "warming": 2}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 || r.URL.Path != "/grpc.health.v1.Health/Check" || r.Header.Get("Content-Type") != "application/grpc" {
+			http.Error(w, "not a grpc health check", http.StatusBadRequest)
+			return
+		}
+		// the request message has the service name as field 1
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil || len(body) < 5 {
+			http.Error(w, "no request message", http.StatusBadRequest)
+			return
+		}
+		var service string
+		if msg := body[5:]; len(msg) > 2 && msg[0] == 0x0a {
+			service = string(msg[2 : 2+int(msg[1])])
+		}
+
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte{0, 0, 0, 0, 2, 0x08, statuses[service]})
+		w.Header().Set("Grpc-Status", "0")
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	check := HealthCheck{GRPC: &GRPCCheck{Address: srv.Listener.Addr().String(), TLS: true}}
+	c.Check(check.Run(hcKey).Status, Equals, OK)
+	check.GRPC.Service = "warming"
+	c.Check(check.Run(hcKey).Status, Equals, Status(Failed))
+}
+
+// grpcRequest describes a health check received by serveGRPCHealth
+type grpcRequest struct {
+	scheme  string
+	service string
+}
+
+// serveGRPCHealth answers health checks with the status of the requested
+// service, speaking just enough HTTP/2 for the health check client.
+func serveGRPCHealth(listener net.Listener, requests chan<- grpcRequest, statuses map[string]byte) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		func() {
+			defer conn.Close()
+			r := bufio.NewReader(conn)
+			preface := make([]byte, 24)
+			if _, err := io.ReadFull(r, preface); err != nil {
+				return
+			}
+			var req grpcRequest
+			for {
+				header := make([]byte, 9)
+				if _, err := io.ReadFull(r, header); err != nil {
+					return
+				}
+				payload := make([]byte, int(header[0])<<16|int(header[1])<<8|int(header[2]))
+				if _, err := io.ReadFull(r, payload); err != nil {
+					return
+				}
+				// the client sends its headers as literals without indexing
+				if header[3] == 1 {
+					for _, scheme := range []string{"http", "https"} {
+						field := append([]byte{7}, ":scheme"...)
+						field = append(append(field, byte(len(scheme))), scheme...)
+						if bytes.Contains(payload, field) {
+							req.scheme = scheme
+						}
+					}
+				}
+				// the request message is the only data frame
+				if header[3] == 0 {
+					if len(payload) > 7 {
+						req.service = string(payload[7:])
+					}
+					break
+				}
+			}
+			requests <- req
+
+			writeFrame := func(typ, flags byte, payload []byte) {
+				header := []byte{byte(len(payload) >> 16), byte(len(payload) >> 8), byte(len(payload)), typ, flags, 0, 0, 0, 1}
+				if typ == 0x4 {
+					header[8] = 0
+				}
+				conn.Write(append(header, payload...))
+			}
+			writeFrame(0x4, 0, nil)
+			writeFrame(0x1, 0x4, []byte{0x88}) // :status 200
+			message := make([]byte, 5, 7)
+			binary.BigEndian.PutUint32(message[1:], 2)
+			message = append(message, 0x08, statuses[req.service])
+			writeFrame(0x0, 0, message)
+			writeFrame(0x1, 0x5, []byte{0x40, 11, 'g', 'r', 'p', 'c', '-', 's', 't', 'a', 't', 'u', 's', 1, '0'})
+		}()
+	}
+}
@@ -24,6 +24,45 @@ func (hc HealthCheck) ValidEntity() error {
 		violations.Add(fmt.Errorf("the KillCountLimit must be set if KillExitCodes are specified"))
 	}
 
+	switch hc.Role {
+	case "", LivenessRole:
+	case ReadinessRole:
+		if hc.KillCountLimit > 0 {
+			violations.Add(fmt.Errorf("a readiness check cannot set a KillCountLimit"))
+		}
+	default:
+		violations.Add(fmt.Errorf("invalid health check role %q", hc.Role))
+	}
+
+	native := 0
+	if hc.HTTP != nil {
+		native++
+		if err := hc.HTTP.Validate(); err != nil {
+			violations.Add(fmt.Errorf("invalid http health check: %s", err))
+		}
+	}
+	if hc.TCP != nil {
+		native++
+		if err := hc.TCP.Validate(); err != nil {
+			violations.Add(fmt.Errorf("invalid tcp health check: %s", err))
+		}
+	}
+	if hc.GRPC != nil {
+		native++
+		if err := hc.GRPC.Validate(); err != nil {
+			violations.Add(fmt.Errorf("invalid grpc health check: %s", err))
+		}
+	}
+	if native > 1 {
+		violations.Add(fmt.Errorf("only one of the HTTP, TCP and GRPC checks can be set"))
+	}
+	if native > 0 && hc.Script != "" {
+		violations.Add(fmt.Errorf("a Script cannot be set with an HTTP, TCP or GRPC check"))
+	}
+	if native > 0 && len(hc.KillExitCodes) > 0 {
+		violations.Add(fmt.Errorf("KillExitCodes only apply to script health checks"))
+	}
+
 	if violations.HasError() {
 		return violations
 	}
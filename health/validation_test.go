@@ -37,3 +37,54 @@ func (vs *ValidationSuite) Test_Validation_Invalid_HealthCheck(c *C) {
 	err = hc.ValidEntity()
 	c.Assert(err, IsNil)
 }
+
+func (vs *ValidationSuite) Test_Validation_Native_HealthCheck(c *C) {
+	hc := HealthCheck{HTTP: &HTTPCheck{URL: "http://localhost:8080/ping", ExpectedBody: "ok"}}
+	c.Assert(hc.ValidEntity(), IsNil)
+
+	// only http and https urls
+	hc.HTTP.URL = "ftp://localhost/ping"
+	c.Assert(hc.ValidEntity(), NotNil)
+
+	// the expected body must be a regular expression
+	hc.HTTP = &HTTPCheck{URL: "http://localhost:8080/ping", ExpectedBody: "("}
+	c.Assert(hc.ValidEntity(), NotNil)
+
+	// addresses need a port
+	hc = HealthCheck{TCP: &TCPCheck{Address: "localhost"}}
+	c.Assert(hc.ValidEntity(), NotNil)
+	hc = HealthCheck{GRPC: &GRPCCheck{Address: "localhost:50051"}}
+	c.Assert(hc.ValidEntity(), IsNil)
+
+	// templates are checked once they are evaluated for each instance
+	hc = HealthCheck{HTTP: &HTTPCheck{URL: "http://localhost:{{plus 8080 .InstanceID}}/health"}}
+	c.Assert(hc.ValidEntity(), IsNil)
+	hc = HealthCheck{TCP: &TCPCheck{Address: "localhost:{{plus 50051 .InstanceID}}"}}
+	c.Assert(hc.ValidEntity(), IsNil)
+	hc = HealthCheck{GRPC: &GRPCCheck{Address: "localhost:{{plus 50051 .InstanceID}}"}}
+	c.Assert(hc.ValidEntity(), IsNil)
+
+	// only one kind of check
+	hc.TCP = &TCPCheck{Address: "localhost:50051"}
+	c.Assert(hc.ValidEntity(), NotNil)
+	hc = HealthCheck{Script: "true", TCP: &TCPCheck{Address: "localhost:22"}}
+	c.Assert(hc.ValidEntity(), NotNil)
+
+	// exit codes only apply to scripts
+	hc = HealthCheck{TCP: &TCPCheck{Address: "localhost:22"}, KillExitCodes: []int{1}, KillCountLimit: 2}
+	c.Assert(hc.ValidEntity(), NotNil)
+}
+
+func (vs *ValidationSuite) Test_Validation_Role_HealthCheck(c *C) {
+	hc := HealthCheck{Script: "true", Role: LivenessRole, KillCountLimit: 3}
+	c.Assert(hc.ValidEntity(), IsNil)
+
+	// readiness checks never kill the container
+	hc.Role = ReadinessRole
+	c.Assert(hc.ValidEntity(), NotNil)
+	hc.KillCountLimit = 0
+	c.Assert(hc.ValidEntity(), IsNil)
+
+	hc.Role = "startup"
+	c.Assert(hc.ValidEntity(), NotNil)
+}
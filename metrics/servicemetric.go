@@ -0,0 +1,132 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// seriesInterval is the interval between the points of a metric series
+var seriesInterval = time.Minute
+
+// GetServiceMetricAverage returns the average value of a metric per instance
+// of a service over a window of time.  Counters are averaged by their rate.
+func (c *Client) GetServiceMetricAverage(window time.Duration, serviceID, metric string, counter bool) (float64, error) {
+	logger := log.WithField("serviceid", serviceID).WithField("metric", metric)
+	logger.Debug("Requesting metric average for service")
+
+	options := serviceMetricOptions(window, window, serviceID, metric, counter)
+	data, err := c.v2performanceQuery(options)
+	if err != nil {
+		return 0, err
+	}
+	avg, ok := averageSeries(data)
+	if !ok {
+		return 0, fmt.Errorf("no data found")
+	}
+	return avg, nil
+}
+
+// GetServiceMetricSeries returns the values of a metric over a window of
+// time, averaged across the instances of a service and ordered by time.
+// Counters are returned as their rate.
+func (c *Client) GetServiceMetricSeries(window time.Duration, serviceID, metric string, counter bool) ([]float64, error) {
+	logger := log.WithField("serviceid", serviceID).WithField("metric", metric)
+	logger.Debug("Requesting metric series for service")
+
+	interval := seriesInterval
+	if window < interval {
+		interval = window
+	}
+	options := serviceMetricOptions(window, interval, serviceID, metric, counter)
+	data, err := c.v2performanceQuery(options)
+	if err != nil {
+		return nil, err
+	}
+	values := mergeSeries(data)
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no data found")
+	}
+	return values, nil
+}
+
+// serviceMetricOptions returns the query for a metric across all the
+// instances of a service, downsampled to the interval.
+func serviceMetricOptions(window, interval time.Duration, serviceID, metric string, counter bool) V2PerformanceOptions {
+	query := V2MetricOptions{
+		Metric:     metric,
+		Aggregator: "avg",
+		Downsample: fmt.Sprintf("%ds-avg", int(interval.Seconds())),
+		Tags: map[string][]string{
+			"controlplane_service_id":  []string{serviceID},
+			"controlplane_instance_id": []string{"*"},
+		},
+	}
+	if counter {
+		query.Rate = true
+		query.RateOptions = V2RateOptions{Counter: true}
+	}
+	return V2PerformanceOptions{
+		Start:   fmt.Sprintf("%ds-ago", int(window.Seconds())),
+		End:     "now",
+		Metrics: []V2MetricOptions{query},
+	}
+}
+
+// mergeSeries returns the average across series of the values at each
+// timestamp, ordered by timestamp.
+func mergeSeries(data *V2PerformanceData) []float64 {
+	sums := make(map[float64]float64)
+	counts := make(map[float64]int)
+	for _, series := range data.Series {
+		for _, dp := range series.Datapoints {
+			sums[dp.Timestamp()] += dp.Value()
+			counts[dp.Timestamp()]++
+		}
+	}
+	timestamps := make([]float64, 0, len(sums))
+	for ts := range sums {
+		timestamps = append(timestamps, ts)
+	}
+	sort.Float64s(timestamps)
+	values := make([]float64, len(timestamps))
+	for i, ts := range timestamps {
+		values[i] = sums[ts] / float64(counts[ts])
+	}
+	return values
+}
+
+// averageSeries returns the average across series of the average value of
+// each series.
+func averageSeries(data *V2PerformanceData) (float64, bool) {
+	var total float64
+	var count int
+	for _, series := range data.Series {
+		if len(series.Datapoints) == 0 {
+			continue
+		}
+		var sum float64
+		for _, dp := range series.Datapoints {
+			sum += dp.Value()
+		}
+		total += sum / float64(len(series.Datapoints))
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return total / float64(count), true
+}
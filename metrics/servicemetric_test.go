@@ -0,0 +1,64 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package metrics
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAverageSeries(t *testing.T) {
+	testData := []byte(`
+	{ "series" : [ { "datapoints" : [ [ 1453835068, 10 ], [ 1453835078, 20 ] ], "metric" : "requests", "tags" : { "controlplane_instance_id" : "0", "controlplane_service_id" : "svc" } }, { "datapoints" : [ [ 1453835068, 45 ] ], "metric" : "requests", "tags" : { "controlplane_instance_id" : "1", "controlplane_service_id" : "svc" } }, { "datapoints" : [ ], "metric" : "requests", "tags" : { "controlplane_instance_id" : "2", "controlplane_service_id" : "svc" } } ], "statuses" : [ { "message" : "", "status" : "SUCCESS" } ] }
+	`)
+
+	var perfdata V2PerformanceData
+	if err := json.Unmarshal(testData, &perfdata); err != nil {
+		t.Fatalf("Could not unmarshal testData: %s", err)
+	}
+
+	avg, ok := averageSeries(&perfdata)
+	if !ok {
+		t.Fatalf("Expected an average")
+	}
+	if avg != 30 {
+		t.Errorf("Expected average 30, got %f", avg)
+	}
+
+	if _, ok := averageSeries(&V2PerformanceData{}); ok {
+		t.Errorf("Expected no average without data")
+	}
+}
+
+func TestMergeSeries(t *testing.T) {
+	testData := []byte(`
+	{ "series" : [ { "datapoints" : [ [ 1453835078, 20 ], [ 1453835068, 10 ] ], "metric" : "requests", "tags" : { "controlplane_instance_id" : "0", "controlplane_service_id" : "svc" } }, { "datapoints" : [ [ 1453835068, 40 ] ], "metric" : "requests", "tags" : { "controlplane_instance_id" : "1", "controlplane_service_id" : "svc" } }, { "datapoints" : [ ], "metric" : "requests", "tags" : { "controlplane_instance_id" : "2", "controlplane_service_id" : "svc" } } ], "statuses" : [ { "message" : "", "status" : "SUCCESS" } ] }
+	`)
+
+	var perfdata V2PerformanceData
+	if err := json.Unmarshal(testData, &perfdata); err != nil {
+		t.Fatalf("Could not unmarshal testData: %s", err)
+	}
+
+	values := mergeSeries(&perfdata)
+	if len(values) != 2 || values[0] != 25 || values[1] != 20 {
+		t.Errorf("Expected [25 20], got %v", values)
+	}
+
+	if values := mergeSeries(&V2PerformanceData{}); len(values) != 0 {
+		t.Errorf("Expected no values without data, got %v", values)
+	}
+}
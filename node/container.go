@@ -19,6 +19,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -37,6 +38,9 @@ import (
 	dockerclient "github.com/fsouza/go-dockerclient"
 )
 
+// cpuQuotaPeriod is the CFS period (in microseconds) over which a service's
+// CPUQuota is enforced.
+const cpuQuotaPeriod = 100000
 
 func (a *HostAgent) setInstanceState(serviceID string, instanceID int, state service.InstanceCurrentState) error {
 	logger := plog.WithFields(log.Fields{
@@ -804,6 +808,21 @@ func (a *HostAgent) createContainerConfig(tenantID string, svc *service.Service,
 		cfg.CPUShares = svc.CPUShares
 	}
 
+	// CPUQuota is a hard limit on cores, enforced by the CFS scheduler as a
+	// share of each period.  It can never be more than the cores on the host.
+	if svc.CPUQuota > 0 {
+		quota := svc.CPUQuota
+		if cores := float64(runtime.NumCPU()); quota > cores {
+			logger.WithFields(log.Fields{
+				"cpuquota": svc.CPUQuota,
+				"cores":    cores,
+			}).Warn("CPU quota exceeds the cores on this host, limiting it to the host cores")
+			quota = cores
+		}
+		hcfg.CPUPeriod = cpuQuotaPeriod
+		hcfg.CPUQuota = int64(quota * cpuQuotaPeriod)
+	}
+
 	hcfg.LogConfig.Type = a.dockerLogDriver
 	hcfg.LogConfig.Config = a.dockerLogConfig
 
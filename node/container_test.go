@@ -16,6 +16,7 @@
 package node
 
 import (
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -61,3 +62,37 @@ func TestSetupContainer_DockerLog(t *testing.T) {
 	assert.Equal(hcfg.LogConfig.Config["bravo"], "two")
 	assert.Equal(hcfg.LogConfig.Config["charlie"], "three")
 }
+
+func TestSetupContainer_CPUQuota(t *testing.T) {
+	assert := assert.New(t)
+
+	fakeHostAgent := &HostAgent{
+		uiport:               ":443",
+		virtualAddressSubnet: "0.0.0.0",
+		pullreg:              &regmocks.Registry{},
+	}
+	fakeService := &service.Service{
+		ImageID: "busybox:latest",
+		ID:      "faketestService",
+		Name:    "fakeTestServiceName",
+	}
+
+	// No quota by default
+	_, hcfg, _, err := fakeHostAgent.createContainerConfig("unused", fakeService, 0, "unused")
+	assert.Nil(err)
+	assert.Equal(int64(0), hcfg.CPUQuota)
+	assert.Equal(int64(0), hcfg.CPUPeriod)
+
+	// A fractional quota is a share of the period
+	fakeService.CPUQuota = 0.5
+	_, hcfg, _, err = fakeHostAgent.createContainerConfig("unused", fakeService, 0, "unused")
+	assert.Nil(err)
+	assert.Equal(int64(100000), hcfg.CPUPeriod)
+	assert.Equal(int64(50000), hcfg.CPUQuota)
+
+	// The quota is limited to the cores on the host
+	fakeService.CPUQuota = float64(runtime.NumCPU() + 4)
+	_, hcfg, _, err = fakeHostAgent.createContainerConfig("unused", fakeService, 0, "unused")
+	assert.Nil(err)
+	assert.Equal(int64(runtime.NumCPU())*100000, hcfg.CPUQuota)
+}
@@ -0,0 +1,184 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"time"
+
+	"github.com/control-center/serviced/validation"
+)
+
+const (
+	// WebhookSink posts events as json to a url
+	WebhookSink = "webhook"
+	// SMTPSink mails events
+	SMTPSink = "smtp"
+	// SyslogSink writes events to a syslog daemon
+	SyslogSink = "syslog"
+)
+
+// Config describes where events are delivered.
+type Config struct {
+	RepeatInterval int // seconds before a firing event is sent again, 0 to only send changes
+	Sinks          []SinkConfig
+	Routes         []Route
+	Silences       []Silence
+}
+
+// SinkConfig describes a destination for events.
+type SinkConfig struct {
+	Name     string
+	Type     string   // webhook, smtp or syslog
+	URL      string   // webhook url
+	Address  string   // smtp server host:port, or syslog host:port (local syslog if empty)
+	Network  string   // syslog network (udp or tcp), empty for local syslog
+	Tag      string   // syslog tag
+	From     string   // smtp sender
+	To       []string // smtp recipients
+	Username string   // smtp user
+	Password string   // smtp password
+}
+
+// Route sends the events of a tenant (or of all tenants if TenantID is empty)
+// of at least a severity to sinks.
+type Route struct {
+	TenantID    string
+	Kinds       []string // health, threshold, or empty for both
+	MinSeverity int
+	Sinks       []string
+}
+
+// Silence suppresses the events that match it between Start and End.  Empty
+// fields match any event.
+type Silence struct {
+	TenantID  string
+	ServiceID string
+	Name      string
+	Start     time.Time
+	End       time.Time
+	Comment   string
+}
+
+// LoadConfig reads the notification config from a json file.
+func LoadConfig(filename string) (*Config, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("could not parse notification config %s: %s", filename, err)
+	}
+	if err := config.ValidEntity(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ValidEntity checks the sinks, and that the routes refer to them.
+func (c Config) ValidEntity() error {
+	violations := validation.NewValidationError()
+	if c.RepeatInterval < 0 {
+		violations.Add(fmt.Errorf("the repeat interval cannot be negative"))
+	}
+	sinks := make(map[string]struct{})
+	for _, sink := range c.Sinks {
+		if _, ok := sinks[sink.Name]; ok {
+			violations.Add(fmt.Errorf("sink %q is defined more than once", sink.Name))
+		}
+		sinks[sink.Name] = struct{}{}
+		if err := sink.ValidEntity(); err != nil {
+			violations.Add(err)
+		}
+	}
+	for _, route := range c.Routes {
+		for _, name := range route.Sinks {
+			if _, ok := sinks[name]; !ok {
+				violations.Add(fmt.Errorf("route for tenant %q refers to unknown sink %q", route.TenantID, name))
+			}
+		}
+		for _, kind := range route.Kinds {
+			if kind != HealthKind && kind != ThresholdKind {
+				violations.Add(fmt.Errorf("route for tenant %q has invalid kind %q", route.TenantID, kind))
+			}
+		}
+	}
+	for _, silence := range c.Silences {
+		if !silence.End.After(silence.Start) {
+			violations.Add(fmt.Errorf("silence %q must end after it starts", silence.Comment))
+		}
+	}
+	if violations.HasError() {
+		return violations
+	}
+	return nil
+}
+
+// ValidEntity checks the settings required by the type of sink.
+func (s SinkConfig) ValidEntity() error {
+	if s.Name == "" {
+		return fmt.Errorf("sinks must have a name")
+	}
+	switch s.Type {
+	case WebhookSink:
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("sink %q has an invalid url %q", s.Name, s.URL)
+		}
+	case SMTPSink:
+		if s.Address == "" || s.From == "" || len(s.To) == 0 {
+			return fmt.Errorf("sink %q must set an address, a sender and recipients", s.Name)
+		}
+	case SyslogSink:
+		if s.Network != "" && s.Address == "" {
+			return fmt.Errorf("sink %q must set an address for network %q", s.Name, s.Network)
+		}
+	default:
+		return fmt.Errorf("sink %q has invalid type %q", s.Name, s.Type)
+	}
+	return nil
+}
+
+// matches returns true if the route applies to the event
+func (r Route) matches(e Event) bool {
+	if r.TenantID != "" && r.TenantID != e.TenantID {
+		return false
+	}
+	if e.Severity < r.MinSeverity {
+		return false
+	}
+	if len(r.Kinds) == 0 {
+		return true
+	}
+	for _, kind := range r.Kinds {
+		if kind == e.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if the silence applies to the event at the given time
+func (s Silence) matches(e Event, now time.Time) bool {
+	if now.Before(s.Start) || !now.Before(s.End) {
+		return false
+	}
+	return (s.TenantID == "" || s.TenantID == e.TenantID) &&
+		(s.ServiceID == "" || s.ServiceID == e.ServiceID) &&
+		(s.Name == "" || s.Name == e.Name)
+}
@@ -0,0 +1,104 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package notify
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notify")
+	if err != nil {
+		t.Fatalf("Could not create a temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "notify.json")
+	data := `{
+		"RepeatInterval": 3600,
+		"Sinks": [
+			{"Name": "ops", "Type": "webhook", "URL": "https://hooks.example.com/serviced"},
+			{"Name": "mail", "Type": "smtp", "Address": "mail.example.com:25", "From": "serviced@example.com", "To": ["ops@example.com"]},
+			{"Name": "local", "Type": "syslog"}
+		],
+		"Routes": [
+			{"Sinks": ["local"]},
+			{"TenantID": "tenant1", "Kinds": ["threshold"], "MinSeverity": 3, "Sinks": ["ops", "mail"]}
+		],
+		"Silences": [
+			{"ServiceID": "svc1", "Start": "2019-01-01T00:00:00Z", "End": "2019-01-02T00:00:00Z", "Comment": "maintenance"}
+		]
+	}`
+	if err := ioutil.WriteFile(filename, []byte(data), 0644); err != nil {
+		t.Fatalf("Could not write config: %s", err)
+	}
+
+	config, err := LoadConfig(filename)
+	if err != nil {
+		t.Fatalf("Could not load config: %s", err)
+	}
+	if config.RepeatInterval != 3600 || len(config.Sinks) != 3 || len(config.Routes) != 2 || len(config.Silences) != 1 {
+		t.Errorf("Unexpected config %+v", config)
+	}
+	if _, err := NewNotifier(*config); err != nil {
+		t.Errorf("Could not create notifier: %s", err)
+	}
+
+	if _, err := LoadConfig(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("Expected an error for a missing config")
+	}
+}
+
+func TestConfig_ValidEntity(t *testing.T) {
+	config := Config{
+		RepeatInterval: -1,
+		Sinks: []SinkConfig{
+			{Name: "ops", Type: "webhook", URL: "ftp://example.com"},
+			{Name: "ops", Type: "pager"},
+			{Name: "mail", Type: "smtp", Address: "mail.example.com:25"},
+			{Name: "remote", Type: "syslog", Network: "udp"},
+			{Type: "syslog"},
+		},
+		Routes: []Route{
+			{Kinds: []string{"other"}, Sinks: []string{"missing"}},
+		},
+		Silences: []Silence{{Comment: "empty"}},
+	}
+	err := config.ValidEntity()
+	if err == nil {
+		t.Fatalf("Expected validation errors")
+	}
+	for _, msg := range []string{
+		"repeat interval",
+		"invalid url",
+		"defined more than once",
+		"invalid type",
+		"sender and recipients",
+		"must set an address",
+		"must have a name",
+		"unknown sink",
+		"invalid kind",
+		"must end after it starts",
+	} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("Expected %q in %s", msg, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notify delivers alert events raised by the master, such as breached
+// thresholds and failing health checks, to webhook, smtp and syslog sinks.
+package notify
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// Firing is the state of an event whose condition is breached
+	Firing = "firing"
+	// Resolved is the state of an event whose condition is no longer breached
+	Resolved = "resolved"
+)
+
+const (
+	// HealthKind is the kind of event raised by a health check
+	HealthKind = "health"
+	// ThresholdKind is the kind of event raised by a threshold
+	ThresholdKind = "threshold"
+)
+
+// Event is the state of an alert condition of a service, or of one of its
+// instances.
+type Event struct {
+	Kind        string
+	State       string
+	TenantID    string
+	ServiceID   string
+	ServiceName string
+	InstanceID  int // -1 for conditions of the whole service
+	Name        string
+	Severity    int
+	Summary     string
+	Value       float64                `json:",omitempty"`
+	Output      string                 `json:",omitempty"`
+	Tags        map[string]interface{} `json:",omitempty"`
+	Timestamp   time.Time
+}
+
+// Key identifies the condition of the event, and is used to deduplicate
+// notifications.
+func (e Event) Key() string {
+	return fmt.Sprintf("%s/%s/%d/%s", e.Kind, e.ServiceID, e.InstanceID, e.Name)
+}
+
+// Subject is a one line description of the event.
+func (e Event) Subject() string {
+	target := e.ServiceName
+	if e.InstanceID >= 0 {
+		target = fmt.Sprintf("%s instance %d", e.ServiceName, e.InstanceID)
+	}
+	return fmt.Sprintf("[%s] %s %s of %s", e.State, e.Kind, e.Name, target)
+}
+
+// Body is a multiline description of the event.
+func (e Event) Body() string {
+	body := fmt.Sprintf("%s\n\nTenant:   %s\nService:  %s (%s)\nSeverity: %d\nTime:     %s\n",
+		e.Summary, e.TenantID, e.ServiceName, e.ServiceID, e.Severity, e.Timestamp.UTC().Format(time.RFC3339))
+	if e.Output != "" {
+		body += fmt.Sprintf("\n%s\n", e.Output)
+	}
+	return body
+}
+
+// Alerts is the state of the alert conditions of the services.  Running has
+// every service by id, and whether it should be running; the conditions of a
+// running service that are missing from Events could not be evaluated.
+type Alerts struct {
+	Events  []Event
+	Running map[string]bool
+}
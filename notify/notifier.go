@@ -0,0 +1,305 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/control-center/serviced/logging"
+)
+
+var plog = logging.PackageLogger()
+
+// alertState is what the notifier remembers about an alert condition that
+// was delivered while firing
+type alertState struct {
+	event Event                // the last event of the condition
+	sent  map[string]time.Time // when the firing event was last delivered, by sink
+}
+
+// Notifier delivers the changes of state of alert conditions to the sinks of
+// the matching routes.  A firing condition is only sent again to a sink after
+// the repeat interval, and a condition that is resolved is only sent to the
+// sinks that received it while firing.  Silenced events are not sent.
+type Notifier struct {
+	mu       sync.Mutex
+	repeat   time.Duration
+	sinks    map[string]Sink
+	routes   []Route
+	silences []Silence
+	alerts   map[string]*alertState
+}
+
+// NewNotifier builds the sinks of the config.
+func NewNotifier(config Config) (*Notifier, error) {
+	sinks, err := newSinks(config)
+	if err != nil {
+		return nil, err
+	}
+	return newNotifier(config, sinks), nil
+}
+
+func newNotifier(config Config, sinks map[string]Sink) *Notifier {
+	return &Notifier{
+		repeat:   time.Duration(config.RepeatInterval) * time.Second,
+		sinks:    sinks,
+		routes:   config.Routes,
+		silences: config.Silences,
+		alerts:   make(map[string]*alertState),
+	}
+}
+
+// Reload replaces the sinks, routes and silences with those of the config.
+// The state of the alert conditions is kept, so that the conditions that were
+// delivered are not sent again.
+func (n *Notifier) Reload(config Config) error {
+	sinks, err := newSinks(config)
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.repeat = time.Duration(config.RepeatInterval) * time.Second
+	n.sinks = sinks
+	n.routes = config.Routes
+	n.silences = config.Silences
+	return nil
+}
+
+// newSinks validates the config and builds its sinks by name.
+func newSinks(config Config) (map[string]Sink, error) {
+	if err := config.ValidEntity(); err != nil {
+		return nil, err
+	}
+	sinks := make(map[string]Sink)
+	for _, sinkConfig := range config.Sinks {
+		sink, err := NewSink(sinkConfig)
+		if err != nil {
+			return nil, err
+		}
+		sinks[sinkConfig.Name] = sink
+	}
+	return sinks, nil
+}
+
+// delivery is an event and the sinks it is to be sent to.
+type delivery struct {
+	key   string
+	event Event
+	names []string
+	sinks []Sink
+}
+
+// Notify takes the current state of the alert conditions of the services and
+// delivers the events that need to be sent.  A condition that is not reported
+// keeps its state while its service is running, is resolved once its service
+// stops, and is forgotten once its service is deleted.  Returns the number of
+// events sent.
+func (n *Notifier) Notify(alerts Alerts, now time.Time) int {
+	// the sinks may be slow to respond, so the events are sent without
+	// holding the lock
+	deliveries := n.plan(alerts, now)
+	sent := 0
+	delivered := make([][]string, len(deliveries))
+	for i, d := range deliveries {
+		delivered[i] = d.send()
+		if len(delivered[i]) > 0 {
+			sent++
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, d := range deliveries {
+		n.record(d, delivered[i], now)
+	}
+	return sent
+}
+
+// plan updates the state of the alert conditions and returns the events that
+// need to be sent.
+func (n *Notifier) plan(alerts Alerts, now time.Time) []delivery {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	deliveries := []delivery{}
+	reported := make(map[string]struct{})
+	for _, event := range alerts.Events {
+		key := event.Key()
+		reported[key] = struct{}{}
+		if d, ok := n.update(key, event, now); ok {
+			deliveries = append(deliveries, d)
+		}
+	}
+
+	for key, alert := range n.alerts {
+		if _, ok := reported[key]; ok {
+			continue
+		}
+		running, ok := alerts.Running[alert.event.ServiceID]
+		if !ok {
+			delete(n.alerts, key)
+		} else if !running {
+			event := alert.event
+			event.State = Resolved
+			event.Summary = fmt.Sprintf("%s is no longer running", event.ServiceName)
+			event.Output = ""
+			event.Timestamp = now
+			if d, ok := n.update(key, event, now); ok {
+				deliveries = append(deliveries, d)
+			}
+		}
+	}
+	return deliveries
+}
+
+// update works out which sinks need an event, and returns false if none of
+// them do.
+func (n *Notifier) update(key string, event Event, now time.Time) (delivery, bool) {
+	alert, ok := n.alerts[key]
+	if !ok {
+		alert = &alertState{sent: make(map[string]time.Time)}
+	}
+	alert.event = event
+	defer func() {
+		if len(alert.sent) > 0 {
+			n.alerts[key] = alert
+		} else {
+			delete(n.alerts, key)
+		}
+	}()
+
+	firing := event.State == Firing
+	var names []string
+	if firing {
+		for _, name := range n.routeSinks(event) {
+			if sentAt, ok := alert.sent[name]; !ok || (n.repeat > 0 && now.Sub(sentAt) >= n.repeat) {
+				names = append(names, name)
+			}
+		}
+	} else {
+		for name := range alert.sent {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	if len(names) == 0 {
+		return delivery{}, false
+	}
+
+	if n.silenced(event, now) {
+		plog.WithFields(log.Fields{
+			"event":    key,
+			"state":    event.State,
+			"tenantid": event.TenantID,
+		}).Debug("Event is silenced")
+		if !firing {
+			alert.sent = make(map[string]time.Time)
+		}
+		return delivery{}, false
+	}
+
+	d := delivery{key: key, event: event}
+	for _, name := range names {
+		sink, ok := n.sinks[name]
+		if !ok {
+			// the sink was removed from the config
+			delete(alert.sent, name)
+			continue
+		}
+		d.names = append(d.names, name)
+		d.sinks = append(d.sinks, sink)
+	}
+	return d, len(d.sinks) > 0
+}
+
+// send delivers the event to its sinks, and returns the names of the sinks
+// that accepted it.
+func (d delivery) send() []string {
+	logger := plog.WithFields(log.Fields{
+		"event":    d.key,
+		"state":    d.event.State,
+		"tenantid": d.event.TenantID,
+	})
+	var delivered []string
+	for i, sink := range d.sinks {
+		if err := sink.Send(d.event); err != nil {
+			logger.WithField("sink", d.names[i]).WithError(err).Warn("Could not deliver event")
+			continue
+		}
+		delivered = append(delivered, d.names[i])
+	}
+	if len(delivered) > 0 {
+		logger.Info("Delivered event")
+	}
+	return delivered
+}
+
+// record remembers the sinks that accepted an event, so that a firing event
+// is not sent to them again before the repeat interval, and a resolved event
+// is not sent to them at all.
+func (n *Notifier) record(d delivery, delivered []string, now time.Time) {
+	if len(delivered) == 0 {
+		return
+	}
+	alert, ok := n.alerts[d.key]
+	if !ok {
+		alert = &alertState{event: d.event, sent: make(map[string]time.Time)}
+	}
+	for _, name := range delivered {
+		if d.event.State == Firing {
+			alert.sent[name] = now
+		} else {
+			delete(alert.sent, name)
+		}
+	}
+	if len(alert.sent) > 0 {
+		n.alerts[d.key] = alert
+	} else {
+		delete(n.alerts, d.key)
+	}
+}
+
+// silenced returns true if a silence applies to the event
+func (n *Notifier) silenced(event Event, now time.Time) bool {
+	for _, silence := range n.silences {
+		if silence.matches(event, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeSinks returns the names of the sinks of the routes that match the
+// event.
+func (n *Notifier) routeSinks(event Event) []string {
+	names := make(map[string]struct{})
+	for _, route := range n.routes {
+		if route.matches(event) {
+			for _, name := range route.Sinks {
+				names[name] = struct{}{}
+			}
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
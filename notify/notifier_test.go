@@ -0,0 +1,277 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package notify
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// testSink records the events sent to it
+type testSink struct {
+	events []Event
+	err    error
+}
+
+func (s *testSink) Send(event Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+// funcSink calls a function with the events sent to it
+type funcSink func(Event) error
+
+func (f funcSink) Send(event Event) error {
+	return f(event)
+}
+
+func testEvent(state string) Event {
+	return Event{
+		Kind:        HealthKind,
+		State:       state,
+		TenantID:    "tenant1",
+		ServiceID:   "svc1",
+		ServiceName: "web",
+		InstanceID:  0,
+		Name:        "running",
+		Severity:    4,
+	}
+}
+
+// testAlerts reports events of the running service svc1
+func testAlerts(events ...Event) Alerts {
+	return Alerts{Events: events, Running: map[string]bool{"svc1": true}}
+}
+
+func TestNotify_Deduplicates(t *testing.T) {
+	sink := &testSink{}
+	n := newNotifier(Config{Routes: []Route{{Sinks: []string{"test"}}}}, map[string]Sink{"test": sink})
+	now := time.Now()
+
+	if sent := n.Notify(testAlerts(testEvent(Resolved)), now); sent != 0 {
+		t.Errorf("Expected no event for a passing check, sent %d", sent)
+	}
+	if sent := n.Notify(testAlerts(testEvent(Firing)), now); sent != 1 {
+		t.Errorf("Expected the firing event to be sent, sent %d", sent)
+	}
+	if sent := n.Notify(testAlerts(testEvent(Firing)), now.Add(time.Hour)); sent != 0 {
+		t.Errorf("Expected the firing event not to be repeated, sent %d", sent)
+	}
+	if sent := n.Notify(testAlerts(testEvent(Resolved)), now.Add(time.Hour)); sent != 1 {
+		t.Errorf("Expected the resolved event to be sent, sent %d", sent)
+	}
+	if sent := n.Notify(testAlerts(testEvent(Resolved)), now.Add(time.Hour)); sent != 0 {
+		t.Errorf("Expected the resolved event not to be repeated, sent %d", sent)
+	}
+	if len(sink.events) != 2 || sink.events[0].State != Firing || sink.events[1].State != Resolved {
+		t.Errorf("Expected a firing and a resolved event, got %v", sink.events)
+	}
+}
+
+func TestNotify_Repeat(t *testing.T) {
+	sink := &testSink{}
+	config := Config{RepeatInterval: 60, Routes: []Route{{Sinks: []string{"test"}}}}
+	n := newNotifier(config, map[string]Sink{"test": sink})
+	now := time.Now()
+
+	n.Notify(testAlerts(testEvent(Firing)), now)
+	if sent := n.Notify(testAlerts(testEvent(Firing)), now.Add(30*time.Second)); sent != 0 {
+		t.Errorf("Expected no repeat before the interval, sent %d", sent)
+	}
+	if sent := n.Notify(testAlerts(testEvent(Firing)), now.Add(time.Minute)); sent != 1 {
+		t.Errorf("Expected a repeat after the interval, sent %d", sent)
+	}
+}
+
+func TestNotify_Forgets(t *testing.T) {
+	sink := &testSink{}
+	n := newNotifier(Config{Routes: []Route{{Sinks: []string{"test"}}}}, map[string]Sink{"test": sink})
+	now := time.Now()
+
+	n.Notify(testAlerts(testEvent(Firing)), now)
+	// the service was deleted
+	n.Notify(Alerts{}, now)
+	if sent := n.Notify(testAlerts(testEvent(Firing)), now); sent != 1 {
+		t.Errorf("Expected the event to be sent again once forgotten, sent %d", sent)
+	}
+}
+
+func TestNotify_Silenced(t *testing.T) {
+	sink := &testSink{}
+	now := time.Now()
+	config := Config{
+		Routes:   []Route{{Sinks: []string{"test"}}},
+		Silences: []Silence{{ServiceID: "svc1", Start: now.Add(-time.Minute), End: now.Add(time.Minute)}},
+	}
+	n := newNotifier(config, map[string]Sink{"test": sink})
+
+	if sent := n.Notify(testAlerts(testEvent(Firing)), now); sent != 0 {
+		t.Errorf("Expected no event while silenced, sent %d", sent)
+	}
+	if sent := n.Notify(testAlerts(testEvent(Firing)), now.Add(time.Minute)); sent != 1 {
+		t.Errorf("Expected the event once the silence ends, sent %d", sent)
+	}
+}
+
+func TestNotify_Routes(t *testing.T) {
+	all, tenant, critical := &testSink{}, &testSink{}, &testSink{}
+	config := Config{
+		Routes: []Route{
+			{Sinks: []string{"all"}},
+			{TenantID: "tenant1", Kinds: []string{HealthKind}, Sinks: []string{"tenant", "all"}},
+			{TenantID: "tenant2", Sinks: []string{"tenant"}},
+			{MinSeverity: 5, Sinks: []string{"critical"}},
+		},
+	}
+	n := newNotifier(config, map[string]Sink{"all": all, "tenant": tenant, "critical": critical})
+
+	n.Notify(testAlerts(testEvent(Firing)), time.Now())
+	if len(all.events) != 1 {
+		t.Errorf("Expected one event to the shared sink, got %d", len(all.events))
+	}
+	if len(tenant.events) != 1 {
+		t.Errorf("Expected one event to the tenant sink, got %d", len(tenant.events))
+	}
+	if len(critical.events) != 0 {
+		t.Errorf("Expected no event to the critical sink, got %d", len(critical.events))
+	}
+}
+
+func TestNotify_Retries(t *testing.T) {
+	sink := &testSink{err: errors.New("unavailable")}
+	n := newNotifier(Config{Routes: []Route{{Sinks: []string{"test"}}}}, map[string]Sink{"test": sink})
+	now := time.Now()
+
+	if sent := n.Notify(testAlerts(testEvent(Firing)), now); sent != 0 {
+		t.Errorf("Expected no event to be delivered, sent %d", sent)
+	}
+	sink.err = nil
+	if sent := n.Notify(testAlerts(testEvent(Firing)), now); sent != 1 {
+		t.Errorf("Expected the event to be delivered on retry, sent %d", sent)
+	}
+}
+
+func TestNotify_KeepsUnevaluated(t *testing.T) {
+	sink := &testSink{}
+	n := newNotifier(Config{Routes: []Route{{Sinks: []string{"test"}}}}, map[string]Sink{"test": sink})
+	now := time.Now()
+
+	n.Notify(testAlerts(testEvent(Firing)), now)
+	// the health check could not be evaluated
+	if sent := n.Notify(testAlerts(), now); sent != 0 {
+		t.Errorf("Expected no event while the condition is not reported, sent %d", sent)
+	}
+	if sent := n.Notify(testAlerts(testEvent(Firing)), now); sent != 0 {
+		t.Errorf("Expected the firing event not to be repeated, sent %d", sent)
+	}
+	if sent := n.Notify(testAlerts(testEvent(Resolved)), now); sent != 1 {
+		t.Errorf("Expected the resolved event to be sent, sent %d", sent)
+	}
+}
+
+func TestNotify_ResolvesStopped(t *testing.T) {
+	sink := &testSink{}
+	n := newNotifier(Config{Routes: []Route{{Sinks: []string{"test"}}}}, map[string]Sink{"test": sink})
+	now := time.Now()
+
+	n.Notify(testAlerts(testEvent(Firing)), now)
+	stopped := Alerts{Running: map[string]bool{"svc1": false}}
+	if sent := n.Notify(stopped, now); sent != 1 {
+		t.Errorf("Expected the event to be resolved when the service stops, sent %d", sent)
+	}
+	if sent := n.Notify(stopped, now); sent != 0 {
+		t.Errorf("Expected the resolved event not to be repeated, sent %d", sent)
+	}
+	if len(sink.events) != 2 || sink.events[1].State != Resolved || sink.events[1].Key() != testEvent(Firing).Key() {
+		t.Errorf("Expected a firing and a resolved event, got %v", sink.events)
+	}
+}
+
+func TestNotify_PerSink(t *testing.T) {
+	up, down := &testSink{}, &testSink{err: errors.New("unavailable")}
+	n := newNotifier(Config{Routes: []Route{{Sinks: []string{"up", "down"}}}}, map[string]Sink{"up": up, "down": down})
+	now := time.Now()
+
+	if sent := n.Notify(testAlerts(testEvent(Firing)), now); sent != 1 {
+		t.Errorf("Expected the event to be delivered, sent %d", sent)
+	}
+	down.err = nil
+	n.Notify(testAlerts(testEvent(Firing)), now)
+	if len(up.events) != 1 || len(down.events) != 1 {
+		t.Errorf("Expected only the failed sink to be retried, got %d and %d events", len(up.events), len(down.events))
+	}
+
+	up.err = errors.New("unavailable")
+	n.Notify(testAlerts(testEvent(Resolved)), now)
+	up.err = nil
+	n.Notify(testAlerts(testEvent(Resolved)), now)
+	if len(up.events) != 2 || len(down.events) != 2 {
+		t.Errorf("Expected the resolved event once to each sink, got %d and %d events", len(up.events), len(down.events))
+	}
+}
+
+func TestNotify_Reload(t *testing.T) {
+	sink := &testSink{}
+	config := Config{
+		Sinks:  []SinkConfig{{Name: "test", Type: WebhookSink, URL: "http://localhost:8080/alerts"}},
+		Routes: []Route{{Sinks: []string{"test"}}},
+	}
+	n := newNotifier(config, map[string]Sink{"test": sink})
+	now := time.Now()
+	n.Notify(testAlerts(testEvent(Firing)), now)
+
+	config.Silences = []Silence{{ServiceID: "svc1", Start: now.Add(-time.Minute), End: now.Add(time.Minute)}}
+	if err := n.Reload(config); err != nil {
+		t.Fatalf("Unexpected error reloading the config: %s", err)
+	}
+	if sent := n.Notify(testAlerts(testEvent(Resolved)), now); sent != 0 {
+		t.Errorf("Expected no event once the silence is loaded, sent %d", sent)
+	}
+
+	config.Routes = []Route{{Sinks: []string{"missing"}}}
+	if err := n.Reload(config); err == nil {
+		t.Errorf("Expected an error reloading an invalid config")
+	}
+}
+
+func TestNotify_SendsUnlocked(t *testing.T) {
+	var n *Notifier
+	sink := funcSink(func(Event) error {
+		// a sink that is slow to respond does not hold up a reload
+		return n.Reload(Config{})
+	})
+	n = newNotifier(Config{Routes: []Route{{Sinks: []string{"test"}}}}, map[string]Sink{"test": sink})
+
+	done := make(chan int)
+	go func() {
+		done <- n.Notify(testAlerts(testEvent(Firing)), time.Now())
+	}()
+	select {
+	case sent := <-done:
+		if sent != 1 {
+			t.Errorf("Expected the firing event to be sent, sent %d", sent)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Notify held the lock while sending the event")
+	}
+	if len(n.alerts) != 1 {
+		t.Errorf("Expected the sent event to be recorded, got %v", n.alerts)
+	}
+}
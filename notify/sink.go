@@ -0,0 +1,138 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// webhookTimeout is how long a webhook may take to accept an event
+var webhookTimeout = 10 * time.Second
+
+// Sink delivers events to a destination.
+type Sink interface {
+	Send(event Event) error
+}
+
+// NewSink returns the sink described by the config.
+func NewSink(config SinkConfig) (Sink, error) {
+	if err := config.ValidEntity(); err != nil {
+		return nil, err
+	}
+	switch config.Type {
+	case WebhookSink:
+		return &webhook{url: config.URL, client: &http.Client{Timeout: webhookTimeout}}, nil
+	case SMTPSink:
+		return &mailer{config: config}, nil
+	default:
+		return &syslogger{config: config}, nil
+	}
+}
+
+// webhook posts events as json
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+func (s *webhook) Send(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s returned %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+// mailer mails events through an smtp server
+type mailer struct {
+	config SinkConfig
+}
+
+func (s *mailer) Send(event Event) error {
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		host, _, err := net.SplitHostPort(s.config.Address)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, host)
+	}
+	return smtp.SendMail(s.config.Address, auth, s.config.From, s.config.To, mailMessage(s.config.From, s.config.To, event))
+}
+
+// mailMessage formats an event as a mail message
+func mailMessage(from string, to []string, event Event) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", event.Subject())
+	fmt.Fprintf(&msg, "Date: %s\r\n", event.Timestamp.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.Replace(event.Body(), "\n", "\r\n", -1))
+	return msg.Bytes()
+}
+
+// syslogger writes events to a local or remote syslog daemon
+type syslogger struct {
+	mu     sync.Mutex
+	config SinkConfig
+	writer *syslog.Writer
+}
+
+func (s *syslogger) Send(event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.writer == nil {
+		tag := s.config.Tag
+		if tag == "" {
+			tag = "serviced"
+		}
+		writer, err := syslog.Dial(s.config.Network, s.config.Address, syslog.LOG_DAEMON|syslog.LOG_NOTICE, tag)
+		if err != nil {
+			return err
+		}
+		s.writer = writer
+	}
+	msg := fmt.Sprintf("%s: %s", event.Subject(), event.Summary)
+	if event.State == Resolved {
+		return s.writer.Info(msg)
+	}
+	switch {
+	case event.Severity >= 5:
+		return s.writer.Crit(msg)
+	case event.Severity == 4:
+		return s.writer.Err(msg)
+	case event.Severity == 3:
+		return s.writer.Warning(msg)
+	default:
+		return s.writer.Notice(msg)
+	}
+}
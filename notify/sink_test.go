@@ -0,0 +1,112 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package notify
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhook(t *testing.T) {
+	var received Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if received.Name == "rejected" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	sink, err := NewSink(SinkConfig{Name: "ops", Type: WebhookSink, URL: server.URL})
+	if err != nil {
+		t.Fatalf("Could not create sink: %s", err)
+	}
+	event := testEvent(Firing)
+	if err := sink.Send(event); err != nil {
+		t.Fatalf("Could not send event: %s", err)
+	}
+	if received.Key() != event.Key() || received.State != Firing {
+		t.Errorf("Unexpected event %+v", received)
+	}
+
+	event.Name = "rejected"
+	if err := sink.Send(event); err == nil {
+		t.Errorf("Expected an error when the webhook fails")
+	}
+}
+
+func TestMailMessage(t *testing.T) {
+	event := testEvent(Firing)
+	event.Summary = "Health check running of web instance 0 is failed"
+	event.Output = "connection refused"
+	event.Timestamp = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	msg := string(mailMessage("serviced@example.com", []string{"a@example.com", "b@example.com"}, event))
+	for _, expected := range []string{
+		"From: serviced@example.com\r\n",
+		"To: a@example.com, b@example.com\r\n",
+		"Subject: [firing] health running of web instance 0\r\n",
+		"Date: Tue, 01 Jan 2019 00:00:00 +0000\r\n",
+		"\r\n\r\nHealth check running of web instance 0 is failed\r\n",
+		"\r\nconnection refused\r\n",
+	} {
+		if !strings.Contains(msg, expected) {
+			t.Errorf("Expected %q in message:\n%s", expected, msg)
+		}
+	}
+}
+
+func TestSyslog(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Could not listen: %s", err)
+	}
+	defer conn.Close()
+
+	sink, err := NewSink(SinkConfig{Name: "remote", Type: SyslogSink, Network: "udp", Address: conn.LocalAddr().String(), Tag: "cc"})
+	if err != nil {
+		t.Fatalf("Could not create sink: %s", err)
+	}
+	event := testEvent(Firing)
+	event.Summary = "failing"
+	if err := sink.Send(event); err != nil {
+		t.Fatalf("Could not send event: %s", err)
+	}
+
+	buf := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Could not read message: %s", err)
+	}
+	// daemon facility with err severity
+	msg := string(buf[:n])
+	if !strings.HasPrefix(msg, "<27>") || !strings.Contains(msg, " cc[") || !strings.Contains(msg, "[firing] health running of web instance 0: failing") {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
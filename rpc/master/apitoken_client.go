@@ -0,0 +1,47 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/domain/apitoken"
+)
+
+// AddAPIToken creates an api token and returns it with its bearer token
+func (c *Client) AddAPIToken(userName, name string, role auth.Role, tenantIDs []string, expiration time.Duration) (apitoken.APIToken, string, error) {
+	request := APITokenRequest{
+		UserName:   userName,
+		Name:       name,
+		Role:       role,
+		TenantIDs:  tenantIDs,
+		Expiration: expiration,
+	}
+	response := APITokenResponse{}
+	err := c.call("AddAPIToken", request, &response)
+	return response.Token, response.BearerToken, err
+}
+
+// GetAPITokens returns all of the api tokens
+func (c *Client) GetAPITokens() ([]apitoken.APIToken, error) {
+	tokens := []apitoken.APIToken{}
+	err := c.call("GetAPITokens", empty, &tokens)
+	return tokens, err
+}
+
+// RemoveAPIToken revokes an api token
+func (c *Client) RemoveAPIToken(id string) error {
+	return c.call("RemoveAPIToken", id, nil)
+}
@@ -0,0 +1,61 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"time"
+
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/domain/apitoken"
+)
+
+// APITokenRequest is the request for creating an api token
+type APITokenRequest struct {
+	UserName   string
+	Name       string
+	Role       auth.Role
+	TenantIDs  []string
+	Expiration time.Duration
+}
+
+// APITokenResponse is the response to creating an api token
+type APITokenResponse struct {
+	Token       apitoken.APIToken
+	BearerToken string
+}
+
+// AddAPIToken creates an api token and returns it with its bearer token
+func (s *Server) AddAPIToken(request APITokenRequest, response *APITokenResponse) error {
+	token, bearer, err := s.f.AddAPIToken(s.context(), request.UserName, request.Name, request.Role, request.TenantIDs, request.Expiration)
+	if err != nil {
+		return err
+	}
+	*response = APITokenResponse{Token: token, BearerToken: bearer}
+	return nil
+}
+
+// GetAPITokens returns all of the api tokens
+func (s *Server) GetAPITokens(unused struct{}, tokens *[]apitoken.APIToken) error {
+	result, err := s.f.GetAPITokens(s.context())
+	if err != nil {
+		return err
+	}
+	*tokens = result
+	return nil
+}
+
+// RemoveAPIToken revokes an api token
+func (s *Server) RemoveAPIToken(id string, _ *struct{}) error {
+	return s.f.RemoveAPIToken(s.context(), id)
+}
@@ -0,0 +1,25 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"github.com/control-center/serviced/domain/auditlog"
+)
+
+// GetAuditEvents returns the events of the audit trail that match the query
+func (c *Client) GetAuditEvents(query auditlog.Query) ([]auditlog.Event, error) {
+	events := []auditlog.Event{}
+	err := c.call("GetAuditEvents", query, &events)
+	return events, err
+}
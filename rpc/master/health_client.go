@@ -36,6 +36,14 @@ func (c *Client) GetServicesHealth() (map[string]map[int]map[string]health.Healt
 	return results, err
 }
 
+// GetServiceHealthHistory returns the recent health check results of each
+// instance of a service.
+func (c *Client) GetServiceHealthHistory(serviceID string) (map[int]map[string]health.HealthCheckHistory, error) {
+	results := make(map[int]map[string]health.HealthCheckHistory)
+	err := c.call("GetServiceHealthHistory", serviceID, &results)
+	return results, err
+}
+
 // ReportHealthStatus sends an update to the health check status cache.
 func (c *Client) ReportHealthStatus(key health.HealthStatusKey, value health.HealthStatus, expires time.Duration) error {
 	request := HealthStatusRequest{
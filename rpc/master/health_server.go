@@ -62,6 +62,17 @@ func (s *Server) GetServicesHealth(unused struct{}, results *map[string]map[int]
 	return nil
 }
 
+// GetServiceHealthHistory returns the recent health check results of each
+// instance of a service.
+func (s *Server) GetServiceHealthHistory(serviceID string, results *map[int]map[string]health.HealthCheckHistory) error {
+	history, err := s.f.GetServiceHealthHistory(s.context(), serviceID)
+	if err != nil {
+		return err
+	}
+	*results = history
+	return nil
+}
+
 // ReportHealthStatus sends an update to the health check status cache.
 func (s *Server) ReportHealthStatus(request HealthStatusRequest, _ *struct{}) error {
 	s.f.ReportHealthStatus(request.Key, request.Value, request.Expires)
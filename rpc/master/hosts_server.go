@@ -164,9 +164,8 @@ func (s *Server) AuthenticateHost(req HostAuthenticationRequest, resp *HostAuthe
 	if p == nil {
 		return facade.ErrPoolNotExists
 	}
-	adminAccess := p.Permissions&pool.AdminAccess != 0
 	dfsAccess := p.Permissions&pool.DFSAccess != 0
-	signed, expires, err := auth.CreateJWTIdentity(host.ID, host.PoolID, adminAccess, dfsAccess, keypem, s.expiration)
+	signed, expires, err := auth.CreateJWTIdentityWithRole(host.ID, host.PoolID, p.GetDelegateRole(), dfsAccess, keypem, s.expiration)
 	if err != nil {
 		s.f.RemoveHostExpiration(s.context(), host.ID)
 		return err
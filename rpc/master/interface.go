@@ -16,13 +16,18 @@ package master
 import (
 	"time"
 
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/domain/addressassignment"
+	"github.com/control-center/serviced/domain/apitoken"
 	"github.com/control-center/serviced/domain/applicationendpoint"
+	"github.com/control-center/serviced/domain/auditlog"
 	"github.com/control-center/serviced/domain/host"
 	"github.com/control-center/serviced/domain/pool"
 	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/domain/servicerevision"
 	"github.com/control-center/serviced/domain/servicetemplate"
+	"github.com/control-center/serviced/domain/snapshotschedule"
 	"github.com/control-center/serviced/domain/user"
 	"github.com/control-center/serviced/health"
 	"github.com/control-center/serviced/isvcs"
@@ -96,6 +101,12 @@ type ClientInterface interface {
 	// GetPoolIPs returns a all IPs in a ResourcePool.
 	GetPoolIPs(poolID string) (*pool.PoolIPs, error)
 
+	// RebalancePool migrates up to limit service instances to even out the commitment of the hosts in a pool
+	RebalancePool(poolID string, limit int, dryRun bool) ([]service.Migration, error)
+
+	// GetPoolUsage returns the resources committed to a pool against its capacity and limits
+	GetPoolUsage(poolID string) (*pool.PoolUsage, error)
+
 	// AddVirtualIP adds a VirtualIP to a specific pool
 	AddVirtualIP(requestVirtualIP pool.VirtualIP) error
 
@@ -126,6 +137,36 @@ type ClientInterface interface {
 	// ClearEmergency will set EmergencyShutdown to false on the service and all child services
 	ClearEmergency(serviceID string) (int, error)
 
+	// PlanServiceUpdate returns the changes that updating a service would make
+	PlanServiceUpdate(svc service.Service) (*service.ChangePlan, error)
+
+	// RollingRestartService restarts the running instances of a service in health-gated batches
+	RollingRestartService(request service.RollingRestartRequest) (*service.RollingRestartStatus, error)
+
+	// GetRollingRestartStatus returns the progress of the most recent rolling restart of a service
+	GetRollingRestartStatus(serviceID string) (*service.RollingRestartStatus, error)
+
+	// ResumeRollingRestart continues a paused rolling restart
+	ResumeRollingRestart(serviceID string) error
+
+	// CancelRollingRestart stops a rolling restart before its next batch
+	CancelRollingRestart(serviceID string) error
+
+	// UpgradeServiceImage changes the image of a service tree one instance at a time
+	UpgradeServiceImage(request service.ImageUpgradeRequest) (*service.ImageUpgradeStatus, error)
+
+	// GetImageUpgradeStatus returns the progress of the most recent image upgrade of a service tree
+	GetImageUpgradeStatus(serviceID string) (*service.ImageUpgradeStatus, error)
+
+	// ResumeImageUpgrade continues a paused image upgrade
+	ResumeImageUpgrade(serviceID string) error
+
+	// AbortImageUpgrade stops an image upgrade and rolls back to the previous images
+	AbortImageUpgrade(serviceID string) error
+
+	// GetPlacementDecisions returns how the scheduler chose a host for each instance of a service
+	GetPlacementDecisions(serviceID string) ([]service.PlacementDecision, error)
+
 	//--------------------------------------------------------------------------
 	// Service Instance Management Functions
 
@@ -163,6 +204,9 @@ type ClientInterface interface {
 	// Deploy an application template
 	DeployTemplate(request servicetemplate.ServiceTemplateDeploymentRequest) (tenantIDs []string, err error)
 
+	// PlanTemplateDeploy returns the services that deploying an application template would create
+	PlanTemplateDeploy(request servicetemplate.ServiceTemplateDeploymentRequest) (*service.ChangePlan, error)
+
 	//--------------------------------------------------------------------------
 	// Volume Management Functions
 
@@ -217,6 +261,63 @@ type ClientInterface interface {
 	// Validate the credentials of the specified user
 	ValidateCredentials(user user.User) (bool, error)
 
+	// GetUsers returns all of the stored user records
+	GetUsers() ([]user.User, error)
+
+	// GetUserRole returns the role explicitly granted to a user
+	GetUserRole(userName string) (auth.Role, error)
+
+	// SetUserRole grants a role to a user
+	SetUserRole(userName string, role auth.Role) error
+
+	// SetUserTenants restricts a user to the given tenants
+	SetUserTenants(userName string, tenantIDs []string) error
+
+	//--------------------------------------------------------------------------
+	// API Token Management Functions
+
+	// AddAPIToken creates an api token and returns it with its bearer token
+	AddAPIToken(userName, name string, role auth.Role, tenantIDs []string, expiration time.Duration) (apitoken.APIToken, string, error)
+
+	// GetAPITokens returns all of the api tokens
+	GetAPITokens() ([]apitoken.APIToken, error)
+
+	// RemoveAPIToken revokes an api token
+	RemoveAPIToken(id string) error
+
+	//--------------------------------------------------------------------------
+	// Audit Trail Functions
+
+	// GetAuditEvents returns the events of the audit trail that match the query
+	GetAuditEvents(query auditlog.Query) ([]auditlog.Event, error)
+
+	//--------------------------------------------------------------------------
+	// Service Revision Functions
+
+	// GetServiceRevisions returns the stored revisions of a service, most recent first
+	GetServiceRevisions(serviceID string) ([]servicerevision.Revision, error)
+
+	// GetServiceRevision returns a revision of a service
+	GetServiceRevision(serviceID string, revision int) (*servicerevision.Revision, error)
+
+	// RevertService updates a service to the definition stored in one of its revisions
+	RevertService(serviceID string, revision int) error
+
+	//--------------------------------------------------------------------------
+	// Snapshot Schedule Functions
+
+	// GetSnapshotSchedules returns the snapshot schedules of all tenants
+	GetSnapshotSchedules() ([]snapshotschedule.Schedule, error)
+
+	// GetSnapshotSchedule returns the snapshot schedule of a tenant
+	GetSnapshotSchedule(tenantID string) (*snapshotschedule.Schedule, error)
+
+	// SetSnapshotSchedule adds or replaces the snapshot schedule of a tenant
+	SetSnapshotSchedule(sched snapshotschedule.Schedule) error
+
+	// RemoveSnapshotSchedule removes the snapshot schedule of a tenant
+	RemoveSnapshotSchedule(tenantID string) error
+
 	//--------------------------------------------------------------------------
 	// Healthcheck Management Functions
 
@@ -226,6 +327,10 @@ type ClientInterface interface {
 	// GetServicesHealth returns health checks for all services.
 	GetServicesHealth() (map[string]map[int]map[string]health.HealthStatus, error)
 
+	// GetServiceHealthHistory returns the recent health check results of each
+	// instance of a service.
+	GetServiceHealthHistory(serviceID string) (map[int]map[string]health.HealthCheckHistory, error)
+
 	// ReportHealthStatus sends an update to the health check status cache.
 	ReportHealthStatus(key health.HealthStatusKey, value health.HealthStatus, expires time.Duration) error
 
@@ -1,6 +1,9 @@
 package mocks
 
+import apitoken "github.com/control-center/serviced/domain/apitoken"
 import applicationendpoint "github.com/control-center/serviced/domain/applicationendpoint"
+import auditlog "github.com/control-center/serviced/domain/auditlog"
+import auth "github.com/control-center/serviced/auth"
 import health "github.com/control-center/serviced/health"
 import host "github.com/control-center/serviced/domain/host"
 import isvcs "github.com/control-center/serviced/isvcs"
@@ -9,7 +12,9 @@ import mock "github.com/stretchr/testify/mock"
 import pool "github.com/control-center/serviced/domain/pool"
 import service "github.com/control-center/serviced/domain/service"
 import servicedefinition "github.com/control-center/serviced/domain/servicedefinition"
+import servicerevision "github.com/control-center/serviced/domain/servicerevision"
 import servicetemplate "github.com/control-center/serviced/domain/servicetemplate"
+import snapshotschedule "github.com/control-center/serviced/domain/snapshotschedule"
 import time "time"
 import user "github.com/control-center/serviced/domain/user"
 import volume "github.com/control-center/serviced/volume"
@@ -20,6 +25,48 @@ type ClientInterface struct {
 	mock.Mock
 }
 
+// AbortImageUpgrade provides a mock function with given fields: serviceID
+func (_m *ClientInterface) AbortImageUpgrade(serviceID string) error {
+	ret := _m.Called(serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddAPIToken provides a mock function with given fields: userName, name, role, tenantIDs, expiration
+func (_m *ClientInterface) AddAPIToken(userName string, name string, role auth.Role, tenantIDs []string, expiration time.Duration) (apitoken.APIToken, string, error) {
+	ret := _m.Called(userName, name, role, tenantIDs, expiration)
+
+	var r0 apitoken.APIToken
+	if rf, ok := ret.Get(0).(func(string, string, auth.Role, []string, time.Duration) apitoken.APIToken); ok {
+		r0 = rf(userName, name, role, tenantIDs, expiration)
+	} else {
+		r0 = ret.Get(0).(apitoken.APIToken)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, auth.Role, []string, time.Duration) string); ok {
+		r1 = rf(userName, name, role, tenantIDs, expiration)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, auth.Role, []string, time.Duration) error); ok {
+		r2 = rf(userName, name, role, tenantIDs, expiration)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // AddHost provides a mock function with given fields: h
 func (_m *ClientInterface) AddHost(h host.Host) ([]byte, error) {
 	ret := _m.Called(h)
@@ -189,6 +236,20 @@ func (_m *ClientInterface) AuthenticateHost(hostID string) (string, int64, error
 	return r0, r1, r2
 }
 
+// CancelRollingRestart provides a mock function with given fields: serviceID
+func (_m *ClientInterface) CancelRollingRestart(serviceID string) error {
+	ret := _m.Called(serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ClearEmergency provides a mock function with given fields: serviceID
 func (_m *ClientInterface) ClearEmergency(serviceID string) (int, error) {
 	ret := _m.Called(serviceID)
@@ -354,6 +415,29 @@ func (_m *ClientInterface) FindHostsInPool(poolID string) ([]host.Host, error) {
 	return r0, r1
 }
 
+// GetAPITokens provides a mock function with given fields: 
+func (_m *ClientInterface) GetAPITokens() ([]apitoken.APIToken, error) {
+	ret := _m.Called()
+
+	var r0 []apitoken.APIToken
+	if rf, ok := ret.Get(0).(func() []apitoken.APIToken); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]apitoken.APIToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetActiveHostIDs provides a mock function with given fields:
 func (_m *ClientInterface) GetActiveHostIDs() ([]string, error) {
 	ret := _m.Called()
@@ -423,6 +507,29 @@ func (_m *ClientInterface) GetAllServiceDetails(since time.Duration) ([]service.
 	return r0, r1
 }
 
+// GetAuditEvents provides a mock function with given fields: query
+func (_m *ClientInterface) GetAuditEvents(query auditlog.Query) ([]auditlog.Event, error) {
+	ret := _m.Called(query)
+
+	var r0 []auditlog.Event
+	if rf, ok := ret.Get(0).(func(auditlog.Query) []auditlog.Event); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auditlog.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(auditlog.Query) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEvaluatedService provides a mock function with given fields: serviceID, instanceID
 func (_m *ClientInterface) GetEvaluatedService(serviceID string, instanceID int) (*service.Service, string, string, error) {
 	ret := _m.Called(serviceID, instanceID)
@@ -552,6 +659,52 @@ func (_m *ClientInterface) GetISvcsHealth(IServiceNames []string) ([]isvcs.IServ
 	return r0, r1
 }
 
+// GetImageUpgradeStatus provides a mock function with given fields: serviceID
+func (_m *ClientInterface) GetImageUpgradeStatus(serviceID string) (*service.ImageUpgradeStatus, error) {
+	ret := _m.Called(serviceID)
+
+	var r0 *service.ImageUpgradeStatus
+	if rf, ok := ret.Get(0).(func(string) *service.ImageUpgradeStatus); ok {
+		r0 = rf(serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ImageUpgradeStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPlacementDecisions provides a mock function with given fields: serviceID
+func (_m *ClientInterface) GetPlacementDecisions(serviceID string) ([]service.PlacementDecision, error) {
+	ret := _m.Called(serviceID)
+
+	var r0 []service.PlacementDecision
+	if rf, ok := ret.Get(0).(func(string) []service.PlacementDecision); ok {
+		r0 = rf(serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.PlacementDecision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPoolIPs provides a mock function with given fields: poolID
 func (_m *ClientInterface) GetPoolIPs(poolID string) (*pool.PoolIPs, error) {
 	ret := _m.Called(poolID)
@@ -575,6 +728,29 @@ func (_m *ClientInterface) GetPoolIPs(poolID string) (*pool.PoolIPs, error) {
 	return r0, r1
 }
 
+// GetPoolUsage provides a mock function with given fields: poolID
+func (_m *ClientInterface) GetPoolUsage(poolID string) (*pool.PoolUsage, error) {
+	ret := _m.Called(poolID)
+
+	var r0 *pool.PoolUsage
+	if rf, ok := ret.Get(0).(func(string) *pool.PoolUsage); ok {
+		r0 = rf(poolID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.PoolUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(poolID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetResourcePool provides a mock function with given fields: poolID
 func (_m *ClientInterface) GetResourcePool(poolID string) (*pool.ResourcePool, error) {
 	ret := _m.Called(poolID)
@@ -621,6 +797,29 @@ func (_m *ClientInterface) GetResourcePools() ([]pool.ResourcePool, error) {
 	return r0, r1
 }
 
+// GetRollingRestartStatus provides a mock function with given fields: serviceID
+func (_m *ClientInterface) GetRollingRestartStatus(serviceID string) (*service.RollingRestartStatus, error) {
+	ret := _m.Called(serviceID)
+
+	var r0 *service.RollingRestartStatus
+	if rf, ok := ret.Get(0).(func(string) *service.RollingRestartStatus); ok {
+		r0 = rf(serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.RollingRestartStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetServiceDetails provides a mock function with given fields: serviceID
 func (_m *ClientInterface) GetServiceDetails(serviceID string) (*service.ServiceDetails, error) {
 	ret := _m.Called(serviceID)
@@ -690,6 +889,29 @@ func (_m *ClientInterface) GetServiceEndpoints(serviceIDs []string, reportImport
 	return r0, r1
 }
 
+// GetServiceHealthHistory provides a mock function with given fields: serviceID
+func (_m *ClientInterface) GetServiceHealthHistory(serviceID string) (map[int]map[string]health.HealthCheckHistory, error) {
+	ret := _m.Called(serviceID)
+
+	var r0 map[int]map[string]health.HealthCheckHistory
+	if rf, ok := ret.Get(0).(func(string) map[int]map[string]health.HealthCheckHistory); ok {
+		r0 = rf(serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]map[string]health.HealthCheckHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetServiceInstances provides a mock function with given fields: serviceID
 func (_m *ClientInterface) GetServiceInstances(serviceID string) ([]service.Instance, error) {
 	ret := _m.Called(serviceID)
@@ -713,6 +935,52 @@ func (_m *ClientInterface) GetServiceInstances(serviceID string) ([]service.Inst
 	return r0, r1
 }
 
+// GetServiceRevision provides a mock function with given fields: serviceID, revision
+func (_m *ClientInterface) GetServiceRevision(serviceID string, revision int) (*servicerevision.Revision, error) {
+	ret := _m.Called(serviceID, revision)
+
+	var r0 *servicerevision.Revision
+	if rf, ok := ret.Get(0).(func(string, int) *servicerevision.Revision); ok {
+		r0 = rf(serviceID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*servicerevision.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(serviceID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceRevisions provides a mock function with given fields: serviceID
+func (_m *ClientInterface) GetServiceRevisions(serviceID string) ([]servicerevision.Revision, error) {
+	ret := _m.Called(serviceID)
+
+	var r0 []servicerevision.Revision
+	if rf, ok := ret.Get(0).(func(string) []servicerevision.Revision); ok {
+		r0 = rf(serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]servicerevision.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetServiceTemplates provides a mock function with given fields:
 func (_m *ClientInterface) GetServiceTemplates() (map[string]servicetemplate.ServiceTemplate, error) {
 	ret := _m.Called()
@@ -759,6 +1027,52 @@ func (_m *ClientInterface) GetServicesHealth() (map[string]map[int]map[string]he
 	return r0, r1
 }
 
+// GetSnapshotSchedule provides a mock function with given fields: tenantID
+func (_m *ClientInterface) GetSnapshotSchedule(tenantID string) (*snapshotschedule.Schedule, error) {
+	ret := _m.Called(tenantID)
+
+	var r0 *snapshotschedule.Schedule
+	if rf, ok := ret.Get(0).(func(string) *snapshotschedule.Schedule); ok {
+		r0 = rf(tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*snapshotschedule.Schedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSnapshotSchedules provides a mock function with given fields: 
+func (_m *ClientInterface) GetSnapshotSchedules() ([]snapshotschedule.Schedule, error) {
+	ret := _m.Called()
+
+	var r0 []snapshotschedule.Schedule
+	if rf, ok := ret.Get(0).(func() []snapshotschedule.Schedule); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]snapshotschedule.Schedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSystemUser provides a mock function with given fields:
 func (_m *ClientInterface) GetSystemUser() (user.User, error) {
 	ret := _m.Called()
@@ -801,6 +1115,50 @@ func (_m *ClientInterface) GetTenantID(serviceID string) (string, error) {
 	return r0, r1
 }
 
+// GetUserRole provides a mock function with given fields: userName
+func (_m *ClientInterface) GetUserRole(userName string) (auth.Role, error) {
+	ret := _m.Called(userName)
+
+	var r0 auth.Role
+	if rf, ok := ret.Get(0).(func(string) auth.Role); ok {
+		r0 = rf(userName)
+	} else {
+		r0 = ret.Get(0).(auth.Role)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsers provides a mock function with given fields: 
+func (_m *ClientInterface) GetUsers() ([]user.User, error) {
+	ret := _m.Called()
+
+	var r0 []user.User
+	if rf, ok := ret.Get(0).(func() []user.User); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVolumeStatus provides a mock function with given fields:
 func (_m *ClientInterface) GetVolumeStatus() (*volume.Statuses, error) {
 	ret := _m.Called()
@@ -870,6 +1228,89 @@ func (_m *ClientInterface) LocateServiceInstance(serviceID string, instanceID in
 	return r0, r1
 }
 
+// PlanServiceUpdate provides a mock function with given fields: svc
+func (_m *ClientInterface) PlanServiceUpdate(svc service.Service) (*service.ChangePlan, error) {
+	ret := _m.Called(svc)
+
+	var r0 *service.ChangePlan
+	if rf, ok := ret.Get(0).(func(service.Service) *service.ChangePlan); ok {
+		r0 = rf(svc)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ChangePlan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(service.Service) error); ok {
+		r1 = rf(svc)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanTemplateDeploy provides a mock function with given fields: request
+func (_m *ClientInterface) PlanTemplateDeploy(request servicetemplate.ServiceTemplateDeploymentRequest) (*service.ChangePlan, error) {
+	ret := _m.Called(request)
+
+	var r0 *service.ChangePlan
+	if rf, ok := ret.Get(0).(func(servicetemplate.ServiceTemplateDeploymentRequest) *service.ChangePlan); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ChangePlan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(servicetemplate.ServiceTemplateDeploymentRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RebalancePool provides a mock function with given fields: poolID, limit, dryRun
+func (_m *ClientInterface) RebalancePool(poolID string, limit int, dryRun bool) ([]service.Migration, error) {
+	ret := _m.Called(poolID, limit, dryRun)
+
+	var r0 []service.Migration
+	if rf, ok := ret.Get(0).(func(string, int, bool) []service.Migration); ok {
+		r0 = rf(poolID, limit, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.Migration)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, bool) error); ok {
+		r1 = rf(poolID, limit, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveAPIToken provides a mock function with given fields: id
+func (_m *ClientInterface) RemoveAPIToken(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveHost provides a mock function with given fields: hostID
 func (_m *ClientInterface) RemoveHost(hostID string) error {
 	ret := _m.Called(hostID)
@@ -940,6 +1381,20 @@ func (_m *ClientInterface) RemoveServiceTemplate(serviceTemplateID string) error
 	return r0
 }
 
+// RemoveSnapshotSchedule provides a mock function with given fields: tenantID
+func (_m *ClientInterface) RemoveSnapshotSchedule(tenantID string) error {
+	ret := _m.Called(tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveVirtualIP provides a mock function with given fields: requestVirtualIP
 func (_m *ClientInterface) RemoveVirtualIP(requestVirtualIP pool.VirtualIP) error {
 	ret := _m.Called(requestVirtualIP)
@@ -1042,6 +1497,71 @@ func (_m *ClientInterface) ResolveServicePath(path string, noprefix bool) ([]ser
 	return r0, r1
 }
 
+// ResumeImageUpgrade provides a mock function with given fields: serviceID
+func (_m *ClientInterface) ResumeImageUpgrade(serviceID string) error {
+	ret := _m.Called(serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResumeRollingRestart provides a mock function with given fields: serviceID
+func (_m *ClientInterface) ResumeRollingRestart(serviceID string) error {
+	ret := _m.Called(serviceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(serviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevertService provides a mock function with given fields: serviceID, revision
+func (_m *ClientInterface) RevertService(serviceID string, revision int) error {
+	ret := _m.Called(serviceID, revision)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(serviceID, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RollingRestartService provides a mock function with given fields: request
+func (_m *ClientInterface) RollingRestartService(request service.RollingRestartRequest) (*service.RollingRestartStatus, error) {
+	ret := _m.Called(request)
+
+	var r0 *service.RollingRestartStatus
+	if rf, ok := ret.Get(0).(func(service.RollingRestartRequest) *service.RollingRestartStatus); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.RollingRestartStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(service.RollingRestartRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendDockerAction provides a mock function with given fields: serviceID, instanceID, action, args
 func (_m *ClientInterface) SendDockerAction(serviceID string, instanceID int, action string, args []string) error {
 	ret := _m.Called(serviceID, instanceID, action, args)
@@ -1077,6 +1597,48 @@ func (_m *ClientInterface) ServiceUse(serviceID string, imageID string, registry
 	return r0, r1
 }
 
+// SetSnapshotSchedule provides a mock function with given fields: sched
+func (_m *ClientInterface) SetSnapshotSchedule(sched snapshotschedule.Schedule) error {
+	ret := _m.Called(sched)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(snapshotschedule.Schedule) error); ok {
+		r0 = rf(sched)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserRole provides a mock function with given fields: userName, role
+func (_m *ClientInterface) SetUserRole(userName string, role auth.Role) error {
+	ret := _m.Called(userName, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, auth.Role) error); ok {
+		r0 = rf(userName, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserTenants provides a mock function with given fields: userName, tenantIDs
+func (_m *ClientInterface) SetUserTenants(userName string, tenantIDs []string) error {
+	ret := _m.Called(userName, tenantIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(userName, tenantIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StopServiceInstance provides a mock function with given fields: serviceID, instanceID
 func (_m *ClientInterface) StopServiceInstance(serviceID string, instanceID int) error {
 	ret := _m.Called(serviceID, instanceID)
@@ -1147,6 +1709,29 @@ func (_m *ClientInterface) UpgradeRegistry(endpoint string, override bool) error
 	return r0
 }
 
+// UpgradeServiceImage provides a mock function with given fields: request
+func (_m *ClientInterface) UpgradeServiceImage(request service.ImageUpgradeRequest) (*service.ImageUpgradeStatus, error) {
+	ret := _m.Called(request)
+
+	var r0 *service.ImageUpgradeStatus
+	if rf, ok := ret.Get(0).(func(service.ImageUpgradeRequest) *service.ImageUpgradeStatus); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.ImageUpgradeStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(service.ImageUpgradeRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateCredentials provides a mock function with given fields: _a0
 func (_m *ClientInterface) ValidateCredentials(_a0 user.User) (bool, error) {
 	ret := _m.Called(_a0)
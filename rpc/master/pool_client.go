@@ -15,6 +15,7 @@ package master
 
 import (
 	"github.com/control-center/serviced/domain/pool"
+	"github.com/control-center/serviced/domain/service"
 )
 
 //GetResourcePool gets the pool for the given poolID or nil
@@ -68,3 +69,22 @@ func (c *Client) AddVirtualIP(requestVirtualIP pool.VirtualIP) error {
 func (c *Client) RemoveVirtualIP(requestVirtualIP pool.VirtualIP) error {
 	return c.call("RemoveVirtualIP", requestVirtualIP, nil)
 }
+
+// RebalancePool migrates up to limit service instances to even out the commitment of the hosts in a pool
+func (c *Client) RebalancePool(poolID string, limit int, dryRun bool) ([]service.Migration, error) {
+	migrations := []service.Migration{}
+	request := RebalancePoolRequest{PoolID: poolID, Limit: limit, DryRun: dryRun}
+	if err := c.call("RebalancePool", request, &migrations); err != nil {
+		return nil, err
+	}
+	return migrations, nil
+}
+
+// GetPoolUsage returns the resources committed to a pool against its capacity and limits
+func (c *Client) GetPoolUsage(poolID string) (*pool.PoolUsage, error) {
+	response := &pool.PoolUsage{}
+	if err := c.call("GetPoolUsage", poolID, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
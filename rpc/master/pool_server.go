@@ -17,8 +17,16 @@ import (
 	"errors"
 
 	"github.com/control-center/serviced/domain/pool"
+	"github.com/control-center/serviced/domain/service"
 )
 
+// RebalancePoolRequest is the request to rebalance the instances of a pool
+type RebalancePoolRequest struct {
+	PoolID string
+	Limit  int
+	DryRun bool
+}
+
 // GetResourcePools returns all ResourcePools
 func (s *Server) GetResourcePools(empty struct{}, poolsReply *[]pool.ResourcePool) error {
 	pools, err := s.f.GetResourcePools(s.context())
@@ -80,3 +88,23 @@ func (s *Server) AddVirtualIP(requestVirtualIP pool.VirtualIP, _ *struct{}) erro
 func (s *Server) RemoveVirtualIP(requestVirtualIP pool.VirtualIP, _ *struct{}) error {
 	return s.f.RemoveVirtualIP(s.context(), requestVirtualIP)
 }
+
+// RebalancePool migrates service instances to even out the commitment of the hosts in a pool
+func (s *Server) RebalancePool(request RebalancePoolRequest, reply *[]service.Migration) error {
+	migrations, err := s.f.RebalancePool(s.context(), request.PoolID, request.Limit, request.DryRun)
+	if err != nil {
+		return err
+	}
+	*reply = migrations
+	return nil
+}
+
+// GetPoolUsage returns the resources committed to a pool against its capacity and limits
+func (s *Server) GetPoolUsage(poolID string, reply *pool.PoolUsage) error {
+	response, err := s.f.GetPoolUsage(s.context(), poolID)
+	if err != nil {
+		return err
+	}
+	*reply = *response
+	return nil
+}
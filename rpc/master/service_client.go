@@ -133,3 +133,77 @@ func (c *Client) RemoveIPs(args []string) error {
 func (c *Client) SetIPs(r addressassignment.AssignmentRequest) error {
 	return c.call("SetIPs", r, new(string))
 }
+
+// PlanServiceUpdate returns the changes that updating a service would make
+func (c *Client) PlanServiceUpdate(svc service.Service) (*service.ChangePlan, error) {
+	plan := &service.ChangePlan{}
+	if err := c.call("PlanServiceUpdate", svc, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// RollingRestartService restarts the running instances of a service in health-gated batches
+func (c *Client) RollingRestartService(request service.RollingRestartRequest) (*service.RollingRestartStatus, error) {
+	status := &service.RollingRestartStatus{}
+	if err := c.call("RollingRestartService", request, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetRollingRestartStatus returns the progress of the most recent rolling restart of a service
+func (c *Client) GetRollingRestartStatus(serviceID string) (*service.RollingRestartStatus, error) {
+	status := &service.RollingRestartStatus{}
+	if err := c.call("GetRollingRestartStatus", serviceID, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// ResumeRollingRestart continues a paused rolling restart
+func (c *Client) ResumeRollingRestart(serviceID string) error {
+	return c.call("ResumeRollingRestart", serviceID, new(string))
+}
+
+// CancelRollingRestart stops a rolling restart before its next batch
+func (c *Client) CancelRollingRestart(serviceID string) error {
+	return c.call("CancelRollingRestart", serviceID, new(string))
+}
+
+// UpgradeServiceImage changes the image of a service tree one instance at a time
+func (c *Client) UpgradeServiceImage(request service.ImageUpgradeRequest) (*service.ImageUpgradeStatus, error) {
+	status := &service.ImageUpgradeStatus{}
+	if err := c.call("UpgradeServiceImage", request, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetImageUpgradeStatus returns the progress of the most recent image upgrade of a service tree
+func (c *Client) GetImageUpgradeStatus(serviceID string) (*service.ImageUpgradeStatus, error) {
+	status := &service.ImageUpgradeStatus{}
+	if err := c.call("GetImageUpgradeStatus", serviceID, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// ResumeImageUpgrade continues a paused image upgrade
+func (c *Client) ResumeImageUpgrade(serviceID string) error {
+	return c.call("ResumeImageUpgrade", serviceID, new(string))
+}
+
+// AbortImageUpgrade stops an image upgrade and rolls back to the previous images
+func (c *Client) AbortImageUpgrade(serviceID string) error {
+	return c.call("AbortImageUpgrade", serviceID, new(string))
+}
+
+// GetPlacementDecisions returns how the scheduler chose a host for each instance of a service
+func (c *Client) GetPlacementDecisions(serviceID string) ([]service.PlacementDecision, error) {
+	decisions := []service.PlacementDecision{}
+	if err := c.call("GetPlacementDecisions", serviceID, &decisions); err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
@@ -166,3 +166,83 @@ func (s *Server) RemoveIPs(args []string, unused *string) error {
 func (s *Server) SetIPs(request addressassignment.AssignmentRequest, unused *string) error {
 	return s.f.SetIPs(s.context(), request)
 }
+
+// PlanServiceUpdate returns the changes that updating a service would make
+func (s *Server) PlanServiceUpdate(svc service.Service, plan *service.ChangePlan) error {
+	result, err := s.f.PlanServiceUpdate(s.context(), svc)
+	if err != nil {
+		return err
+	}
+	*plan = *result
+	return nil
+}
+
+// RollingRestartService restarts the running instances of a service in health-gated batches
+func (s *Server) RollingRestartService(request service.RollingRestartRequest, status *service.RollingRestartStatus) error {
+	result, err := s.f.RollingRestartService(s.context(), request)
+	if err != nil {
+		return err
+	}
+	*status = *result
+	return nil
+}
+
+// GetRollingRestartStatus returns the progress of the most recent rolling restart of a service
+func (s *Server) GetRollingRestartStatus(serviceID string, status *service.RollingRestartStatus) error {
+	result, err := s.f.GetRollingRestartStatus(s.context(), serviceID)
+	if err != nil {
+		return err
+	}
+	*status = *result
+	return nil
+}
+
+// ResumeRollingRestart continues a paused rolling restart
+func (s *Server) ResumeRollingRestart(serviceID string, unused *string) error {
+	return s.f.ResumeRollingRestart(s.context(), serviceID)
+}
+
+// CancelRollingRestart stops a rolling restart before its next batch
+func (s *Server) CancelRollingRestart(serviceID string, unused *string) error {
+	return s.f.CancelRollingRestart(s.context(), serviceID)
+}
+
+// UpgradeServiceImage changes the image of a service tree one instance at a time
+func (s *Server) UpgradeServiceImage(request service.ImageUpgradeRequest, status *service.ImageUpgradeStatus) error {
+	result, err := s.f.UpgradeServiceImage(s.context(), request)
+	if err != nil {
+		return err
+	}
+	*status = *result
+	return nil
+}
+
+// GetImageUpgradeStatus returns the progress of the most recent image upgrade of a service tree
+func (s *Server) GetImageUpgradeStatus(serviceID string, status *service.ImageUpgradeStatus) error {
+	result, err := s.f.GetImageUpgradeStatus(s.context(), serviceID)
+	if err != nil {
+		return err
+	}
+	*status = *result
+	return nil
+}
+
+// ResumeImageUpgrade continues a paused image upgrade
+func (s *Server) ResumeImageUpgrade(serviceID string, unused *string) error {
+	return s.f.ResumeImageUpgrade(s.context(), serviceID)
+}
+
+// AbortImageUpgrade stops an image upgrade and rolls back to the previous images
+func (s *Server) AbortImageUpgrade(serviceID string, unused *string) error {
+	return s.f.AbortImageUpgrade(s.context(), serviceID)
+}
+
+// GetPlacementDecisions returns how the scheduler chose a host for each instance of a service
+func (s *Server) GetPlacementDecisions(serviceID string, decisions *[]service.PlacementDecision) error {
+	result, err := s.f.GetPlacementDecisions(s.context(), serviceID)
+	if err != nil {
+		return err
+	}
+	*decisions = result
+	return nil
+}
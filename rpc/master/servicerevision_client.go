@@ -0,0 +1,47 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"github.com/control-center/serviced/domain/servicerevision"
+)
+
+// GetServiceRevisions returns the stored revisions of a service, most recent first
+func (c *Client) GetServiceRevisions(serviceID string) ([]servicerevision.Revision, error) {
+	revisions := []servicerevision.Revision{}
+	err := c.call("GetServiceRevisions", serviceID, &revisions)
+	return revisions, err
+}
+
+// GetServiceRevision returns a revision of a service
+func (c *Client) GetServiceRevision(serviceID string, revision int) (*servicerevision.Revision, error) {
+	req := ServiceRevisionRequest{
+		ServiceID: serviceID,
+		Revision:  revision,
+	}
+	rev := &servicerevision.Revision{}
+	if err := c.call("GetServiceRevision", req, rev); err != nil {
+		return nil, err
+	}
+	return rev, nil
+}
+
+// RevertService updates a service to the definition stored in one of its revisions
+func (c *Client) RevertService(serviceID string, revision int) error {
+	req := ServiceRevisionRequest{
+		ServiceID: serviceID,
+		Revision:  revision,
+	}
+	return c.call("RevertService", req, new(string))
+}
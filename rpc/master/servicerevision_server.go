@@ -0,0 +1,49 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"github.com/control-center/serviced/domain/servicerevision"
+)
+
+// ServiceRevisionRequest identifies a revision of a service
+type ServiceRevisionRequest struct {
+	ServiceID string
+	Revision  int
+}
+
+// GetServiceRevisions returns the stored revisions of a service, most recent first
+func (s *Server) GetServiceRevisions(serviceID string, revisions *[]servicerevision.Revision) error {
+	result, err := s.f.GetServiceRevisions(s.context(), serviceID)
+	if err != nil {
+		return err
+	}
+	*revisions = result
+	return nil
+}
+
+// GetServiceRevision returns a revision of a service
+func (s *Server) GetServiceRevision(request ServiceRevisionRequest, revision *servicerevision.Revision) error {
+	result, err := s.f.GetServiceRevision(s.context(), request.ServiceID, request.Revision)
+	if err != nil {
+		return err
+	}
+	*revision = *result
+	return nil
+}
+
+// RevertService updates a service to the definition stored in one of its revisions
+func (s *Server) RevertService(request ServiceRevisionRequest, unused *string) error {
+	return s.f.RevertService(s.context(), request.ServiceID, request.Revision)
+}
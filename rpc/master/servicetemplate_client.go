@@ -14,6 +14,7 @@
 package master
 
 import (
+	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/servicetemplate"
 )
 
@@ -54,3 +55,11 @@ func (c *Client) DeployTemplate(request servicetemplate.ServiceTemplateDeploymen
 
 }
 
+// PlanTemplateDeploy returns the services that deploying an application template would create
+func (c *Client) PlanTemplateDeploy(request servicetemplate.ServiceTemplateDeploymentRequest) (*service.ChangePlan, error) {
+	plan := &service.ChangePlan{}
+	if err := c.call("PlanTemplateDeploy", request, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
@@ -14,6 +14,7 @@
 package master
 
 import (
+	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/domain/servicetemplate"
 )
 
@@ -52,3 +53,13 @@ func (s *Server) DeployTemplate(request servicetemplate.ServiceTemplateDeploymen
 	*response = tenantIDs
 	return nil
 }
+
+// PlanTemplateDeploy returns the services that deploying an application template would create
+func (s *Server) PlanTemplateDeploy(request servicetemplate.ServiceTemplateDeploymentRequest, plan *service.ChangePlan) error {
+	result, err := s.f.PlanTemplateDeploy(s.context(), request.PoolID, request.TemplateID, request.DeploymentID)
+	if err != nil {
+		return err
+	}
+	*plan = *result
+	return nil
+}
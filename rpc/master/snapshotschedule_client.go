@@ -0,0 +1,44 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"github.com/control-center/serviced/domain/snapshotschedule"
+)
+
+// GetSnapshotSchedules returns the snapshot schedules of all tenants
+func (c *Client) GetSnapshotSchedules() ([]snapshotschedule.Schedule, error) {
+	schedules := []snapshotschedule.Schedule{}
+	err := c.call("GetSnapshotSchedules", empty, &schedules)
+	return schedules, err
+}
+
+// GetSnapshotSchedule returns the snapshot schedule of a tenant
+func (c *Client) GetSnapshotSchedule(tenantID string) (*snapshotschedule.Schedule, error) {
+	schedule := &snapshotschedule.Schedule{}
+	if err := c.call("GetSnapshotSchedule", tenantID, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// SetSnapshotSchedule adds or replaces the snapshot schedule of a tenant
+func (c *Client) SetSnapshotSchedule(schedule snapshotschedule.Schedule) error {
+	return c.call("SetSnapshotSchedule", schedule, new(string))
+}
+
+// RemoveSnapshotSchedule removes the snapshot schedule of a tenant
+func (c *Client) RemoveSnapshotSchedule(tenantID string) error {
+	return c.call("RemoveSnapshotSchedule", tenantID, new(string))
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"github.com/control-center/serviced/domain/snapshotschedule"
+)
+
+// GetSnapshotSchedules returns the snapshot schedules of all tenants
+func (s *Server) GetSnapshotSchedules(unused struct{}, schedules *[]snapshotschedule.Schedule) error {
+	result, err := s.f.GetSnapshotSchedules(s.context())
+	if err != nil {
+		return err
+	}
+	*schedules = result
+	return nil
+}
+
+// GetSnapshotSchedule returns the snapshot schedule of a tenant
+func (s *Server) GetSnapshotSchedule(tenantID string, schedule *snapshotschedule.Schedule) error {
+	result, err := s.f.GetSnapshotSchedule(s.context(), tenantID)
+	if err != nil {
+		return err
+	}
+	*schedule = *result
+	return nil
+}
+
+// SetSnapshotSchedule adds or replaces the snapshot schedule of a tenant
+func (s *Server) SetSnapshotSchedule(schedule snapshotschedule.Schedule, unused *string) error {
+	return s.f.SetSnapshotSchedule(s.context(), schedule)
+}
+
+// RemoveSnapshotSchedule removes the snapshot schedule of a tenant
+func (s *Server) RemoveSnapshotSchedule(tenantID string, unused *string) error {
+	return s.f.RemoveSnapshotSchedule(s.context(), tenantID)
+}
@@ -14,6 +14,7 @@
 package master
 
 import (
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/domain/user"
 )
 
@@ -30,3 +31,29 @@ func (c *Client) ValidateCredentials(user user.User) (bool, error) {
 	err := c.call("ValidateCredentials", user, &result)
 	return result, err
 }
+
+// GetUsers returns all of the stored user records
+func (c *Client) GetUsers() ([]user.User, error) {
+	users := []user.User{}
+	err := c.call("GetUsers", empty, &users)
+	return users, err
+}
+
+// GetUserRole returns the role explicitly granted to a user
+func (c *Client) GetUserRole(userName string) (auth.Role, error) {
+	var role auth.Role
+	err := c.call("GetUserRole", userName, &role)
+	return role, err
+}
+
+// SetUserRole grants a role to a user
+func (c *Client) SetUserRole(userName string, role auth.Role) error {
+	request := UserRoleRequest{UserName: userName, Role: role}
+	return c.call("SetUserRole", request, nil)
+}
+
+// SetUserTenants restricts a user to the given tenants
+func (c *Client) SetUserTenants(userName string, tenantIDs []string) error {
+	request := UserTenantsRequest{UserName: userName, TenantIDs: tenantIDs}
+	return c.call("SetUserTenants", request, nil)
+}
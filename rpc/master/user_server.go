@@ -14,9 +14,22 @@
 package master
 
 import (
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/domain/user"
 )
 
+// UserRoleRequest is the request for setting the role of a user
+type UserRoleRequest struct {
+	UserName string
+	Role     auth.Role
+}
+
+// UserTenantsRequest is the request for restricting a user to tenants
+type UserTenantsRequest struct {
+	UserName  string
+	TenantIDs []string
+}
+
 // Get the system user
 func (s *Server) GetSystemUser(unused struct{}, systemUser *user.User) error {
 	result, err := s.f.GetSystemUser(s.context())
@@ -36,3 +49,37 @@ func (s *Server) ValidateCredentials(someUser user.User, valid *bool) error {
 	*valid = result
 	return nil
 }
+
+// GetUsers returns all of the stored user records
+func (s *Server) GetUsers(unused struct{}, users *[]user.User) error {
+	result, err := s.f.GetUsers(s.context())
+	if err != nil {
+		return err
+	}
+	// never send password hashes over the wire
+	for i := range result {
+		result[i].Password = ""
+	}
+	*users = result
+	return nil
+}
+
+// GetUserRole returns the role explicitly granted to a user
+func (s *Server) GetUserRole(userName string, role *auth.Role) error {
+	result, err := s.f.GetUserRole(s.context(), userName)
+	if err != nil {
+		return err
+	}
+	*role = result
+	return nil
+}
+
+// SetUserRole grants a role to a user
+func (s *Server) SetUserRole(request UserRoleRequest, _ *struct{}) error {
+	return s.f.SetUserRole(s.context(), request.UserName, request.Role)
+}
+
+// SetUserTenants restricts a user to the given tenants
+func (s *Server) SetUserTenants(request UserTenantsRequest, _ *struct{}) error {
+	return s.f.SetUserTenants(s.context(), request.UserName, request.TenantIDs)
+}
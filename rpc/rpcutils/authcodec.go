@@ -52,10 +52,71 @@ var (
 		"ControlCenterAgent.SendLogMessage":      struct{}{},
 		"ControlCenterAgent.AddHostPrivate":      struct{}{},
 	}
+	// RPC calls that may be made by identities with a role below cluster-admin.
+	// Calls that are not listed here or in NonAdminRequiredCalls require admin
+	// access.
+	RoleRequiredCalls = map[string]auth.Role{
+		"Master.FindHostsInPool":             auth.RoleViewer,
+		"Master.GetActiveHostIDs":            auth.RoleViewer,
+		"Master.GetAllPublicEndpoints":       auth.RoleViewer,
+		"Master.GetAllServiceDetails":        auth.RoleViewer,
+		"Master.GetHostPublicKey":            auth.RoleViewer,
+		"Master.GetImageUpgradeStatus":       auth.RoleViewer,
+		"Master.GetISvcsHealth":              auth.RoleViewer,
+		"Master.GetPlacementDecisions":       auth.RoleViewer,
+		"Master.GetPoolIPs":                  auth.RoleViewer,
+		"Master.GetResourcePool":             auth.RoleViewer,
+		"Master.GetResourcePools":            auth.RoleViewer,
+		"Master.GetRollingRestartStatus":     auth.RoleViewer,
+		"Master.GetService":                  auth.RoleViewer,
+		"Master.GetServiceDetails":           auth.RoleViewer,
+		"Master.GetServiceDetailsByTenantID": auth.RoleViewer,
+		"Master.GetServiceEndpoints":         auth.RoleViewer,
+		"Master.GetServiceHealthHistory":     auth.RoleViewer,
+		"Master.GetServiceInstances":         auth.RoleViewer,
+		"Master.GetServiceRevision":          auth.RoleViewer,
+		"Master.GetServiceRevisions":         auth.RoleViewer,
+		"Master.GetServiceTemplates":         auth.RoleViewer,
+		"Master.GetServicesHealth":           auth.RoleViewer,
+		"Master.GetSnapshotSchedule":         auth.RoleViewer,
+		"Master.GetSnapshotSchedules":        auth.RoleViewer,
+		"Master.GetTenantID":                 auth.RoleViewer,
+		"Master.GetVolumeStatus":             auth.RoleViewer,
+		"Master.HostsAuthenticated":          auth.RoleViewer,
+		"Master.LocateServiceInstance":       auth.RoleViewer,
+		"Master.ResolveServicePath":          auth.RoleViewer,
+		"Master.WaitService":                 auth.RoleViewer,
+		"Master.CancelRollingRestart":        auth.RoleOperator,
+		"Master.ClearEmergency":              auth.RoleOperator,
+		"Master.ResumeRollingRestart":        auth.RoleOperator,
+		"Master.RollingRestartService":       auth.RoleOperator,
+		"Master.SendDockerAction":            auth.RoleOperator,
+		"Master.StopServiceInstance":         auth.RoleOperator,
+		"Master.AbortImageUpgrade":           auth.RoleTenantAdmin,
+		"Master.AddPublicEndpointPort":       auth.RoleTenantAdmin,
+		"Master.AddPublicEndpointVHost":      auth.RoleTenantAdmin,
+		"Master.DeployTemplate":              auth.RoleTenantAdmin,
+		"Master.EnablePublicEndpointPort":    auth.RoleTenantAdmin,
+		"Master.EnablePublicEndpointVHost":   auth.RoleTenantAdmin,
+		"Master.PlanServiceUpdate":           auth.RoleTenantAdmin,
+		"Master.PlanTemplateDeploy":          auth.RoleTenantAdmin,
+		"Master.RemoveIPs":                   auth.RoleTenantAdmin,
+		"Master.RemovePublicEndpointPort":    auth.RoleTenantAdmin,
+		"Master.RemovePublicEndpointVHost":   auth.RoleTenantAdmin,
+		"Master.RemoveSnapshotSchedule":      auth.RoleTenantAdmin,
+		"Master.ResumeImageUpgrade":          auth.RoleTenantAdmin,
+		"Master.RevertService":               auth.RoleTenantAdmin,
+		"Master.ServiceUse":                  auth.RoleTenantAdmin,
+		"Master.SetIPs":                      auth.RoleTenantAdmin,
+		"Master.SetSnapshotSchedule":         auth.RoleTenantAdmin,
+		"Master.UpgradeServiceImage":         auth.RoleTenantAdmin,
+	}
 	endian = binary.BigEndian
 
 	ErrNoAdmin = errors.New("Delegate does not have admin access")
 
+	ErrInsufficientRole = errors.New("Delegate does not have the role required for this call")
+
 	log = logging.PackageLogger()
 )
 
@@ -78,6 +139,13 @@ func requiresAdmin(callName string) bool {
 	return !ok
 }
 
+// Checks the RPC method name to see if it may be called by an identity with a
+//  role below cluster-admin, and returns the role it requires.
+func requiredRole(callName string) (auth.Role, bool) {
+	role, ok := RoleRequiredCalls[callName]
+	return role, ok
+}
+
 // We nead a ReadWriteCloser that we can pass to the underlying codec and use
 //  To buffer requests and responses from the actual connection
 type ByteBufferReadWriteCloser struct {
@@ -166,7 +234,12 @@ func (a *AuthServerCodec) ReadRequestHeader(r *rpc.Request) error {
 	//   (unless ReadRequestHeader returns an error)
 	if requiresAuthentication(r.ServiceMethod) {
 		if a.lastError == nil {
-			if requiresAdmin(r.ServiceMethod) && (ident == nil || !ident.HasAdminAccess()) {
+			if role, ok := requiredRole(r.ServiceMethod); ok {
+				if ident == nil || !ident.Role().Satisfies(role) {
+					log.WithField("ServiceMethod", r.ServiceMethod).WithField("role", role).Debug("Received unauthorized RPC request")
+					a.lastError = ErrInsufficientRole
+				}
+			} else if requiresAdmin(r.ServiceMethod) && (ident == nil || !ident.HasAdminAccess()) {
 				log.WithField("ServiceMethod", r.ServiceMethod).Debug("Received unauthorized RPC request")
 				a.lastError = ErrNoAdmin
 			}
@@ -133,6 +133,26 @@ func (s *MySuite) TestReadRequestHeader(c *C) {
 	err = codectest.authServerCodec.ReadRequestHeader(req)
 	c.Assert(err, IsNil)
 	codectest.conn.AssertExpectations(c)
+
+	// Test error with a role required method and an insufficient role
+	req = &rpc.Request{ServiceMethod: "Master.DeployTemplate"}
+	codectest.wrappedServerCodec.On("ReadRequestHeader", req).Return(nil).Once()
+	codectest.headerParser.On("ReadHeader", codectest.conn).Return(ident, body, nil).Once()
+	ident.On("Role").Return(auth.RoleViewer).Once()
+	err = codectest.authServerCodec.ReadRequestHeader(req)
+	// Error won't come through until we call ReadRequestBody
+	c.Assert(err, IsNil)
+	err = codectest.authServerCodec.ReadRequestBody(&b)
+	c.Assert(err, Equals, ErrInsufficientRole)
+	codectest.conn.AssertExpectations(c)
+
+	// Test success with a role required method and a sufficient role
+	codectest.wrappedServerCodec.On("ReadRequestHeader", req).Return(nil).Once()
+	codectest.headerParser.On("ReadHeader", codectest.conn).Return(ident, body, nil).Once()
+	ident.On("Role").Return(auth.RoleClusterAdmin).Once()
+	err = codectest.authServerCodec.ReadRequestHeader(req)
+	c.Assert(err, IsNil)
+	codectest.conn.AssertExpectations(c)
 }
 
 func (s *MySuite) TestReadRequestBody(c *C) {
@@ -300,3 +320,11 @@ func (s *MySuite) TestRequiresAdmin(c *C) {
 	result = requiresAdmin("RPCTestType.AdminRequiredCall")
 	c.Assert(result, Equals, true)
 }
+
+func (s *MySuite) TestRequiredRole(c *C) {
+	role, ok := requiredRole("Master.GetService")
+	c.Assert(ok, Equals, true)
+	c.Assert(role, Equals, auth.RoleViewer)
+	_, ok = requiredRole("RPCTestType.NonAdminRequiredCall")
+	c.Assert(ok, Equals, false)
+}
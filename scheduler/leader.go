@@ -15,6 +15,8 @@ package scheduler
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/control-center/serviced/commons"
@@ -22,6 +24,7 @@ import (
 	"github.com/control-center/serviced/dao"
 	"github.com/control-center/serviced/datastore"
 	"github.com/control-center/serviced/domain/host"
+	"github.com/control-center/serviced/domain/service"
 	"github.com/control-center/serviced/facade"
 	"github.com/control-center/serviced/scheduler/strategy"
 	"github.com/control-center/serviced/zzk"
@@ -61,7 +64,26 @@ func Lead(shutdown <-chan interface{}, conn coordclient.Connection, cpClient dao
 // service has an address assignment the host will already be selected. If not
 // the host with the least amount of memory committed to running containers will
 // be chosen.  Returns the hostid, hostip (if it has an address assignment).
-func (l *leader) SelectHost(sn *zkservice.ServiceNode) (string, error) {
+// The decision is recorded so that it can be explained later.
+func (l *leader) SelectHost(sn *zkservice.ServiceNode, instanceID int) (string, error) {
+	decision := &service.PlacementDecision{
+		ServiceID:  sn.ID,
+		InstanceID: instanceID,
+		PoolID:     l.poolID,
+		Timestamp:  time.Now(),
+	}
+	hostID, err := l.selectHost(sn, decision)
+	decision.HostID = hostID
+	if err != nil {
+		decision.Message = err.Error()
+	} else if hostID == "" {
+		decision.Message = "no hosts available"
+	}
+	l.facade.RecordPlacementDecision(datastore.Get(), *decision)
+	return hostID, err
+}
+
+func (l *leader) selectHost(sn *zkservice.ServiceNode, decision *service.PlacementDecision) (string, error) {
 	logger := plog.WithFields(log.Fields{
 		"serviceid":   sn.ID,
 		"servicename": sn.Name,
@@ -138,6 +160,7 @@ func (l *leader) SelectHost(sn *zkservice.ServiceNode) (string, error) {
 		// is the host available?
 		for _, h := range hosts {
 			if h.ID == hostID {
+				decision.Message = fmt.Sprintf("host is assigned ip address %s", assignment.IPAddr)
 				return hostID, nil
 			}
 		}
@@ -146,12 +169,24 @@ func (l *leader) SelectHost(sn *zkservice.ServiceNode) (string, error) {
 		return "", errors.New("assigned ip is not available")
 	}
 
+	// an instance that is being migrated to rebalance the pool goes to the
+	// host that the rebalancer chose, if the host is still available
+	if hostID, ok := l.facade.GetMigrationTarget(datastore.Get(), sn.ID, decision.InstanceID); ok {
+		for _, h := range hosts {
+			if h.ID == hostID {
+				decision.Message = "instance is being migrated to rebalance the pool"
+				return hostID, nil
+			}
+		}
+		logger.WithField("hostid", hostID).Warn("Migration target is not available")
+	}
+
 	hp := sn.HostPolicy
 	strat, err := strategy.Get(string(hp))
 	if err != nil {
 		return "", err
 	}
 
-	return StrategySelectHost(sn, hosts, strat, l.facade)
+	return StrategySelectHost(sn, hosts, strat, l.facade, decision)
 }
 
@@ -18,14 +18,17 @@ import (
 	"sync"
 	"time"
 
+	"github.com/control-center/serviced/config"
 	coordclient "github.com/control-center/serviced/coordinator/client"
 	"github.com/control-center/serviced/coordinator/storage"
 	"github.com/control-center/serviced/dao"
 	"github.com/control-center/serviced/datastore"
 	imgreg "github.com/control-center/serviced/dfs/registry"
+	"github.com/control-center/serviced/dfs/schedule"
 	"github.com/control-center/serviced/dfs/ttl"
 	"github.com/control-center/serviced/facade"
 	"github.com/control-center/serviced/logging"
+	"github.com/control-center/serviced/utils"
 	"github.com/control-center/serviced/zzk"
 	"github.com/zenoss/glog"
 
@@ -201,6 +204,35 @@ func (s *scheduler) mainloop(conn coordclient.Connection) {
 		}()
 	}
 
+	// takes the snapshots of tenants that have a snapshot schedule
+	wg.Add(1)
+	go func() {
+		defer glog.Infof("Stopping snapshot schedules")
+		defer wg.Done()
+		schedule.RunSnapshotSchedules(s.cpDao, s.facade, config.GetOptions().SnapshotSpacePercent, _shutdown, time.Minute)
+	}()
+
+	// takes and prunes the scheduled backups of the master
+	if options := config.GetOptions(); options.BackupSchedule != "" {
+		if cron, err := utils.ParseCronSchedule(options.BackupSchedule); err != nil {
+			glog.Errorf("Could not parse backup schedule %q: %s", options.BackupSchedule, err)
+		} else {
+			backupSchedule := schedule.BackupSchedule{
+				Cron:                 cron,
+				Excludes:             options.BackupExcludes,
+				SnapshotSpacePercent: options.SnapshotSpacePercent,
+				KeepLast:             options.BackupKeepLast,
+				KeepDays:             options.BackupKeepDays,
+			}
+			wg.Add(1)
+			go func() {
+				defer glog.Infof("Stopping backup schedule")
+				defer wg.Done()
+				schedule.RunBackupSchedule(s.cpDao, s.facade, backupSchedule, _shutdown, time.Minute)
+			}()
+		}
+	}
+
 	// wait for something to happen
 	for {
 		select {
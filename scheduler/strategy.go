@@ -44,7 +44,9 @@ type StrategyService struct {
 	svc *zkservice.ServiceNode
 }
 
-func StrategySelectHost(sn *zkservice.ServiceNode, hosts []host.Host, strat strategy.Strategy, facade *facade.Facade) (string, error) {
+// StrategySelectHost applies the strategy to choose a host for the service,
+// and adds how each host was evaluated to the placement decision.
+func StrategySelectHost(sn *zkservice.ServiceNode, hosts []host.Host, strat strategy.Strategy, facade *facade.Facade, decision *service.PlacementDecision) (string, error) {
 
 	glog.V(2).Infof("Applying %s strategy for service %s", strat.Name(), sn.ID)
 
@@ -75,7 +77,14 @@ func StrategySelectHost(sn *zkservice.ServiceNode, hosts []host.Host, strat stra
 		glog.V(2).Infof("Host %s is running %d service instances", h.HostID(), len(h.services))
 		shosts = append(shosts, h)
 	}
-	if result, err := strat.SelectHost(&StrategyService{sn}, shosts); result == nil || err != nil {
+	svc := &StrategyService{sn}
+	decision.Strategy = strat.Name()
+
+	result, eval, err := strategy.SelectHost(strat, svc, shosts)
+	if eval != nil {
+		decision.Candidates = placementCandidates(eval)
+	}
+	if result == nil || err != nil {
 		return "", err
 	} else {
 		h := result.(*StrategyHost).host
@@ -84,6 +93,37 @@ func StrategySelectHost(sn *zkservice.ServiceNode, hosts []host.Host, strat stra
 	}
 }
 
+// placementCandidates describes each host of the evaluation, in the order the
+// hosts were scored.
+func placementCandidates(eval *strategy.Evaluation) []service.PlacementCandidate {
+	candidates := []service.PlacementCandidate{}
+	for _, scored := range eval.Undersubscribed {
+		candidates = append(candidates, scoredCandidate(scored, false))
+	}
+	for _, scored := range eval.Oversubscribed {
+		candidates = append(candidates, scoredCandidate(scored, true))
+	}
+	for _, excluded := range eval.Excluded {
+		candidates = append(candidates, service.PlacementCandidate{
+			HostID:   excluded.Host.HostID(),
+			Excluded: true,
+			Reason:   excluded.Reason,
+		})
+	}
+	return candidates
+}
+
+func scoredCandidate(scored *strategy.ScoredHost, oversubscribed bool) service.PlacementCandidate {
+	return service.PlacementCandidate{
+		HostID:         scored.Host.HostID(),
+		Score:          scored.Score,
+		CPUScore:       scored.CPUScore,
+		MemoryScore:    scored.MemoryScore,
+		Instances:      scored.NumInstances,
+		Oversubscribed: oversubscribed,
+	}
+}
+
 // Implement everything
 
 func (h *StrategyHost) HostID() string {
@@ -94,6 +134,10 @@ func (h *StrategyHost) RunningServices() []strategy.ServiceConfig {
 	return h.services
 }
 
+func (h *StrategyHost) Labels() map[string]string {
+	return h.host.Labels
+}
+
 func (h *StrategyHost) TotalCores() int {
 	return h.host.Cores
 }
@@ -106,6 +150,14 @@ func (s *StrategyService) GetServiceID() string {
 	return s.svc.ID
 }
 
+func (s *StrategyService) GetServiceName() string {
+	return s.svc.Name
+}
+
+func (s *StrategyService) GetDeploymentID() string {
+	return s.svc.DeploymentID
+}
+
 func (s *StrategyService) RequestedCorePercent() int {
 	return s.svc.CPUCommitment
 }
@@ -118,10 +170,26 @@ func (s *StrategyService) HostPolicy() servicedefinition.HostPolicy {
 	return s.svc.HostPolicy
 }
 
+func (s *StrategyService) HostLabels() *servicedefinition.LabelSelector {
+	return s.svc.HostLabels
+}
+
+func (s *StrategyService) Affinity() *servicedefinition.Affinity {
+	return s.svc.Affinity
+}
+
 func (s *StrategyRunningService) GetServiceID() string {
 	return s.svc.ServiceID
 }
 
+func (s *StrategyRunningService) GetServiceName() string {
+	return s.svc.ServiceName
+}
+
+func (s *StrategyRunningService) GetDeploymentID() string {
+	return s.svc.DeploymentID
+}
+
 func (s *StrategyRunningService) RequestedCorePercent() int {
 	return s.svc.CPUCommitment
 }
@@ -133,3 +201,11 @@ func (s *StrategyRunningService) RequestedMemoryBytes() uint64 {
 func (s *StrategyRunningService) HostPolicy() servicedefinition.HostPolicy {
 	return s.svc.HostPolicy
 }
+
+func (s *StrategyRunningService) HostLabels() *servicedefinition.LabelSelector {
+	return s.svc.HostLabels
+}
+
+func (s *StrategyRunningService) Affinity() *servicedefinition.Affinity {
+	return s.svc.Affinity
+}
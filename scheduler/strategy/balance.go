@@ -22,7 +22,13 @@ func (s *BalanceStrategy) Name() string {
 }
 
 func (s *BalanceStrategy) SelectHost(service ServiceConfig, hosts []Host) (Host, error) {
-	under, over := ScoreHosts(service, hosts)
+	host, _, err := s.SelectHostEvaluated(service, hosts)
+	return host, err
+}
+
+func (s *BalanceStrategy) SelectHostEvaluated(service ServiceConfig, hosts []Host) (Host, *Evaluation, error) {
+	eval := EvaluateHosts(service, hosts)
+	under, over := eval.Undersubscribed, eval.Oversubscribed
 
 	// Return the host with the greatest amount of free resources that can handle
 	// the service. In case of a tie, choose the one running fewer instances.
@@ -36,13 +42,13 @@ func (s *BalanceStrategy) SelectHost(service ServiceConfig, hosts []Host) (Host,
 				choice = scored
 			}
 		}
-		return choice.Host, nil
+		return choice.Host, eval, nil
 	}
 
 	// Return the host for which this service will least oversubscribe memory
 	if over != nil && len(over) > 0 {
-		return over[0].Host, nil
+		return over[0].Host, eval, nil
 	}
 
-	return nil, nil
+	return nil, eval, nil
 }
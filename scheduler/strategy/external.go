@@ -0,0 +1,209 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strategy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/zenoss/glog"
+)
+
+// ExternalTimeout is the time to wait for an external strategy to choose a
+// host.
+var ExternalTimeout = 10 * time.Second
+
+const unixScheme = "unix://"
+
+// ExternalService describes a service to an external strategy.
+type ExternalService struct {
+	ServiceID            string
+	ServiceName          string
+	DeploymentID         string
+	RequestedCorePercent int
+	RequestedMemoryBytes uint64
+	HostPolicy           servicedefinition.HostPolicy
+	HostLabels           *servicedefinition.LabelSelector
+	Affinity             *servicedefinition.Affinity
+}
+
+// ExternalHost describes a candidate host to an external strategy.
+type ExternalHost struct {
+	HostID          string
+	TotalCores      int
+	TotalMemory     uint64
+	Labels          map[string]string
+	RunningServices []ExternalService
+}
+
+// ExternalRequest is posted as JSON to an external strategy to choose a host
+// for an instance of a service.
+type ExternalRequest struct {
+	Service ExternalService
+	Hosts   []ExternalHost
+}
+
+// ExternalResponse is returned as JSON by an external strategy.  HostIDs
+// ranks the hosts the service may run on, best first; if it is empty, no
+// host is selected.
+type ExternalResponse struct {
+	HostIDs []string
+	Error   string
+}
+
+// ExternalStrategy delegates the choice of host to a process outside of
+// serviced, which is reached over HTTP at an http:// or https:// address, or
+// over a unix socket at a unix:// address.  Hosts that the service may not run
+// on, such as hosts without its required labels, are not offered to it.
+type ExternalStrategy struct {
+	name   string
+	url    string
+	client *http.Client
+}
+
+// NewExternalStrategy returns a strategy that is selected by the host policy
+// "external:NAME" and posts requests to the address.
+func NewExternalStrategy(name, address string) (*ExternalStrategy, error) {
+	if name == "" {
+		return nil, errors.New("external strategy name is required")
+	}
+	s := &ExternalStrategy{name: servicedefinition.ExternalPrefix + name}
+	switch {
+	case strings.HasPrefix(address, unixScheme):
+		socket := strings.TrimPrefix(address, unixScheme)
+		if socket == "" {
+			return nil, fmt.Errorf("invalid address for external strategy %s: %s", name, address)
+		}
+		s.url = "http://unix/"
+		s.client = &http.Client{
+			Timeout: ExternalTimeout,
+			Transport: &http.Transport{
+				Dial: func(_, _ string) (net.Conn, error) {
+					return net.DialTimeout("unix", socket, ExternalTimeout)
+				},
+			},
+		}
+	case strings.HasPrefix(address, "http://"), strings.HasPrefix(address, "https://"):
+		s.url = address
+		s.client = &http.Client{Timeout: ExternalTimeout}
+	default:
+		return nil, fmt.Errorf("invalid address for external strategy %s: %s", name, address)
+	}
+	return s, nil
+}
+
+// ParseExternalStrategy returns an external strategy from a NAME=ADDRESS
+// definition.
+func ParseExternalStrategy(definition string) (*ExternalStrategy, error) {
+	parts := strings.SplitN(definition, "=", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid external strategy %s: expected NAME=ADDRESS", definition)
+	}
+	return NewExternalStrategy(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+}
+
+func (s *ExternalStrategy) Name() string {
+	return s.name
+}
+
+func (s *ExternalStrategy) SelectHost(service ServiceConfig, hosts []Host) (Host, error) {
+	host, _, err := s.SelectHostEvaluated(service, hosts)
+	return host, err
+}
+
+// SelectHostEvaluated offers the external strategy only the hosts that
+// EvaluateHosts did not exclude, and only accepts one of those hosts in return.
+func (s *ExternalStrategy) SelectHostEvaluated(service ServiceConfig, hosts []Host) (Host, *Evaluation, error) {
+	eval := EvaluateHosts(service, hosts)
+	candidates := make(map[string]struct{})
+	for _, scored := range append(append([]*ScoredHost{}, eval.Undersubscribed...), eval.Oversubscribed...) {
+		candidates[scored.Host.HostID()] = struct{}{}
+	}
+
+	request := ExternalRequest{Service: externalService(service), Hosts: []ExternalHost{}}
+	hostmap := make(map[string]Host)
+	for _, host := range hosts {
+		if _, ok := candidates[host.HostID()]; !ok {
+			continue
+		}
+		hostmap[host.HostID()] = host
+		eh := ExternalHost{
+			HostID:          host.HostID(),
+			TotalCores:      host.TotalCores(),
+			TotalMemory:     host.TotalMemory(),
+			Labels:          host.Labels(),
+			RunningServices: []ExternalService{},
+		}
+		for _, svc := range host.RunningServices() {
+			eh.RunningServices = append(eh.RunningServices, externalService(svc))
+		}
+		request.Hosts = append(request.Hosts, eh)
+	}
+	if len(request.Hosts) == 0 {
+		return nil, eval, nil
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, eval, err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, eval, fmt.Errorf("could not reach external strategy %s: %s", s.name, err)
+	}
+	defer resp.Body.Close()
+
+	var response ExternalResponse
+	if resp.StatusCode != http.StatusOK {
+		// the strategy may explain the failure in the body of the response
+		if err := json.NewDecoder(resp.Body).Decode(&response); err == nil && response.Error != "" {
+			return nil, eval, fmt.Errorf("external strategy %s: %s", s.name, response.Error)
+		}
+		return nil, eval, fmt.Errorf("external strategy %s returned %s", s.name, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, eval, fmt.Errorf("invalid response from external strategy %s: %s", s.name, err)
+	}
+	if response.Error != "" {
+		return nil, eval, fmt.Errorf("external strategy %s: %s", s.name, response.Error)
+	}
+
+	for _, hostID := range response.HostIDs {
+		if host, ok := hostmap[hostID]; ok {
+			return host, eval, nil
+		}
+		glog.Warningf("External strategy %s chose host %s, which is not a candidate for service %s", s.name, hostID, service.GetServiceID())
+	}
+	return nil, eval, nil
+}
+
+func externalService(svc ServiceConfig) ExternalService {
+	return ExternalService{
+		ServiceID:            svc.GetServiceID(),
+		ServiceName:          svc.GetServiceName(),
+		DeploymentID:         svc.GetDeploymentID(),
+		RequestedCorePercent: svc.RequestedCorePercent(),
+		RequestedMemoryBytes: svc.RequestedMemoryBytes(),
+		HostPolicy:           svc.HostPolicy(),
+		HostLabels:           svc.HostLabels(),
+		Affinity:             svc.Affinity(),
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package strategy_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/scheduler/strategy"
+	. "gopkg.in/check.v1"
+)
+
+// externalHandler answers strategy requests with the ids of the hosts in
+// reverse order, and records the last request.
+type externalHandler struct {
+	request *strategy.ExternalRequest
+}
+
+func (h *externalHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.request = &strategy.ExternalRequest{}
+	if err := json.NewDecoder(r.Body).Decode(h.request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(strategy.ExternalResponse{Error: err.Error()})
+		return
+	}
+	response := strategy.ExternalResponse{HostIDs: []string{"unknown"}}
+	for i := len(h.request.Hosts) - 1; i >= 0; i-- {
+		response.HostIDs = append(response.HostIDs, h.request.Hosts[i].HostID)
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+func (s *StrategySuite) TestExternalStrategyHTTP(c *C) {
+	handler := &externalHandler{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	strat, err := strategy.ParseExternalStrategy("reverse=" + server.URL)
+	c.Assert(err, IsNil)
+	c.Assert(strat.Name(), Equals, "external:reverse")
+
+	hostA := newLabeledHost(2, 2, map[string]string{"disk": "ssd"})
+	hostB := newHost(2, 2)
+	running := newService(1, 1)
+	svc := newService(1, 1)
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{running})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{})
+
+	host, err := strat.SelectHost(svc, []strategy.Host{hostA, hostB})
+	c.Assert(err, IsNil)
+	c.Assert(host, Equals, hostB)
+
+	c.Assert(handler.request.Service.ServiceID, Equals, svc.GetServiceID())
+	c.Assert(handler.request.Service.RequestedCorePercent, Equals, 100)
+	c.Assert(handler.request.Hosts, HasLen, 2)
+	c.Assert(handler.request.Hosts[0].Labels, DeepEquals, map[string]string{"disk": "ssd"})
+	c.Assert(handler.request.Hosts[0].RunningServices, HasLen, 1)
+	c.Assert(handler.request.Hosts[0].RunningServices[0].ServiceID, Equals, running.GetServiceID())
+}
+
+func (s *StrategySuite) TestExternalStrategyUnixSocket(c *C) {
+	dir, err := ioutil.TempDir("", "strategy")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "strategy.sock")
+	listener, err := net.Listen("unix", socket)
+	c.Assert(err, IsNil)
+	server := &http.Server{Handler: &externalHandler{}}
+	go server.Serve(listener)
+	defer listener.Close()
+
+	strat, err := strategy.NewExternalStrategy("reverse", "unix://"+socket)
+	c.Assert(err, IsNil)
+
+	hostA := newHost(2, 2)
+	hostB := newHost(2, 2)
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{})
+
+	host, err := strat.SelectHost(newService(1, 1), []strategy.Host{hostA, hostB})
+	c.Assert(err, IsNil)
+	c.Assert(host, Equals, hostB)
+}
+
+func (s *StrategySuite) TestExternalStrategyError(c *C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(strategy.ExternalResponse{Error: "no capacity"})
+	}))
+	defer server.Close()
+
+	strat, err := strategy.NewExternalStrategy("broken", server.URL)
+	c.Assert(err, IsNil)
+
+	hostA := newHost(2, 2)
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{})
+
+	host, err := strat.SelectHost(newService(1, 1), []strategy.Host{hostA})
+	c.Assert(host, IsNil)
+	c.Assert(err, ErrorMatches, "external strategy external:broken: no capacity")
+}
+
+func (s *StrategySuite) TestParseExternalStrategyInvalid(c *C) {
+	for _, definition := range []string{"reverse", "=http://localhost", "reverse=localhost:8080", "reverse=unix://"} {
+		_, err := strategy.ParseExternalStrategy(definition)
+		c.Assert(err, NotNil, Commentf("definition %s", definition))
+	}
+}
+
+func (s *StrategySuite) TestRegister(c *C) {
+	strat, err := strategy.NewExternalStrategy("registered", "http://localhost:8080")
+	c.Assert(err, IsNil)
+	c.Assert(strategy.Register(strat), IsNil)
+	c.Assert(strategy.Register(strat), Equals, strategy.ErrStrategyExists)
+	c.Assert(strategy.Register(&strategy.PackStrategy{}), Equals, strategy.ErrStrategyExists)
+
+	found, err := strategy.Get("EXTERNAL:registered")
+	c.Assert(err, IsNil)
+	c.Assert(found, Equals, strat)
+}
+
+func (s *StrategySuite) TestExternalStrategyOnlyOffersCandidates(c *C) {
+	hostA := newLabeledHost(2, 2, map[string]string{"disk": "ssd"})
+	hostB := newHost(2, 2)
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{})
+	svc := newLabeledService(1, 1, &servicedefinition.LabelSelector{Required: map[string]string{"disk": "ssd"}})
+
+	var request strategy.ExternalRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&request)
+		// prefers the host that does not have the required labels
+		json.NewEncoder(w).Encode(strategy.ExternalResponse{HostIDs: []string{hostB.HostID(), hostA.HostID()}})
+	}))
+	defer server.Close()
+
+	strat, err := strategy.NewExternalStrategy("labels", server.URL)
+	c.Assert(err, IsNil)
+
+	host, eval, err := strat.SelectHostEvaluated(svc, []strategy.Host{hostA, hostB})
+	c.Assert(err, IsNil)
+	c.Assert(host, Equals, hostA)
+	c.Assert(request.Hosts, HasLen, 1)
+	c.Assert(request.Hosts[0].HostID, Equals, hostA.HostID())
+	c.Assert(eval.Excluded, HasLen, 1)
+	c.Assert(eval.Excluded[0].Host, Equals, hostB)
+}
+
+func (s *StrategySuite) TestExternalStrategyStatus(c *C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>bad gateway</html>"))
+	}))
+	defer server.Close()
+
+	strat, err := strategy.NewExternalStrategy("proxied", server.URL)
+	c.Assert(err, IsNil)
+
+	hostA := newHost(2, 2)
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{})
+
+	host, err := strat.SelectHost(newService(1, 1), []strategy.Host{hostA})
+	c.Assert(host, IsNil)
+	c.Assert(err, ErrorMatches, "external strategy external:proxied returned 502 Bad Gateway")
+}
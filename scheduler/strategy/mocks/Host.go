@@ -38,3 +38,13 @@ func (m *Host) RunningServices() []strategy.ServiceConfig {
 
 	return r0
 }
+func (m *Host) Labels() map[string]string {
+	ret := m.Called()
+
+	var r0 map[string]string
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(map[string]string)
+	}
+
+	return r0
+}
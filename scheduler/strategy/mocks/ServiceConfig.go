@@ -36,3 +36,37 @@ func (m *ServiceConfig) HostPolicy() servicedefinition.HostPolicy {
 
 	return r0
 }
+func (m *ServiceConfig) HostLabels() *servicedefinition.LabelSelector {
+	ret := m.Called()
+
+	var r0 *servicedefinition.LabelSelector
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*servicedefinition.LabelSelector)
+	}
+
+	return r0
+}
+func (m *ServiceConfig) GetServiceName() string {
+	ret := m.Called()
+
+	r0 := ret.Get(0).(string)
+
+	return r0
+}
+func (m *ServiceConfig) GetDeploymentID() string {
+	ret := m.Called()
+
+	r0 := ret.Get(0).(string)
+
+	return r0
+}
+func (m *ServiceConfig) Affinity() *servicedefinition.Affinity {
+	ret := m.Called()
+
+	var r0 *servicedefinition.Affinity
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*servicedefinition.Affinity)
+	}
+
+	return r0
+}
@@ -22,7 +22,13 @@ func (s *PackStrategy) Name() string {
 }
 
 func (s *PackStrategy) SelectHost(service ServiceConfig, hosts []Host) (Host, error) {
-	under, over := ScoreHosts(service, hosts)
+	host, _, err := s.SelectHostEvaluated(service, hosts)
+	return host, err
+}
+
+func (s *PackStrategy) SelectHostEvaluated(service ServiceConfig, hosts []Host) (Host, *Evaluation, error) {
+	eval := EvaluateHosts(service, hosts)
+	under, over := eval.Undersubscribed, eval.Oversubscribed
 
 	// Return the host with the least amount of free resources that can handle
 	// the service. In case of a tie, choose the one running more instances.
@@ -38,13 +44,13 @@ func (s *PackStrategy) SelectHost(service ServiceConfig, hosts []Host) (Host, er
 				choice = scored
 			}
 		}
-		return choice.Host, nil
+		return choice.Host, eval, nil
 	}
 
 	// Return the host for which this service will least oversubscribe memory
 	if over != nil && len(over) > 0 {
-		return over[0].Host, nil
+		return over[0].Host, eval, nil
 	}
 
-	return nil, nil
+	return nil, eval, nil
 }
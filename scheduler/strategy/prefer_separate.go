@@ -22,7 +22,13 @@ func (s *PreferSeparateStrategy) Name() string {
 }
 
 func (s *PreferSeparateStrategy) SelectHost(service ServiceConfig, hosts []Host) (Host, error) {
-	under, over := ScoreHosts(service, hosts)
+	host, _, err := s.SelectHostEvaluated(service, hosts)
+	return host, err
+}
+
+func (s *PreferSeparateStrategy) SelectHostEvaluated(service ServiceConfig, hosts []Host) (Host, *Evaluation, error) {
+	eval := EvaluateHosts(service, hosts)
+	under, over := eval.Undersubscribed, eval.Oversubscribed
 
 	if under != nil && len(under) > 0 {
 		var (
@@ -35,12 +41,12 @@ func (s *PreferSeparateStrategy) SelectHost(service ServiceConfig, hosts []Host)
 				idx = i
 			}
 		}
-		return under[idx].Host, nil
+		return under[idx].Host, eval, nil
 	}
 
 	// Return the host for which this service will least oversubscribe memory
 	if over != nil && len(over) > 0 {
-		return over[0].Host, nil
+		return over[0].Host, eval, nil
 	}
-	return nil, nil
+	return nil, eval, nil
 }
@@ -0,0 +1,174 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strategy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/zenoss/glog"
+)
+
+// Migration moves a running service from one host to another to even out the
+// commitment of the hosts.  FromScore is the score of the source host before
+// the move, and ToScore is the score of the target host after the move.
+type Migration struct {
+	Service   ServiceConfig
+	From      Host
+	To        Host
+	FromScore int
+	ToScore   int
+}
+
+// HostScore returns the percentage of cores plus the percentage of memory that
+// are committed to the services running on the host, which is how ScoreHosts
+// scores a host that is not oversubscribed.
+func HostScore(host Host) int {
+	var (
+		usedCpu int
+		usedMem uint64
+	)
+	for _, svc := range host.RunningServices() {
+		usedCpu += svc.RequestedCorePercent()
+		usedMem += svc.RequestedMemoryBytes()
+	}
+	return commitmentScore(host, usedCpu, usedMem)
+}
+
+func commitmentScore(host Host, usedCpu int, usedMem uint64) int {
+	cpuScore, memScore := 100, 100
+	if totalCpu := host.TotalCores(); totalCpu > 0 {
+		cpuScore = usedCpu / totalCpu
+	}
+	if totalMem := host.TotalMemory(); totalMem > 0 {
+		memScore = int(usedMem * 100 / totalMem)
+	}
+	return cpuScore + memScore
+}
+
+// plannedHost is a host with the services it will be running once the planned
+// migrations are done.
+type plannedHost struct {
+	Host
+	services []ServiceConfig
+	score    int
+}
+
+func (h *plannedHost) RunningServices() []ServiceConfig {
+	return h.services
+}
+
+type byScoreDesc []*plannedHost
+
+func (l byScoreDesc) Len() int           { return len(l) }
+func (l byScoreDesc) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
+func (l byScoreDesc) Less(i, j int) bool { return l[i].score > l[j].score }
+
+// PlanRebalance returns up to limit migrations that even out the commitment
+// of the hosts.  Each migration moves a service from the most committed host
+// it can be moved from to the host that EvaluateHosts would choose for it, and
+// is only planned if neither host ends up with a higher score than the source
+// host had before.  Services for which canMove returns false are left where
+// they are, as are services that request no resources and services that are
+// placed by an external strategy.
+func PlanRebalance(hosts []Host, limit int, canMove func(ServiceConfig) bool) []*Migration {
+	planned := make([]*plannedHost, len(hosts))
+	for i, host := range hosts {
+		services := append([]ServiceConfig{}, host.RunningServices()...)
+		planned[i] = &plannedHost{Host: host, services: services, score: HostScore(host)}
+	}
+
+	migrations := []*Migration{}
+	for len(migrations) < limit {
+		sort.Sort(byScoreDesc(planned))
+		var migration *Migration
+		for i, from := range planned {
+			others := []Host{}
+			for j, host := range planned {
+				if i != j {
+					others = append(others, host)
+				}
+			}
+			if migration = planMigration(from, others, canMove); migration != nil {
+				break
+			}
+		}
+		if migration == nil {
+			break
+		}
+		glog.V(2).Infof("Planned migration of service %s from host %s (%d) to host %s (%d)", migration.Service.GetServiceID(), migration.From.HostID(), migration.FromScore, migration.To.HostID(), migration.ToScore)
+		migrations = append(migrations, migration)
+	}
+	return migrations
+}
+
+// planMigration chooses the service to move off of the host that leaves the
+// two hosts involved with the lowest score, and applies the move to the
+// planned hosts.  Returns nil if no move lowers the score of the host.
+func planMigration(from *plannedHost, others []Host, canMove func(ServiceConfig) bool) *Migration {
+	var (
+		best      *Migration
+		bestIndex int
+		bestTo    *plannedHost
+	)
+	bestScore := from.score
+	for i, svc := range from.services {
+		if !isMovable(svc, canMove) {
+			continue
+		}
+		remaining := append(append([]ServiceConfig{}, from.services[:i]...), from.services[i+1:]...)
+		fromScore := HostScore(&plannedHost{Host: from.Host, services: remaining})
+
+		for _, scored := range EvaluateHosts(svc, others).Undersubscribed {
+			if isSeparatePolicy(svc.HostPolicy()) && scored.NumInstances > 0 {
+				continue
+			}
+			to := scored.Host.(*plannedHost)
+			toScore := HostScore(&plannedHost{Host: to.Host, services: append(append([]ServiceConfig{}, to.services...), svc)})
+			score := fromScore
+			if toScore > score {
+				score = toScore
+			}
+			if score < bestScore {
+				best = &Migration{Service: svc, From: from.Host, To: to.Host, FromScore: from.score, ToScore: toScore}
+				bestIndex, bestTo, bestScore = i, to, score
+			}
+			break
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	from.services = append(from.services[:bestIndex], from.services[bestIndex+1:]...)
+	from.score = HostScore(from)
+	bestTo.services = append(bestTo.services, best.Service)
+	bestTo.score = HostScore(bestTo)
+	return best
+}
+
+func isMovable(svc ServiceConfig, canMove func(ServiceConfig) bool) bool {
+	if svc.RequestedCorePercent() == 0 && svc.RequestedMemoryBytes() == 0 {
+		return false
+	}
+	if strings.HasPrefix(strings.ToLower(string(svc.HostPolicy())), servicedefinition.ExternalPrefix) {
+		return false
+	}
+	return canMove == nil || canMove(svc)
+}
+
+func isSeparatePolicy(policy servicedefinition.HostPolicy) bool {
+	return policy == servicedefinition.PreferSeparate || policy == servicedefinition.RequireSeparate
+}
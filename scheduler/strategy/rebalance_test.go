@@ -0,0 +1,105 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package strategy_test
+
+import (
+	"github.com/control-center/serviced/domain/servicedefinition"
+	"github.com/control-center/serviced/scheduler/strategy"
+	. "gopkg.in/check.v1"
+)
+
+// Given a loaded host and a new empty host, verify that an instance is moved
+// to the new host, and that no more are moved once the hosts are even.
+func (s *StrategySuite) TestPlanRebalanceNewHost(c *C) {
+	hostA := newHost(4, 4)
+	hostB := newHost(4, 4)
+
+	svc := newService(1, 1)
+	svc2 := newService(1, 1)
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{svc, svc2})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{})
+
+	c.Assert(strategy.HostScore(hostA), Equals, 100)
+	c.Assert(strategy.HostScore(hostB), Equals, 0)
+
+	migrations := strategy.PlanRebalance([]strategy.Host{hostA, hostB}, 5, nil)
+	c.Assert(migrations, HasLen, 1)
+	c.Assert(migrations[0].From, Equals, hostA)
+	c.Assert(migrations[0].To, Equals, hostB)
+	c.Assert(migrations[0].FromScore, Equals, 100)
+	c.Assert(migrations[0].ToScore, Equals, 50)
+}
+
+// Given a loaded host and two empty hosts, verify that instances are spread to
+// both empty hosts, and that no more than the limit are moved.
+func (s *StrategySuite) TestPlanRebalanceLimit(c *C) {
+	hostA := newHost(8, 8)
+	hostB := newHost(8, 8)
+	hostC := newHost(8, 8)
+
+	running := []strategy.ServiceConfig{}
+	for i := 0; i < 4; i++ {
+		running = append(running, newService(1, 1))
+	}
+	hostA.On("RunningServices").Return(running)
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{})
+	hostC.On("RunningServices").Return([]strategy.ServiceConfig{})
+	hosts := []strategy.Host{hostA, hostB, hostC}
+
+	migrations := strategy.PlanRebalance(hosts, 5, nil)
+	c.Assert(migrations, HasLen, 2)
+	c.Assert(migrations[0].From, Equals, hostA)
+	c.Assert(migrations[1].From, Equals, hostA)
+	c.Assert(migrations[0].To, Not(Equals), migrations[1].To)
+	c.Assert(migrations[1].FromScore, Equals, 74)
+
+	migrations = strategy.PlanRebalance(hosts, 1, nil)
+	c.Assert(migrations, HasLen, 1)
+
+	migrations = strategy.PlanRebalance(hosts, 0, nil)
+	c.Assert(migrations, HasLen, 0)
+}
+
+// Verify that instances are not moved to hosts that do not have the labels
+// they require, or when they cannot be moved.
+func (s *StrategySuite) TestPlanRebalanceConstraints(c *C) {
+	hostA := newLabeledHost(2, 2, map[string]string{"disk": "ssd"})
+	hostB := newHost(2, 2)
+
+	selector := &servicedefinition.LabelSelector{Required: map[string]string{"disk": "ssd"}}
+	svc := newLabeledService(1, 1, selector)
+	svc2 := newLabeledService(1, 1, selector)
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{svc, svc2})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{})
+	hosts := []strategy.Host{hostA, hostB}
+
+	c.Assert(strategy.PlanRebalance(hosts, 5, nil), HasLen, 0)
+
+	svc3 := newService(1, 1)
+	hostC := newHost(4, 4)
+	hostC.On("RunningServices").Return([]strategy.ServiceConfig{newService(0, 0), svc3, newService(1, 1)})
+	hostD := newHost(4, 4)
+	hostD.On("RunningServices").Return([]strategy.ServiceConfig{})
+	hosts = []strategy.Host{hostC, hostD}
+
+	canMove := func(svc strategy.ServiceConfig) bool {
+		return svc.GetServiceID() == svc3.GetServiceID()
+	}
+	migrations := strategy.PlanRebalance(hosts, 5, canMove)
+	c.Assert(migrations, HasLen, 1)
+	c.Assert(migrations[0].Service, Equals, svc3)
+	c.Assert(strategy.PlanRebalance(hosts, 5, func(strategy.ServiceConfig) bool { return false }), HasLen, 0)
+}
@@ -22,21 +22,27 @@ func (s *RequireSeparateStrategy) Name() string {
 }
 
 func (s *RequireSeparateStrategy) SelectHost(service ServiceConfig, hosts []Host) (Host, error) {
-	under, over := ScoreHosts(service, hosts)
+	host, _, err := s.SelectHostEvaluated(service, hosts)
+	return host, err
+}
+
+func (s *RequireSeparateStrategy) SelectHostEvaluated(service ServiceConfig, hosts []Host) (Host, *Evaluation, error) {
+	eval := EvaluateHosts(service, hosts)
+	under, over := eval.Undersubscribed, eval.Oversubscribed
 
 	if under != nil && len(under) > 0 {
 		for _, h := range under {
 			if h.NumInstances == 0 {
-				return h.Host, nil
+				return h.Host, eval, nil
 			}
 		}
 	}
 	if over != nil && len(over) > 0 {
 		for _, h := range over {
 			if h.NumInstances == 0 {
-				return h.Host, nil
+				return h.Host, eval, nil
 			}
 		}
 	}
-	return nil, nil
+	return nil, eval, nil
 }
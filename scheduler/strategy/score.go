@@ -22,9 +22,32 @@ import (
 type ScoredHost struct {
 	Host         Host
 	Score        int
+	CPUScore     int
+	MemoryScore  int
 	NumInstances int
 }
 
+// ExcludedHost is a host that was not scored for a service, and the reason
+// why.
+type ExcludedHost struct {
+	Host   Host
+	Reason string
+}
+
+// Reasons that hosts are excluded from scoring
+const (
+	ReasonMissingLabels = "host does not have the labels required by the service"
+	ReasonSeparated     = "host is running a service that must be separated from the service"
+	ReasonNotPreferred  = "host does not match the preferences of the service"
+)
+
+// Evaluation describes how a set of hosts was evaluated for a service.
+type Evaluation struct {
+	Undersubscribed []*ScoredHost
+	Oversubscribed  []*ScoredHost
+	Excluded        []*ExcludedHost
+}
+
 type scoredHostList []*ScoredHost
 
 func (l scoredHostList) Len() int {
@@ -43,8 +66,107 @@ func (l scoredHostList) Less(i, j int) bool {
 // enough resources to handle the service, sorted in order of combined free
 // resources. The second lists hosts that do not have enough resources to
 // handle the service, sorted in order of percentage memory used were the
-// service deployed to the host.
+// service deployed to the host. Hosts excluded by EvaluateHosts are not
+// returned.
 func ScoreHosts(service ServiceConfig, hosts []Host) ([]*ScoredHost, []*ScoredHost) {
+	eval := EvaluateHosts(service, hosts)
+	return eval.Undersubscribed, eval.Oversubscribed
+}
+
+// EvaluateHosts scores the hosts for a service. Hosts that do not have the
+// labels required by the service, or that run a service it must be separated
+// from, are excluded. If any host that also matches the preferences of the
+// service can run the service without being oversubscribed, all other hosts
+// are excluded as well.
+func EvaluateHosts(service ServiceConfig, hosts []Host) *Evaluation {
+	selector := service.HostLabels()
+
+	excluded := []*ExcludedHost{}
+	eligible, preferred, others := []Host{}, []Host{}, []Host{}
+	for _, host := range hosts {
+		if selector != nil && !selector.MatchesRequired(host.Labels()) {
+			glog.V(2).Infof("Host %s does not have the labels required by service %s", host.HostID(), service.GetServiceID())
+			excluded = append(excluded, &ExcludedHost{Host: host, Reason: ReasonMissingLabels})
+			continue
+		}
+		if isSeparated(service, host) {
+			glog.V(2).Infof("Host %s is running a service that must be separated from service %s", host.HostID(), service.GetServiceID())
+			excluded = append(excluded, &ExcludedHost{Host: host, Reason: ReasonSeparated})
+			continue
+		}
+		eligible = append(eligible, host)
+		if isPreferred(service, host) {
+			preferred = append(preferred, host)
+		} else {
+			others = append(others, host)
+		}
+	}
+
+	if len(preferred) > 0 {
+		if under, over := scoreHosts(service, preferred); len(under) > 0 {
+			for _, host := range others {
+				excluded = append(excluded, &ExcludedHost{Host: host, Reason: ReasonNotPreferred})
+			}
+			return &Evaluation{Undersubscribed: under, Oversubscribed: over, Excluded: excluded}
+		}
+		glog.V(2).Infof("No hosts preferred by service %s can run it", service.GetServiceID())
+	}
+	under, over := scoreHosts(service, eligible)
+	return &Evaluation{Undersubscribed: under, Oversubscribed: over, Excluded: excluded}
+}
+
+// isSeparated returns true if the service must not share the host with a
+// service from the same deployment that is running on the host, or if that
+// service must not share the host with this service.
+func isSeparated(service ServiceConfig, host Host) bool {
+	affinity := service.Affinity()
+	for _, svc := range host.RunningServices() {
+		if svc.GetDeploymentID() != service.GetDeploymentID() {
+			continue
+		}
+		if affinity != nil && affinity.Separates(svc.GetServiceName()) {
+			return true
+		}
+		if a := svc.Affinity(); a != nil && a.Separates(service.GetServiceName()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPreferred returns true if the host has all of the labels preferred by the
+// service and is running all of the services from the same deployment that
+// the service should be colocated with. Returns false if the service has no
+// preferences.
+func isPreferred(service ServiceConfig, host Host) bool {
+	selector, affinity := service.HostLabels(), service.Affinity()
+	preferLabels := selector != nil && len(selector.Preferred) > 0
+	preferServices := affinity != nil && len(affinity.ColocateWith) > 0
+	if !preferLabels && !preferServices {
+		return false
+	}
+
+	if preferLabels && !selector.MatchesPreferred(host.Labels()) {
+		return false
+	}
+
+	if preferServices {
+		running := make(map[string]struct{})
+		for _, svc := range host.RunningServices() {
+			if svc.GetDeploymentID() == service.GetDeploymentID() {
+				running[svc.GetServiceName()] = struct{}{}
+			}
+		}
+		for _, name := range affinity.ColocateWith {
+			if _, ok := running[name]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func scoreHosts(service ServiceConfig, hosts []Host) ([]*ScoredHost, []*ScoredHost) {
 
 	glog.V(2).Infof("Scoring %d hosts for service %s", len(hosts), service.GetServiceID())
 	glog.V(2).Infof("Service %s is requesting %d memory and %d percent CPU", service.GetServiceID(), service.RequestedMemoryBytes(), service.RequestedCorePercent())
@@ -98,6 +220,8 @@ func ScoreHosts(service ServiceConfig, hosts []Host) ([]*ScoredHost, []*ScoredHo
 		}
 
 		glog.V(2).Infof("Host %s CPU score: %s, memory score: %s", host.HostID(), cpuScore, memScore)
+		scoredHost.CPUScore = cpuScore
+		scoredHost.MemoryScore = memScore
 		if cpuScore <= 100 && memScore <= 100 {
 			glog.V(2).Infof("Host %s can run service %s", host.HostID(), service.GetServiceID())
 			scoredHost.Score = cpuScore + memScore
@@ -16,6 +16,7 @@
 package strategy_test
 
 import (
+	"github.com/control-center/serviced/domain/servicedefinition"
 	"github.com/control-center/serviced/scheduler/strategy"
 	"github.com/control-center/serviced/scheduler/strategy/mocks"
 	"github.com/control-center/serviced/utils"
@@ -27,20 +28,45 @@ const (
 )
 
 func newHost(cores int, memgigs uint64) *mocks.Host {
+	return newLabeledHost(cores, memgigs, map[string]string{})
+}
+
+func newLabeledHost(cores int, memgigs uint64, labels map[string]string) *mocks.Host {
 	host := &mocks.Host{}
 	host.On("TotalCores").Return(cores)
 	host.On("TotalMemory").Return(memgigs * Gigabyte)
 	id, _ := utils.NewUUID36()
 	host.On("HostID").Return(id)
+	host.On("Labels").Return(labels)
 	return host
 }
 
 func newService(cores int, memgigs uint64) *mocks.ServiceConfig {
+	return newLabeledService(cores, memgigs, nil)
+}
+
+func newLabeledService(cores int, memgigs uint64, selector *servicedefinition.LabelSelector) *mocks.ServiceConfig {
+	return newMockService(cores, memgigs, "", selector, nil)
+}
+
+func newAffinityService(cores int, memgigs uint64, name string, affinity *servicedefinition.Affinity) *mocks.ServiceConfig {
+	return newMockService(cores, memgigs, name, nil, affinity)
+}
+
+func newMockService(cores int, memgigs uint64, name string, selector *servicedefinition.LabelSelector, affinity *servicedefinition.Affinity) *mocks.ServiceConfig {
 	id, _ := utils.NewUUID36()
+	if name == "" {
+		name = id
+	}
 	svc := &mocks.ServiceConfig{}
 	svc.On("RequestedCorePercent").Return(cores * 100)
 	svc.On("RequestedMemoryBytes").Return(memgigs * Gigabyte)
 	svc.On("GetServiceID").Return(id)
+	svc.On("GetServiceName").Return(name)
+	svc.On("GetDeploymentID").Return("test-deployment")
+	svc.On("HostLabels").Return(selector)
+	svc.On("Affinity").Return(affinity)
+	svc.On("HostPolicy").Return(servicedefinition.HostPolicy(servicedefinition.DEFAULT))
 	return svc
 }
 
@@ -143,3 +169,161 @@ func (s *StrategySuite) TestMemoryPreferred(c *C) {
 	c.Assert(over[0].Host, Equals, hostA)
 	c.Assert(over[1].Host, Equals, hostB)
 }
+
+// Given three hosts, only one of which has the labels required by the
+// service, verify that the other hosts are not considered
+func (s *StrategySuite) TestRequiredLabels(c *C) {
+	hostA := newLabeledHost(2, 2, map[string]string{"disk": "hdd"})
+	hostB := newLabeledHost(2, 2, map[string]string{"disk": "ssd"})
+	hostC := newHost(2, 2)
+
+	svc := newService(2, 2)
+	svc2 := newLabeledService(1, 1, &servicedefinition.LabelSelector{
+		Required: map[string]string{"disk": "ssd"},
+	})
+
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{svc})
+	hostC.On("RunningServices").Return([]strategy.ServiceConfig{})
+
+	under, over := strategy.ScoreHosts(svc2, []strategy.Host{hostA, hostB, hostC})
+
+	c.Assert(under, HasLen, 0)
+	c.Assert(over, HasLen, 1)
+	c.Assert(over[0].Host, Equals, hostB)
+}
+
+// Given two hosts, one of which has the labels preferred by the service but
+// fewer free resources, verify that the preferred host is the only one scored
+// as long as it can run the service
+func (s *StrategySuite) TestPreferredLabels(c *C) {
+	hostA := newLabeledHost(4, 4, map[string]string{"latency": "low"})
+	hostB := newHost(4, 4)
+
+	svc := newService(2, 2)
+	svc2 := newLabeledService(1, 1, &servicedefinition.LabelSelector{
+		Preferred: map[string]string{"latency": "low"},
+	})
+
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{svc})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{})
+
+	under, over := strategy.ScoreHosts(svc2, []strategy.Host{hostA, hostB})
+
+	c.Assert(under, HasLen, 1)
+	c.Assert(under[0].Host, Equals, hostA)
+	c.Assert(over, HasLen, 0)
+
+	// once the preferred host is full, fall back to the other hosts
+	svc3 := newLabeledService(3, 3, svc2.HostLabels())
+	under, over = strategy.ScoreHosts(svc3, []strategy.Host{hostA, hostB})
+
+	c.Assert(under, HasLen, 1)
+	c.Assert(under[0].Host, Equals, hostB)
+	c.Assert(over, HasLen, 1)
+	c.Assert(over[0].Host, Equals, hostA)
+}
+
+// Given two hosts, one of which is running a service that the service must be
+// separated from, verify that the host is not considered
+func (s *StrategySuite) TestAntiAffinity(c *C) {
+	hostA := newHost(4, 4)
+	hostB := newHost(4, 4)
+
+	backup := newAffinityService(1, 1, "backup", nil)
+	other := newService(2, 2)
+	database := newAffinityService(1, 1, "database", &servicedefinition.Affinity{
+		SeparateFrom: []string{"backup"},
+	})
+
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{backup})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{other})
+
+	under, over := strategy.ScoreHosts(database, []strategy.Host{hostA, hostB})
+
+	c.Assert(under, HasLen, 1)
+	c.Assert(under[0].Host, Equals, hostB)
+	c.Assert(over, HasLen, 0)
+}
+
+// Given a host running a service that must be separated from the service
+// being scheduled, verify that the rule is applied in both directions
+func (s *StrategySuite) TestAntiAffinityRunningService(c *C) {
+	hostA := newHost(4, 4)
+	hostB := newHost(4, 4)
+
+	database := newAffinityService(1, 1, "database", &servicedefinition.Affinity{
+		SeparateFrom: []string{"backup"},
+	})
+	backup := newAffinityService(1, 1, "backup", nil)
+
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{database})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{})
+
+	under, over := strategy.ScoreHosts(backup, []strategy.Host{hostA, hostB})
+
+	c.Assert(under, HasLen, 1)
+	c.Assert(under[0].Host, Equals, hostB)
+	c.Assert(over, HasLen, 0)
+}
+
+// Given two hosts, one of which has fewer free resources but is running the
+// service that the service should be colocated with, verify that the host
+// running that service is preferred
+func (s *StrategySuite) TestAffinity(c *C) {
+	hostA := newHost(4, 4)
+	hostB := newHost(4, 4)
+
+	cache := newAffinityService(2, 2, "cache", nil)
+	app := newAffinityService(1, 1, "app", &servicedefinition.Affinity{
+		ColocateWith: []string{"cache"},
+	})
+
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{cache})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{})
+
+	under, over := strategy.ScoreHosts(app, []strategy.Host{hostA, hostB})
+
+	c.Assert(under, HasLen, 1)
+	c.Assert(under[0].Host, Equals, hostA)
+	c.Assert(over, HasLen, 0)
+
+	// once the colocated host is full, fall back to the other hosts
+	bigApp := newAffinityService(3, 3, "app", app.Affinity())
+	under, over = strategy.ScoreHosts(bigApp, []strategy.Host{hostA, hostB})
+
+	c.Assert(under, HasLen, 1)
+	c.Assert(under[0].Host, Equals, hostB)
+	c.Assert(over, HasLen, 1)
+	c.Assert(over[0].Host, Equals, hostA)
+}
+
+// Given three hosts, verify that the evaluation reports the scores of the
+// hosts that can run the service and the reasons the others were excluded
+func (s *StrategySuite) TestEvaluateHosts(c *C) {
+	hostA := newLabeledHost(2, 2, map[string]string{"disk": "ssd", "latency": "low"})
+	hostB := newLabeledHost(2, 2, map[string]string{"disk": "ssd"})
+	hostC := newHost(2, 2)
+
+	svc := newLabeledService(1, 1, &servicedefinition.LabelSelector{
+		Required:  map[string]string{"disk": "ssd"},
+		Preferred: map[string]string{"latency": "low"},
+	})
+
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{})
+	hostC.On("RunningServices").Return([]strategy.ServiceConfig{})
+
+	eval := strategy.EvaluateHosts(svc, []strategy.Host{hostA, hostB, hostC})
+
+	c.Assert(eval.Undersubscribed, HasLen, 1)
+	c.Assert(eval.Undersubscribed[0].Host, Equals, hostA)
+	c.Assert(eval.Undersubscribed[0].CPUScore, Equals, 50)
+	c.Assert(eval.Undersubscribed[0].MemoryScore, Equals, 50)
+	c.Assert(eval.Oversubscribed, HasLen, 0)
+	c.Assert(eval.Excluded, HasLen, 2)
+	c.Assert(eval.Excluded[0].Host, Equals, hostC)
+	c.Assert(eval.Excluded[0].Reason, Equals, strategy.ReasonMissingLabels)
+	c.Assert(eval.Excluded[1].Host, Equals, hostB)
+	c.Assert(eval.Excluded[1].Reason, Equals, strategy.ReasonNotPreferred)
+}
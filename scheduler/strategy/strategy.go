@@ -16,13 +16,16 @@ package strategy
 import (
 	"errors"
 	"strings"
+	"sync"
 
 	"github.com/control-center/serviced/domain/servicedefinition"
 )
 
 var (
 	strategies        []Strategy
+	strategiesLock    sync.RWMutex
 	ErrNoSuchStrategy = errors.New("no such scheduler strategy")
+	ErrStrategyExists = errors.New("scheduler strategy already exists")
 )
 
 func init() {
@@ -39,13 +42,18 @@ type Host interface {
 	TotalCores() int
 	TotalMemory() uint64
 	RunningServices() []ServiceConfig
+	Labels() map[string]string
 }
 
 type ServiceConfig interface {
 	GetServiceID() string
+	GetServiceName() string
+	GetDeploymentID() string
 	RequestedCorePercent() int
 	RequestedMemoryBytes() uint64
 	HostPolicy() servicedefinition.HostPolicy
+	HostLabels() *servicedefinition.LabelSelector
+	Affinity() *servicedefinition.Affinity
 }
 
 type Strategy interface {
@@ -55,11 +63,47 @@ type Strategy interface {
 	SelectHost(svc ServiceConfig, hosts []Host) (Host, error)
 }
 
+// EvaluatingStrategy is a strategy that reports how it evaluated the hosts it
+// chose from.
+type EvaluatingStrategy interface {
+	Strategy
+	// Chooses the best host for svc to run on, and returns the evaluation of
+	// the hosts that the choice was based on
+	SelectHostEvaluated(svc ServiceConfig, hosts []Host) (Host, *Evaluation, error)
+}
+
+// SelectHost chooses a host for svc with the strategy.  It also returns the
+// evaluation of the hosts that the strategy used, or nil if the strategy does
+// not report one.
+func SelectHost(strategy Strategy, svc ServiceConfig, hosts []Host) (Host, *Evaluation, error) {
+	if s, ok := strategy.(EvaluatingStrategy); ok {
+		return s.SelectHostEvaluated(svc, hosts)
+	}
+	host, err := strategy.SelectHost(svc, hosts)
+	return host, nil, err
+}
+
+// Register adds a strategy that can be selected by name, such as a strategy
+// that is implemented outside of serviced.
+func Register(strategy Strategy) error {
+	strategiesLock.Lock()
+	defer strategiesLock.Unlock()
+	for _, s := range strategies {
+		if strings.ToLower(s.Name()) == strings.ToLower(strategy.Name()) {
+			return ErrStrategyExists
+		}
+	}
+	strategies = append(strategies, strategy)
+	return nil
+}
+
 func Get(name string) (Strategy, error) {
 	// Default to servicedefinition.Balance
 	if len(name) == 0 {
 		name = servicedefinition.Balance
 	}
+	strategiesLock.RLock()
+	defer strategiesLock.RUnlock()
 	for _, strategy := range strategies {
 		if strings.ToLower(strategy.Name()) == strings.ToLower(name) {
 			return strategy, nil
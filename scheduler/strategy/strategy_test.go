@@ -18,6 +18,8 @@ package strategy_test
 import (
 	"testing"
 
+	"github.com/control-center/serviced/scheduler/strategy"
+	"github.com/control-center/serviced/scheduler/strategy/mocks"
 	. "gopkg.in/check.v1"
 )
 
@@ -28,3 +30,30 @@ type StrategySuite struct{}
 var (
 	_ = Suite(&StrategySuite{})
 )
+
+func (s *StrategySuite) TestSelectHostReportsEvaluation(c *C) {
+	hostA := newHost(5, 5)
+	hostB := newHost(5, 5)
+	svc := newService(3, 3)
+	hostA.On("RunningServices").Return([]strategy.ServiceConfig{svc})
+	hostB.On("RunningServices").Return([]strategy.ServiceConfig{})
+
+	host, eval, err := strategy.SelectHost(&strategy.BalanceStrategy{}, newService(1, 1), []strategy.Host{hostA, hostB})
+	c.Assert(err, IsNil)
+	c.Assert(host, Equals, hostB)
+	c.Assert(eval, NotNil)
+	c.Assert(eval.Undersubscribed, HasLen, 2)
+	c.Assert(eval.Undersubscribed[0].Host, Equals, hostB)
+}
+
+func (s *StrategySuite) TestSelectHostWithoutEvaluation(c *C) {
+	hostA := newHost(5, 5)
+	svc := newService(1, 1)
+	strat := &mocks.Strategy{}
+	strat.On("SelectHost", svc, []strategy.Host{hostA}).Return(hostA, nil)
+
+	host, eval, err := strategy.SelectHost(strat, svc, []strategy.Host{hostA})
+	c.Assert(err, IsNil)
+	c.Assert(host, Equals, hostA)
+	c.Assert(eval, IsNil)
+}
@@ -0,0 +1,162 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronAliases are the shorthand schedules accepted by ParseCronSchedule
+var cronAliases = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// cronField describes the range of values of a field of a cron schedule
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// CronSchedule is a parsed cron expression with the five standard fields
+// (minute, hour, day of month, month and day of week).  Each field may be *,
+// a value, a range (a-b), a list (a,b,c) or any of those with a step (*/n).
+type CronSchedule struct {
+	spec   string
+	fields [5]uint64 // bitset of the allowed values of each field
+	anyDOM bool      // the day of month is *
+	anyDOW bool      // the day of week is *
+}
+
+// ParseCronSchedule parses a cron expression, or one of the aliases @hourly,
+// @daily, @midnight, @weekly and @monthly.
+func ParseCronSchedule(spec string) (*CronSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	expr := spec
+	if alias, ok := cronAliases[strings.ToLower(spec)]; ok {
+		expr = alias
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("cron schedule %q must have %d fields", spec, len(cronFields))
+	}
+	s := &CronSchedule{spec: spec}
+	for i, part := range parts {
+		bits, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron schedule %q: %s", spec, err)
+		}
+		s.fields[i] = bits
+	}
+	// sunday may be written as 0 or 7
+	if s.fields[4]&(1<<7) != 0 {
+		s.fields[4] = (s.fields[4] | 1) &^ (1 << 7)
+	}
+	s.anyDOM = parts[2] == "*"
+	s.anyDOW = parts[4] == "*"
+	return s, nil
+}
+
+func parseCronField(part string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(part, ",") {
+		rng, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in %s %q", field.name, item)
+			}
+			rng = item[:i]
+		}
+		lo, hi := field.min, field.max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid %s %q", field.name, item)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid %s %q", field.name, item)
+				}
+			} else if step > 1 {
+				hi = field.max
+			}
+			if lo < field.min || hi > field.max || lo > hi {
+				return 0, fmt.Errorf("%s %q is out of range %d-%d", field.name, item, field.min, field.max)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// String returns the expression the schedule was parsed from
+func (s *CronSchedule) String() string {
+	return s.spec
+}
+
+func (s *CronSchedule) matches(field, value int) bool {
+	return s.fields[field]&(1<<uint(value)) != 0
+}
+
+// matchesDay follows cron in allowing either the day of month or the day of
+// week to match when both are restricted.
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dom, dow := s.matches(2, t.Day()), s.matches(4, int(t.Weekday()))
+	if s.anyDOM || s.anyDOW {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next returns the first minute after t that matches the schedule, in the
+// location of t, or the zero time if there is none within five years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !s.matches(3, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !s.matches(1, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !s.matches(0, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	for _, spec := range []string{"* * * * *", "0 */6 * * *", "15,45 9-17 * * 1-5", "0 0 1 1 *", "@daily", "@Weekly", "30 2 * * 7"} {
+		if _, err := ParseCronSchedule(spec); err != nil {
+			t.Errorf("Unexpected error for %q: %s", spec, err)
+		}
+	}
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		if _, err := ParseCronSchedule(spec); err == nil {
+			t.Errorf("Expected error for %q", spec)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday, 2019-01-02 10:20:30
+	start := time.Date(2019, 1, 2, 10, 20, 30, 0, time.UTC)
+	for _, tc := range []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2019, 1, 2, 10, 21, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2019, 1, 2, 11, 0, 0, 0, time.UTC)},
+		{"0 */6 * * *", time.Date(2019, 1, 2, 12, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2019, 1, 6, 0, 0, 0, 0, time.UTC)},
+		{"30 2 * * 7", time.Date(2019, 1, 6, 2, 30, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 3 *", time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)},
+		// day of month or day of week
+		{"0 0 15 * 5", time.Date(2019, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	} {
+		s, err := ParseCronSchedule(tc.spec)
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %s", tc.spec, err)
+		}
+		if next := s.Next(start); !next.Equal(tc.next) {
+			t.Errorf("Next(%s) for %q: got %s, expected %s", start, tc.spec, next, tc.next)
+		}
+	}
+}
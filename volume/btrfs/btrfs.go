@@ -607,8 +607,17 @@ func (v *BtrfsVolume) Export(label, parent string, writer io.Writer, excludes []
 	} else if !exists {
 		return volume.ErrSnapshotDoesNotExist
 	}
+	var parentpath string
+	if parent = strings.TrimSpace(parent); parent != "" {
+		if exists, err := v.snapshotExists(parent); err != nil {
+			return err
+		} else if !exists {
+			return volume.ErrSnapshotDoesNotExist
+		}
+		parentpath = v.snapshotPath(parent)
+	}
 	// TODO: add to tarfile and include metadata
-	if err := runBtrfsSend(writer, v.sudoer, parent, v.snapshotPath(label)); err != nil {
+	if err := runBtrfsSend(writer, v.sudoer, parentpath, v.snapshotPath(label)); err != nil {
 		glog.Errorf("Could not export snapshot %s: %s", label, err)
 		return err
 	}
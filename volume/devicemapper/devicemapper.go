@@ -1267,6 +1267,9 @@ func (v *DeviceMapperVolume) Rollback(label string) error {
 func (v *DeviceMapperVolume) Export(label, parent string, writer io.Writer, excludes []string) error {
 	glog.V(2).Infof("Export() (%s) START", v.name)
 	defer glog.V(2).Infof("Export() (%s) END", v.name)
+	if strings.TrimSpace(parent) != "" {
+		return volume.ErrExportParentUnsupported
+	}
 	if !v.snapshotExists(label) {
 		return volume.ErrSnapshotDoesNotExist
 	}
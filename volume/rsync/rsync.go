@@ -650,6 +650,9 @@ func (v *RsyncVolume) Rollback(label string) (err error) {
 
 // Export implements volume.Volume.Export
 func (v *RsyncVolume) Export(label, parent string, writer io.Writer, excludes []string) error {
+	if strings.TrimSpace(parent) != "" {
+		return volume.ErrExportParentUnsupported
+	}
 	if len(excludes) > 0 {
 		glog.Warning("rsync backups do not support excluding directories")
 	}
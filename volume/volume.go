@@ -78,6 +78,7 @@ var (
 	ErrInsufficientPermissions = errors.New("insufficient permissions to run command")
 	ErrTagAlreadyExists        = errors.New("a snapshot with the given tag already exists")
 	ErrInvalidSnapshot         = errors.New("invalid snapshot")
+	ErrExportParentUnsupported = errors.New("driver cannot export the changes since a parent snapshot")
 )
 
 func init() {
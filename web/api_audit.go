@@ -0,0 +1,88 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/control-center/serviced/domain/auditlog"
+	"github.com/control-center/serviced/domain/service"
+	"github.com/zenoss/go-json-rest"
+)
+
+// getAuditEvents returns the events of the audit trail, most recent first.
+// This call supports filtering with the since, until, user, action, type,
+// service and id parameters, and paging with the offset and limit parameters.
+func getAuditEvents(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	query, err := buildAuditQuery(r)
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	events, err := facade.GetAuditEvents(dataCtx, query)
+	if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	w.WriteJson(events)
+}
+
+func buildAuditQuery(r *rest.Request) (auditlog.Query, error) {
+	values := r.URL.Query()
+	query := auditlog.Query{
+		User:     values.Get("user"),
+		Action:   values.Get("action"),
+		Type:     values.Get("type"),
+		EntityID: values.Get("id"),
+	}
+
+	if serviceID := values.Get("service"); serviceID != "" {
+		if query.Type != "" && query.Type != service.GetType() {
+			return auditlog.Query{}, fmt.Errorf("service may not be used with type %s", query.Type)
+		}
+		query.Type = service.GetType()
+		query.EntityID = serviceID
+	}
+
+	var err error
+	if since := values.Get("since"); since != "" {
+		if query.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			return auditlog.Query{}, err
+		}
+	}
+	if until := values.Get("until"); until != "" {
+		if query.Until, err = time.Parse(time.RFC3339, until); err != nil {
+			return auditlog.Query{}, err
+		}
+	}
+	if offset := values.Get("offset"); offset != "" {
+		if query.Offset, err = strconv.Atoi(offset); err != nil {
+			return auditlog.Query{}, err
+		}
+	}
+	if limit := values.Get("limit"); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil {
+			return auditlog.Query{}, err
+		}
+	}
+
+	return query, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/control-center/serviced/domain/auditlog"
+	"github.com/control-center/serviced/facade"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestWebSuite) TestRestGetAuditEventsShouldFilterAndPage(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/audit?service=svc1&user=oncall&since=2019-05-01T00:00:00Z&offset=10&limit=5", "")
+	expected := auditlog.Query{
+		User:     "oncall",
+		Type:     "service",
+		EntityID: "svc1",
+		Since:    time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC),
+		Offset:   10,
+		Limit:    5,
+	}
+	events := []auditlog.Event{{ID: "event1", User: "oncall", Action: "stop", Type: "service", EntityID: "svc1"}}
+
+	s.mockFacade.
+		On("GetAuditEvents", s.ctx.getDatastoreContext(), expected).
+		Return(events, nil)
+
+	getAuditEvents(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	var result []auditlog.Event
+	s.getResult(c, &result)
+	c.Assert(result, HasLen, 1)
+	c.Assert(result[0].ID, Equals, "event1")
+}
+
+func (s *TestWebSuite) TestRestGetAuditEventsShouldRejectBadTime(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/audit?since=yesterday", "")
+
+	getAuditEvents(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Not(Equals), http.StatusOK)
+	s.mockFacade.AssertNotCalled(c, "GetAuditEvents", mock.Anything, mock.Anything)
+}
+
+func (s *TestWebSuite) TestRestGetAuditEventsShouldForbidRestrictedUsers(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/audit", "")
+
+	s.mockFacade.
+		On("GetAuditEvents", s.ctx.getDatastoreContext(), auditlog.Query{}).
+		Return(nil, facade.ErrTenantNotAuthorized)
+
+	getAuditEvents(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusForbidden)
+}
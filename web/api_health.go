@@ -0,0 +1,38 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"net/url"
+
+	"github.com/zenoss/go-json-rest"
+)
+
+// getServiceHealthHistory returns the recent health check results of each
+// instance of a service
+func getServiceHealthHistory(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	serviceID, err := url.QueryUnescape(r.PathParam("serviceId"))
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	history, err := ctx.getFacade().GetServiceHealthHistory(ctx.getDatastoreContext(), serviceID)
+	if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	w.WriteJson(history)
+}
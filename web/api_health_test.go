@@ -0,0 +1,85 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/control-center/serviced/facade"
+	"github.com/control-center/serviced/health"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestWebSuite) TestRestGetServiceHealthHistory(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services/svc1/health/history", "")
+	request.PathParams["serviceId"] = "svc1"
+
+	history := map[int]map[string]health.HealthCheckHistory{
+		0: {"running": health.NewHealthCheckHistory([]health.HealthStatus{
+			{Status: health.OK},
+			{Status: health.Failed, Output: "connection refused"},
+		})},
+	}
+	s.mockFacade.
+		On("GetServiceHealthHistory", s.ctx.getDatastoreContext(), "svc1").
+		Return(history, nil)
+
+	getServiceHealthHistory(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	var actual map[int]map[string]health.HealthCheckHistory
+	s.getResult(c, &actual)
+	c.Assert(actual[0]["running"].Results, HasLen, 2)
+	c.Assert(actual[0]["running"].Results[1].Output, Equals, "connection refused")
+	c.Assert(actual[0]["running"].Transitions, Equals, 1)
+}
+
+func (s *TestWebSuite) TestRestGetServiceHealthHistoryShouldForbidOtherTenants(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services/svc1/health/history", "")
+	request.PathParams["serviceId"] = "svc1"
+
+	s.mockFacade.
+		On("GetServiceHealthHistory", s.ctx.getDatastoreContext(), "svc1").
+		Return(nil, facade.ErrTenantNotAuthorized)
+
+	getServiceHealthHistory(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusForbidden)
+}
+
+func (s *TestWebSuite) TestRestGetServicesHealthShouldIncludeFailureOutput(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/servicehealth", "")
+
+	s.mockFacade.
+		On("GetServicesHealth", s.ctx.getDatastoreContext()).
+		Return(map[string]map[int]map[string]health.HealthStatus{
+			"svc1": {0: {
+				"answering": {Status: health.Failed, ExitCode: 7, Output: "connection refused"},
+				"running":   {Status: health.OK, Output: "ok"},
+			}},
+		}, nil)
+
+	restGetServicesHealth(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	var actual struct {
+		Statuses map[string]map[int]map[string]health.HealthStatus
+	}
+	s.getResult(c, &actual)
+	c.Assert(actual.Statuses["svc1"][0]["answering"].ExitCode, Equals, 7)
+	c.Assert(actual.Statuses["svc1"][0]["answering"].Output, Equals, "connection refused")
+	c.Assert(actual.Statuses["svc1"][0]["running"].Output, Equals, "")
+}
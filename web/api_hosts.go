@@ -103,3 +103,71 @@ func getHostStatuses(w *rest.ResponseWriter, r *rest.Request, ctx *requestContex
 
 	w.WriteJson(statuses)
 }
+
+// getHostLabels returns the labels assigned to a host.
+func getHostLabels(w *rest.ResponseWriter, r *rest.Request, c *requestContext) {
+	ctx := c.getDatastoreContext()
+	f := c.getFacade()
+
+	hostID, err := url.QueryUnescape(r.PathParam("hostId"))
+	if err != nil {
+		writeJSON(w, err, http.StatusBadRequest)
+		return
+	} else if len(hostID) == 0 {
+		writeJSON(w, "hostId must be specified", http.StatusBadRequest)
+		return
+	}
+
+	h, err := f.GetHost(ctx, hostID)
+	if err != nil {
+		restServerError(w, err)
+		return
+	} else if h == nil {
+		writeJSON(w, "host not found", http.StatusNotFound)
+		return
+	}
+
+	labels := h.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	w.WriteJson(labels)
+}
+
+// putHostLabels replaces the labels assigned to a host.
+func putHostLabels(w *rest.ResponseWriter, r *rest.Request, c *requestContext) {
+	ctx := c.getDatastoreContext()
+	f := c.getFacade()
+
+	hostID, err := url.QueryUnescape(r.PathParam("hostId"))
+	if err != nil {
+		writeJSON(w, err, http.StatusBadRequest)
+		return
+	} else if len(hostID) == 0 {
+		writeJSON(w, "hostId must be specified", http.StatusBadRequest)
+		return
+	}
+
+	var payload map[string]string
+	if err := r.DecodeJsonPayload(&payload); err != nil {
+		writeJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	h, err := f.GetHost(ctx, hostID)
+	if err != nil {
+		restServerError(w, err)
+		return
+	} else if h == nil {
+		writeJSON(w, "host not found", http.StatusNotFound)
+		return
+	}
+
+	h.Labels = payload
+	if err := f.UpdateHost(ctx, h); err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	writeJSON(w, "Host Labels Updated.", http.StatusOK)
+}
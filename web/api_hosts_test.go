@@ -89,3 +89,46 @@ func (s *TestWebSuite) TestGetHostsForPoolShouldReturnBadRequestForMissingPoolId
 	getHostsForPool(&(s.writer), &request, s.ctx)
 	c.Assert(s.recorder.Code, Equals, http.StatusBadRequest)
 }
+
+func (s *TestWebSuite) TestGetHostLabelsShouldReturnLabels(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/hosts/firstHost/labels", "")
+	request.PathParams["hostId"] = "firstHost"
+
+	s.mockFacade.
+		On("GetHost", s.ctx.getDatastoreContext(), "firstHost").
+		Return(&host.Host{ID: "firstHost", Labels: map[string]string{"disk": "ssd"}}, nil)
+
+	getHostLabels(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	var labels map[string]string
+	s.getResult(c, &labels)
+	c.Assert(labels, DeepEquals, map[string]string{"disk": "ssd"})
+}
+
+func (s *TestWebSuite) TestPutHostLabelsShouldUpdateHost(c *C) {
+	request := s.buildRequest("PUT", "http://www.example.com/api/v2/hosts/secondHost/labels", `{"disk": "ssd", "latency": "low"}`)
+	request.PathParams["hostId"] = "secondHost"
+
+	s.mockFacade.
+		On("GetHost", s.ctx.getDatastoreContext(), "secondHost").
+		Return(&host.Host{ID: "secondHost", Labels: map[string]string{"disk": "hdd"}}, nil)
+	s.mockFacade.
+		On("UpdateHost", s.ctx.getDatastoreContext(), &host.Host{
+			ID:     "secondHost",
+			Labels: map[string]string{"disk": "ssd", "latency": "low"},
+		}).
+		Return(nil)
+
+	putHostLabels(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	s.mockFacade.AssertExpectations(c)
+}
+
+func (s *TestWebSuite) TestPutHostLabelsShouldReturnBadRequestForInvalidPayload(c *C) {
+	request := s.buildRequest("PUT", "http://www.example.com/api/v2/hosts/secondHost/labels", `["disk"]`)
+	request.PathParams["hostId"] = "secondHost"
+	putHostLabels(&(s.writer), &request, s.ctx)
+	c.Assert(s.recorder.Code, Equals, http.StatusBadRequest)
+}
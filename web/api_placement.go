@@ -0,0 +1,63 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/control-center/serviced/domain/service"
+	"github.com/zenoss/go-json-rest"
+)
+
+// getServicePlacement returns how the scheduler chose a host for each instance
+// of a service.  The instance query parameter limits the result to a single
+// instance.
+func getServicePlacement(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	serviceID, err := url.QueryUnescape(r.PathParam("serviceId"))
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	instanceID := -1
+	if instance := r.URL.Query().Get("instance"); instance != "" {
+		if instanceID, err = strconv.Atoi(instance); err != nil || instanceID < 0 {
+			writeJSON(w, "instance must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	f := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	decisions, err := f.GetPlacementDecisions(dataCtx, serviceID)
+	if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	if instanceID >= 0 {
+		found := []service.PlacementDecision{}
+		for _, decision := range decisions {
+			if decision.InstanceID == instanceID {
+				found = append(found, decision)
+			}
+		}
+		decisions = found
+	}
+
+	w.WriteJson(decisions)
+}
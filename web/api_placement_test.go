@@ -0,0 +1,84 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/facade"
+	. "gopkg.in/check.v1"
+)
+
+var placementTestDecisions = []service.PlacementDecision{
+	{ServiceID: "svc1", InstanceID: 0, Strategy: "balance", HostID: "host1"},
+	{ServiceID: "svc1", InstanceID: 1, Message: "no hosts available"},
+}
+
+func (s *TestWebSuite) TestRestGetServicePlacement(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services/svc1/placement", "")
+	request.PathParams["serviceId"] = "svc1"
+
+	s.mockFacade.
+		On("GetPlacementDecisions", s.ctx.getDatastoreContext(), "svc1").
+		Return(placementTestDecisions, nil)
+
+	getServicePlacement(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	var decisions []service.PlacementDecision
+	s.getResult(c, &decisions)
+	c.Assert(decisions, HasLen, 2)
+}
+
+func (s *TestWebSuite) TestRestGetServicePlacementForInstance(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services/svc1/placement?instance=1", "")
+	request.PathParams["serviceId"] = "svc1"
+
+	s.mockFacade.
+		On("GetPlacementDecisions", s.ctx.getDatastoreContext(), "svc1").
+		Return(placementTestDecisions, nil)
+
+	getServicePlacement(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	var decisions []service.PlacementDecision
+	s.getResult(c, &decisions)
+	c.Assert(decisions, HasLen, 1)
+	c.Assert(decisions[0].Message, Equals, "no hosts available")
+}
+
+func (s *TestWebSuite) TestRestGetServicePlacementShouldRejectBadInstance(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services/svc1/placement?instance=x", "")
+	request.PathParams["serviceId"] = "svc1"
+
+	getServicePlacement(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusBadRequest)
+}
+
+func (s *TestWebSuite) TestRestGetServicePlacementShouldForbidOtherTenants(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services/svc1/placement", "")
+	request.PathParams["serviceId"] = "svc1"
+
+	s.mockFacade.
+		On("GetPlacementDecisions", s.ctx.getDatastoreContext(), "svc1").
+		Return(nil, facade.ErrTenantNotAuthorized)
+
+	getServicePlacement(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusForbidden)
+}
@@ -0,0 +1,141 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/facade"
+	"github.com/zenoss/go-json-rest"
+)
+
+// rollingRestartRequest is the payload for starting a rolling restart.  The
+// timeout is a duration string, such as "5m".
+type rollingRestartRequest struct {
+	BatchSize    int
+	BatchPercent int
+	Timeout      string
+	AutoLaunch   bool
+}
+
+// postRollingRestart restarts the running instances of a service in
+// health-gated batches.
+func postRollingRestart(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	serviceID, err := url.QueryUnescape(r.PathParam("serviceId"))
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	var req rollingRestartRequest
+	if err := r.DecodeJsonPayload(&req); err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	request := service.RollingRestartRequest{
+		ServiceID:    serviceID,
+		BatchSize:    req.BatchSize,
+		BatchPercent: req.BatchPercent,
+		AutoLaunch:   req.AutoLaunch,
+	}
+	if req.Timeout != "" {
+		if request.Timeout, err = time.ParseDuration(req.Timeout); err != nil {
+			restBadRequest(w, err)
+			return
+		}
+	}
+
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	status, err := facade.RollingRestartService(dataCtx, request)
+	if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	w.WriteJson(status)
+}
+
+// getRollingRestart returns the progress of the most recent rolling restart
+// of a service.
+func getRollingRestart(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	serviceID, err := url.QueryUnescape(r.PathParam("serviceId"))
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	f := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	status, err := f.GetRollingRestartStatus(dataCtx, serviceID)
+	if err == facade.ErrNoRollingRestart {
+		writeJSON(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	w.WriteJson(status)
+}
+
+// postResumeRollingRestart continues a paused rolling restart.
+func postResumeRollingRestart(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	serviceID, err := url.QueryUnescape(r.PathParam("serviceId"))
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	f := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	if err := f.ResumeRollingRestart(dataCtx, serviceID); err == facade.ErrNoRollingRestart {
+		writeJSON(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	restSuccess(w)
+}
+
+// postCancelRollingRestart stops a rolling restart before its next batch.
+func postCancelRollingRestart(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	serviceID, err := url.QueryUnescape(r.PathParam("serviceId"))
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	f := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	if err := f.CancelRollingRestart(dataCtx, serviceID); err == facade.ErrNoRollingRestart {
+		writeJSON(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	restSuccess(w)
+}
@@ -0,0 +1,95 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/control-center/serviced/domain/service"
+	"github.com/control-center/serviced/facade"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestWebSuite) TestRestPostRollingRestart(c *C) {
+	request := s.buildRequest("POST", "http://www.example.com/api/v2/services/svc1/rollingrestart", `{"BatchPercent": 50, "Timeout": "2m"}`)
+	request.PathParams["serviceId"] = "svc1"
+	expected := service.RollingRestartRequest{ServiceID: "svc1", BatchPercent: 50, Timeout: 2 * time.Minute}
+	status := &service.RollingRestartStatus{ServiceID: "svc1", State: service.RollingRestartRunning, BatchSize: 2, Batches: 2}
+
+	s.mockFacade.
+		On("RollingRestartService", s.ctx.getDatastoreContext(), expected).
+		Return(status, nil)
+
+	postRollingRestart(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	var result service.RollingRestartStatus
+	s.getResult(c, &result)
+	c.Assert(result.BatchSize, Equals, 2)
+	c.Assert(result.State, Equals, service.RollingRestartRunning)
+}
+
+func (s *TestWebSuite) TestRestPostRollingRestartBadTimeout(c *C) {
+	request := s.buildRequest("POST", "http://www.example.com/api/v2/services/svc1/rollingrestart", `{"BatchSize": 1, "Timeout": "soon"}`)
+	request.PathParams["serviceId"] = "svc1"
+
+	postRollingRestart(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Not(Equals), http.StatusOK)
+	s.mockFacade.AssertNotCalled(c, "RollingRestartService", mock.Anything, mock.Anything)
+}
+
+func (s *TestWebSuite) TestRestGetRollingRestartNotFound(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services/svc1/rollingrestart", "")
+	request.PathParams["serviceId"] = "svc1"
+
+	s.mockFacade.
+		On("GetRollingRestartStatus", s.ctx.getDatastoreContext(), "svc1").
+		Return(nil, facade.ErrNoRollingRestart)
+
+	getRollingRestart(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusNotFound)
+}
+
+func (s *TestWebSuite) TestRestPostResumeRollingRestartShouldForbidOtherTenants(c *C) {
+	request := s.buildRequest("POST", "http://www.example.com/api/v2/services/svc1/rollingrestart/resume", "")
+	request.PathParams["serviceId"] = "svc1"
+
+	s.mockFacade.
+		On("ResumeRollingRestart", s.ctx.getDatastoreContext(), "svc1").
+		Return(facade.ErrTenantNotAuthorized)
+
+	postResumeRollingRestart(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusForbidden)
+}
+
+func (s *TestWebSuite) TestRestPostCancelRollingRestart(c *C) {
+	request := s.buildRequest("POST", "http://www.example.com/api/v2/services/svc1/rollingrestart/cancel", "")
+	request.PathParams["serviceId"] = "svc1"
+
+	s.mockFacade.
+		On("CancelRollingRestart", s.ctx.getDatastoreContext(), "svc1").
+		Return(nil)
+
+	postCancelRollingRestart(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	s.mockFacade.AssertCalled(c, "CancelRollingRestart", s.ctx.getDatastoreContext(), "svc1")
+}
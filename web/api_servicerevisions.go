@@ -0,0 +1,106 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/zenoss/go-json-rest"
+)
+
+// getServiceRevisions returns the stored revisions of a service, most recent
+// first.
+func getServiceRevisions(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	serviceID, err := url.QueryUnescape(r.PathParam("serviceId"))
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	revisions, err := facade.GetServiceRevisions(dataCtx, serviceID)
+	if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	w.WriteJson(revisions)
+}
+
+// getServiceRevision returns a revision of a service, including the service
+// definition and config files as of that revision.
+func getServiceRevision(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	serviceID, revision, err := getServiceRevisionParams(r)
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	rev, err := facade.GetServiceRevision(dataCtx, serviceID, revision)
+	if datastore.IsErrNoSuchEntity(err) {
+		msg := fmt.Sprintf("Revision %d of service %v Not Found", revision, serviceID)
+		writeJSON(w, msg, http.StatusNotFound)
+		return
+	} else if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	w.WriteJson(rev)
+}
+
+// postRevertService updates a service to the definition stored in one of its
+// revisions.
+func postRevertService(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	serviceID, revision, err := getServiceRevisionParams(r)
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	if err := facade.RevertService(dataCtx, serviceID, revision); datastore.IsErrNoSuchEntity(err) {
+		msg := fmt.Sprintf("Revision %d of service %v Not Found", revision, serviceID)
+		writeJSON(w, msg, http.StatusNotFound)
+		return
+	} else if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	restSuccess(w)
+}
+
+func getServiceRevisionParams(r *rest.Request) (string, int, error) {
+	serviceID, err := url.QueryUnescape(r.PathParam("serviceId"))
+	if err != nil {
+		return "", 0, err
+	}
+	revision, err := strconv.Atoi(r.PathParam("revision"))
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid revision: %s", r.PathParam("revision"))
+	}
+	return serviceID, revision, nil
+}
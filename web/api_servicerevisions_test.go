@@ -0,0 +1,100 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/servicerevision"
+	"github.com/control-center/serviced/facade"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestWebSuite) TestRestGetServiceRevisions(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services/svc1/revisions", "")
+	request.PathParams["serviceId"] = "svc1"
+	revisions := []servicerevision.Revision{
+		{ID: servicerevision.RevisionID("svc1", 2), ServiceID: "svc1", Revision: 2},
+		{ID: servicerevision.RevisionID("svc1", 1), ServiceID: "svc1", Revision: 1},
+	}
+
+	s.mockFacade.
+		On("GetServiceRevisions", s.ctx.getDatastoreContext(), "svc1").
+		Return(revisions, nil)
+
+	getServiceRevisions(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	var result []servicerevision.Revision
+	s.getResult(c, &result)
+	c.Assert(result, HasLen, 2)
+	c.Assert(result[0].Revision, Equals, 2)
+}
+
+func (s *TestWebSuite) TestRestGetServiceRevisionsShouldForbidOtherTenants(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services/svc1/revisions", "")
+	request.PathParams["serviceId"] = "svc1"
+
+	s.mockFacade.
+		On("GetServiceRevisions", s.ctx.getDatastoreContext(), "svc1").
+		Return(nil, facade.ErrTenantNotAuthorized)
+
+	getServiceRevisions(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusForbidden)
+}
+
+func (s *TestWebSuite) TestRestGetServiceRevisionNotFound(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services/svc1/revisions/3", "")
+	request.PathParams["serviceId"] = "svc1"
+	request.PathParams["revision"] = "3"
+
+	s.mockFacade.
+		On("GetServiceRevision", s.ctx.getDatastoreContext(), "svc1", 3).
+		Return(nil, datastore.ErrNoSuchEntity{Key: servicerevision.Key(servicerevision.RevisionID("svc1", 3))})
+
+	getServiceRevision(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusNotFound)
+}
+
+func (s *TestWebSuite) TestRestPostRevertService(c *C) {
+	request := s.buildRequest("POST", "http://www.example.com/api/v2/services/svc1/revisions/1/revert", "")
+	request.PathParams["serviceId"] = "svc1"
+	request.PathParams["revision"] = "1"
+
+	s.mockFacade.
+		On("RevertService", s.ctx.getDatastoreContext(), "svc1", 1).
+		Return(nil)
+
+	postRevertService(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	s.mockFacade.AssertCalled(c, "RevertService", s.ctx.getDatastoreContext(), "svc1", 1)
+}
+
+func (s *TestWebSuite) TestRestPostRevertServiceShouldRejectBadRevision(c *C) {
+	request := s.buildRequest("POST", "http://www.example.com/api/v2/services/svc1/revisions/latest/revert", "")
+	request.PathParams["serviceId"] = "svc1"
+	request.PathParams["revision"] = "latest"
+
+	postRevertService(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Not(Equals), http.StatusOK)
+	s.mockFacade.AssertNotCalled(c, "RevertService", mock.Anything, mock.Anything, mock.Anything)
+}
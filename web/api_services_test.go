@@ -294,3 +294,22 @@ func (s *TestWebSuite) TestRestQueryServiceDetailsShouldQueryForUpdated(c *C) {
 	query := service.Query{Tags: []string{}, Since: time.Duration(5 * time.Second)}
 	s.mockFacade.AssertCalled(c, "QueryServiceDetails", s.ctx.getDatastoreContext(), query)
 }
+
+func (s *TestWebSuite) TestAuthorizeAllTenantsShouldForbidRestrictedUsers(c *C) {
+	request := s.buildRequest("PUT", "http://www.example.com/services/svc1", "")
+	s.mockFacade.On("IsTenantRestricted", mock.Anything).Return(true, nil)
+
+	ok := s.ctx.sc.authorizeAllTenants(&(s.writer), &request)
+
+	c.Assert(ok, Equals, false)
+	c.Assert(s.recorder.Code, Equals, http.StatusForbidden)
+}
+
+func (s *TestWebSuite) TestAuthorizeAllTenantsShouldAllowUnrestrictedUsers(c *C) {
+	request := s.buildRequest("PUT", "http://www.example.com/services/svc1", "")
+	s.mockFacade.On("IsTenantRestricted", mock.Anything).Return(false, nil)
+
+	ok := s.ctx.sc.authorizeAllTenants(&(s.writer), &request)
+
+	c.Assert(ok, Equals, true)
+}
@@ -0,0 +1,113 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/snapshotschedule"
+	"github.com/zenoss/go-json-rest"
+)
+
+// getSnapshotSchedules returns the snapshot schedules of the tenants the user
+// may act on
+func getSnapshotSchedules(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	schedules, err := facade.GetSnapshotSchedules(dataCtx)
+	if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	w.WriteJson(schedules)
+}
+
+// getSnapshotSchedule returns the snapshot schedule of a tenant
+func getSnapshotSchedule(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	tenantID, err := url.QueryUnescape(r.PathParam("tenantId"))
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	sched, err := facade.GetSnapshotSchedule(dataCtx, tenantID)
+	if datastore.IsErrNoSuchEntity(err) {
+		msg := fmt.Sprintf("Snapshot Schedule of tenant %v Not Found", tenantID)
+		writeJSON(w, msg, http.StatusNotFound)
+		return
+	} else if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	w.WriteJson(sched)
+}
+
+// putSnapshotSchedule adds or replaces the snapshot schedule of a tenant.  The
+// tenant in the path takes precedence over the one in the payload.
+func putSnapshotSchedule(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	tenantID, err := url.QueryUnescape(r.PathParam("tenantId"))
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	var sched snapshotschedule.Schedule
+	if err := r.DecodeJsonPayload(&sched); err != nil {
+		restBadRequest(w, err)
+		return
+	}
+	sched.TenantID = tenantID
+
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	if err := facade.SetSnapshotSchedule(dataCtx, sched); err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	restSuccess(w)
+}
+
+// deleteSnapshotSchedule removes the snapshot schedule of a tenant
+func deleteSnapshotSchedule(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	tenantID, err := url.QueryUnescape(r.PathParam("tenantId"))
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	if err := facade.RemoveSnapshotSchedule(dataCtx, tenantID); datastore.IsErrNoSuchEntity(err) {
+		msg := fmt.Sprintf("Snapshot Schedule of tenant %v Not Found", tenantID)
+		writeJSON(w, msg, http.StatusNotFound)
+		return
+	} else if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	restSuccess(w)
+}
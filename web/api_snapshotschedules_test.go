@@ -0,0 +1,107 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain"
+	"github.com/control-center/serviced/domain/snapshotschedule"
+	"github.com/control-center/serviced/facade"
+	"github.com/stretchr/testify/mock"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestWebSuite) TestRestGetSnapshotSchedules(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/snapshotschedules", "")
+	schedules := []snapshotschedule.Schedule{
+		{TenantID: "tenant1", Cron: "@daily"},
+		{TenantID: "tenant2", Cron: "0 * * * *"},
+	}
+
+	s.mockFacade.
+		On("GetSnapshotSchedules", s.ctx.getDatastoreContext()).
+		Return(schedules, nil)
+
+	getSnapshotSchedules(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	var result []snapshotschedule.Schedule
+	s.getResult(c, &result)
+	c.Assert(result, HasLen, 2)
+	c.Assert(result[1].Cron, Equals, "0 * * * *")
+}
+
+func (s *TestWebSuite) TestRestGetSnapshotScheduleNotFound(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/snapshotschedules/tenant1", "")
+	request.PathParams["tenantId"] = "tenant1"
+
+	s.mockFacade.
+		On("GetSnapshotSchedule", s.ctx.getDatastoreContext(), "tenant1").
+		Return(nil, datastore.ErrNoSuchEntity{Key: snapshotschedule.Key("tenant1")})
+
+	getSnapshotSchedule(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusNotFound)
+}
+
+func (s *TestWebSuite) TestRestPutSnapshotSchedule(c *C) {
+	request := s.buildRequest("PUT", "http://www.example.com/api/v2/snapshotschedules/tenant1",
+		`{"TenantID": "other", "Cron": "@daily", "Retention": {"KeepDaily": 7}}`)
+	request.PathParams["tenantId"] = "tenant1"
+	expected := snapshotschedule.Schedule{
+		TenantID:  "tenant1",
+		Cron:      "@daily",
+		Retention: domain.RetentionPolicy{KeepDaily: 7},
+	}
+
+	s.mockFacade.
+		On("SetSnapshotSchedule", s.ctx.getDatastoreContext(), expected).
+		Return(nil)
+
+	putSnapshotSchedule(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	s.mockFacade.AssertCalled(c, "SetSnapshotSchedule", s.ctx.getDatastoreContext(), expected)
+}
+
+func (s *TestWebSuite) TestRestPutSnapshotScheduleShouldForbidOtherTenants(c *C) {
+	request := s.buildRequest("PUT", "http://www.example.com/api/v2/snapshotschedules/tenant1", `{"Cron": "@daily"}`)
+	request.PathParams["tenantId"] = "tenant1"
+
+	s.mockFacade.
+		On("SetSnapshotSchedule", s.ctx.getDatastoreContext(), mock.AnythingOfType("snapshotschedule.Schedule")).
+		Return(facade.ErrTenantNotAuthorized)
+
+	putSnapshotSchedule(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusForbidden)
+}
+
+func (s *TestWebSuite) TestRestDeleteSnapshotSchedule(c *C) {
+	request := s.buildRequest("DELETE", "http://www.example.com/api/v2/snapshotschedules/tenant1", "")
+	request.PathParams["tenantId"] = "tenant1"
+
+	s.mockFacade.
+		On("RemoveSnapshotSchedule", s.ctx.getDatastoreContext(), "tenant1").
+		Return(nil)
+
+	deleteSnapshotSchedule(&(s.writer), &request, s.ctx)
+
+	c.Assert(s.recorder.Code, Equals, http.StatusOK)
+	s.mockFacade.AssertCalled(c, "RemoveSnapshotSchedule", s.ctx.getDatastoreContext(), "tenant1")
+}
@@ -0,0 +1,110 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/apitoken"
+	"github.com/zenoss/go-json-rest"
+)
+
+// apiTokenRequest describes the API token to create.  Expiration is a
+// duration such as "720h"; tokens without one never expire.
+type apiTokenRequest struct {
+	UserName   string
+	Name       string
+	Role       auth.Role
+	TenantIDs  []string
+	Expiration string
+}
+
+// apiTokenResponse carries the bearer token, which is only ever returned
+// when the token is created.
+type apiTokenResponse struct {
+	Token       apitoken.APIToken
+	BearerToken string
+}
+
+// getAPITokens returns all of the API tokens
+func getAPITokens(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	tokens, err := facade.GetAPITokens(dataCtx)
+	if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	w.WriteJson(tokens)
+}
+
+// postAPIToken creates an API token and returns it along with its bearer
+// token
+func postAPIToken(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	var req apiTokenRequest
+	if err := r.DecodeJsonPayload(&req); err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	var expiration time.Duration
+	if req.Expiration != "" {
+		var err error
+		if expiration, err = time.ParseDuration(req.Expiration); err != nil {
+			restBadRequest(w, err)
+			return
+		}
+	}
+
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	token, bearer, err := facade.AddAPIToken(dataCtx, req.UserName, req.Name, req.Role, req.TenantIDs, expiration)
+	if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	w.WriteJson(&apiTokenResponse{Token: token, BearerToken: bearer})
+}
+
+// deleteAPIToken revokes an API token
+func deleteAPIToken(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
+	tokenID, err := url.QueryUnescape(r.PathParam("tokenId"))
+	if err != nil {
+		restBadRequest(w, err)
+		return
+	}
+
+	facade := ctx.getFacade()
+	dataCtx := ctx.getDatastoreContext()
+
+	if err := facade.RemoveAPIToken(dataCtx, tokenID); datastore.IsErrNoSuchEntity(err) {
+		msg := fmt.Sprintf("API Token %v Not Found", tokenID)
+		writeJSON(w, msg, http.StatusNotFound)
+		return
+	} else if err != nil {
+		restServerError(w, err)
+		return
+	}
+
+	restSuccess(w)
+}
@@ -23,3 +23,8 @@ func pamValidateLogin(_ *login, _ string) bool {
 	glog.Errorf("pamValidateLogin is not supported on this platform")
 	return false
 }
+
+func pamValidateLoginOnly(_ *login, _ string) bool {
+	glog.Errorf("pamValidateLoginOnly is not supported on this platform")
+	return false
+}
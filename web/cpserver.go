@@ -26,9 +26,11 @@ import (
 	"strings"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/control-center/serviced/auth"
 	"github.com/control-center/serviced/config"
 	daoclient "github.com/control-center/serviced/dao/client"
 	"github.com/control-center/serviced/datastore"
+	"github.com/control-center/serviced/domain/apitoken"
 	"github.com/control-center/serviced/facade"
 	"github.com/control-center/serviced/node"
 	"github.com/control-center/serviced/rpc/master"
@@ -250,11 +252,14 @@ func (sc *ServiceConfig) Serve(shutdown <-chan (interface{})) {
 
 var methods = []string{"GET", "POST", "PUT", "DELETE", "HEAD"}
 
-func routeToInternalServiceProxy(path string, target string, requiresAuth bool, routes []rest.Route) []rest.Route {
+// routeToInternalServiceProxy proxies requests under path to the target url.
+// Callers must be logged in with at least the required role, unless it is
+// auth.RoleNone.
+func (sc *ServiceConfig) routeToInternalServiceProxy(path string, target string, requiredRole auth.Role, routes []rest.Route) []rest.Route {
 	logger := plog.WithFields(logrus.Fields{
 		"path":         path,
 		"target":       target,
-		"requiresauth": requiresAuth,
+		"requiredrole": requiredRole,
 	})
 
 	targetURL, err := url.Parse(target)
@@ -265,8 +270,7 @@ func routeToInternalServiceProxy(path string, target string, requiresAuth bool,
 	// Wrap the normal http.Handler in a rest.handlerFunc
 	handlerFunc := func(w *rest.ResponseWriter, r *rest.Request) {
 		// All proxied requests should be authenticated first
-		if requiresAuth && !loginOK(w, r) {
-			restUnauthorized(w)
+		if requiredRole != auth.RoleNone && !sc.authorizeRequest(w, r, requiredRole) {
 			return
 		}
 		proxy := node.NewReverseProxy(path, targetURL)
@@ -294,10 +298,29 @@ func (sc *ServiceConfig) unAuthorizedClient(realfunc handlerClientFunc) handlerF
 	}
 }
 
-func (sc *ServiceConfig) authorizedClient(realfunc handlerClientFunc) handlerFunc {
+// authorizedClient verifies that the caller has the required role before
+// handing the request to a control plane client.  The client acts as the
+// system user, so callers that are restricted to some of the tenants are
+// refused.
+func (sc *ServiceConfig) authorizedClient(requiredRole auth.Role, realfunc handlerClientFunc) handlerFunc {
+	check := func(w *rest.ResponseWriter, r *rest.Request) bool {
+		return sc.authorizeRequest(w, r, requiredRole) && sc.authorizeAllTenants(w, r)
+	}
+	return sc.newClientHandler(check, realfunc)
+}
+
+// authorizedInfoClient is authorizedClient for routes that return no tenant
+// data, which callers that are restricted to some of the tenants may use.
+func (sc *ServiceConfig) authorizedInfoClient(requiredRole auth.Role, realfunc handlerClientFunc) handlerFunc {
+	check := func(w *rest.ResponseWriter, r *rest.Request) bool {
+		return sc.authorizeRequest(w, r, requiredRole)
+	}
+	return sc.newClientHandler(check, realfunc)
+}
+
+func (sc *ServiceConfig) newClientHandler(check checkFunc, realfunc handlerClientFunc) handlerFunc {
 	return func(w *rest.ResponseWriter, r *rest.Request) {
-		if !loginOK(w, r) {
-			restUnauthorized(w)
+		if !check(w, r) {
 			return
 		}
 		client, err := sc.getClient()
@@ -353,17 +376,53 @@ func (sc *ServiceConfig) newRequestHandler(check checkFunc, realfunc ctxhandlerF
 	}
 }
 
-func (sc *ServiceConfig) checkAuth(realfunc ctxhandlerFunc) handlerFunc {
+func (sc *ServiceConfig) checkAuth(requiredRole auth.Role, realfunc ctxhandlerFunc) handlerFunc {
 	check := func(w *rest.ResponseWriter, r *rest.Request) bool {
-		if !loginOK(w, r) {
-			restUnauthorized(w)
-			return false
-		}
-		return true
+		return sc.authorizeRequest(w, r, requiredRole)
 	}
 	return sc.newRequestHandler(check, realfunc)
 }
 
+// authorizeRequest verifies that the caller is logged in with at least the
+// required role, and writes the appropriate error response if not.
+func (sc *ServiceConfig) authorizeRequest(w *rest.ResponseWriter, r *rest.Request, requiredRole auth.Role) bool {
+	role, ok := sc.loginOK(w, r)
+	if !ok {
+		restUnauthorized(w)
+		return false
+	}
+	if !role.Satisfies(requiredRole) {
+		plog.WithFields(logrus.Fields{
+			"url":          r.URL.String(),
+			"role":         role,
+			"requiredrole": requiredRole,
+		}).Debug("Request denied; insufficient role")
+		restForbidden(w)
+		return false
+	}
+	return true
+}
+
+// authorizeAllTenants verifies that the caller is not restricted to some of
+// the tenants, and writes the appropriate error response if it is.
+func (sc *ServiceConfig) authorizeAllTenants(w *rest.ResponseWriter, r *rest.Request) bool {
+	reqCtx := newRequestContextFromRequest(sc, r)
+	restricted, err := reqCtx.getFacade().IsTenantRestricted(reqCtx.getDatastoreContext())
+	if err != nil {
+		restServerError(w, err)
+		return false
+	}
+	if restricted {
+		plog.WithFields(logrus.Fields{
+			"url":      r.URL.String(),
+			"username": reqCtx.username,
+		}).Debug("Request denied; user is restricted to some of the tenants")
+		restForbidden(w)
+		return false
+	}
+	return true
+}
+
 func (sc *ServiceConfig) noAuth(realfunc ctxhandlerFunc) handlerFunc {
 	check := func(w *rest.ResponseWriter, r *rest.Request) bool {
 		return true
@@ -385,6 +444,15 @@ func newRequestContext(sc *ServiceConfig) *requestContext {
 func newRequestContextFromRequest(sc *ServiceConfig, r *rest.Request) *requestContext {
 	context := &requestContext{sc: sc}
 
+	if token, err := auth.ExtractRestToken(r.Request); err == nil && token != "" {
+		// Requests made with an API token act as the token, so that the
+		// token's tenant restrictions apply.
+		if apiToken, err := auth.ParseAPIToken(token); err == nil {
+			context.username = apitoken.Principal(apiToken.ID())
+			return context
+		}
+	}
+
 	username, err := getUser(r)
 	if err == nil {
 		context.username = username
@@ -918,10 +918,12 @@ func RestBackupCreate(w *rest.ResponseWriter, r *rest.Request, client *daoclient
 	if getUserErr != nil {
 		plog.WithError(getUserErr).Error("Unable to get user name")
 	}
+	_, incremental := r.URL.Query()["incremental"]
 	req := dao.BackupRequest{
 		Dirpath:              dir,
 		SnapshotSpacePercent: snapshotSpacePercent,
 		Username:             username,
+		Incremental:          incremental,
 	}
 	err := client.AsyncBackup(req, &filePath)
 	if err != nil {
@@ -13,7 +13,10 @@
 
 package web
 
-import "github.com/zenoss/go-json-rest"
+import (
+	"github.com/control-center/serviced/auth"
+	"github.com/zenoss/go-json-rest"
+)
 
 //getRoutes returns all registered rest routes
 func (sc *ServiceConfig) getRoutes() []rest.Route {
@@ -24,90 +27,90 @@ func (sc *ServiceConfig) getRoutes() []rest.Route {
 		rest.Route{"GET", "/", gz(mainPage)},
 
 		// Backups
-		rest.Route{"GET", "/backup/check", gz(sc.authorizedClient(RestBackupCheck))},
-		rest.Route{"GET", "/backup/create", gz(sc.authorizedClient(RestBackupCreate))},
-		rest.Route{"GET", "/backup/restore", gz(sc.authorizedClient(RestBackupRestore))},
-		rest.Route{"GET", "/backup/list", gz(sc.authorizedClient(RestBackupFileList))},
-		rest.Route{"GET", "/backup/status", gz(sc.authorizedClient(RestBackupStatus))},
-		rest.Route{"GET", "/backup/restore/status", gz(sc.authorizedClient(RestRestoreStatus))},
+		rest.Route{"GET", "/backup/check", gz(sc.authorizedClient(auth.RoleClusterAdmin, RestBackupCheck))},
+		rest.Route{"GET", "/backup/create", gz(sc.authorizedClient(auth.RoleClusterAdmin, RestBackupCreate))},
+		rest.Route{"GET", "/backup/restore", gz(sc.authorizedClient(auth.RoleClusterAdmin, RestBackupRestore))},
+		rest.Route{"GET", "/backup/list", gz(sc.authorizedClient(auth.RoleViewer, RestBackupFileList))},
+		rest.Route{"GET", "/backup/status", gz(sc.authorizedClient(auth.RoleViewer, RestBackupStatus))},
+		rest.Route{"GET", "/backup/restore/status", gz(sc.authorizedClient(auth.RoleViewer, RestRestoreStatus))},
 
 		// Hosts
-		rest.Route{"GET", "/hosts", gz(sc.checkAuth(restGetHosts))},
-		rest.Route{"GET", "/hosts/running", gz(sc.checkAuth(restGetActiveHostIDs))},
-		rest.Route{"GET", "/hosts/defaultHostAlias", gz(sc.checkAuth(restGetDefaultHostAlias))},
-		rest.Route{"GET", "/hosts/:hostId", gz(sc.checkAuth(restGetHost))},
-		rest.Route{"POST", "/hosts/add", gz(sc.checkAuth(restAddHost))},
-		rest.Route{"DELETE", "/hosts/:hostId", gz(sc.checkAuth(restRemoveHost))},
-		rest.Route{"PUT", "/hosts/:hostId", gz(sc.checkAuth(restUpdateHost))},
-		rest.Route{"GET", "/hosts/:hostId/running", gz(sc.authorizedClient(restGetRunningForHost))},
-		rest.Route{"DELETE", "/hosts/:hostId/:serviceStateId", gz(sc.authorizedClient(restKillRunning))},
-		rest.Route{"POST", "/hosts/:hostId/key", gz(sc.checkAuth(restResetHostKey))},
+		rest.Route{"GET", "/hosts", gz(sc.checkAuth(auth.RoleViewer, restGetHosts))},
+		rest.Route{"GET", "/hosts/running", gz(sc.checkAuth(auth.RoleViewer, restGetActiveHostIDs))},
+		rest.Route{"GET", "/hosts/defaultHostAlias", gz(sc.checkAuth(auth.RoleViewer, restGetDefaultHostAlias))},
+		rest.Route{"GET", "/hosts/:hostId", gz(sc.checkAuth(auth.RoleViewer, restGetHost))},
+		rest.Route{"POST", "/hosts/add", gz(sc.checkAuth(auth.RoleClusterAdmin, restAddHost))},
+		rest.Route{"DELETE", "/hosts/:hostId", gz(sc.checkAuth(auth.RoleClusterAdmin, restRemoveHost))},
+		rest.Route{"PUT", "/hosts/:hostId", gz(sc.checkAuth(auth.RoleClusterAdmin, restUpdateHost))},
+		rest.Route{"GET", "/hosts/:hostId/running", gz(sc.authorizedClient(auth.RoleViewer, restGetRunningForHost))},
+		rest.Route{"DELETE", "/hosts/:hostId/:serviceStateId", gz(sc.authorizedClient(auth.RoleOperator, restKillRunning))},
+		rest.Route{"POST", "/hosts/:hostId/key", gz(sc.checkAuth(auth.RoleClusterAdmin, restResetHostKey))},
 
 		// Pools
-		rest.Route{"GET", "/pools/:poolId", gz(sc.checkAuth(restGetPool))},
-		rest.Route{"DELETE", "/pools/:poolId", gz(sc.checkAuth(restRemovePool))},
-		rest.Route{"PUT", "/pools/:poolId", gz(sc.checkAuth(restUpdatePool))},
-		rest.Route{"POST", "/pools/add", gz(sc.checkAuth(restAddPool))},
-		rest.Route{"GET", "/pools", gz(sc.checkAuth(restGetPools))},
-		rest.Route{"GET", "/pools/:poolId/hosts", gz(sc.checkAuth(restGetHostsForResourcePool))},
+		rest.Route{"GET", "/pools/:poolId", gz(sc.checkAuth(auth.RoleViewer, restGetPool))},
+		rest.Route{"DELETE", "/pools/:poolId", gz(sc.checkAuth(auth.RoleClusterAdmin, restRemovePool))},
+		rest.Route{"PUT", "/pools/:poolId", gz(sc.checkAuth(auth.RoleClusterAdmin, restUpdatePool))},
+		rest.Route{"POST", "/pools/add", gz(sc.checkAuth(auth.RoleClusterAdmin, restAddPool))},
+		rest.Route{"GET", "/pools", gz(sc.checkAuth(auth.RoleViewer, restGetPools))},
+		rest.Route{"GET", "/pools/:poolId/hosts", gz(sc.checkAuth(auth.RoleViewer, restGetHostsForResourcePool))},
 
 		// Pools (VirtualIP)
-		rest.Route{"PUT", "/pools/:poolId/virtualip", gz(sc.checkAuth(restAddPoolVirtualIP))},
-		rest.Route{"DELETE", "/pools/:poolId/virtualip/*ip", gz(sc.checkAuth(restRemovePoolVirtualIP))},
+		rest.Route{"PUT", "/pools/:poolId/virtualip", gz(sc.checkAuth(auth.RoleClusterAdmin, restAddPoolVirtualIP))},
+		rest.Route{"DELETE", "/pools/:poolId/virtualip/*ip", gz(sc.checkAuth(auth.RoleClusterAdmin, restRemovePoolVirtualIP))},
 
 		// Pools (IPs)
-		rest.Route{"GET", "/pools/:poolId/ips", gz(sc.checkAuth(restGetPoolIps))},
+		rest.Route{"GET", "/pools/:poolId/ips", gz(sc.checkAuth(auth.RoleViewer, restGetPoolIps))},
 
 		// Services (Apps)
-		rest.Route{"GET", "/services", gz(sc.checkAuth(restGetAllServices))},
-		rest.Route{"GET", "/servicehealth", gz(sc.checkAuth(restGetServicesHealth))},
-		rest.Route{"GET", "/services/:serviceId", gz(sc.authorizedClient(restGetService))},
-		rest.Route{"GET", "/services/:serviceId/running", gz(sc.authorizedClient(restGetRunningForService))},
-		rest.Route{"GET", "/services/:serviceId/:serviceStateId/logs", gz(sc.authorizedClient(restGetServiceStateLogs))},
-		rest.Route{"GET", "/services/:serviceId/:serviceStateId/logs/download", gz(sc.authorizedClient(downloadServiceStateLogs))},
-		rest.Route{"POST", "/services/add", gz(sc.authorizedClient(restAddService))},
-		rest.Route{"POST", "/services/deploy", gz(sc.authorizedClient(restDeployService))},
-		rest.Route{"PUT", "/services/restartServices", gz(sc.checkAuth(restRestartServices))},
-		rest.Route{"PUT", "/services/startServices", gz(sc.checkAuth(restStartServices))},
-		rest.Route{"PUT", "/services/stopServices", gz(sc.checkAuth(restStopServices))},
-		rest.Route{"DELETE", "/services/:serviceId", gz(sc.checkAuth(restRemoveService))},
-		rest.Route{"GET", "/services/:serviceId/logs", gz(sc.authorizedClient(restGetServiceLogs))},
-		rest.Route{"PUT", "/services/:serviceId", gz(sc.authorizedClient(restUpdateService))},
-		rest.Route{"GET", "/services/:serviceId/snapshot", gz(sc.authorizedClient(restSnapshotService))},
-		rest.Route{"PUT", "/services/:serviceId/restartService", gz(sc.checkAuth(restRestartService))},
-		rest.Route{"PUT", "/services/:serviceId/startService", gz(sc.checkAuth(restStartService))},
-		rest.Route{"PUT", "/services/:serviceId/stopService", gz(sc.checkAuth(restStopService))},
-		rest.Route{"POST", "/services/:serviceId/migrate", sc.authorizedClient(restPostServicesForMigration)},
+		rest.Route{"GET", "/services", gz(sc.checkAuth(auth.RoleViewer, restGetAllServices))},
+		rest.Route{"GET", "/servicehealth", gz(sc.checkAuth(auth.RoleViewer, restGetServicesHealth))},
+		rest.Route{"GET", "/services/:serviceId", gz(sc.authorizedClient(auth.RoleViewer, restGetService))},
+		rest.Route{"GET", "/services/:serviceId/running", gz(sc.authorizedClient(auth.RoleViewer, restGetRunningForService))},
+		rest.Route{"GET", "/services/:serviceId/:serviceStateId/logs", gz(sc.authorizedClient(auth.RoleViewer, restGetServiceStateLogs))},
+		rest.Route{"GET", "/services/:serviceId/:serviceStateId/logs/download", gz(sc.authorizedClient(auth.RoleViewer, downloadServiceStateLogs))},
+		rest.Route{"POST", "/services/add", gz(sc.authorizedClient(auth.RoleTenantAdmin, restAddService))},
+		rest.Route{"POST", "/services/deploy", gz(sc.authorizedClient(auth.RoleTenantAdmin, restDeployService))},
+		rest.Route{"PUT", "/services/restartServices", gz(sc.checkAuth(auth.RoleOperator, restRestartServices))},
+		rest.Route{"PUT", "/services/startServices", gz(sc.checkAuth(auth.RoleOperator, restStartServices))},
+		rest.Route{"PUT", "/services/stopServices", gz(sc.checkAuth(auth.RoleOperator, restStopServices))},
+		rest.Route{"DELETE", "/services/:serviceId", gz(sc.checkAuth(auth.RoleTenantAdmin, restRemoveService))},
+		rest.Route{"GET", "/services/:serviceId/logs", gz(sc.authorizedClient(auth.RoleViewer, restGetServiceLogs))},
+		rest.Route{"PUT", "/services/:serviceId", gz(sc.authorizedClient(auth.RoleTenantAdmin, restUpdateService))},
+		rest.Route{"GET", "/services/:serviceId/snapshot", gz(sc.authorizedClient(auth.RoleTenantAdmin, restSnapshotService))},
+		rest.Route{"PUT", "/services/:serviceId/restartService", gz(sc.checkAuth(auth.RoleOperator, restRestartService))},
+		rest.Route{"PUT", "/services/:serviceId/startService", gz(sc.checkAuth(auth.RoleOperator, restStartService))},
+		rest.Route{"PUT", "/services/:serviceId/stopService", gz(sc.checkAuth(auth.RoleOperator, restStopService))},
+		rest.Route{"POST", "/services/:serviceId/migrate", sc.authorizedClient(auth.RoleTenantAdmin, restPostServicesForMigration)},
 
 		// Services (Virtual Host)
-		rest.Route{"PUT", "/services/:serviceId/endpoint/:application/vhosts/*name", gz(sc.checkAuth(restAddVirtualHost))},
-		rest.Route{"DELETE", "/services/:serviceId/endpoint/:application/vhosts/*name", gz(sc.checkAuth(restRemoveVirtualHost))},
-		rest.Route{"POST", "/services/:serviceId/endpoint/:application/vhosts/*name", gz(sc.checkAuth(restVirtualHostEnable))},
+		rest.Route{"PUT", "/services/:serviceId/endpoint/:application/vhosts/*name", gz(sc.checkAuth(auth.RoleTenantAdmin, restAddVirtualHost))},
+		rest.Route{"DELETE", "/services/:serviceId/endpoint/:application/vhosts/*name", gz(sc.checkAuth(auth.RoleTenantAdmin, restRemoveVirtualHost))},
+		rest.Route{"POST", "/services/:serviceId/endpoint/:application/vhosts/*name", gz(sc.checkAuth(auth.RoleTenantAdmin, restVirtualHostEnable))},
 		// Services (Endpoint Ports)
-		rest.Route{"PUT", "/services/:serviceId/endpoint/:application/ports/*portname", gz(sc.checkAuth(restAddPort))},
-		rest.Route{"DELETE", "/services/:serviceId/endpoint/:application/ports/*portname", gz(sc.checkAuth(restRemovePort))},
-		rest.Route{"POST", "/services/:serviceId/endpoint/:application/ports/*portname", gz(sc.checkAuth(restPortEnable))},
+		rest.Route{"PUT", "/services/:serviceId/endpoint/:application/ports/*portname", gz(sc.checkAuth(auth.RoleTenantAdmin, restAddPort))},
+		rest.Route{"DELETE", "/services/:serviceId/endpoint/:application/ports/*portname", gz(sc.checkAuth(auth.RoleTenantAdmin, restRemovePort))},
+		rest.Route{"POST", "/services/:serviceId/endpoint/:application/ports/*portname", gz(sc.checkAuth(auth.RoleTenantAdmin, restPortEnable))},
 
 		// Services (IP)
-		rest.Route{"PUT", "/services/:serviceId/ip", gz(sc.checkAuth(restServiceAutomaticAssignIP))},
-		rest.Route{"PUT", "/services/:serviceId/ip/*ip", gz(sc.checkAuth(restServiceManualAssignIP))},
+		rest.Route{"PUT", "/services/:serviceId/ip", gz(sc.checkAuth(auth.RoleTenantAdmin, restServiceAutomaticAssignIP))},
+		rest.Route{"PUT", "/services/:serviceId/ip/*ip", gz(sc.checkAuth(auth.RoleTenantAdmin, restServiceManualAssignIP))},
 
 		// Service templates (App templates)
-		rest.Route{"GET", "/templates", gz(sc.checkAuth(restGetAppTemplates))},
-		rest.Route{"POST", "/templates/add", gz(sc.checkAuth(restAddAppTemplate))},
-		rest.Route{"DELETE", "/templates/:templateId", gz(sc.checkAuth(restRemoveAppTemplate))},
-		rest.Route{"POST", "/templates/deploy", gz(sc.checkAuth(restDeployAppTemplate))},
-		rest.Route{"POST", "/templates/deploy/status", gz(sc.checkAuth(restDeployAppTemplateStatus))},
-		rest.Route{"GET", "/templates/deploy/active", gz(sc.checkAuth(restDeployAppTemplateActive))},
+		rest.Route{"GET", "/templates", gz(sc.checkAuth(auth.RoleViewer, restGetAppTemplates))},
+		rest.Route{"POST", "/templates/add", gz(sc.checkAuth(auth.RoleClusterAdmin, restAddAppTemplate))},
+		rest.Route{"DELETE", "/templates/:templateId", gz(sc.checkAuth(auth.RoleClusterAdmin, restRemoveAppTemplate))},
+		rest.Route{"POST", "/templates/deploy", gz(sc.checkAuth(auth.RoleTenantAdmin, restDeployAppTemplate))},
+		rest.Route{"POST", "/templates/deploy/status", gz(sc.checkAuth(auth.RoleViewer, restDeployAppTemplateStatus))},
+		rest.Route{"GET", "/templates/deploy/active", gz(sc.checkAuth(auth.RoleViewer, restDeployAppTemplateActive))},
 
 		// Login
 		rest.Route{"POST", "/login", gz(sc.noAuth(restLogin))},
 		rest.Route{"DELETE", "/login", gz(restLogout)},
 
 		// "Misc" stuff
-		rest.Route{"GET", "/top/services", gz(sc.checkAuth(restGetTopServices))},
-		rest.Route{"GET", "/config", gz(sc.authorizedClient(restGetUIConfig))},
-		rest.Route{"GET", "/servicestatus", gz(sc.checkAuth(restGetConciseServiceStatus))},
+		rest.Route{"GET", "/top/services", gz(sc.checkAuth(auth.RoleViewer, restGetTopServices))},
+		rest.Route{"GET", "/config", gz(sc.authorizedInfoClient(auth.RoleViewer, restGetUIConfig))},
+		rest.Route{"GET", "/servicestatus", gz(sc.checkAuth(auth.RoleViewer, restGetConciseServiceStatus))},
 
 		// Generic static data
 		rest.Route{"GET", "/favicon.ico", gz(favIcon)},
@@ -116,52 +119,72 @@ func (sc *ServiceConfig) getRoutes() []rest.Route {
 		rest.Route{"GET", "/licenses.html", gz(licenses)},
 
 		// Info about serviced itself
-		rest.Route{"GET", "/dockerIsLoggedIn", gz(sc.authorizedClient(restDockerIsLoggedIn))},
+		rest.Route{"GET", "/dockerIsLoggedIn", gz(sc.authorizedInfoClient(auth.RoleViewer, restDockerIsLoggedIn))},
 		rest.Route{"GET", "/stats", gz(sc.isCollectingStats())},
 		rest.Route{"GET", "/version", gz(restGetServicedVersion)},
-		rest.Route{"GET", "/storage", gz(sc.authorizedClient(restGetStorage))},
+		rest.Route{"GET", "/storage", gz(sc.authorizedClient(auth.RoleViewer, restGetStorage))},
 
 		// V2 API
-		rest.Route{"GET", "/api/v2/pools", gz(sc.checkAuth(getPools))},
-		rest.Route{"GET", "/api/v2/pools/:poolId/hosts", gz(sc.checkAuth(getHostsForPool))},
-		rest.Route{"GET", "/api/v2/hosts", gz(sc.checkAuth(getHosts))},
-		rest.Route{"GET", "/api/v2/hosts/:hostId/instances", gz(sc.checkAuth(restGetHostInstances))},
-		rest.Route{"GET", "/api/v2/internalservices", gz(sc.checkAuth(getAllInternalServices))},
-		rest.Route{"GET", "/api/v2/internalservices/:id", gz(sc.checkAuth(getInternalService))},
-		rest.Route{"GET", "/api/v2/internalservices/:id/instances", gz(sc.checkAuth(getInternalServiceInstances))},
-		rest.Route{"GET", "/api/v2/internalservicestatuses", gz(sc.checkAuth(getInternalServiceStatuses))},
-		rest.Route{"GET", "/api/v2/services", gz(sc.checkAuth(getAllServiceDetails))},
-		rest.Route{"GET", "/api/v2/services/:serviceId", gz(sc.checkAuth(getServiceDetails))},
-		rest.Route{"PUT", "/api/v2/services/:serviceId", gz(sc.checkAuth(putServiceDetails))},
-		rest.Route{"GET", "/api/v2/services/:serviceId/services", gz(sc.checkAuth(getChildServiceDetails))},
-		rest.Route{"GET", "/api/v2/services/:serviceId/instances", gz(sc.checkAuth(restGetServiceInstances))},
-		rest.Route{"GET", "/api/v2/services/:serviceId/monitoringprofile", gz(sc.checkAuth(restGetServiceMonitoringProfile))},
-		rest.Route{"GET", "/api/v2/services/:serviceId/publicendpoints", gz(sc.checkAuth(restGetServicePublicEndpoints))},
-		rest.Route{"GET", "/api/v2/services/:serviceId/ipassignments", gz(sc.checkAuth(restGetServiceIPAssignments))},
-		rest.Route{"GET", "/api/v2/services/:serviceId/exportendpoints", gz(sc.checkAuth(restGetServiceExportedEndpoints))},
-		rest.Route{"GET", "/api/v2/services/:serviceId/descendantstates", gz(sc.checkAuth(restCountDescendantStates))},
-		rest.Route{"GET", "/api/v2/services/:serviceId/context", gz(sc.checkAuth(getServiceContext))},
-		rest.Route{"PUT", "/api/v2/services/:serviceId/context", gz(sc.checkAuth(putServiceContext))},
-		rest.Route{"GET", "/api/v2/statuses", gz(sc.checkAuth(restGetAggregateServices))},
-		rest.Route{"GET", "/api/v2/hoststatuses", gz(sc.checkAuth(getHostStatuses))},
-
-		rest.Route{"GET", "/api/v2/services/:serviceId/serviceconfigs", gz(sc.checkAuth(restGetServiceConfigFiles))},
-		rest.Route{"POST", "/api/v2/services/:serviceId/serviceconfigs", gz(sc.checkAuth(restAddServiceConfigFile))},
-		rest.Route{"GET", "/api/v2/serviceconfigs/:fileId", gz(sc.checkAuth(restGetServiceConfigFile))},
-		rest.Route{"PUT", "/api/v2/serviceconfigs/:fileId", gz(sc.checkAuth(restUpdateServiceConfigFile))},
-		rest.Route{"DELETE", "/api/v2/serviceconfigs/:fileId", gz(sc.checkAuth(restDeleteServiceConfigFile))},
+		rest.Route{"GET", "/api/v2/pools", gz(sc.checkAuth(auth.RoleViewer, getPools))},
+		rest.Route{"GET", "/api/v2/pools/:poolId/hosts", gz(sc.checkAuth(auth.RoleViewer, getHostsForPool))},
+		rest.Route{"GET", "/api/v2/hosts", gz(sc.checkAuth(auth.RoleViewer, getHosts))},
+		rest.Route{"GET", "/api/v2/hosts/:hostId/instances", gz(sc.checkAuth(auth.RoleViewer, restGetHostInstances))},
+		rest.Route{"GET", "/api/v2/hosts/:hostId/labels", gz(sc.checkAuth(auth.RoleViewer, getHostLabels))},
+		rest.Route{"PUT", "/api/v2/hosts/:hostId/labels", gz(sc.checkAuth(auth.RoleClusterAdmin, putHostLabels))},
+		rest.Route{"GET", "/api/v2/internalservices", gz(sc.checkAuth(auth.RoleViewer, getAllInternalServices))},
+		rest.Route{"GET", "/api/v2/internalservices/:id", gz(sc.checkAuth(auth.RoleViewer, getInternalService))},
+		rest.Route{"GET", "/api/v2/internalservices/:id/instances", gz(sc.checkAuth(auth.RoleViewer, getInternalServiceInstances))},
+		rest.Route{"GET", "/api/v2/internalservicestatuses", gz(sc.checkAuth(auth.RoleViewer, getInternalServiceStatuses))},
+		rest.Route{"GET", "/api/v2/services", gz(sc.checkAuth(auth.RoleViewer, getAllServiceDetails))},
+		rest.Route{"GET", "/api/v2/services/:serviceId", gz(sc.checkAuth(auth.RoleViewer, getServiceDetails))},
+		rest.Route{"PUT", "/api/v2/services/:serviceId", gz(sc.checkAuth(auth.RoleTenantAdmin, putServiceDetails))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/services", gz(sc.checkAuth(auth.RoleViewer, getChildServiceDetails))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/instances", gz(sc.checkAuth(auth.RoleViewer, restGetServiceInstances))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/monitoringprofile", gz(sc.checkAuth(auth.RoleViewer, restGetServiceMonitoringProfile))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/publicendpoints", gz(sc.checkAuth(auth.RoleViewer, restGetServicePublicEndpoints))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/ipassignments", gz(sc.checkAuth(auth.RoleViewer, restGetServiceIPAssignments))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/exportendpoints", gz(sc.checkAuth(auth.RoleViewer, restGetServiceExportedEndpoints))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/descendantstates", gz(sc.checkAuth(auth.RoleViewer, restCountDescendantStates))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/context", gz(sc.checkAuth(auth.RoleViewer, getServiceContext))},
+		rest.Route{"PUT", "/api/v2/services/:serviceId/context", gz(sc.checkAuth(auth.RoleTenantAdmin, putServiceContext))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/revisions", gz(sc.checkAuth(auth.RoleViewer, getServiceRevisions))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/revisions/:revision", gz(sc.checkAuth(auth.RoleViewer, getServiceRevision))},
+		rest.Route{"POST", "/api/v2/services/:serviceId/revisions/:revision/revert", gz(sc.checkAuth(auth.RoleTenantAdmin, postRevertService))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/placement", gz(sc.checkAuth(auth.RoleViewer, getServicePlacement))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/health/history", gz(sc.checkAuth(auth.RoleViewer, getServiceHealthHistory))},
+		rest.Route{"GET", "/api/v2/services/:serviceId/rollingrestart", gz(sc.checkAuth(auth.RoleViewer, getRollingRestart))},
+		rest.Route{"POST", "/api/v2/services/:serviceId/rollingrestart", gz(sc.checkAuth(auth.RoleOperator, postRollingRestart))},
+		rest.Route{"POST", "/api/v2/services/:serviceId/rollingrestart/resume", gz(sc.checkAuth(auth.RoleOperator, postResumeRollingRestart))},
+		rest.Route{"POST", "/api/v2/services/:serviceId/rollingrestart/cancel", gz(sc.checkAuth(auth.RoleOperator, postCancelRollingRestart))},
+		rest.Route{"GET", "/api/v2/statuses", gz(sc.checkAuth(auth.RoleViewer, restGetAggregateServices))},
+		rest.Route{"GET", "/api/v2/hoststatuses", gz(sc.checkAuth(auth.RoleViewer, getHostStatuses))},
+		rest.Route{"GET", "/api/v2/snapshotschedules", gz(sc.checkAuth(auth.RoleViewer, getSnapshotSchedules))},
+		rest.Route{"GET", "/api/v2/snapshotschedules/:tenantId", gz(sc.checkAuth(auth.RoleViewer, getSnapshotSchedule))},
+		rest.Route{"PUT", "/api/v2/snapshotschedules/:tenantId", gz(sc.checkAuth(auth.RoleTenantAdmin, putSnapshotSchedule))},
+		rest.Route{"DELETE", "/api/v2/snapshotschedules/:tenantId", gz(sc.checkAuth(auth.RoleTenantAdmin, deleteSnapshotSchedule))},
+		rest.Route{"GET", "/api/v2/apitokens", gz(sc.checkAuth(auth.RoleClusterAdmin, getAPITokens))},
+		rest.Route{"POST", "/api/v2/apitokens", gz(sc.checkAuth(auth.RoleClusterAdmin, postAPIToken))},
+		rest.Route{"DELETE", "/api/v2/apitokens/:tokenId", gz(sc.checkAuth(auth.RoleClusterAdmin, deleteAPIToken))},
+		rest.Route{"GET", "/api/v2/audit", gz(sc.checkAuth(auth.RoleClusterAdmin, getAuditEvents))},
+
+		rest.Route{"GET", "/api/v2/services/:serviceId/serviceconfigs", gz(sc.checkAuth(auth.RoleViewer, restGetServiceConfigFiles))},
+		rest.Route{"POST", "/api/v2/services/:serviceId/serviceconfigs", gz(sc.checkAuth(auth.RoleTenantAdmin, restAddServiceConfigFile))},
+		rest.Route{"GET", "/api/v2/serviceconfigs/:fileId", gz(sc.checkAuth(auth.RoleViewer, restGetServiceConfigFile))},
+		rest.Route{"PUT", "/api/v2/serviceconfigs/:fileId", gz(sc.checkAuth(auth.RoleTenantAdmin, restUpdateServiceConfigFile))},
+		rest.Route{"DELETE", "/api/v2/serviceconfigs/:fileId", gz(sc.checkAuth(auth.RoleTenantAdmin, restDeleteServiceConfigFile))},
 	}
 
 	// Hardcoding these target URLs for now.
 	// TODO: When internal services are allowed to run on other hosts, look that up.
-	// All API calls require authentication
-	routes = routeToInternalServiceProxy("/api/controlplane/elastic", "http://127.0.0.1:9100/", true, routes)
-	routes = routeToInternalServiceProxy("/metrics/api", "http://127.0.0.1:8888/api", true, routes)
-	routes = routeToInternalServiceProxy("/api/controlplane/kibana", "http://127.0.0.1:5601", true, routes)
+	// All API calls require authentication; the elastic proxy allows writes, so
+	// only cluster admins may use it.
+	routes = sc.routeToInternalServiceProxy("/api/controlplane/elastic", "http://127.0.0.1:9100/", auth.RoleClusterAdmin, routes)
+	routes = sc.routeToInternalServiceProxy("/metrics/api", "http://127.0.0.1:8888/api", auth.RoleViewer, routes)
+	routes = sc.routeToInternalServiceProxy("/api/controlplane/kibana", "http://127.0.0.1:5601", auth.RoleViewer, routes)
 
 	// Allow static assets for metrics data to be loaded without authentication since they are
 	// included in index.html by default.
-	routes = routeToInternalServiceProxy("/metrics/static", "http://127.0.0.1:8888/static", false, routes)
+	routes = sc.routeToInternalServiceProxy("/metrics/static", "http://127.0.0.1:8888/static", auth.RoleNone, routes)
 
 	return routes
 }
@@ -73,7 +73,8 @@ func restServiceManualAssignIP(w *rest.ResponseWriter, r *rest.Request, ctx *req
 	restSuccess(w)
 }
 
-// restGetServicesHealth returns health checks for all services
+// restGetServicesHealth returns health checks for all services.  The output
+// of a health check is only included while it is not passing.
 func restGetServicesHealth(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
 	healthStatuses, err := ctx.getFacade().GetServicesHealth(ctx.getDatastoreContext())
 	if err != nil {
@@ -81,6 +82,16 @@ func restGetServicesHealth(w *rest.ResponseWriter, r *rest.Request, ctx *request
 		restServerError(w, err)
 		return
 	}
+	for _, instances := range healthStatuses {
+		for _, checks := range instances {
+			for name, status := range checks {
+				if status.Status == health.OK {
+					status.Output = ""
+					checks[name] = status
+				}
+			}
+		}
+	}
 
 	w.WriteJson(struct {
 		Timestamp int64
@@ -15,6 +15,7 @@ package web
 
 import (
 	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/datastore"
 	userdomain "github.com/control-center/serviced/domain/user"
 	"github.com/control-center/serviced/rpc/master"
 	"github.com/control-center/serviced/utils"
@@ -41,6 +42,7 @@ var adminGroup = "sudo"
 type sessionT struct {
 	ID       string
 	User     string
+	Role     auth.Role
 	creation time.Time
 	access   time.Time
 }
@@ -103,50 +105,68 @@ func purgeOldsessionTs() {
 /*
  * This function should be called by any secure REST resource
  */
-func loginWithBasicAuthOK(r *rest.Request) bool {
+func loginWithBasicAuthOK(r *rest.Request) (auth.Role, bool) {
 	cookie, err := r.Request.Cookie(sessionCookie)
 	if err != nil {
 		glog.V(1).Info("Error getting cookie ", err)
-		return false
+		return auth.RoleNone, false
 	}
 	sessionsLock.Lock()
 	defer sessionsLock.Unlock()
 	value, err := url.QueryUnescape(strings.Replace(cookie.Value, "+", url.QueryEscape("+"), -1))
 	if err != nil {
 		glog.Warning("Unable to decode session ", cookie.Value)
-		return false
+		return auth.RoleNone, false
 	}
 	session, err := findsessionT(value)
 	if err != nil {
 		glog.Info("Unable to find session ", value)
-		return false
+		return auth.RoleNone, false
 	}
 	session.access = time.Now()
 	glog.V(2).Infof("sessionT %s used", session.ID)
-	return true
+	return session.Role, true
 }
 
-func loginWithTokenOK(r *rest.Request, token string) bool {
+func loginWithTokenOK(r *rest.Request, token string) (auth.Role, bool) {
 	restToken, err := auth.ParseRestToken(token)
 	if err != nil {
 		msg := "Unable to parse rest token"
 		plog.WithError(err).WithField("url", r.URL.String()).Debug(msg)
-		return false
+		return auth.RoleNone, false
 	} else {
 		if !restToken.ValidateRequestHash(r.Request) {
 			msg := "Could not login with rest token. Request signature does not match token."
 			plog.WithField("url", r.URL.String()).Debug(msg)
-			return false
-		} else if !restToken.HasAdminAccess() {
+			return auth.RoleNone, false
+		} else if role := restToken.Role(); !role.Valid() {
 			msg := "Could not login with rest token. Insufficient permissions."
 			plog.WithField("url", r.URL.String()).Debug(msg)
-			return false
+			return auth.RoleNone, false
 		} else {
-			return true
+			return role, true
 		}
 	}
 }
 
+// loginWithAPITokenOK authenticates the request with a long-lived API token.
+// It returns auth.ErrNotAPIToken if the token is some other kind of token.
+func (sc *ServiceConfig) loginWithAPITokenOK(r *rest.Request, token string) (auth.Role, error) {
+	apiToken, err := sc.facade.AuthenticateAPIToken(datastore.Get(), token)
+	if err == auth.ErrNotAPIToken {
+		return auth.RoleNone, err
+	} else if err != nil {
+		msg := "Could not login with API token"
+		plog.WithError(err).WithField("url", r.URL.String()).Debug(msg)
+		return auth.RoleNone, err
+	} else if !apiToken.Role.Valid() {
+		msg := "Could not login with API token. Insufficient permissions."
+		plog.WithField("url", r.URL.String()).Debug(msg)
+		return auth.RoleNone, auth.ErrInvalidRole
+	}
+	return apiToken.Role, nil
+}
+
 func loginWithAuth0TokenOK(r *rest.Request, token string) (auth.Auth0Token, bool) {
 	auth0Token, err := auth.ParseAuth0Token(token)
 	if err != nil {
@@ -164,27 +184,36 @@ func loginWithAuth0TokenOK(r *rest.Request, token string) (auth.Auth0Token, bool
 	}
 }
 
-func loginWithAuth0CookieOk(r *rest.Request) bool {
+func loginWithAuth0CookieOk(r *rest.Request) (auth.Role, bool) {
 	cookie, err := r.Request.Cookie(auth0TokenCookie)
 	if err != nil {
 		glog.V(1).Info("Error getting cookie ", err)
-		return false
+		return auth.RoleNone, false
 	}
 	token := cookie.Value
-	_, result := loginWithAuth0TokenOK(r, token)
-	return result
+	if parsed, ok := loginWithAuth0TokenOK(r, token); ok {
+		return parsed.Role(), true
+	}
+	return auth.RoleNone, false
 }
 
-func loginOK(w *rest.ResponseWriter, r *rest.Request) bool {
+// loginOK authenticates the request and returns the role granted to the
+// caller.
+func (sc *ServiceConfig) loginOK(w *rest.ResponseWriter, r *rest.Request) (auth.Role, bool) {
 	token, tErr := auth.ExtractRestToken(r.Request)
 	if tErr != nil { // There is a token in the header but we could not extract it
 		msg := "Unable to extract auth token from header"
 		plog.WithError(tErr).WithField("url", r.URL.String()).Debug(msg)
-		return false
+		return auth.RoleNone, false
+	}
+	if token != "null" && token != "" {
+		if role, err := sc.loginWithAPITokenOK(r, token); err != auth.ErrNotAPIToken {
+			return role, err == nil
+		}
 	}
 	if auth.Auth0IsConfigured() {
-		if auth0LoginOK(w, r, token) {
-			return true
+		if role, ok := auth0LoginOK(w, r, token); ok {
+			return role, true
 		}
 		// CC-4109: even with auth0 configured, we still need token authentication for REST calls.
 		return loginWithTokenOK(r, token)
@@ -192,7 +221,7 @@ func loginOK(w *rest.ResponseWriter, r *rest.Request) bool {
 	return basicAuthLoginOK(w, r, token)
 }
 
-func auth0LoginOK(w *rest.ResponseWriter, r *rest.Request, token string) bool {
+func auth0LoginOK(w *rest.ResponseWriter, r *rest.Request, token string) (auth.Role, bool) {
 	if token != "null" && token != "" {
 		if parsed, ok := loginWithAuth0TokenOK(r, token); ok {
 			// Set cookie with token, so api calls can work.
@@ -222,15 +251,15 @@ func auth0LoginOK(w *rest.ResponseWriter, r *rest.Request, token string) bool {
 					Secure:   false,
 					HttpOnly: false,
 				})
-			return true
+			return parsed.Role(), true
 		}
-		return false
+		return auth.RoleNone, false
 	} else {
 		return loginWithAuth0CookieOk(r)
 	}
 }
 
-func basicAuthLoginOK(w *rest.ResponseWriter, r *rest.Request, token string) bool {
+func basicAuthLoginOK(w *rest.ResponseWriter, r *rest.Request, token string) (auth.Role, bool) {
 	if token != "null" && token != "" {
 		return loginWithTokenOK(r, token)
 	} else {
@@ -290,11 +319,11 @@ func restLoginWithBasicAuth(w *rest.ResponseWriter, r *rest.Request, ctx *reques
 		return
 	}
 
-	if validateLogin(&creds, client) {
+	if role, ok := validateLogin(&creds, client); ok {
 		sessionsLock.Lock()
 		defer sessionsLock.Unlock()
 
-		session, err := createsessionT(creds.Username)
+		session, err := createsessionT(creds.Username, role)
 		if err != nil {
 			writeJSON(w, &simpleResponse{"sessionT could not be created", loginLink()}, http.StatusInternalServerError)
 			return
@@ -338,7 +367,10 @@ func restLogin(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
 		if _, ok := loginWithAuth0TokenOK(r, token); ok {
 			w.WriteJson(&simpleResponse{"Accepted", homeLink()})
 			return
-		} else if loginWithTokenOK(r, token) {
+		} else if _, ok := loginWithTokenOK(r, token); ok {
+			w.WriteJson(&simpleResponse{"Accepted", homeLink()})
+			return
+		} else if _, err := ctx.sc.loginWithAPITokenOK(r, token); err == nil {
 			w.WriteJson(&simpleResponse{"Accepted", homeLink()})
 			return
 		}
@@ -348,16 +380,32 @@ func restLogin(w *rest.ResponseWriter, r *rest.Request, ctx *requestContext) {
 	}
 }
 
-func validateLogin(creds *login, client master.ClientInterface) bool {
+// validateLogin authenticates the user and returns the role they are granted.
+// Users with a stored role may log in without belonging to the admin group;
+// members of the admin group without a stored role are cluster admins.  If
+// the stored role cannot be looked up, the login is refused.
+func validateLogin(creds *login, client master.ClientInterface) (auth.Role, bool) {
 	glog.V(1).Info("validateLogin()")
 	systemUser, err := client.GetSystemUser()
 	if err == nil && creds.Username == systemUser.Name {
 		validated := cpValidateLogin(creds, client)
 		if validated {
-			return validated
+			return auth.RoleClusterAdmin, validated
 		}
 	}
-	return pamValidateLogin(creds, adminGroup)
+	role, err := client.GetUserRole(creds.Username)
+	if err != nil {
+		glog.Errorf("Unable to look up the role of user %s: %s", creds.Username, err)
+		return auth.RoleNone, false
+	}
+	if role != auth.RoleNone {
+		if !role.Valid() {
+			glog.Errorf("User %s has an invalid role %q", creds.Username, role)
+			return auth.RoleNone, false
+		}
+		return role, pamValidateLoginOnly(creds, adminGroup)
+	}
+	return auth.RoleClusterAdmin, pamValidateLogin(creds, adminGroup)
 }
 
 func cpValidateLogin(creds *login, client master.ClientInterface) bool {
@@ -376,12 +424,12 @@ func cpValidateLogin(creds *login, client master.ClientInterface) bool {
 	return result
 }
 
-func createsessionT(user string) (*sessionT, error) {
+func createsessionT(user string, role auth.Role) (*sessionT, error) {
 	sid, err := randomsessionTId()
 	if err != nil {
 		return nil, err
 	}
-	return &sessionT{sid, user, time.Now(), time.Now()}, nil
+	return &sessionT{sid, user, role, time.Now(), time.Now()}, nil
 }
 
 func findsessionT(sid string) (*sessionT, error) {
@@ -423,11 +471,32 @@ func deleteSessionT(sid string) {
 	delete(sessions, sid)
 }
 
+// getUser returns the name of the user who authenticated the request.  The
+// name is taken from the session or the auth0 token of the request, and never
+// from the username cookie, which is only set for display and can be forged.
 func getUser(r *rest.Request) (string, error) {
-	for _, cookie := range r.Cookies() {
-		if cookie.Name == usernameCookie {
-			return cookie.Value, nil
+	if auth.Auth0IsConfigured() {
+		token, err := auth.ExtractRestToken(r.Request)
+		if err == nil && (token == "" || token == "null") {
+			if cookie, cerr := r.Request.Cookie(auth0TokenCookie); cerr == nil {
+				token = cookie.Value
+			}
+		}
+		if err == nil && token != "" && token != "null" {
+			if parsed, err := auth.ParseAuth0Token(token); err == nil {
+				return parsed.User(), nil
+			}
+		}
+	}
+	if cookie, err := r.Request.Cookie(sessionCookie); err == nil {
+		value, err := url.QueryUnescape(strings.Replace(cookie.Value, "+", url.QueryEscape("+"), -1))
+		if err == nil {
+			sessionsLock.RLock()
+			defer sessionsLock.RUnlock()
+			if session, err := findsessionT(value); err == nil {
+				return session.User, nil
+			}
 		}
 	}
-	return "", errors.New("Unable to retriever user name")
+	return "", errors.New("Unable to retrieve user name")
 }
@@ -0,0 +1,73 @@
+// Copyright 2019 The Serviced Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build unit
+
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/control-center/serviced/auth"
+	"github.com/control-center/serviced/domain/user"
+	mastermocks "github.com/control-center/serviced/rpc/master/mocks"
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestWebSuite) TestGetUserIgnoresUsernameCookie(c *C) {
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services", "")
+	request.AddCookie(&http.Cookie{Name: usernameCookie, Value: "admin"})
+
+	_, err := getUser(&request)
+	c.Assert(err, NotNil)
+	c.Assert(newRequestContextFromRequest(nil, &request).username, Equals, "")
+}
+
+func (s *TestWebSuite) TestGetUserFromSession(c *C) {
+	session, err := createsessionT("viewer", auth.RoleViewer)
+	c.Assert(err, IsNil)
+	sessionsLock.Lock()
+	sessions[session.ID] = session
+	sessionsLock.Unlock()
+	defer deleteSessionT(session.ID)
+
+	request := s.buildRequest("GET", "http://www.example.com/api/v2/services", "")
+	request.AddCookie(&http.Cookie{Name: sessionCookie, Value: session.ID})
+	request.AddCookie(&http.Cookie{Name: usernameCookie, Value: "admin"})
+
+	user, err := getUser(&request)
+	c.Assert(err, IsNil)
+	c.Assert(user, Equals, "viewer")
+	c.Assert(newRequestContextFromRequest(nil, &request).username, Equals, "viewer")
+}
+
+func (s *TestWebSuite) TestValidateLoginRejectsInvalidRole(c *C) {
+	client := &mastermocks.ClientInterface{}
+	client.On("GetSystemUser").Return(user.User{Name: "zenoss_system"}, nil)
+	client.On("GetUserRole", "oncall").Return(auth.Role("superuser"), nil)
+
+	role, ok := validateLogin(&login{Username: "oncall", Password: "secret"}, client)
+	c.Assert(ok, Equals, false)
+	c.Assert(role, Equals, auth.RoleNone)
+}
+
+func (s *TestWebSuite) TestValidateLoginRejectsUnknownRole(c *C) {
+	client := &mastermocks.ClientInterface{}
+	client.On("GetSystemUser").Return(user.User{Name: "zenoss_system"}, nil)
+	client.On("GetUserRole", "oncall").Return(auth.RoleNone, errors.New("datastore unavailable"))
+
+	role, ok := validateLogin(&login{Username: "oncall", Password: "secret"}, client)
+	c.Assert(ok, Equals, false)
+	c.Assert(role, Equals, auth.RoleNone)
+}
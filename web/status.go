@@ -253,6 +253,7 @@ func convertDomainHealthToNewHealth(sources []domain.HealthCheckStatus) map[stri
 		result[hcs.Name] = health.HealthStatus{
 			Status:    status,
 			StartedAt: time.Unix(hcs.Timestamp, 0),
+			Output:    hcs.Failure,
 		}
 	}
 	return result
@@ -19,6 +19,7 @@ import (
 	"path"
 
 	"github.com/zenoss/go-json-rest"
+	"github.com/control-center/serviced/facade"
 	"github.com/control-center/serviced/utils"
 )
 
@@ -64,10 +65,22 @@ func restUnauthorized(w *rest.ResponseWriter) {
 	return
 }
 
+/*
+ * Inform the user that they are logged in, but may not do what they asked.
+ */
+func restForbidden(w *rest.ResponseWriter) {
+	writeJSON(w, &simpleResponse{"Forbidden", homeLink()}, http.StatusForbidden)
+	return
+}
+
 /*
  * Provide a generic response for an oopsie.
  */
 func restServerError(w *rest.ResponseWriter, err error) {
+	if err == facade.ErrTenantNotAuthorized {
+		restForbidden(w)
+		return
+	}
 	writeJSON(w, &simpleResponse{fmt.Sprintf("Internal Server Error: %v", err), homeLink()}, http.StatusInternalServerError)
 	return
 }
@@ -40,8 +40,11 @@ func (err ServiceError) Error() string {
 type ServiceNode struct {
 	ID                          string
 	Name                        string
+	DeploymentID                string
 	DesiredState                int
 	HostPolicy                  servicedefinition.HostPolicy
+	HostLabels                  *servicedefinition.LabelSelector
+	Affinity                    *servicedefinition.Affinity
 	Instances                   int
 	RAMCommitment               utils.EngNotation
 	CPUCommitment               int
@@ -57,12 +60,15 @@ func NewServiceNodeFromService(s *service.Service) (*ServiceNode, error) {
 	sn := ServiceNode{
 		ID:            s.ID,
 		Name:          s.Name,
+		DeploymentID:  s.DeploymentID,
 		DesiredState:  s.DesiredState,
 		Instances:     s.Instances,
 		CPUCommitment: int(s.CPUCommitment),
 		RAMCommitment: s.RAMCommitment,
 		ChangeOptions: s.ChangeOptions,
 		HostPolicy:    s.HostPolicy,
+		HostLabels:    s.HostLabels,
+		Affinity:      s.Affinity,
 	}
 
 	// Copy address assignment if it exists. Note whether assignment is expected, so the scheduler can verify it later.
@@ -26,7 +26,7 @@ import (
 
 // ServiceHandler handles all non-zookeeper interactions required by the service
 type ServiceHandler interface {
-	SelectHost(*ServiceNode) (string, error)
+	SelectHost(*ServiceNode, int) (string, error)
 }
 
 // ServiceListener is the listener for /services
@@ -291,7 +291,7 @@ func (l *ServiceListener) Start(sn *ServiceNode, instanceID int) bool {
 	})
 
 	// pick a host
-	hostID, err := l.handler.SelectHost(sn)
+	hostID, err := l.handler.SelectHost(sn, instanceID)
 	if err != nil {
 		logger.WithError(err).Warn("Could not select host")
 		return false